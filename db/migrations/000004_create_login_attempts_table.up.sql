@@ -0,0 +1,10 @@
+CREATE TABLE login_attempts (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	username	varchar(100)	NOT NULL,
+	ip			varchar(45)		NOT NULL,
+	user_agent	varchar(500)	NOT NULL,
+	reason		varchar(50)		NOT NULL,
+	PRIMARY KEY (id),
+	INDEX (username, created_at)
+);
@@ -0,0 +1 @@
+DROP TABLE limiter_hits;
\ No newline at end of file
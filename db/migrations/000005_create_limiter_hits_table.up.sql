@@ -0,0 +1,7 @@
+CREATE TABLE limiter_hits (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp(3)	NOT NULL,
+	limiter_key	varchar(200)	NOT NULL,
+	PRIMARY KEY (id),
+	INDEX (limiter_key, created_at)
+);
@@ -0,0 +1 @@
+DROP TABLE user_totps;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE user_totps (
+	user_id			int				NOT NULL,
+	created_at		timestamp		NOT NULL,
+	updated_at		timestamp		NOT NULL,
+	secret			varchar(200)	NOT NULL,
+	confirmed_at	timestamp		NULL,
+	last_used_step	bigint			NOT NULL	DEFAULT 0,
+	PRIMARY KEY (user_id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
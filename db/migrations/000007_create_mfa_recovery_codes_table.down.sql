@@ -0,0 +1 @@
+DROP TABLE mfa_recovery_codes;
\ No newline at end of file
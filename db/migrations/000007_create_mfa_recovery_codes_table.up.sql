@@ -0,0 +1,9 @@
+CREATE TABLE mfa_recovery_codes (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	used_at		timestamp		NULL,
+	user_id		int				NOT NULL,
+	code		varchar(128)	NOT NULL,
+	PRIMARY KEY (id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE api_keys (
+	id				int				NOT NULL	AUTO_INCREMENT,
+	created_at		timestamp		NOT NULL,
+	updated_at		timestamp		NOT NULL,
+	deleted_at		timestamp		NULL,
+	user_id			int				NOT NULL,
+	name			varchar(100)	NOT NULL,
+	prefix			varchar(20)		NOT NULL,
+	key_hash		varchar(128)	NOT NULL,
+	scopes			varchar(255)	NOT NULL,
+	expires_at		timestamp		NULL,
+	last_used_at	timestamp		NULL,
+	PRIMARY KEY (id),
+	UNIQUE KEY (key_hash),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
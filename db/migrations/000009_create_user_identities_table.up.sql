@@ -0,0 +1,10 @@
+CREATE TABLE user_identities (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	user_id		int				NOT NULL,
+	issuer		varchar(250)	NOT NULL,
+	subject		varchar(250)	NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE KEY (issuer, subject),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
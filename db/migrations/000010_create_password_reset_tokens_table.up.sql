@@ -0,0 +1,11 @@
+CREATE TABLE password_reset_tokens (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	expires_at	timestamp		NOT NULL,
+	used_at		timestamp		NULL,
+	user_id		int				NOT NULL,
+	token_hash	varchar(128)	NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE KEY (token_hash),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1 @@
+DROP TABLE user_invitations;
\ No newline at end of file
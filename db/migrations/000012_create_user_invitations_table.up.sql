@@ -0,0 +1,15 @@
+CREATE TABLE user_invitations (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	updated_at	timestamp		NOT NULL,
+	expires_at	timestamp		NOT NULL,
+	accepted_at	timestamp		NULL,
+	revoked_at	timestamp		NULL,
+	invited_by	int				NOT NULL,
+	user_id		int				NOT NULL,
+	token_hash	varchar(128)	NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE KEY (token_hash),
+	FOREIGN KEY (invited_by) REFERENCES users(id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE password_history (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	user_id		int				NOT NULL,
+	password	varchar(128)	NOT NULL,
+	PRIMARY KEY (id),
+	KEY (user_id, id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+INSERT INTO password_history (created_at, user_id, password)
+	SELECT NOW(), id, password
+	FROM users
+	WHERE password <> '';
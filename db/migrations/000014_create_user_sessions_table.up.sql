@@ -0,0 +1,14 @@
+CREATE TABLE user_sessions (
+	id				int				NOT NULL	AUTO_INCREMENT,
+	created_at		timestamp		NOT NULL,
+	expires_at		timestamp		NOT NULL,
+	last_seen_at	timestamp		NOT NULL,
+	revoked_at		timestamp		NULL,
+	user_id			int				NOT NULL,
+	device			varchar(100)	NOT NULL,
+	ip				varchar(45)		NOT NULL,
+	user_agent		varchar(255)	NOT NULL,
+	PRIMARY KEY (id),
+	KEY (user_id, revoked_at),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE audit_logs (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	request_id	varchar(64)		NOT NULL,
+	actor_id	int				NOT NULL,
+	user_id		int				NOT NULL,
+	action		varchar(50)		NOT NULL,
+	method		varchar(10)		NOT NULL,
+	path		varchar(255)	NOT NULL,
+	status		int				NOT NULL,
+	PRIMARY KEY (id),
+	INDEX (actor_id, created_at),
+	INDEX (user_id, created_at)
+);
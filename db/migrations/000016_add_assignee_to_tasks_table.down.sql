@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP FOREIGN KEY tasks_assignee_id_fk;
+ALTER TABLE tasks DROP COLUMN assignee_id;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN assignee_id int NULL AFTER user_id;
+UPDATE tasks SET assignee_id = user_id;
+ALTER TABLE tasks MODIFY assignee_id int NOT NULL;
+ALTER TABLE tasks ADD CONSTRAINT tasks_assignee_id_fk FOREIGN KEY (assignee_id) REFERENCES users(id);
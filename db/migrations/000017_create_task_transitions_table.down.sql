@@ -0,0 +1 @@
+DROP TABLE task_transitions;
\ No newline at end of file
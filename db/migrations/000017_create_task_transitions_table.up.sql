@@ -0,0 +1,12 @@
+CREATE TABLE task_transitions (
+	id				int				NOT NULL	AUTO_INCREMENT,
+	created_at		timestamp		NOT NULL,
+	task_id			int				NOT NULL,
+	user_id			int				NOT NULL,
+	from_status		varchar(20)		NOT NULL,
+	to_status		varchar(20)		NOT NULL,
+	PRIMARY KEY (id),
+	INDEX (task_id, created_at),
+	FOREIGN KEY (task_id) REFERENCES tasks(id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
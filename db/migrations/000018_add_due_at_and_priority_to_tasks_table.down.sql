@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP INDEX tasks_due_at_idx;
+ALTER TABLE tasks DROP COLUMN priority;
+ALTER TABLE tasks DROP COLUMN due_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN due_at timestamp NULL AFTER status;
+ALTER TABLE tasks ADD COLUMN priority varchar(20) NOT NULL DEFAULT 'normal' AFTER due_at;
+ALTER TABLE tasks ADD INDEX tasks_due_at_idx (due_at);
@@ -0,0 +1 @@
+DROP TABLE task_reminders;
\ No newline at end of file
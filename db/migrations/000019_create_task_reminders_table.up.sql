@@ -0,0 +1,10 @@
+CREATE TABLE task_reminders (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	task_id		int				NOT NULL,
+	threshold	varchar(20)		NOT NULL,
+	due_at		timestamp		NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE (task_id, threshold, due_at),
+	FOREIGN KEY (task_id) REFERENCES tasks(id)
+);
@@ -0,0 +1 @@
+DROP TABLE task_comments;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE task_comments (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	updated_at	timestamp		NOT NULL,
+	deleted_at	timestamp		NULL,
+	task_id		int				NOT NULL,
+	user_id		int				NOT NULL,
+	body		varchar(5000)	NOT NULL,
+	PRIMARY KEY (id),
+	INDEX (task_id, created_at),
+	FOREIGN KEY (task_id) REFERENCES tasks(id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1 @@
+DROP TABLE task_attachments;
\ No newline at end of file
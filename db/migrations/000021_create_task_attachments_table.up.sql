@@ -0,0 +1,15 @@
+CREATE TABLE task_attachments (
+	id				int				NOT NULL	AUTO_INCREMENT,
+	created_at		timestamp		NOT NULL,
+	deleted_at		timestamp		NULL,
+	task_id			int				NOT NULL,
+	user_id			int				NOT NULL,
+	filename		varchar(255)	NOT NULL,
+	content_type	varchar(100)	NOT NULL,
+	size			bigint			NOT NULL,
+	storage_key		varchar(255)	NOT NULL,
+	PRIMARY KEY (id),
+	INDEX (task_id, created_at),
+	FOREIGN KEY (task_id) REFERENCES tasks(id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
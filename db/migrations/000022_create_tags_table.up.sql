@@ -0,0 +1,8 @@
+CREATE TABLE tags (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	updated_at	timestamp		NOT NULL,
+	name		varchar(50)		NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE (name)
+);
@@ -0,0 +1 @@
+DROP TABLE task_tags;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE task_tags (
+	task_id		int				NOT NULL,
+	tag_id		int				NOT NULL,
+	PRIMARY KEY (task_id, tag_id),
+	INDEX (tag_id),
+	FOREIGN KEY (task_id) REFERENCES tasks(id),
+	FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
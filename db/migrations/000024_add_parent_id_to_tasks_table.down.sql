@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP FOREIGN KEY tasks_parent_id_fk;
+ALTER TABLE tasks DROP COLUMN parent_id;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN parent_id int NULL AFTER assignee_id;
+ALTER TABLE tasks ADD CONSTRAINT tasks_parent_id_fk FOREIGN KEY (parent_id) REFERENCES tasks(id);
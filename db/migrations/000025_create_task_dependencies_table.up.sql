@@ -0,0 +1,11 @@
+CREATE TABLE task_dependencies (
+	task_id			int			NOT NULL,
+	blocked_by_id	int			NOT NULL,
+	created_at		timestamp	NOT NULL,
+	user_id			int			NOT NULL,
+	PRIMARY KEY (task_id, blocked_by_id),
+	INDEX (blocked_by_id),
+	FOREIGN KEY (task_id) REFERENCES tasks(id),
+	FOREIGN KEY (blocked_by_id) REFERENCES tasks(id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
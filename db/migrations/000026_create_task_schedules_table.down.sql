@@ -0,0 +1 @@
+DROP TABLE task_schedules;
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE task_schedules (
+	id				int				NOT NULL	AUTO_INCREMENT,
+	created_at		timestamp		NOT NULL,
+	updated_at		timestamp		NOT NULL,
+	deleted_at		timestamp		NULL,
+	user_id			int				NOT NULL,
+	assignee_id		int				NOT NULL,
+	summary			varchar(2500)	NOT NULL,
+	priority		varchar(20)		NOT NULL	DEFAULT 'normal',
+	cron			varchar(100)	NOT NULL,
+	enabled			boolean			NOT NULL	DEFAULT TRUE,
+	next_run_at		timestamp		NOT NULL,
+	last_run_at		timestamp		NULL,
+	locked_by		varchar(64)		NULL,
+	locked_until	timestamp		NULL,
+	PRIMARY KEY (id),
+	INDEX (enabled, next_run_at),
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	FOREIGN KEY (assignee_id) REFERENCES users(id)
+);
@@ -0,0 +1 @@
+DROP TABLE task_schedule_runs;
\ No newline at end of file
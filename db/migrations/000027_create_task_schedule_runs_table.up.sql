@@ -0,0 +1,9 @@
+CREATE TABLE task_schedule_runs (
+	schedule_id	int			NOT NULL,
+	run_at		timestamp	NOT NULL,
+	created_at	timestamp	NOT NULL,
+	task_id		int			NULL,
+	PRIMARY KEY (schedule_id, run_at),
+	FOREIGN KEY (schedule_id) REFERENCES task_schedules(id),
+	FOREIGN KEY (task_id) REFERENCES tasks(id)
+);
@@ -0,0 +1 @@
+DROP TABLE task_templates;
\ No newline at end of file
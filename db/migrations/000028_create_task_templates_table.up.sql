@@ -0,0 +1,12 @@
+CREATE TABLE task_templates (
+	id			int				NOT NULL	AUTO_INCREMENT,
+	created_at	timestamp		NOT NULL,
+	updated_at	timestamp		NOT NULL,
+	user_id		int				NOT NULL,
+	name		varchar(100)	NOT NULL,
+	summary		varchar(2500)	NOT NULL,
+	priority	varchar(20)		NOT NULL	DEFAULT 'normal',
+	PRIMARY KEY (id),
+	UNIQUE (name),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
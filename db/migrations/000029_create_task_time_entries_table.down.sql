@@ -0,0 +1 @@
+DROP TABLE task_time_entries;
\ No newline at end of file
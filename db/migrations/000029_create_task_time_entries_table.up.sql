@@ -0,0 +1,17 @@
+CREATE TABLE task_time_entries (
+	id				int				NOT NULL	AUTO_INCREMENT,
+	created_at		timestamp		NOT NULL,
+	updated_at		timestamp		NOT NULL,
+	task_id			int				NOT NULL,
+	user_id			int				NOT NULL,
+	started_at		timestamp		NOT NULL,
+	ended_at		timestamp		NULL,
+	note			varchar(500)	NOT NULL	DEFAULT '',
+	running_user_id	int				AS (IF(ended_at IS NULL, user_id, NULL)),
+	PRIMARY KEY (id),
+	UNIQUE (running_user_id),
+	INDEX (task_id, started_at),
+	INDEX (user_id, started_at),
+	FOREIGN KEY (task_id) REFERENCES tasks(id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX task_transitions_to_status_created_at_idx ON task_transitions;
+DROP INDEX tasks_created_at_idx ON tasks;
\ No newline at end of file
@@ -0,0 +1,2 @@
+CREATE INDEX tasks_created_at_idx ON tasks (created_at);
+CREATE INDEX task_transitions_to_status_created_at_idx ON task_transitions (to_status, created_at);
\ No newline at end of file
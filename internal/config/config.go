@@ -26,10 +26,153 @@ type Crypto struct {
 	ExpiresIn int64 `mapstructure:"expires_in"`
 }
 
+type LoginGuard struct {
+	Store                  string `mapstructure:"store"`
+	Window                 int64  `mapstructure:"window"`
+	MaxAttemptsPerUsername int    `mapstructure:"max_attempts_per_username"`
+	MaxAttemptsPerIP       int    `mapstructure:"max_attempts_per_ip"`
+	LockoutThreshold       int    `mapstructure:"lockout_threshold"`
+	LockoutDuration        int64  `mapstructure:"lockout_duration"`
+	BaseDelay              int64  `mapstructure:"base_delay"`
+	MaxDelay               int64  `mapstructure:"max_delay"`
+}
+
+type RateLimitPolicy struct {
+	Capacity    int   `mapstructure:"capacity"`
+	RefillEvery int64 `mapstructure:"refill_every"`
+}
+
+type RateLimit struct {
+	Groups map[string]RateLimitPolicy `mapstructure:"groups"`
+}
+
+type Mfa struct {
+	Issuer         string `mapstructure:"issuer"`
+	EnforceManager bool   `mapstructure:"enforce_manager"`
+}
+
+type Oidc struct {
+	Enabled          bool   `mapstructure:"enabled"`
+	Issuer           string `mapstructure:"issuer"`
+	ClientID         string `mapstructure:"client_id"`
+	ClientSecret     string
+	RedirectURL      string   `mapstructure:"redirect_url"`
+	Scopes           []string `mapstructure:"scopes"`
+	RoleClaim        string   `mapstructure:"role_claim"`
+	ManagerGroups    []string `mapstructure:"manager_groups"`
+	SessionExpiresIn int64    `mapstructure:"session_expires_in"`
+	CookieSecure     bool     `mapstructure:"cookie_secure"`
+}
+
+type Mailer struct {
+	Driver   string `mapstructure:"driver"`
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string
+	From     string `mapstructure:"from"`
+}
+
+type PasswordReset struct {
+	URL       string `mapstructure:"url"`
+	ExpiresIn int64  `mapstructure:"expires_in"`
+}
+
+type Invitation struct {
+	URL       string `mapstructure:"url"`
+	ExpiresIn int64  `mapstructure:"expires_in"`
+}
+
+type PasswordPolicy struct {
+	MinLength        int    `mapstructure:"min_length"`
+	MaxLength        int    `mapstructure:"max_length"`
+	RequireUppercase bool   `mapstructure:"require_uppercase"`
+	RequireLowercase bool   `mapstructure:"require_lowercase"`
+	RequireDigit     bool   `mapstructure:"require_digit"`
+	RequireSymbol    bool   `mapstructure:"require_symbol"`
+	History          int    `mapstructure:"history"`
+	BreachedList     string `mapstructure:"breached_list"`
+}
+
+type Session struct {
+	CacheTTL int64 `mapstructure:"cache_ttl"`
+}
+
+type Impersonation struct {
+	ExpiresIn int64 `mapstructure:"expires_in"`
+}
+
+type TaskTransition struct {
+	From  string   `mapstructure:"from"`
+	To    string   `mapstructure:"to"`
+	Roles []string `mapstructure:"roles"`
+}
+
+type TaskWorkflow struct {
+	Transitions []TaskTransition `mapstructure:"transitions"`
+}
+
+type TaskReminder struct {
+	Enabled  bool  `mapstructure:"enabled"`
+	Interval int64 `mapstructure:"interval"`
+	DueSoon  int64 `mapstructure:"due_soon"`
+}
+
+type TaskBulk struct {
+	MaxItems int `mapstructure:"max_items"`
+}
+
+type TaskImport struct {
+	MaxRows int `mapstructure:"max_rows"`
+}
+
+type TaskScheduler struct {
+	Enabled  bool  `mapstructure:"enabled"`
+	Interval int64 `mapstructure:"interval"`
+	Lease    int64 `mapstructure:"lease"`
+}
+
+type TaskComment struct {
+	EditWindow int64 `mapstructure:"edit_window"`
+}
+
+type BlobStore struct {
+	Driver    string `mapstructure:"driver"`
+	Dir       string `mapstructure:"dir"`
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string
+}
+
+type TaskAttachment struct {
+	MaxSize      int64    `mapstructure:"max_size"`
+	AllowedTypes []string `mapstructure:"allowed_types"`
+}
+
 type Config struct {
-	Server ServerConfig `mapstructure:"server"`
-	MySQL  MySQLConfig  `mapstructure:"mysql"`
-	Crypto Crypto       `mapstructure:"crypto"`
+	Server         ServerConfig   `mapstructure:"server"`
+	MySQL          MySQLConfig    `mapstructure:"mysql"`
+	Crypto         Crypto         `mapstructure:"crypto"`
+	LoginGuard     LoginGuard     `mapstructure:"login_guard"`
+	RateLimit      RateLimit      `mapstructure:"rate_limit"`
+	Mfa            Mfa            `mapstructure:"mfa"`
+	Oidc           Oidc           `mapstructure:"oidc"`
+	Mailer         Mailer         `mapstructure:"mailer"`
+	PasswordReset  PasswordReset  `mapstructure:"password_reset"`
+	Invitation     Invitation     `mapstructure:"invitation"`
+	PasswordPolicy PasswordPolicy `mapstructure:"password_policy"`
+	Session        Session        `mapstructure:"session"`
+	Impersonation  Impersonation  `mapstructure:"impersonation"`
+	TaskWorkflow   TaskWorkflow   `mapstructure:"task_workflow"`
+	TaskReminder   TaskReminder   `mapstructure:"task_reminder"`
+	TaskScheduler  TaskScheduler  `mapstructure:"task_scheduler"`
+	TaskBulk       TaskBulk       `mapstructure:"task_bulk"`
+	TaskImport     TaskImport     `mapstructure:"task_import"`
+	TaskComment    TaskComment    `mapstructure:"task_comment"`
+	BlobStore      BlobStore      `mapstructure:"blob_store"`
+	TaskAttachment TaskAttachment `mapstructure:"task_attachment"`
 }
 
 func LoadConfig() Config {
@@ -51,6 +194,9 @@ func LoadConfig() Config {
 	configuration.MySQL.Password = os.Getenv("MYSQL_PASSWORD")
 	configuration.Crypto.HashKey = os.Getenv("CRYPTO_HASH_KEY")
 	configuration.Crypto.JwtKey = os.Getenv("CRYPTO_JWT_KEY")
+	configuration.Oidc.ClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+	configuration.Mailer.Password = os.Getenv("SMTP_PASSWORD")
+	configuration.BlobStore.SecretKey = os.Getenv("BLOB_STORE_SECRET_KEY")
 
 	return configuration
 }
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type ApiKeyController interface {
+	CreateApiKey(ctx *gin.Context)
+	ListApiKeys(ctx *gin.Context)
+	DeleteApiKey(ctx *gin.Context)
+}
+
+type apiKeyController struct {
+	apiKeyService service.ApiKeyService
+}
+
+func NewApiKeyController(router *gin.RouterGroup, apiKeyService service.ApiKeyService,
+	middlewareAccessToken, middlewareNotImpersonated func(ctx *gin.Context)) ApiKeyController {
+	impl := &apiKeyController{
+		apiKeyService: apiKeyService,
+	}
+
+	router.POST("/me/api-keys", middlewareAccessToken, middlewareNotImpersonated, impl.CreateApiKey)
+	router.GET("/me/api-keys", middlewareAccessToken, impl.ListApiKeys)
+	router.DELETE("/me/api-keys/:id", middlewareAccessToken, impl.DeleteApiKey)
+
+	return impl
+}
+
+// @Summary create api key
+// @Description The full key is only returned once, on creation
+// @Schemes
+// @Tags api-key
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.CreateApiKeyDto true "api key"
+// @Success 201 {object} dto.ApiKeyResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/api-keys [post]
+func (impl *apiKeyController) CreateApiKey(ctx *gin.Context) {
+	var data dto.CreateApiKeyDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	apiKey, key, err := impl.apiKeyService.CreateApiKey(ctx, userID, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	res := impl.ParseApiKeyDto(apiKey)
+	res.Key = key
+
+	ctx.JSON(http.StatusCreated, dto.ApiKeyResponse{Data: res})
+}
+
+// @Summary list api keys
+// @Schemes
+// @Tags api-key
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} dto.ApiKeysResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/api-keys [get]
+func (impl *apiKeyController) ListApiKeys(ctx *gin.Context) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	apiKeys, err := impl.apiKeyService.ListApiKeys(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.ApiKeyDto{}
+	for _, k := range apiKeys {
+		data = append(data, impl.ParseApiKeyDto(&k))
+	}
+
+	ctx.JSON(http.StatusOK, dto.ApiKeysResponse{Data: data})
+}
+
+// @Summary delete api key
+// @Schemes
+// @Tags api-key
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "api key id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/api-keys/{id} [delete]
+func (impl *apiKeyController) DeleteApiKey(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	if err := impl.apiKeyService.DeleteApiKey(ctx, userID, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *apiKeyController) ParseApiKeyDto(apiKey *model.ApiKey) dto.ApiKeyDto {
+	dto := dto.ApiKeyDto{
+		ID:        apiKey.ID,
+		CreatedAt: apiKey.CreatedAt.Format("2006-01-02 15:04:05"),
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.ScopeList(),
+	}
+	if apiKey.ExpiresAt != nil {
+		dto.ExpiresAt = apiKey.ExpiresAt.Format("2006-01-02 15:04:05")
+	}
+	if apiKey.LastUsedAt != nil {
+		dto.LastUsedAt = apiKey.LastUsedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return dto
+}
@@ -0,0 +1,281 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestApiKeyControllerCreateApiKey(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(apiKeyService *mock.MockApiKeyService)
+		expectedStatusCode int
+		expectedBody       dto.ApiKeyResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should create api key": {
+			inputPayload: `{"name": "integration", "scopes": ["tasks:read"]}`,
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().CreateApiKey(gomock.Any(), 1, dto.CreateApiKeyDto{
+					Name:   "integration",
+					Scopes: []model.ApiKeyScope{model.ApiKeyScopeTasksRead},
+				}).Return(&model.ApiKey{
+					ID:        1,
+					CreatedAt: now,
+					UserID:    1,
+					Name:      "integration",
+					Prefix:    "sh_1a2b3c4d",
+					Scopes:    "tasks:read",
+				}, "sh_1a2b3c4d_secret", nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.ApiKeyResponse{Data: dto.ApiKeyDto{
+				ID:        1,
+				CreatedAt: now.Format("2006-01-02 15:04:05"),
+				Name:      "integration",
+				Prefix:    "sh_1a2b3c4d",
+				Scopes:    []model.ApiKeyScope{model.ApiKeyScopeTasksRead},
+				Key:       "sh_1a2b3c4d_secret",
+			}},
+		},
+		"should throw bad request when scope is invalid": {
+			inputPayload:       `{"name": "integration", "scopes": ["tasks:delete"]}`,
+			mocking:            func(apiKeyService *mock.MockApiKeyService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/me/api-keys",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "scopes[0]", Code: "oneof", Message: "scopes[0] has an invalid value"},
+				},
+			},
+		},
+		"should throw internal server error": {
+			inputPayload: `{"name": "integration", "scopes": ["tasks:read"]}`,
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().CreateApiKey(gomock.Any(), 1, gomock.Any()).Return(nil, "", fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/me/api-keys",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("POST", "/api/me/api-keys", strings.NewReader(cs.inputPayload))
+
+			apiKeyServiceMock := mock.NewMockApiKeyService(ctrl)
+			apiKeyController := controller.NewApiKeyController(r.Group("/api"), apiKeyServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(apiKeyServiceMock)
+
+			// when
+			apiKeyController.CreateApiKey(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.ApiKeyResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestApiKeyControllerListApiKeys(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		mocking            func(apiKeyService *mock.MockApiKeyService)
+		expectedStatusCode int
+		expectedBody       dto.ApiKeysResponse
+	}{
+		"should list api keys without secrets": {
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().ListApiKeys(gomock.Any(), 1).Return([]model.ApiKey{{
+					ID:         1,
+					CreatedAt:  now,
+					UserID:     1,
+					Name:       "integration",
+					Prefix:     "sh_1a2b3c4d",
+					KeyHash:    "hash",
+					Scopes:     "tasks:read,tasks:write",
+					ExpiresAt:  &now,
+					LastUsedAt: &now,
+				}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.ApiKeysResponse{Data: []dto.ApiKeyDto{{
+				ID:         1,
+				CreatedAt:  now.Format("2006-01-02 15:04:05"),
+				Name:       "integration",
+				Prefix:     "sh_1a2b3c4d",
+				Scopes:     []model.ApiKeyScope{model.ApiKeyScopeTasksRead, model.ApiKeyScopeTasksWrite},
+				ExpiresAt:  now.Format("2006-01-02 15:04:05"),
+				LastUsedAt: now.Format("2006-01-02 15:04:05"),
+			}}},
+		},
+		"should list empty api keys": {
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().ListApiKeys(gomock.Any(), 1).Return([]model.ApiKey{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.ApiKeysResponse{Data: []dto.ApiKeyDto{}},
+		},
+		"should throw internal server error": {
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().ListApiKeys(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("GET", "/api/me/api-keys", nil)
+
+			apiKeyServiceMock := mock.NewMockApiKeyService(ctrl)
+			apiKeyController := controller.NewApiKeyController(r.Group("/api"), apiKeyServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(apiKeyServiceMock)
+
+			// when
+			apiKeyController.ListApiKeys(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.ApiKeysResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestApiKeyControllerDeleteApiKey(t *testing.T) {
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(apiKeyService *mock.MockApiKeyService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should delete api key": {
+			inputID: "1",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().DeleteApiKey(gomock.Any(), 1, 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw not found": {
+			inputID: "2",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().DeleteApiKey(gomock.Any(), 1, 2).
+					Return(&exception.NotFoundException{Message: "api key not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "api key not found",
+				Instance: "/api/me/api-keys/2",
+				Code:     "not_found",
+			},
+		},
+		"should throw bad request when id is invalid": {
+			inputID:            "abc",
+			mocking:            func(apiKeyService *mock.MockApiKeyService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/me/api-keys/abc",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "id", Code: "numeric", Message: "id must be a number"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/me/api-keys/"+cs.inputID, nil)
+
+			apiKeyServiceMock := mock.NewMockApiKeyService(ctrl)
+			apiKeyController := controller.NewApiKeyController(r.Group("/api"), apiKeyServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(apiKeyServiceMock)
+
+			// when
+			apiKeyController.DeleteApiKey(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
@@ -1,33 +1,46 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/viniosilva/swordhealth-api/internal/dto"
 	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
 	"github.com/viniosilva/swordhealth-api/internal/service"
 )
 
 type AuthController interface {
 	Login(ctx *gin.Context)
+	VerifyMfa(ctx *gin.Context)
 }
 
 type authController struct {
-	authService   service.AuthService
-	userService   service.UserService
-	cryptoService service.CryptoService
+	authService       service.AuthService
+	userService       service.UserService
+	cryptoService     service.CryptoService
+	loginGuardService service.LoginGuardService
+	mfaService        service.MfaService
+	sessionService    service.SessionService
 }
 
-func NewAuthController(router *gin.RouterGroup, authService service.AuthService,
-	userService service.UserService, cryptoService service.CryptoService) AuthController {
+func NewAuthController(router *gin.RouterGroup, authService service.AuthService, userService service.UserService,
+	cryptoService service.CryptoService, loginGuardService service.LoginGuardService, mfaService service.MfaService,
+	sessionService service.SessionService) AuthController {
 	impl := &authController{
-		authService:   authService,
-		userService:   userService,
-		cryptoService: cryptoService,
+		authService:       authService,
+		userService:       userService,
+		cryptoService:     cryptoService,
+		loginGuardService: loginGuardService,
+		mfaService:        mfaService,
+		sessionService:    sessionService,
 	}
 
 	router.POST("/auth/login", impl.Login)
+	router.POST("/auth/mfa/verify", impl.VerifyMfa)
 
 	return impl
 }
@@ -39,13 +52,21 @@ func NewAuthController(router *gin.RouterGroup, authService service.AuthService,
 // @Produce json
 // @Security BasicAuth
 // @Success 200 {object} dto.AuthLoginResponse
-// @Failure 400 {object} dto.ApiError
-// @Failure 403 {object} dto.ApiError
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Header 429 {integer} Retry-After "seconds to wait before retrying"
 // @Router /auth/login [post]
 func (impl *authController) Login(ctx *gin.Context) {
 	username, password, err := impl.authService.DecodeBasicAuth(ctx, ctx.Request.Header.Get("Authorization"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: err.Error()})
+		ctx.Error(err)
+		return
+	}
+
+	ip, userAgent := ctx.ClientIP(), ctx.Request.UserAgent()
+	if err := impl.loginGuardService.Check(ctx, username, ip, userAgent); err != nil {
+		ctx.Error(err)
 		return
 	}
 
@@ -53,19 +74,137 @@ func (impl *authController) Login(ctx *gin.Context) {
 	user, err := impl.userService.GetUserByUsernameAndPassword(ctx, username, encryptedPassword)
 	if err != nil {
 		if _, ok := err.(*exception.NotFoundException); ok {
-			ctx.JSON(http.StatusForbidden, dto.ApiError{Error: err.Error()})
+			if err := impl.loginGuardService.RegisterFailure(ctx, username, ip, userAgent); err != nil {
+				ctx.Error(err)
+				return
+			}
+			err = &exception.ForbiddenException{Message: err.Error()}
+		}
+		ctx.Error(err)
+		return
+	}
+
+	issueLoginToken(ctx, impl.cryptoService, impl.sessionService, impl.mfaService, impl.loginGuardService, user)
+}
+
+// @Summary verify mfa challenge
+// @Schemes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyMfaDto true "mfa challenge"
+// @Success 200 {object} dto.AuthLoginResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Router /auth/mfa/verify [post]
+func (impl *authController) VerifyMfa(ctx *gin.Context) {
+	var data dto.VerifyMfaDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	claims, err := impl.cryptoService.DecryptJwt(ctx, data.MfaToken)
+	if err != nil {
+		if _, ok := err.(*exception.ExpiredTokenException); !ok {
+			err = &exception.ForbiddenException{Message: err.Error()}
+		}
+		ctx.Error(err)
+		return
+	}
+	if tokenUse, _ := claims[model.TokenUseClaim].(string); model.TokenUse(tokenUse) != model.TokenUseMfaChallenge {
+		ctx.Error(&exception.ForbiddenException{Message: "invalid token use"})
+		return
+	}
+
+	userID, _ := strconv.Atoi(fmt.Sprint(claims["sub"]))
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ip, userAgent := ctx.ClientIP(), ctx.Request.UserAgent()
+	if err := impl.loginGuardService.Check(ctx, user.Username, ip, userAgent); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := impl.mfaService.VerifyCode(ctx, user.ID, data.Code); err != nil {
+		if _, ok := err.(*exception.InvalidMfaCodeException); ok {
+			if err := impl.loginGuardService.RegisterFailure(ctx, user.Username, ip, userAgent); err != nil {
+				ctx.Error(err)
+				return
+			}
+		}
+		ctx.Error(err)
+		return
+	}
+
+	impl.loginGuardService.RegisterSuccess(ctx, user.Username)
+	issueAccessToken(ctx, impl.cryptoService, impl.sessionService, user)
+}
+
+// issueLoginToken ends a login: users who must pass MFA get a challenge, or
+// enrollment, token and everyone else an access token. The login guard, when
+// given, is only cleared once the access token is due, so failed MFA codes
+// keep counting after the password was right.
+func issueLoginToken(ctx *gin.Context, cryptoService service.CryptoService, sessionService service.SessionService,
+	mfaService service.MfaService, loginGuardService service.LoginGuardService, user *model.User) {
+	mfaStatus, err := mfaService.GetStatus(ctx, user)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if mfaStatus.Required {
+		tokenUse := model.TokenUseMfaChallenge
+		if !mfaStatus.Enrolled {
+			tokenUse = model.TokenUseMfaEnrollment
+		}
+
+		mfaToken, err := cryptoService.EncryptJwt(ctx, user.ID, map[string]interface{}{
+			"username":          user.Username,
+			"role":              user.Role,
+			"exp":               time.Now().Add(service.MfaTokenExpiresIn).Unix(),
+			model.TokenUseClaim: tokenUse,
+		})
+		if err != nil {
+			ctx.Error(err)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+
+		ctx.JSON(http.StatusOK, dto.AuthLoginResponse{
+			MfaToken:              mfaToken,
+			MfaRequired:           mfaStatus.Enrolled,
+			MfaEnrollmentRequired: !mfaStatus.Enrolled,
+		})
+		return
+	}
+
+	if loginGuardService != nil {
+		loginGuardService.RegisterSuccess(ctx, user.Username)
+	}
+	issueAccessToken(ctx, cryptoService, sessionService, user)
+}
+
+func issueAccessToken(ctx *gin.Context, cryptoService service.CryptoService, sessionService service.SessionService,
+	user *model.User) {
+	session, err := sessionService.CreateSession(ctx, user.ID, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
-	accessToken, err := impl.cryptoService.EncryptJwt(ctx, user.ID, map[string]interface{}{
-		"username": user.Username,
-		"role":     user.Role,
+	accessToken, err := cryptoService.EncryptJwt(ctx, user.ID, map[string]interface{}{
+		"username":           user.Username,
+		"role":               user.Role,
+		model.SessionIDClaim: session.ID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+		ctx.Error(err)
 		return
 	}
 
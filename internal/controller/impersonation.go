@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type ImpersonationController interface {
+	Impersonate(ctx *gin.Context)
+}
+
+type impersonationController struct {
+	impersonationService service.ImpersonationService
+	cryptoService        service.CryptoService
+	auditService         service.AuditService
+	expiresIn            time.Duration
+}
+
+func NewImpersonationController(router *gin.RouterGroup, impersonationService service.ImpersonationService,
+	cryptoService service.CryptoService, auditService service.AuditService, expiresIn time.Duration,
+	middlewareAccessToken, middlewareUserManager, middlewareNotImpersonated func(ctx *gin.Context)) ImpersonationController {
+	impl := &impersonationController{
+		impersonationService: impersonationService,
+		cryptoService:        cryptoService,
+		auditService:         auditService,
+		expiresIn:            expiresIn,
+	}
+
+	router.POST("/auth/impersonate/:userID", middlewareAccessToken, middlewareUserManager, middlewareNotImpersonated,
+		impl.Impersonate)
+
+	return impl
+}
+
+// @Summary impersonate user
+// @Description Issues a short-lived access token for a technician carrying the manager as `act` claim
+// @Schemes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param userID path int true "user id"
+// @Success 200 {object} dto.AuthLoginResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/impersonate/{userID} [post]
+func (impl *impersonationController) Impersonate(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "userID", Tag: "numeric", Message: "userID must be a number"}},
+		})
+		return
+	}
+
+	paramActorID, _ := ctx.Params.Get("sub")
+	actorID, _ := strconv.Atoi(paramActorID)
+	paramSessionID, _ := ctx.Params.Get(model.SessionIDClaim)
+	sessionID, _ := strconv.Atoi(paramSessionID)
+
+	user, err := impl.impersonationService.Impersonate(ctx, actorID, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	accessToken, err := impl.cryptoService.EncryptJwt(ctx, user.ID, map[string]interface{}{
+		"username":           user.Username,
+		"role":               user.Role,
+		"exp":                time.Now().Add(impl.expiresIn).Unix(),
+		model.SessionIDClaim: sessionID,
+		model.ActorClaim:     map[string]interface{}{"sub": actorID},
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	_, err = impl.auditService.Record(ctx, model.AuditLog{
+		RequestID: ctx.GetString(RequestIDKey),
+		ActorID:   actorID,
+		UserID:    user.ID,
+		Action:    model.AuditActionImpersonationStart,
+		Method:    ctx.Request.Method,
+		Path:      ctx.Request.URL.Path,
+		Status:    http.StatusOK,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.AuthLoginResponse{AccessToken: accessToken})
+}
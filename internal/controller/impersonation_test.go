@@ -0,0 +1,165 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestImpersonationControllerImpersonate(t *testing.T) {
+	technician := &model.User{ID: 2, Username: "technician", Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputUserID        string
+		mocking            func(impersonationService *mock.MockImpersonationService, cryptoService *mock.MockCryptoService, auditService *mock.MockAuditService)
+		expectedStatusCode int
+		expectedBody       dto.AuthLoginResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should issue impersonation token": {
+			inputUserID: "2",
+			mocking: func(impersonationService *mock.MockImpersonationService, cryptoService *mock.MockCryptoService, auditService *mock.MockAuditService) {
+				impersonationService.EXPECT().Impersonate(gomock.Any(), 1, 2).Return(technician, nil)
+				cryptoService.EXPECT().EncryptJwt(gomock.Any(), 2, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, sub interface{}, claims map[string]interface{}) (string, error) {
+						assert.Equal(t, "technician", claims["username"])
+						assert.Equal(t, model.UserRoleTechnician, claims["role"])
+						assert.Equal(t, 7, claims[model.SessionIDClaim])
+						assert.Equal(t, map[string]interface{}{"sub": 1}, claims[model.ActorClaim])
+						assert.InDelta(t, time.Now().Add(5*time.Minute).Unix(), claims["exp"], 5)
+						return "impersonation-token", nil
+					})
+				auditService.EXPECT().Record(gomock.Any(), model.AuditLog{
+					ActorID: 1,
+					UserID:  2,
+					Action:  model.AuditActionImpersonationStart,
+					Method:  "POST",
+					Path:    "/api/auth/impersonate/2",
+					Status:  http.StatusOK,
+				}).Return(&model.AuditLog{ID: 1}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.AuthLoginResponse{AccessToken: "impersonation-token"},
+		},
+		"should throw bad request when user id is invalid": {
+			inputUserID: "abc",
+			mocking: func(impersonationService *mock.MockImpersonationService, cryptoService *mock.MockCryptoService, auditService *mock.MockAuditService) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/auth/impersonate/abc",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "userID", Code: "numeric", Message: "userID must be a number"},
+				},
+			},
+		},
+		"should throw forbidden when user is a manager": {
+			inputUserID: "3",
+			mocking: func(impersonationService *mock.MockImpersonationService, cryptoService *mock.MockCryptoService, auditService *mock.MockAuditService) {
+				impersonationService.EXPECT().Impersonate(gomock.Any(), 1, 3).
+					Return(nil, &exception.ForbiddenException{Message: "only technicians can be impersonated"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "only technicians can be impersonated",
+				Instance: "/api/auth/impersonate/3",
+				Code:     "forbidden",
+			},
+		},
+		"should throw internal server error when impersonation can not be audited": {
+			inputUserID: "2",
+			mocking: func(impersonationService *mock.MockImpersonationService, cryptoService *mock.MockCryptoService, auditService *mock.MockAuditService) {
+				impersonationService.EXPECT().Impersonate(gomock.Any(), 1, 2).Return(technician, nil)
+				cryptoService.EXPECT().EncryptJwt(gomock.Any(), 2, gomock.Any()).Return("impersonation-token", nil)
+				auditService.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/auth/impersonate/2",
+				Code:     "internal_error",
+			},
+		},
+		"should throw internal server error": {
+			inputUserID: "2",
+			mocking: func(impersonationService *mock.MockImpersonationService, cryptoService *mock.MockCryptoService, auditService *mock.MockAuditService) {
+				impersonationService.EXPECT().Impersonate(gomock.Any(), 1, 2).Return(technician, nil)
+				cryptoService.EXPECT().EncryptJwt(gomock.Any(), 2, gomock.Any()).Return("", fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/auth/impersonate/2",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params,
+				gin.Param{Key: "userID", Value: cs.inputUserID},
+				gin.Param{Key: "sub", Value: "1"},
+				gin.Param{Key: model.SessionIDClaim, Value: "7"},
+			)
+			ctx.Request = httptest.NewRequest("POST", "/api/auth/impersonate/"+cs.inputUserID, nil)
+
+			impersonationServiceMock := mock.NewMockImpersonationService(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			auditServiceMock := mock.NewMockAuditService(ctrl)
+			impersonationController := controller.NewImpersonationController(r.Group("/api"), impersonationServiceMock,
+				cryptoServiceMock, auditServiceMock, 5*time.Minute, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(impersonationServiceMock, cryptoServiceMock, auditServiceMock)
+
+			// when
+			impersonationController.Impersonate(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.AuthLoginResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type InvitationController interface {
+	Invite(ctx *gin.Context)
+	ListInvitations(ctx *gin.Context)
+	ResendInvitation(ctx *gin.Context)
+	RevokeInvitation(ctx *gin.Context)
+	AcceptInvitation(ctx *gin.Context)
+}
+
+type invitationController struct {
+	invitationService service.InvitationService
+}
+
+func NewInvitationController(router *gin.RouterGroup, invitationService service.InvitationService,
+	middlewareAccessToken, middlewareUserManager, middlewareNotImpersonated func(ctx *gin.Context)) InvitationController {
+	impl := &invitationController{
+		invitationService: invitationService,
+	}
+
+	router.POST("/users/invitations", middlewareAccessToken, middlewareUserManager, middlewareNotImpersonated, impl.Invite)
+	router.GET("/users/invitations", middlewareAccessToken, middlewareUserManager, impl.ListInvitations)
+	router.POST("/users/invitations/:id/resend", middlewareAccessToken, middlewareUserManager, impl.ResendInvitation)
+	router.DELETE("/users/invitations/:id", middlewareAccessToken, middlewareUserManager, impl.RevokeInvitation)
+	router.POST("/auth/invitations/accept", impl.AcceptInvitation)
+
+	return impl
+}
+
+// @Summary invite user
+// @Description Creates a pending user and mails an invite link to choose a password
+// @Schemes
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.CreateInvitationDto true "invitation"
+// @Success 201 {object} dto.InvitationResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/invitations [post]
+func (impl *invitationController) Invite(ctx *gin.Context) {
+	registerValidationUserEnum()
+
+	var data dto.CreateInvitationDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	invitation, err := impl.invitationService.Invite(ctx, userID, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.InvitationResponse{Data: impl.ParseInvitationDto(invitation)})
+}
+
+// @Summary list pending invitations
+// @Schemes
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} dto.InvitationsResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/invitations [get]
+func (impl *invitationController) ListInvitations(ctx *gin.Context) {
+	invitations, err := impl.invitationService.ListInvitations(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.InvitationDto{}
+	for _, i := range invitations {
+		data = append(data, impl.ParseInvitationDto(&i))
+	}
+
+	ctx.JSON(http.StatusOK, dto.InvitationsResponse{Data: data})
+}
+
+// @Summary resend invitation
+// @Description Issues a new invite link with a renewed expiration, the previous link stops working
+// @Schemes
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "invitation id"
+// @Success 200 {object} dto.InvitationResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/invitations/{id}/resend [post]
+func (impl *invitationController) ResendInvitation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	invitation, err := impl.invitationService.ResendInvitation(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.InvitationResponse{Data: impl.ParseInvitationDto(invitation)})
+}
+
+// @Summary revoke invitation
+// @Schemes
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "invitation id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/invitations/{id} [delete]
+func (impl *invitationController) RevokeInvitation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	if err := impl.invitationService.RevokeInvitation(ctx, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary accept invitation
+// @Description Sets the invited user password and activates the account
+// @Schemes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptInvitationDto true "invite token and password"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/invitations/accept [post]
+func (impl *invitationController) AcceptInvitation(ctx *gin.Context) {
+	var data dto.AcceptInvitationDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	if err := impl.invitationService.AcceptInvitation(ctx, data.Token, data.Password); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *invitationController) ParseInvitationDto(invitation *model.Invitation) dto.InvitationDto {
+	return dto.InvitationDto{
+		ID:        invitation.ID,
+		CreatedAt: invitation.CreatedAt.Format("2006-01-02 15:04:05"),
+		ExpiresAt: invitation.ExpiresAt.Format("2006-01-02 15:04:05"),
+		Status:    invitation.Status(time.Now()),
+		User: dto.UserDto{
+			ID:       invitation.UserID,
+			Username: invitation.Username,
+			Email:    invitation.Email,
+			Role:     invitation.Role,
+			Status:   model.UserStatusPending,
+		},
+		InvitedBy: dto.UserDto{ID: invitation.InvitedBy},
+	}
+}
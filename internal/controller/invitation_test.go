@@ -0,0 +1,441 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestInvitationControllerInvite(t *testing.T) {
+	now := time.Now()
+	invitation := &model.Invitation{
+		ID:        1,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+		InvitedBy: 1,
+		UserID:    2,
+		Username:  "username",
+		Email:     "email@email.com",
+		Role:      model.UserRoleTechnician,
+	}
+
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(invitationService *mock.MockInvitationService)
+		expectedStatusCode int
+		expectedBody       dto.InvitationResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should invite user": {
+			inputPayload: `{"username": "username", "email": "email@email.com"}`,
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().Invite(gomock.Any(), 1, dto.CreateInvitationDto{
+					Username: "username", Email: "email@email.com",
+				}).Return(invitation, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.InvitationResponse{Data: dto.InvitationDto{
+				ID:        1,
+				CreatedAt: now.Format("2006-01-02 15:04:05"),
+				ExpiresAt: now.Add(time.Hour).Format("2006-01-02 15:04:05"),
+				Status:    model.InvitationStatusPending,
+				User: dto.UserDto{
+					ID:       2,
+					Username: "username",
+					Email:    "email@email.com",
+					Role:     model.UserRoleTechnician,
+					Status:   model.UserStatusPending,
+				},
+				InvitedBy: dto.UserDto{ID: 1},
+			}},
+		},
+		"should throw bad request when role is invalid": {
+			inputPayload:       `{"username": "username", "email": "email@email.com", "role": "admin"}`,
+			mocking:            func(invitationService *mock.MockInvitationService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/users/invitations",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "role", Code: "enum", Message: "role has an invalid value"},
+				},
+			},
+		},
+		"should throw conflict when username is already taken": {
+			inputPayload: `{"username": "username", "email": "email@email.com"}`,
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().Invite(gomock.Any(), 1, gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "username already taken"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "username already taken",
+				Instance: "/api/users/invitations",
+				Code:     "conflict",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("POST", "/api/users/invitations", strings.NewReader(cs.inputPayload))
+
+			invitationServiceMock := mock.NewMockInvitationService(ctrl)
+			invitationController := controller.NewInvitationController(r.Group("/api"), invitationServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(invitationServiceMock)
+
+			// when
+			invitationController.Invite(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.InvitationResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestInvitationControllerListInvitations(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		mocking            func(invitationService *mock.MockInvitationService)
+		expectedStatusCode int
+		expectedBody       dto.InvitationsResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should list invitations with expired status": {
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().ListInvitations(gomock.Any()).Return([]model.Invitation{{
+					ID:        1,
+					CreatedAt: now.Add(-2 * time.Hour),
+					ExpiresAt: now.Add(-time.Hour),
+					InvitedBy: 1,
+					UserID:    2,
+					Username:  "username",
+					Email:     "email@email.com",
+					Role:      model.UserRoleTechnician,
+				}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.InvitationsResponse{Data: []dto.InvitationDto{{
+				ID:        1,
+				CreatedAt: now.Add(-2 * time.Hour).Format("2006-01-02 15:04:05"),
+				ExpiresAt: now.Add(-time.Hour).Format("2006-01-02 15:04:05"),
+				Status:    model.InvitationStatusExpired,
+				User: dto.UserDto{
+					ID:       2,
+					Username: "username",
+					Email:    "email@email.com",
+					Role:     model.UserRoleTechnician,
+					Status:   model.UserStatusPending,
+				},
+				InvitedBy: dto.UserDto{ID: 1},
+			}}},
+		},
+		"should throw internal server error": {
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().ListInvitations(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/users/invitations",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/users/invitations", nil)
+
+			invitationServiceMock := mock.NewMockInvitationService(ctrl)
+			invitationController := controller.NewInvitationController(r.Group("/api"), invitationServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(invitationServiceMock)
+
+			// when
+			invitationController.ListInvitations(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.InvitationsResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestInvitationControllerResendInvitation(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(invitationService *mock.MockInvitationService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should resend invitation": {
+			inputID: "1",
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().ResendInvitation(gomock.Any(), 1).
+					Return(&model.Invitation{ID: 1, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		"should throw bad request when id is not a number": {
+			inputID:            "abc",
+			mocking:            func(invitationService *mock.MockInvitationService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/users/invitations/abc/resend",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "id", Code: "numeric", Message: "id must be a number"},
+				},
+			},
+		},
+		"should throw conflict when invitation was accepted": {
+			inputID: "1",
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().ResendInvitation(gomock.Any(), 1).
+					Return(nil, &exception.ConflictException{Message: "invitation already accepted"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "invitation already accepted",
+				Instance: "/api/users/invitations/1/resend",
+				Code:     "conflict",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID})
+			ctx.Request = httptest.NewRequest("POST", "/api/users/invitations/"+cs.inputID+"/resend", nil)
+
+			invitationServiceMock := mock.NewMockInvitationService(ctrl)
+			invitationController := controller.NewInvitationController(r.Group("/api"), invitationServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(invitationServiceMock)
+
+			// when
+			invitationController.ResendInvitation(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestInvitationControllerRevokeInvitation(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(invitationService *mock.MockInvitationService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should revoke invitation": {
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().RevokeInvitation(gomock.Any(), 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw not found": {
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().RevokeInvitation(gomock.Any(), 1).
+					Return(&exception.NotFoundException{Message: "invitation not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "invitation not found",
+				Instance: "/api/users/invitations/1",
+				Code:     "not_found",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/users/invitations/1", nil)
+
+			invitationServiceMock := mock.NewMockInvitationService(ctrl)
+			invitationController := controller.NewInvitationController(r.Group("/api"), invitationServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(invitationServiceMock)
+
+			// when
+			invitationController.RevokeInvitation(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestInvitationControllerAcceptInvitation(t *testing.T) {
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(invitationService *mock.MockInvitationService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should accept invitation": {
+			inputPayload: `{"token": "token", "password": "12345"}`,
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().AcceptInvitation(gomock.Any(), "token", "12345").Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw forbidden when invitation is invalid": {
+			inputPayload: `{"token": "token", "password": "12345"}`,
+			mocking: func(invitationService *mock.MockInvitationService) {
+				invitationService.EXPECT().AcceptInvitation(gomock.Any(), "token", "12345").
+					Return(&exception.ForbiddenException{Message: "invalid or expired invitation"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "invalid or expired invitation",
+				Instance: "/api/auth/invitations/accept",
+				Code:     "forbidden",
+			},
+		},
+		"should throw bad request when token is missing": {
+			inputPayload:       `{"password": "12345"}`,
+			mocking:            func(invitationService *mock.MockInvitationService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/auth/invitations/accept",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "token", Code: "required", Message: "token is required"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/auth/invitations/accept", strings.NewReader(cs.inputPayload))
+
+			invitationServiceMock := mock.NewMockInvitationService(ctrl)
+			invitationController := controller.NewInvitationController(r.Group("/api"), invitationServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(invitationServiceMock)
+
+			// when
+			invitationController.AcceptInvitation(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type MfaController interface {
+	EnrollTotp(ctx *gin.Context)
+	ConfirmTotp(ctx *gin.Context)
+}
+
+type mfaController struct {
+	mfaService  service.MfaService
+	userService service.UserService
+}
+
+func NewMfaController(router *gin.RouterGroup, mfaService service.MfaService, userService service.UserService,
+	middlewareMfaEnrollmentToken, middlewareNotImpersonated func(ctx *gin.Context)) MfaController {
+	impl := &mfaController{
+		mfaService:  mfaService,
+		userService: userService,
+	}
+
+	router.POST("/me/mfa/totp", middlewareMfaEnrollmentToken, middlewareNotImpersonated, impl.EnrollTotp)
+	router.POST("/me/mfa/totp/verify", middlewareMfaEnrollmentToken, middlewareNotImpersonated, impl.ConfirmTotp)
+
+	return impl
+}
+
+// @Summary enroll totp
+// @Schemes
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 201 {object} dto.TotpEnrollmentResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/mfa/totp [post]
+func (impl *mfaController) EnrollTotp(ctx *gin.Context) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	enrollment, err := impl.mfaService.EnrollTotp(ctx, user)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.TotpEnrollmentResponse{Data: dto.TotpEnrollmentDto{
+		Secret:     enrollment.Secret,
+		OtpauthURI: enrollment.OtpauthURI,
+	}})
+}
+
+// @Summary confirm totp enrollment
+// @Schemes
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.ConfirmTotpDto true "totp code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/mfa/totp/verify [post]
+func (impl *mfaController) ConfirmTotp(ctx *gin.Context) {
+	var data dto.ConfirmTotpDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	recoveryCodes, err := impl.mfaService.ConfirmTotp(ctx, userID, data.Code)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.RecoveryCodesResponse{Data: dto.RecoveryCodesDto{RecoveryCodes: recoveryCodes}})
+}
@@ -0,0 +1,268 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestMfaControllerEnrollTotp(t *testing.T) {
+	now := time.Now()
+	user := &model.User{
+		ID:        1,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Username:  "username",
+		Email:     "email@email.com",
+		Role:      model.UserRoleManager,
+	}
+
+	var cases = map[string]struct {
+		mocking            func(mfaService *mock.MockMfaService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TotpEnrollmentResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should enroll totp": {
+			mocking: func(mfaService *mock.MockMfaService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil)
+				mfaService.EXPECT().EnrollTotp(gomock.Any(), user).Return(&model.TotpEnrollment{
+					Secret:     "JBSWY3DPEHPK3PXP",
+					OtpauthURI: "otpauth://totp/Sword%20Health:username?secret=JBSWY3DPEHPK3PXP",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TotpEnrollmentResponse{Data: dto.TotpEnrollmentDto{
+				Secret:     "JBSWY3DPEHPK3PXP",
+				OtpauthURI: "otpauth://totp/Sword%20Health:username?secret=JBSWY3DPEHPK3PXP",
+			}},
+		},
+		"should throw conflict when totp is already enrolled": {
+			mocking: func(mfaService *mock.MockMfaService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil)
+				mfaService.EXPECT().EnrollTotp(gomock.Any(), user).
+					Return(nil, &exception.ConflictException{Message: "totp already enrolled"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "totp already enrolled",
+				Instance: "/api/me/mfa/totp",
+				Code:     "conflict",
+			},
+		},
+		"should throw internal server error": {
+			mocking: func(mfaService *mock.MockMfaService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/me/mfa/totp",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("POST", "/api/me/mfa/totp", nil)
+
+			mfaServiceMock := mock.NewMockMfaService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			mfaController := controller.NewMfaController(r.Group("/api"), mfaServiceMock, userServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(mfaServiceMock, userServiceMock)
+
+			// when
+			mfaController.EnrollTotp(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TotpEnrollmentResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestMfaControllerConfirmTotp(t *testing.T) {
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(mfaService *mock.MockMfaService)
+		expectedStatusCode int
+		expectedBody       dto.RecoveryCodesResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should confirm totp and return recovery codes": {
+			inputPayload: `{"code": "123456"}`,
+			mocking: func(mfaService *mock.MockMfaService) {
+				mfaService.EXPECT().ConfirmTotp(gomock.Any(), 1, "123456").Return([]string{"a1b2c-3d4e5"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.RecoveryCodesResponse{Data: dto.RecoveryCodesDto{
+				RecoveryCodes: []string{"a1b2c-3d4e5"},
+			}},
+		},
+		"should throw bad request when code is invalid": {
+			inputPayload:       `{"code": "abc"}`,
+			mocking:            func(mfaService *mock.MockMfaService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/me/mfa/totp/verify",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "code", Code: "len", Message: "code must have exactly 6 characters"},
+				},
+			},
+		},
+		"should throw invalid mfa code": {
+			inputPayload: `{"code": "123456"}`,
+			mocking: func(mfaService *mock.MockMfaService) {
+				mfaService.EXPECT().ConfirmTotp(gomock.Any(), 1, "123456").
+					Return(nil, &exception.InvalidMfaCodeException{Message: "invalid mfa code"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:invalid_mfa_code",
+				Title:    "Invalid MFA code",
+				Status:   http.StatusForbidden,
+				Detail:   "invalid mfa code",
+				Instance: "/api/me/mfa/totp/verify",
+				Code:     "invalid_mfa_code",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("POST", "/api/me/mfa/totp/verify", strings.NewReader(cs.inputPayload))
+
+			mfaServiceMock := mock.NewMockMfaService(ctrl)
+			mfaController := controller.NewMfaController(r.Group("/api"), mfaServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(mfaServiceMock)
+
+			// when
+			mfaController.ConfirmTotp(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.RecoveryCodesResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestMfaControllerImpersonated(t *testing.T) {
+	var cases = map[string]struct {
+		inputPath string
+	}{
+		"should throw forbidden when enrolling while impersonating": {
+			inputPath: "/api/me/mfa/totp",
+		},
+		"should throw forbidden when confirming while impersonating": {
+			inputPath: "/api/me/mfa/totp/verify",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			r := gin.New()
+
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			auditServiceMock := mock.NewMockAuditService(ctrl)
+			middlewareController := controller.NewMiddlewareController(cryptoServiceMock, nil, nil, sessionServiceMock, auditServiceMock)
+			r.Use(middlewareController.HandleErrors)
+			controller.NewMfaController(r.Group("/api"), mock.NewMockMfaService(ctrl), mock.NewMockUserService(ctrl),
+				middlewareController.MfaEnrollmentToken, middlewareController.NotImpersonated)
+
+			cryptoServiceMock.EXPECT().DecryptJwt(gomock.Any(), "123.abc.x0z").Return(map[string]interface{}{
+				"sub":                2,
+				model.TokenUseClaim:  string(model.TokenUseAccess),
+				model.SessionIDClaim: 7,
+				model.ActorClaim:     map[string]interface{}{"sub": 1},
+			}, nil)
+			sessionServiceMock.EXPECT().ValidateSession(gomock.Any(), 1, 7).Return(nil)
+			auditServiceMock.EXPECT().Record(gomock.Any(), gomock.Any()).Return(&model.AuditLog{ID: 1}, nil)
+			auditServiceMock.EXPECT().UpdateStatus(gomock.Any(), 1, http.StatusForbidden).Return(nil)
+
+			req := httptest.NewRequest("POST", cs.inputPath, strings.NewReader(`{"code": "123456"}`))
+			req.Header.Add("authorization", "bearer 123.abc.x0z")
+
+			// when
+			r.ServeHTTP(res, req)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, http.StatusForbidden, res.Result().StatusCode)
+			assert.Equal(t, dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "not allowed while impersonating",
+				Instance: cs.inputPath,
+				Code:     "forbidden",
+			}, errorBody)
+		})
+	}
+}
@@ -1,37 +1,143 @@
 package controller
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
 	"github.com/viniosilva/swordhealth-api/internal/service"
+	"golang.org/x/exp/slices"
 )
 
+const (
+	RequestIDHeader = "X-Request-ID"
+	RequestIDKey    = "request_id"
+)
+
+// requestIDPattern is what a client request id must look like to be kept,
+// so it always fits the audit logs.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type problemType struct {
+	status int
+	title  string
+}
+
+var problemTypes = map[exception.ErrorCode]problemType{
+	exception.ErrorCodeInternal:             {http.StatusInternalServerError, "Internal server error"},
+	exception.ErrorCodeInvalidPayload:       {http.StatusBadRequest, "Invalid payload"},
+	exception.ErrorCodeValidationFailed:     {http.StatusBadRequest, "Validation failed"},
+	exception.ErrorCodeInvalidAuthorization: {http.StatusBadRequest, "Invalid authorization"},
+	exception.ErrorCodeUnauthorized:         {http.StatusUnauthorized, "Unauthorized"},
+	exception.ErrorCodeForbidden:            {http.StatusForbidden, "Forbidden"},
+	exception.ErrorCodeExpiredToken:         {http.StatusForbidden, "Expired token"},
+	exception.ErrorCodeNotFound:             {http.StatusNotFound, "Not found"},
+	exception.ErrorCodeForeignKeyConstraint: {http.StatusBadRequest, "Foreign key constraint"},
+	exception.ErrorCodeConflict:             {http.StatusConflict, "Conflict"},
+	exception.ErrorCodeInvalidMfaCode:       {http.StatusForbidden, "Invalid MFA code"},
+	exception.ErrorCodeTooManyRequests:      {http.StatusTooManyRequests, "Too many requests"},
+	exception.ErrorCodeAccountLocked:        {http.StatusTooManyRequests, "Account locked"},
+}
+
 type MiddlewareController interface {
+	RequestID(ctx *gin.Context)
+	HandleErrors(ctx *gin.Context)
 	AccessToken(ctx *gin.Context)
+	MfaEnrollmentToken(ctx *gin.Context)
 	UserManager(ctx *gin.Context)
+	NotImpersonated(ctx *gin.Context)
+	RateLimit(policy service.RateLimitPolicy) func(ctx *gin.Context)
 }
 
 type middlewareController struct {
-	cryptoService service.CryptoService
+	cryptoService    service.CryptoService
+	rateLimitService service.RateLimitService
+	apiKeyService    service.ApiKeyService
+	sessionService   service.SessionService
+	auditService     service.AuditService
 }
 
-func NewMiddlewareController(cryptoService service.CryptoService) MiddlewareController {
+func NewMiddlewareController(cryptoService service.CryptoService, rateLimitService service.RateLimitService,
+	apiKeyService service.ApiKeyService, sessionService service.SessionService,
+	auditService service.AuditService) MiddlewareController {
 	impl := &middlewareController{
-		cryptoService: cryptoService,
+		cryptoService:    cryptoService,
+		rateLimitService: rateLimitService,
+		apiKeyService:    apiKeyService,
+		sessionService:   sessionService,
+		auditService:     auditService,
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(exception.JSONTagName)
 	}
 
 	return impl
 }
 
+func (impl *middlewareController) RequestID(ctx *gin.Context) {
+	requestID := ctx.Request.Header.Get(RequestIDHeader)
+	if !requestIDPattern.MatchString(requestID) {
+		b := make([]byte, 16)
+		rand.Read(b)
+		requestID = hex.EncodeToString(b)
+	}
+
+	ctx.Set(RequestIDKey, requestID)
+	ctx.Header(RequestIDHeader, requestID)
+
+	ctx.Next()
+}
+
+func (impl *middlewareController) HandleErrors(ctx *gin.Context) {
+	ctx.Next()
+
+	if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+		return
+	}
+
+	err := ctx.Errors.Last().Err
+	problem := impl.ParseProblemDetails(ctx, err)
+	body, _ := json.Marshal(problem)
+
+	if retryAfter := impl.retryAfter(err); retryAfter > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+
+	ctx.Data(problem.Status, dto.ProblemContentType, body)
+}
+
 func (impl *middlewareController) AccessToken(ctx *gin.Context) {
+	splitedAuthorization := strings.Split(ctx.Request.Header.Get("authorization"), " ")
+	if strings.ToLower(splitedAuthorization[0]) == "apikey" {
+		impl.authenticateApiKey(ctx)
+		return
+	}
+
+	impl.authenticate(ctx, model.TokenUseAccess)
+}
+
+func (impl *middlewareController) MfaEnrollmentToken(ctx *gin.Context) {
+	impl.authenticate(ctx, model.TokenUseAccess, model.TokenUseMfaEnrollment)
+}
+
+func (impl *middlewareController) authenticate(ctx *gin.Context, tokenUses ...model.TokenUse) {
 	splitedBearerMiddleware := strings.Split(ctx.Request.Header.Get("authorization"), " ")
 	if strings.ToLower(splitedBearerMiddleware[0]) != "bearer" || len(splitedBearerMiddleware) != 2 {
-		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: "invalid authorization"})
+		ctx.Error(&exception.InvalidAuthorizationException{Message: "invalid authorization"})
 		ctx.Abort()
 		return
 	}
@@ -39,14 +145,123 @@ func (impl *middlewareController) AccessToken(ctx *gin.Context) {
 	accessToken := splitedBearerMiddleware[1]
 	claims, err := impl.cryptoService.DecryptJwt(ctx, accessToken)
 	if err != nil {
-		ctx.JSON(http.StatusForbidden, dto.ApiError{Error: err.Error()})
+		if _, ok := err.(*exception.ExpiredTokenException); !ok {
+			err = &exception.ForbiddenException{Message: err.Error()}
+		}
+		ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	tokenUse, _ := claims[model.TokenUseClaim].(string)
+	if !slices.Contains(tokenUses, model.TokenUse(tokenUse)) {
+		ctx.Error(&exception.ForbiddenException{Message: "invalid token use"})
 		ctx.Abort()
 		return
 	}
 
+	userID, _ := strconv.Atoi(fmt.Sprint(claims["sub"]))
+	actorID := 0
+	if act, ok := claims[model.ActorClaim].(map[string]interface{}); ok {
+		actorID, _ = strconv.Atoi(fmt.Sprint(act["sub"]))
+		if actorID == 0 {
+			ctx.Error(&exception.ForbiddenException{Message: "invalid actor"})
+			ctx.Abort()
+			return
+		}
+	}
+
+	if model.TokenUse(tokenUse) == model.TokenUseAccess {
+		sessionID, err := strconv.Atoi(fmt.Sprint(claims[model.SessionIDClaim]))
+		if err != nil {
+			ctx.Error(&exception.ForbiddenException{Message: "invalid session"})
+			ctx.Abort()
+			return
+		}
+
+		// impersonation tokens live on the session of the manager who issued them
+		sessionUserID := userID
+		if actorID != 0 {
+			sessionUserID = actorID
+		}
+		if err := impl.sessionService.ValidateSession(ctx, sessionUserID, sessionID); err != nil {
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+	}
+
 	for k, v := range claims {
+		if k == model.ActorClaim {
+			continue
+		}
 		ctx.Params = append(ctx.Params, gin.Param{Key: k, Value: fmt.Sprint(v)})
 	}
+	if actorID != 0 {
+		ctx.Params = append(ctx.Params, gin.Param{Key: model.ActorClaim, Value: strconv.Itoa(actorID)})
+	}
+
+	if actorID == 0 {
+		ctx.Next()
+		return
+	}
+
+	impl.auditImpersonation(ctx, actorID, userID)
+}
+
+// auditImpersonation records the impersonated request before running it, so
+// nothing is done as the user without a trace, and then sets its status.
+func (impl *middlewareController) auditImpersonation(ctx *gin.Context, actorID, userID int) {
+	auditLog, err := impl.auditService.Record(ctx, model.AuditLog{
+		RequestID: ctx.GetString(RequestIDKey),
+		ActorID:   actorID,
+		UserID:    userID,
+		Action:    model.AuditActionImpersonationRequest,
+		Method:    ctx.Request.Method,
+		Path:      ctx.Request.URL.Path,
+	})
+	if err != nil {
+		ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+
+	status := ctx.Writer.Status()
+	if len(ctx.Errors) > 0 && !ctx.Writer.Written() {
+		status = impl.ParseProblemDetails(ctx, ctx.Errors.Last().Err).Status
+	}
+	impl.auditService.UpdateStatus(ctx, auditLog.ID, status)
+}
+
+func (impl *middlewareController) authenticateApiKey(ctx *gin.Context) {
+	splitedApiKeyMiddleware := strings.Split(ctx.Request.Header.Get("authorization"), " ")
+	if len(splitedApiKeyMiddleware) != 2 || splitedApiKeyMiddleware[1] == "" {
+		ctx.Error(&exception.InvalidAuthorizationException{Message: "invalid authorization"})
+		ctx.Abort()
+		return
+	}
+
+	apiKey, user, err := impl.apiKeyService.Authenticate(ctx, splitedApiKeyMiddleware[1])
+	if err != nil {
+		ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if !apiKey.HasScope(impl.apiKeyScope(ctx)) {
+		ctx.Error(&exception.ForbiddenException{Message: "insufficient api key scope"})
+		ctx.Abort()
+		return
+	}
+
+	ctx.Params = append(ctx.Params,
+		gin.Param{Key: "sub", Value: strconv.Itoa(user.ID)},
+		gin.Param{Key: "role", Value: string(user.Role)},
+		gin.Param{Key: "username", Value: user.Username},
+		gin.Param{Key: "api_key_id", Value: strconv.Itoa(apiKey.ID)},
+	)
 
 	ctx.Next()
 }
@@ -54,10 +269,168 @@ func (impl *middlewareController) AccessToken(ctx *gin.Context) {
 func (impl *middlewareController) UserManager(ctx *gin.Context) {
 	role, _ := ctx.Params.Get("role")
 	if role != string(model.UserRoleManager) {
-		ctx.JSON(http.StatusUnauthorized, dto.ApiError{Error: "unauthorized user role"})
+		ctx.Error(&exception.UnauthorizedException{Message: "unauthorized user role"})
 		ctx.Abort()
 		return
 	}
 
 	ctx.Next()
 }
+
+func (impl *middlewareController) NotImpersonated(ctx *gin.Context) {
+	if _, ok := ctx.Params.Get(model.ActorClaim); ok {
+		ctx.Error(&exception.ForbiddenException{Message: "not allowed while impersonating"})
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+}
+
+func (impl *middlewareController) RateLimit(policy service.RateLimitPolicy) func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		if policy.Capacity <= 0 {
+			ctx.Next()
+			return
+		}
+
+		rateLimit, err := impl.rateLimitService.Take(ctx, policy, impl.rateLimitIdentity(ctx))
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Limit", strconv.Itoa(rateLimit.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(rateLimit.Remaining))
+		ctx.Header("RateLimit-Reset", strconv.Itoa(int(math.Ceil(rateLimit.Reset.Seconds()))))
+
+		if !rateLimit.Allowed() {
+			ctx.Error(&exception.TooManyRequestsException{
+				Message:    "rate limit exceeded",
+				RetryAfter: rateLimit.RetryAfter,
+			})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func (impl *middlewareController) ParseProblemDetails(ctx *gin.Context, err error) dto.ProblemDetails {
+	return parseProblemDetails(ctx, err)
+}
+
+// parseProblemDetails maps err to its RFC 7807 problem, hiding the detail of
+// internal errors.
+func parseProblemDetails(ctx *gin.Context, err error) dto.ProblemDetails {
+	code := exception.ErrorCodeInternal
+	detail := "internal server error"
+	var fieldErrors []dto.ProblemFieldError
+
+	switch e := err.(type) {
+	case *exception.ValidationException:
+		code = exception.ErrorCodeInvalidPayload
+		if len(e.Fields) > 0 {
+			code = exception.ErrorCodeValidationFailed
+		}
+		for _, f := range e.Fields {
+			fieldErrors = append(fieldErrors, dto.ProblemFieldError{Field: f.Field, Code: f.Tag, Message: f.Message})
+		}
+	case *exception.InvalidAuthorizationException:
+		code = exception.ErrorCodeInvalidAuthorization
+	case *exception.UnauthorizedException:
+		code = exception.ErrorCodeUnauthorized
+	case *exception.ForbiddenException:
+		code = exception.ErrorCodeForbidden
+	case *exception.ExpiredTokenException:
+		code = exception.ErrorCodeExpiredToken
+	case *exception.NotFoundException:
+		code = exception.ErrorCodeNotFound
+	case *exception.ForeignKeyConstraintException:
+		code = exception.ErrorCodeForeignKeyConstraint
+	case *exception.ConflictException:
+		code = exception.ErrorCodeConflict
+	case *exception.InvalidMfaCodeException:
+		code = exception.ErrorCodeInvalidMfaCode
+	case *exception.TooManyRequestsException:
+		code = exception.ErrorCodeTooManyRequests
+	case *exception.AccountLockedException:
+		code = exception.ErrorCodeAccountLocked
+	}
+	if code != exception.ErrorCodeInternal {
+		detail = err.Error()
+	}
+
+	pt := problemTypes[code]
+
+	return dto.ProblemDetails{
+		Type:      fmt.Sprintf("urn:swordhealth:problem:%s", code),
+		Title:     pt.title,
+		Status:    pt.status,
+		Detail:    detail,
+		Instance:  ctx.Request.URL.Path,
+		Code:      string(code),
+		RequestID: ctx.GetString(RequestIDKey),
+		Errors:    fieldErrors,
+	}
+}
+
+func (impl *middlewareController) rateLimitIdentity(ctx *gin.Context) string {
+	if sub, ok := ctx.Params.Get("sub"); ok {
+		return fmt.Sprintf("user:%s", sub)
+	}
+
+	splitedBearerMiddleware := strings.Split(ctx.Request.Header.Get("authorization"), " ")
+	if strings.ToLower(splitedBearerMiddleware[0]) == "apikey" && len(splitedBearerMiddleware) == 2 {
+		return fmt.Sprintf("apikey:%s", impl.cryptoService.Hash(splitedBearerMiddleware[1]))
+	}
+	if strings.ToLower(splitedBearerMiddleware[0]) == "bearer" && len(splitedBearerMiddleware) == 2 {
+		if claims, err := impl.cryptoService.DecryptJwt(ctx, splitedBearerMiddleware[1]); err == nil {
+			if sub, ok := claims["sub"]; ok {
+				return fmt.Sprintf("user:%v", sub)
+			}
+		}
+	}
+
+	return fmt.Sprintf("ip:%s", ctx.ClientIP())
+}
+
+// apiKeyScopeResources maps the first path segment of a route to the scope
+// resource it needs. Routes missing here, such as /me/* and /auth/*, can not
+// be reached with an API key.
+var apiKeyScopeResources = map[string]string{
+	"tasks":          "tasks",
+	"tags":           "tasks",
+	"task-templates": "tasks",
+	"task-schedules": "tasks",
+	"time-entries":   "tasks",
+	"reports":        "tasks",
+	"users":          "users",
+}
+
+func (impl *middlewareController) apiKeyScope(ctx *gin.Context) model.ApiKeyScope {
+	path := strings.TrimPrefix(strings.TrimPrefix(ctx.Request.URL.Path, "/api"), "/")
+	resource, ok := apiKeyScopeResources[strings.Split(path, "/")[0]]
+	if !ok {
+		return ""
+	}
+
+	access := "write"
+	if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+		access = "read"
+	}
+
+	return model.ApiKeyScope(fmt.Sprintf("%s:%s", resource, access))
+}
+
+func (impl *middlewareController) retryAfter(err error) time.Duration {
+	switch e := err.(type) {
+	case *exception.TooManyRequestsException:
+		return e.RetryAfter
+	case *exception.AccountLockedException:
+		return e.RetryAfter
+	}
+
+	return 0
+}
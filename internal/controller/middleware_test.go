@@ -5,50 +5,128 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/viniosilva/swordhealth-api/internal/controller"
 	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
 	"github.com/viniosilva/swordhealth-api/mock"
 )
 
 func TestMiddlewareControllerAccessToken(t *testing.T) {
 	var cases = map[string]struct {
 		inputAuthorization  string
-		mocking             func(cryptoService *mock.MockCryptoService)
+		mocking             func(cryptoService *mock.MockCryptoService, sessionService *mock.MockSessionService)
 		expectedUserIDParam string
 		expectedStatusCode  int
-		expectedErrorBody   dto.ApiError
+		expectedErrorBody   dto.ProblemDetails
 	}{
 		"should next": {
 			inputAuthorization: "bearer 123.abc.x0z",
-			mocking: func(cryptoService *mock.MockCryptoService) {
+			mocking: func(cryptoService *mock.MockCryptoService, sessionService *mock.MockSessionService) {
 				cryptoService.EXPECT().DecryptJwt(gomock.Any(), gomock.Any()).
 					Return(map[string]interface{}{
-						"sub": 1,
+						"sub":                1,
+						model.SessionIDClaim: 7,
 					}, nil)
+				sessionService.EXPECT().ValidateSession(gomock.Any(), 1, 7).Return(nil)
 			},
 			expectedUserIDParam: "1",
 			expectedStatusCode:  http.StatusOK,
 		},
+		"should throw forbidden when token has no session": {
+			inputAuthorization: "bearer 123.abc.x0z",
+			mocking: func(cryptoService *mock.MockCryptoService, sessionService *mock.MockSessionService) {
+				cryptoService.EXPECT().DecryptJwt(gomock.Any(), gomock.Any()).
+					Return(map[string]interface{}{
+						"sub": 1,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "invalid session",
+				Instance: "/api/healthcheck",
+				Code:     "forbidden",
+			},
+		},
+		"should throw forbidden when session is revoked": {
+			inputAuthorization: "bearer 123.abc.x0z",
+			mocking: func(cryptoService *mock.MockCryptoService, sessionService *mock.MockSessionService) {
+				cryptoService.EXPECT().DecryptJwt(gomock.Any(), gomock.Any()).
+					Return(map[string]interface{}{
+						"sub":                1,
+						model.SessionIDClaim: 7,
+					}, nil)
+				sessionService.EXPECT().ValidateSession(gomock.Any(), 1, 7).
+					Return(&exception.ForbiddenException{Message: "session revoked"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "session revoked",
+				Instance: "/api/healthcheck",
+				Code:     "forbidden",
+			},
+		},
 		"should throw invalid authorization error": {
 			inputAuthorization: "error",
-			mocking:            func(cryptoService *mock.MockCryptoService) {},
+			mocking:            func(cryptoService *mock.MockCryptoService, sessionService *mock.MockSessionService) {},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedErrorBody:  dto.ApiError{Error: "invalid authorization"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:invalid_authorization",
+				Title:    "Invalid authorization",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid authorization",
+				Instance: "/api/healthcheck",
+				Code:     "invalid_authorization",
+			},
+		},
+		"should throw forbidden when token is a mfa challenge": {
+			inputAuthorization: "bearer 123.abc.x0z",
+			mocking: func(cryptoService *mock.MockCryptoService, sessionService *mock.MockSessionService) {
+				cryptoService.EXPECT().DecryptJwt(gomock.Any(), gomock.Any()).
+					Return(map[string]interface{}{
+						"sub":               1,
+						model.TokenUseClaim: string(model.TokenUseMfaChallenge),
+					}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "invalid token use",
+				Instance: "/api/healthcheck",
+				Code:     "forbidden",
+			},
 		},
 		"should throw error on decrypt jwt": {
 			inputAuthorization: "bearer 123.abc.x0z",
-			mocking: func(cryptoService *mock.MockCryptoService) {
+			mocking: func(cryptoService *mock.MockCryptoService, sessionService *mock.MockSessionService) {
 				cryptoService.EXPECT().DecryptJwt(gomock.Any(), gomock.Any()).
 					Return(nil, fmt.Errorf("error"))
 			},
 			expectedStatusCode: http.StatusForbidden,
-			expectedErrorBody:  dto.ApiError{Error: "error"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "error",
+				Instance: "/api/healthcheck",
+				Code:     "forbidden",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -64,19 +142,361 @@ func TestMiddlewareControllerAccessToken(t *testing.T) {
 			ctx.Request.Header.Add("authorization", cs.inputAuthorization)
 
 			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
-			middlewareController := controller.NewMiddlewareController(cryptoServiceMock)
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			middlewareController := controller.NewMiddlewareController(cryptoServiceMock, nil, nil, sessionServiceMock, nil)
+
+			cs.mocking(cryptoServiceMock, sessionServiceMock)
+
+			// when
+			middlewareController.AccessToken(ctx)
+			middlewareController.HandleErrors(ctx)
+			userIDParam, _ := ctx.Params.Get("sub")
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedUserIDParam, userIDParam)
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestMiddlewareControllerAccessTokenApiKey(t *testing.T) {
+	user := &model.User{ID: 1, Username: "username", Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputMethod         string
+		inputPath           string
+		inputAuthorization  string
+		mocking             func(apiKeyService *mock.MockApiKeyService)
+		expectedUserIDParam string
+		expectedRoleParam   string
+		expectedStatusCode  int
+		expectedErrorBody   dto.ProblemDetails
+	}{
+		"should next when api key has read scope": {
+			inputMethod:        "GET",
+			inputPath:          "/api/tasks",
+			inputAuthorization: "ApiKey sh_1a2b3c4d_secret",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "sh_1a2b3c4d_secret").
+					Return(&model.ApiKey{ID: 1, UserID: 1, Scopes: "tasks:read"}, user, nil)
+			},
+			expectedUserIDParam: "1",
+			expectedRoleParam:   "technician",
+			expectedStatusCode:  http.StatusOK,
+		},
+		"should throw forbidden when api key has no write scope": {
+			inputMethod:        "POST",
+			inputPath:          "/api/tasks",
+			inputAuthorization: "ApiKey sh_1a2b3c4d_secret",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "sh_1a2b3c4d_secret").
+					Return(&model.ApiKey{ID: 1, UserID: 1, Scopes: "tasks:read"}, user, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "insufficient api key scope",
+				Instance: "/api/tasks",
+				Code:     "forbidden",
+			},
+		},
+		"should next when api key reads tags with the tasks scope": {
+			inputMethod:        "GET",
+			inputPath:          "/api/tags",
+			inputAuthorization: "ApiKey sh_1a2b3c4d_secret",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "sh_1a2b3c4d_secret").
+					Return(&model.ApiKey{ID: 1, UserID: 1, Scopes: "tasks:read"}, user, nil)
+			},
+			expectedUserIDParam: "1",
+			expectedRoleParam:   "technician",
+			expectedStatusCode:  http.StatusOK,
+		},
+		"should throw forbidden when api key reaches a route without scope": {
+			inputMethod:        "GET",
+			inputPath:          "/api/me/sessions",
+			inputAuthorization: "ApiKey sh_1a2b3c4d_secret",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "sh_1a2b3c4d_secret").
+					Return(&model.ApiKey{ID: 1, UserID: 1, Scopes: "tasks:read,tasks:write,users:read,users:write"}, user, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "insufficient api key scope",
+				Instance: "/api/me/sessions",
+				Code:     "forbidden",
+			},
+		},
+		"should throw forbidden when api key manages api keys": {
+			inputMethod:        "POST",
+			inputPath:          "/api/me/api-keys",
+			inputAuthorization: "ApiKey sh_1a2b3c4d_secret",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "sh_1a2b3c4d_secret").
+					Return(&model.ApiKey{ID: 1, UserID: 1, Scopes: "tasks:read,tasks:write,users:read,users:write"}, user, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "insufficient api key scope",
+				Instance: "/api/me/api-keys",
+				Code:     "forbidden",
+			},
+		},
+		"should throw expired token when api key is expired": {
+			inputMethod:        "GET",
+			inputPath:          "/api/tasks",
+			inputAuthorization: "ApiKey sh_1a2b3c4d_secret",
+			mocking: func(apiKeyService *mock.MockApiKeyService) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "sh_1a2b3c4d_secret").
+					Return(nil, nil, &exception.ExpiredTokenException{Message: "api key expired"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:expired_token",
+				Title:    "Expired token",
+				Status:   http.StatusForbidden,
+				Detail:   "api key expired",
+				Instance: "/api/tasks",
+				Code:     "expired_token",
+			},
+		},
+		"should throw invalid authorization when api key is missing": {
+			inputMethod:        "GET",
+			inputPath:          "/api/tasks",
+			inputAuthorization: "ApiKey",
+			mocking:            func(apiKeyService *mock.MockApiKeyService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:invalid_authorization",
+				Title:    "Invalid authorization",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid authorization",
+				Instance: "/api/tasks",
+				Code:     "invalid_authorization",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest(cs.inputMethod, cs.inputPath, nil)
+			ctx.Request.Header.Add("authorization", cs.inputAuthorization)
+
+			apiKeyServiceMock := mock.NewMockApiKeyService(ctrl)
+			middlewareController := controller.NewMiddlewareController(nil, nil, apiKeyServiceMock, nil, nil)
+
+			cs.mocking(apiKeyServiceMock)
+
+			// when
+			middlewareController.AccessToken(ctx)
+			middlewareController.HandleErrors(ctx)
+			userIDParam, _ := ctx.Params.Get("sub")
+			roleParam, _ := ctx.Params.Get("role")
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedUserIDParam, userIDParam)
+			assert.Equal(t, cs.expectedRoleParam, roleParam)
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestMiddlewareControllerMfaEnrollmentToken(t *testing.T) {
+	var cases = map[string]struct {
+		inputTokenUse       model.TokenUse
+		expectedUserIDParam string
+		expectedStatusCode  int
+	}{
+		"should next with access token": {
+			inputTokenUse:       model.TokenUseAccess,
+			expectedUserIDParam: "1",
+			expectedStatusCode:  http.StatusOK,
+		},
+		"should next with mfa enrollment token": {
+			inputTokenUse:       model.TokenUseMfaEnrollment,
+			expectedUserIDParam: "1",
+			expectedStatusCode:  http.StatusOK,
+		},
+		"should throw forbidden with mfa challenge token": {
+			inputTokenUse:      model.TokenUseMfaChallenge,
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/me/mfa/totp", nil)
+			ctx.Request.Header.Add("authorization", "bearer 123.abc.x0z")
+
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			middlewareController := controller.NewMiddlewareController(cryptoServiceMock, nil, nil, sessionServiceMock, nil)
+
+			claims := map[string]interface{}{"sub": 1}
+			if cs.inputTokenUse != model.TokenUseAccess {
+				claims[model.TokenUseClaim] = string(cs.inputTokenUse)
+			} else {
+				claims[model.SessionIDClaim] = 7
+				sessionServiceMock.EXPECT().ValidateSession(gomock.Any(), 1, 7).Return(nil)
+			}
+			cryptoServiceMock.EXPECT().DecryptJwt(gomock.Any(), gomock.Any()).Return(claims, nil)
+
+			// when
+			middlewareController.MfaEnrollmentToken(ctx)
+			middlewareController.HandleErrors(ctx)
+			userIDParam, _ := ctx.Params.Get("sub")
+
+			// then
+			assert.Equal(t, cs.expectedUserIDParam, userIDParam)
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+		})
+	}
+}
+
+func TestMiddlewareControllerAccessTokenImpersonation(t *testing.T) {
+	var cases = map[string]struct {
+		inputActor          interface{}
+		mocking             func(sessionService *mock.MockSessionService, auditService *mock.MockAuditService)
+		expectedUserIDParam string
+		expectedActorParam  string
+		expectedStatusCode  int
+		expectedErrorBody   dto.ProblemDetails
+	}{
+		"should next and audit impersonated request": {
+			inputActor: map[string]interface{}{"sub": float64(1)},
+			mocking: func(sessionService *mock.MockSessionService, auditService *mock.MockAuditService) {
+				sessionService.EXPECT().ValidateSession(gomock.Any(), 1, 7).Return(nil)
+				auditService.EXPECT().Record(gomock.Any(), model.AuditLog{
+					RequestID: "abc",
+					ActorID:   1,
+					UserID:    2,
+					Action:    model.AuditActionImpersonationRequest,
+					Method:    "GET",
+					Path:      "/api/tasks",
+				}).Return(&model.AuditLog{ID: 3}, nil)
+				auditService.EXPECT().UpdateStatus(gomock.Any(), 3, http.StatusOK).Return(nil)
+			},
+			expectedUserIDParam: "2",
+			expectedActorParam:  "1",
+			expectedStatusCode:  http.StatusOK,
+		},
+		"should throw internal server error when impersonated request can not be audited": {
+			inputActor: map[string]interface{}{"sub": float64(1)},
+			mocking: func(sessionService *mock.MockSessionService, auditService *mock.MockAuditService) {
+				sessionService.EXPECT().ValidateSession(gomock.Any(), 1, 7).Return(nil)
+				auditService.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedUserIDParam: "2",
+			expectedActorParam:  "1",
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:      "urn:swordhealth:problem:internal_error",
+				Title:     "Internal server error",
+				Status:    http.StatusInternalServerError,
+				Detail:    "internal server error",
+				Instance:  "/api/tasks",
+				Code:      "internal_error",
+				RequestID: "abc",
+			},
+		},
+		"should throw forbidden when actor is invalid": {
+			inputActor:         map[string]interface{}{"sub": "abc"},
+			mocking:            func(sessionService *mock.MockSessionService, auditService *mock.MockAuditService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:      "urn:swordhealth:problem:forbidden",
+				Title:     "Forbidden",
+				Status:    http.StatusForbidden,
+				Detail:    "invalid actor",
+				Instance:  "/api/tasks",
+				Code:      "forbidden",
+				RequestID: "abc",
+			},
+		},
+		"should throw forbidden when actor session is revoked": {
+			inputActor: map[string]interface{}{"sub": float64(1)},
+			mocking: func(sessionService *mock.MockSessionService, auditService *mock.MockAuditService) {
+				sessionService.EXPECT().ValidateSession(gomock.Any(), 1, 7).
+					Return(&exception.ForbiddenException{Message: "session revoked"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:      "urn:swordhealth:problem:forbidden",
+				Title:     "Forbidden",
+				Status:    http.StatusForbidden,
+				Detail:    "session revoked",
+				Instance:  "/api/tasks",
+				Code:      "forbidden",
+				RequestID: "abc",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks", nil)
+			ctx.Request.Header.Add("authorization", "bearer 123.abc.x0z")
+			ctx.Set(controller.RequestIDKey, "abc")
+
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			auditServiceMock := mock.NewMockAuditService(ctrl)
+			middlewareController := controller.NewMiddlewareController(cryptoServiceMock, nil, nil, sessionServiceMock,
+				auditServiceMock)
 
-			cs.mocking(cryptoServiceMock)
+			cryptoServiceMock.EXPECT().DecryptJwt(gomock.Any(), gomock.Any()).Return(map[string]interface{}{
+				"sub":                2,
+				model.SessionIDClaim: 7,
+				model.ActorClaim:     cs.inputActor,
+			}, nil)
+			cs.mocking(sessionServiceMock, auditServiceMock)
 
 			// when
 			middlewareController.AccessToken(ctx)
+			middlewareController.HandleErrors(ctx)
 			userIDParam, _ := ctx.Params.Get("sub")
+			actorParam, _ := ctx.Params.Get(model.ActorClaim)
 
-			var errorBody dto.ApiError
+			var errorBody dto.ProblemDetails
 			json.Unmarshal(res.Body.Bytes(), &errorBody)
 
 			// then
 			assert.Equal(t, cs.expectedUserIDParam, userIDParam)
+			assert.Equal(t, cs.expectedActorParam, actorParam)
 			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
 			assert.Equal(t, cs.expectedErrorBody, errorBody)
 		})
@@ -87,7 +507,7 @@ func TestMiddlewareControllerHealth(t *testing.T) {
 	var cases = map[string]struct {
 		inputRole          model.UserRole
 		expectedStatusCode int
-		expectedErrorBody  dto.ApiError
+		expectedErrorBody  dto.ProblemDetails
 	}{
 		"should next": {
 			inputRole:          model.UserRoleManager,
@@ -96,7 +516,14 @@ func TestMiddlewareControllerHealth(t *testing.T) {
 		"should throw unauthorized exception when user role is not manager": {
 			inputRole:          model.UserRoleTechnician,
 			expectedStatusCode: http.StatusUnauthorized,
-			expectedErrorBody:  dto.ApiError{Error: "unauthorized user role"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:unauthorized",
+				Title:    "Unauthorized",
+				Status:   http.StatusUnauthorized,
+				Detail:   "unauthorized user role",
+				Instance: "/api/healthcheck",
+				Code:     "unauthorized",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -111,17 +538,278 @@ func TestMiddlewareControllerHealth(t *testing.T) {
 			ctx.Request = httptest.NewRequest("GET", "/api/healthcheck", nil)
 			ctx.Params = append(ctx.Params, gin.Param{Key: "role", Value: string(cs.inputRole)})
 
-			middlewareController := controller.NewMiddlewareController(nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
 
 			// when
 			middlewareController.UserManager(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestMiddlewareControllerNotImpersonated(t *testing.T) {
+	var cases = map[string]struct {
+		inputParams        gin.Params
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should next": {
+			inputParams:        gin.Params{{Key: "sub", Value: "1"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		"should throw forbidden when impersonating": {
+			inputParams:        gin.Params{{Key: "sub", Value: "2"}, {Key: model.ActorClaim, Value: "1"}},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "not allowed while impersonating",
+				Instance: "/api/me/password",
+				Code:     "forbidden",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("PUT", "/api/me/password", nil)
+			ctx.Params = cs.inputParams
+
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			// when
+			middlewareController.NotImpersonated(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestMiddlewareControllerRequestID(t *testing.T) {
+	var cases = map[string]struct {
+		inputRequestID    string
+		expectedRequestID string
+	}{
+		"should keep request id from header": {
+			inputRequestID:    "abc123",
+			expectedRequestID: "abc123",
+		},
+		"should generate request id when header is too long": {
+			inputRequestID: strings.Repeat("a", 65),
+		},
+		"should generate request id when header has invalid characters": {
+			inputRequestID: "abc 123;",
+		},
+		"should generate request id": {},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/healthcheck", nil)
+			ctx.Request.Header.Add(controller.RequestIDHeader, cs.inputRequestID)
+
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
 
-			var errorBody dto.ApiError
+			// when
+			middlewareController.RequestID(ctx)
+			requestID := ctx.GetString(controller.RequestIDKey)
+
+			// then
+			assert.Equal(t, requestID, res.Header().Get(controller.RequestIDHeader))
+			if cs.expectedRequestID != "" {
+				assert.Equal(t, cs.expectedRequestID, requestID)
+			} else {
+				assert.Len(t, requestID, 32)
+			}
+		})
+	}
+}
+
+func TestMiddlewareControllerHandleErrors(t *testing.T) {
+	var cases = map[string]struct {
+		inputErr            error
+		expectedStatusCode  int
+		expectedContentType string
+		expectedErrorBody   dto.ProblemDetails
+	}{
+		"should next without errors": {
+			expectedStatusCode: http.StatusOK,
+		},
+		"should write problem details": {
+			inputErr:            &exception.NotFoundException{Message: "user not found"},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedContentType: dto.ProblemContentType,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:      "urn:swordhealth:problem:not_found",
+				Title:     "Not found",
+				Status:    http.StatusNotFound,
+				Detail:    "user not found",
+				Instance:  "/api/healthcheck",
+				Code:      "not_found",
+				RequestID: "abc123",
+			},
+		},
+		"should hide unexpected errors": {
+			inputErr:            fmt.Errorf("sql: connection refused"),
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: dto.ProblemContentType,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:      "urn:swordhealth:problem:internal_error",
+				Title:     "Internal server error",
+				Status:    http.StatusInternalServerError,
+				Detail:    "internal server error",
+				Instance:  "/api/healthcheck",
+				Code:      "internal_error",
+				RequestID: "abc123",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/healthcheck", nil)
+			ctx.Set(controller.RequestIDKey, "abc123")
+			if cs.inputErr != nil {
+				ctx.Error(cs.inputErr)
+			}
+
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			// when
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
 			json.Unmarshal(res.Body.Bytes(), &errorBody)
 
 			// then
 			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedContentType, res.Header().Get("Content-Type"))
 			assert.Equal(t, cs.expectedErrorBody, errorBody)
 		})
 	}
 }
+
+func TestMiddlewareControllerRateLimit(t *testing.T) {
+	policy := service.RateLimitPolicy{Name: "tasks", Capacity: 10, RefillEvery: time.Second}
+
+	var cases = map[string]struct {
+		inputPolicy         service.RateLimitPolicy
+		inputUserID         string
+		mocking             func(rateLimitService *mock.MockRateLimitService)
+		expectedStatusCode  int
+		expectedLimit       string
+		expectedRemaining   string
+		expectedReset       string
+		expectedRetryAfter  string
+		expectedErrorDetail string
+	}{
+		"should next keyed by user": {
+			inputPolicy: policy,
+			inputUserID: "1",
+			mocking: func(rateLimitService *mock.MockRateLimitService) {
+				rateLimitService.EXPECT().Take(gomock.Any(), policy, "user:1").
+					Return(&model.RateLimit{Limit: 10, Remaining: 9, Reset: time.Second}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedLimit:      "10",
+			expectedRemaining:  "9",
+			expectedReset:      "1",
+		},
+		"should next keyed by ip": {
+			inputPolicy: policy,
+			mocking: func(rateLimitService *mock.MockRateLimitService) {
+				rateLimitService.EXPECT().Take(gomock.Any(), policy, "ip:192.0.2.1").
+					Return(&model.RateLimit{Limit: 10, Remaining: 9, Reset: time.Second}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedLimit:      "10",
+			expectedRemaining:  "9",
+			expectedReset:      "1",
+		},
+		"should next when rate limit is disabled": {
+			inputPolicy:        service.RateLimitPolicy{Name: "tasks"},
+			mocking:            func(rateLimitService *mock.MockRateLimitService) {},
+			expectedStatusCode: http.StatusOK,
+		},
+		"should next when rate limit service fails": {
+			inputPolicy: policy,
+			inputUserID: "1",
+			mocking: func(rateLimitService *mock.MockRateLimitService) {
+				rateLimitService.EXPECT().Take(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		"should throw too many requests when rate limit is exceeded": {
+			inputPolicy: policy,
+			inputUserID: "1",
+			mocking: func(rateLimitService *mock.MockRateLimitService) {
+				rateLimitService.EXPECT().Take(gomock.Any(), policy, "user:1").
+					Return(&model.RateLimit{Limit: 10, Remaining: 0, Reset: 10 * time.Second, RetryAfter: 500 * time.Millisecond}, nil)
+			},
+			expectedStatusCode:  http.StatusTooManyRequests,
+			expectedLimit:       "10",
+			expectedRemaining:   "0",
+			expectedReset:       "10",
+			expectedRetryAfter:  "1",
+			expectedErrorDetail: "rate limit exceeded",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks", nil)
+			if cs.inputUserID != "" {
+				ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: cs.inputUserID})
+			}
+
+			rateLimitServiceMock := mock.NewMockRateLimitService(ctrl)
+			middlewareController := controller.NewMiddlewareController(nil, rateLimitServiceMock, nil, nil, nil)
+
+			cs.mocking(rateLimitServiceMock)
+
+			// when
+			middlewareController.RateLimit(cs.inputPolicy)(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedLimit, res.Header().Get("RateLimit-Limit"))
+			assert.Equal(t, cs.expectedRemaining, res.Header().Get("RateLimit-Remaining"))
+			assert.Equal(t, cs.expectedReset, res.Header().Get("RateLimit-Reset"))
+			assert.Equal(t, cs.expectedRetryAfter, res.Header().Get("Retry-After"))
+			assert.Equal(t, cs.expectedErrorDetail, errorBody.Detail)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+const OidcSessionCookie = "oidc_session"
+
+type OidcController interface {
+	Login(ctx *gin.Context)
+	Callback(ctx *gin.Context)
+}
+
+type oidcController struct {
+	oidcService      service.OidcService
+	cryptoService    service.CryptoService
+	sessionService   service.SessionService
+	mfaService       service.MfaService
+	sessionExpiresIn int
+	cookieSecure     bool
+}
+
+func NewOidcController(router *gin.RouterGroup, oidcService service.OidcService, cryptoService service.CryptoService,
+	sessionService service.SessionService, mfaService service.MfaService, sessionExpiresIn int, cookieSecure bool) OidcController {
+	impl := &oidcController{
+		oidcService:      oidcService,
+		cryptoService:    cryptoService,
+		sessionService:   sessionService,
+		mfaService:       mfaService,
+		sessionExpiresIn: sessionExpiresIn,
+		cookieSecure:     cookieSecure,
+	}
+
+	router.GET("/auth/oidc/login", impl.Login)
+	router.GET("/auth/oidc/callback", impl.Callback)
+
+	return impl
+}
+
+// @Summary oidc login
+// @Description Redirects to the identity provider using authorization code flow with PKCE
+// @Schemes
+// @Tags auth
+// @Success 302
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/oidc/login [get]
+func (impl *oidcController) Login(ctx *gin.Context) {
+	authorization, err := impl.oidcService.Authorize(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(OidcSessionCookie, authorization.Session, impl.sessionExpiresIn, "/", "", impl.cookieSecure, true)
+	ctx.Redirect(http.StatusFound, authorization.URL)
+}
+
+// @Summary oidc callback
+// @Schemes
+// @Tags auth
+// @Produce json
+// @Param code query string true "authorization code"
+// @Param state query string true "state"
+// @Success 200 {object} dto.AuthLoginResponse
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/oidc/callback [get]
+func (impl *oidcController) Callback(ctx *gin.Context) {
+	if idpError := ctx.Query("error"); idpError != "" {
+		ctx.Error(&exception.ForbiddenException{Message: idpError})
+		return
+	}
+
+	session, _ := ctx.Cookie(OidcSessionCookie)
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(OidcSessionCookie, "", -1, "/", "", impl.cookieSecure, true)
+
+	user, err := impl.oidcService.Callback(ctx, session, ctx.Query("state"), ctx.Query("code"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	issueLoginToken(ctx, impl.cryptoService, impl.sessionService, impl.mfaService, nil, user)
+}
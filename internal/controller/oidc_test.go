@@ -0,0 +1,207 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestOidcControllerLogin(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(oidcService *mock.MockOidcService)
+		expectedStatusCode int
+		expectedLocation   string
+		expectedCookie     string
+	}{
+		"should redirect to identity provider": {
+			mocking: func(oidcService *mock.MockOidcService) {
+				oidcService.EXPECT().Authorize(gomock.Any()).Return(&model.OidcAuthorization{
+					URL:     "http://idp/authorize?state=abc",
+					Session: "sealed",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedLocation:   "http://idp/authorize?state=abc",
+			expectedCookie:     "sealed",
+		},
+		"should throw internal server error": {
+			mocking: func(oidcService *mock.MockOidcService) {
+				oidcService.EXPECT().Authorize(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/auth/oidc/login", nil)
+
+			oidcServiceMock := mock.NewMockOidcService(ctrl)
+			oidcController := controller.NewOidcController(r.Group("/api"), oidcServiceMock, nil, nil, nil, 600, true)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(oidcServiceMock)
+
+			// when
+			oidcController.Login(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			cookie := ""
+			for _, c := range res.Result().Cookies() {
+				if c.Name == controller.OidcSessionCookie {
+					cookie = c.Value
+					assert.True(t, c.HttpOnly)
+					assert.True(t, c.Secure)
+				}
+			}
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedLocation, res.Header().Get("Location"))
+			assert.Equal(t, cs.expectedCookie, cookie)
+		})
+	}
+}
+
+func TestOidcControllerCallback(t *testing.T) {
+	user := &model.User{ID: 1, Username: "jdoe", Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputQuery         string
+		mocking            func(oidcService *mock.MockOidcService, cryptoService *mock.MockCryptoService, mfaService *mock.MockMfaService)
+		expectedStatusCode int
+		expectedBody       dto.AuthLoginResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should issue access token": {
+			inputQuery: "?code=code&state=state",
+			mocking: func(oidcService *mock.MockOidcService, cryptoService *mock.MockCryptoService, mfaService *mock.MockMfaService) {
+				oidcService.EXPECT().Callback(gomock.Any(), "sealed", "state", "code").Return(user, nil)
+				mfaService.EXPECT().GetStatus(gomock.Any(), user).Return(&model.MfaStatus{}, nil)
+				cryptoService.EXPECT().EncryptJwt(gomock.Any(), 1, map[string]interface{}{
+					"username":           "jdoe",
+					"role":               model.UserRoleTechnician,
+					model.SessionIDClaim: 7,
+				}).Return("access-token", nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.AuthLoginResponse{AccessToken: "access-token"},
+		},
+		"should return mfa token when user has mfa enrolled": {
+			inputQuery: "?code=code&state=state",
+			mocking: func(oidcService *mock.MockOidcService, cryptoService *mock.MockCryptoService, mfaService *mock.MockMfaService) {
+				oidcService.EXPECT().Callback(gomock.Any(), "sealed", "state", "code").Return(user, nil)
+				mfaService.EXPECT().GetStatus(gomock.Any(), user).Return(&model.MfaStatus{Enrolled: true, Required: true}, nil)
+				cryptoService.EXPECT().EncryptJwt(gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userID int, claims map[string]interface{}) (string, error) {
+						assert.Equal(t, model.TokenUseMfaChallenge, claims[model.TokenUseClaim])
+						return "mfa-token", nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.AuthLoginResponse{MfaToken: "mfa-token", MfaRequired: true},
+		},
+		"should return enrollment token when mfa is required but not enrolled": {
+			inputQuery: "?code=code&state=state",
+			mocking: func(oidcService *mock.MockOidcService, cryptoService *mock.MockCryptoService, mfaService *mock.MockMfaService) {
+				oidcService.EXPECT().Callback(gomock.Any(), "sealed", "state", "code").Return(user, nil)
+				mfaService.EXPECT().GetStatus(gomock.Any(), user).Return(&model.MfaStatus{Required: true}, nil)
+				cryptoService.EXPECT().EncryptJwt(gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userID int, claims map[string]interface{}) (string, error) {
+						assert.Equal(t, model.TokenUseMfaEnrollment, claims[model.TokenUseClaim])
+						return "enrollment-token", nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.AuthLoginResponse{MfaToken: "enrollment-token", MfaEnrollmentRequired: true},
+		},
+		"should throw forbidden when identity provider returns an error": {
+			inputQuery: "?error=access_denied&state=state",
+			mocking: func(oidcService *mock.MockOidcService, cryptoService *mock.MockCryptoService, mfaService *mock.MockMfaService) {
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "access_denied",
+				Instance: "/api/auth/oidc/callback",
+				Code:     "forbidden",
+			},
+		},
+		"should throw forbidden when state is invalid": {
+			inputQuery: "?code=code&state=forged",
+			mocking: func(oidcService *mock.MockOidcService, cryptoService *mock.MockCryptoService, mfaService *mock.MockMfaService) {
+				oidcService.EXPECT().Callback(gomock.Any(), "sealed", "forged", "code").
+					Return(nil, &exception.ForbiddenException{Message: "invalid oidc state"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "invalid oidc state",
+				Instance: "/api/auth/oidc/callback",
+				Code:     "forbidden",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/auth/oidc/callback"+cs.inputQuery, nil)
+			ctx.Request.AddCookie(&http.Cookie{Name: controller.OidcSessionCookie, Value: "sealed"})
+
+			oidcServiceMock := mock.NewMockOidcService(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			mfaServiceMock := mock.NewMockMfaService(ctrl)
+			oidcController := controller.NewOidcController(r.Group("/api"), oidcServiceMock, cryptoServiceMock,
+				sessionServiceMock, mfaServiceMock, 600, false)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			sessionServiceMock.EXPECT().CreateSession(gomock.Any(), user.ID, gomock.Any(), gomock.Any()).AnyTimes().
+				Return(&model.Session{ID: 7, UserID: user.ID}, nil)
+			cs.mocking(oidcServiceMock, cryptoServiceMock, mfaServiceMock)
+
+			// when
+			oidcController.Callback(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.AuthLoginResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
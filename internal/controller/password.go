@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type PasswordController interface {
+	ForgotPassword(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
+}
+
+type passwordController struct {
+	passwordResetService service.PasswordResetService
+}
+
+func NewPasswordController(router *gin.RouterGroup, passwordResetService service.PasswordResetService) PasswordController {
+	impl := &passwordController{
+		passwordResetService: passwordResetService,
+	}
+
+	router.POST("/auth/password/forgot", impl.ForgotPassword)
+	router.POST("/auth/password/reset", impl.ResetPassword)
+
+	return impl
+}
+
+// @Summary forgot password
+// @Description Always accepted, whether or not an account uses the email
+// @Schemes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordDto true "email"
+// @Success 202
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Router /auth/password/forgot [post]
+func (impl *passwordController) ForgotPassword(ctx *gin.Context) {
+	var data dto.ForgotPasswordDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	go impl.passwordResetService.ForgotPassword(ctx.Copy(), data.Email)
+
+	ctx.Status(http.StatusAccepted)
+}
+
+// @Summary reset password
+// @Schemes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordDto true "reset token and new password"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/password/reset [post]
+func (impl *passwordController) ResetPassword(ctx *gin.Context) {
+	var data dto.ResetPasswordDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	if err := impl.passwordResetService.ResetPassword(ctx, data.Token, data.Password); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,175 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestPasswordControllerForgotPassword(t *testing.T) {
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(passwordResetService *mock.MockPasswordResetService, async chan bool)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should accept when account exists": {
+			inputPayload: `{"email": "email@email.com"}`,
+			mocking: func(passwordResetService *mock.MockPasswordResetService, async chan bool) {
+				passwordResetService.EXPECT().ForgotPassword(gomock.Any(), "email@email.com").
+					Do(func(ctx context.Context, email string) { async <- true })
+			},
+			expectedStatusCode: http.StatusAccepted,
+		},
+		"should accept without revealing internal errors": {
+			inputPayload: `{"email": "unknown@email.com"}`,
+			mocking: func(passwordResetService *mock.MockPasswordResetService, async chan bool) {
+				passwordResetService.EXPECT().ForgotPassword(gomock.Any(), "unknown@email.com").
+					DoAndReturn(func(ctx context.Context, email string) error {
+						async <- true
+						return fmt.Errorf("error")
+					})
+			},
+			expectedStatusCode: http.StatusAccepted,
+		},
+		"should throw bad request when email is invalid": {
+			inputPayload: `{"email": "email"}`,
+			mocking: func(passwordResetService *mock.MockPasswordResetService, async chan bool) {
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/auth/password/forgot",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "email", Code: "email", Message: "email must be a valid email"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/auth/password/forgot", strings.NewReader(cs.inputPayload))
+
+			passwordResetServiceMock := mock.NewMockPasswordResetService(ctrl)
+			passwordController := controller.NewPasswordController(r.Group("/api"), passwordResetServiceMock)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			async := make(chan bool, 1)
+			cs.mocking(passwordResetServiceMock, async)
+
+			// when
+			passwordController.ForgotPassword(ctx)
+			middlewareController.HandleErrors(ctx)
+			<-async
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestPasswordControllerResetPassword(t *testing.T) {
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(passwordResetService *mock.MockPasswordResetService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should reset password": {
+			inputPayload: `{"token": "token", "password": "12345"}`,
+			mocking: func(passwordResetService *mock.MockPasswordResetService) {
+				passwordResetService.EXPECT().ResetPassword(gomock.Any(), "token", "12345").Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw forbidden when token is invalid": {
+			inputPayload: `{"token": "token", "password": "12345"}`,
+			mocking: func(passwordResetService *mock.MockPasswordResetService) {
+				passwordResetService.EXPECT().ResetPassword(gomock.Any(), "token", "12345").
+					Return(&exception.ForbiddenException{Message: "invalid or expired password reset token"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "invalid or expired password reset token",
+				Instance: "/api/auth/password/reset",
+				Code:     "forbidden",
+			},
+		},
+		"should throw bad request when password is missing": {
+			inputPayload:       `{"token": "token"}`,
+			mocking:            func(passwordResetService *mock.MockPasswordResetService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/auth/password/reset",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "password", Code: "required", Message: "password is required"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/auth/password/reset", strings.NewReader(cs.inputPayload))
+
+			passwordResetServiceMock := mock.NewMockPasswordResetService(ctrl)
+			passwordController := controller.NewPasswordController(r.Group("/api"), passwordResetServiceMock)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(passwordResetServiceMock)
+
+			// when
+			passwordController.ResetPassword(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type ReportController interface {
+	ReportTasks(ctx *gin.Context)
+}
+
+type reportController struct {
+	taskReportService service.TaskReportService
+}
+
+func NewReportController(router *gin.RouterGroup, taskReportService service.TaskReportService,
+	middlewareAccessToken, middlewareUserManager func(ctx *gin.Context)) ReportController {
+	impl := &reportController{
+		taskReportService: taskReportService,
+	}
+
+	router.GET("/reports/tasks", middlewareAccessToken, middlewareUserManager, impl.ReportTasks)
+
+	return impl
+}
+
+// @Summary report tasks
+// @Description Tasks per status, created and closed per day or week, mean time to close and the work of each technician, from from to to (the last 7 days by default)
+// @Schemes
+// @Tags report
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param from query string false "first day (2006-01-02)"
+// @Param to query string false "last day (2006-01-02)"
+// @Param interval query string false "interval" Enums(day, week)
+// @Success 200 {object} dto.TaskReportResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /reports/tasks [get]
+func (impl *reportController) ReportTasks(ctx *gin.Context) {
+	var filter dto.TaskReportFilterDto
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	report, err := impl.taskReportService.ReportTasks(ctx, filter)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskReportResponse{Data: impl.ParseTaskReportDto(report)})
+}
+
+func (impl *reportController) ParseTaskReportDto(report *model.TaskReport) dto.TaskReportDto {
+	res := dto.TaskReportDto{
+		From:     report.From.Format("2006-01-02"),
+		To:       report.To.Format("2006-01-02"),
+		Interval: report.Interval,
+		Closed: dto.TaskCloseStatsDto{
+			Total:              report.Closed.Total,
+			MeanSecondsToClose: roundSeconds(report.Closed.MeanSeconds),
+		},
+		Statuses:    []dto.TaskStatusCountDto{},
+		Periods:     []dto.TaskPeriodCountDto{},
+		Technicians: []dto.TechnicianTaskStatsDto{},
+	}
+	for _, s := range report.Statuses {
+		res.Statuses = append(res.Statuses, dto.TaskStatusCountDto{Status: s.Status, Total: s.Total})
+	}
+	for _, p := range report.Periods {
+		res.Periods = append(res.Periods, dto.TaskPeriodCountDto{Period: p.Period, Created: p.Created, Closed: p.Closed})
+	}
+	for _, t := range report.Technicians {
+		res.Technicians = append(res.Technicians, dto.TechnicianTaskStatsDto{
+			User:               dto.UserDto{ID: t.UserID, Username: t.Username},
+			Assigned:           t.Assigned,
+			Closed:             t.Closed,
+			MeanSecondsToClose: roundSeconds(t.MeanSeconds),
+			LoggedSeconds:      t.LoggedSeconds,
+		})
+	}
+
+	return res
+}
+
+func roundSeconds(seconds *float64) *int64 {
+	if seconds == nil {
+		return nil
+	}
+
+	rounded := int64(math.Round(*seconds))
+	return &rounded
+}
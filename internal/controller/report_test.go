@@ -0,0 +1,149 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestReportControllerReportTasks(t *testing.T) {
+	from := time.Date(1992, 8, 17, 0, 0, 0, 0, time.Local)
+	mean := 93600.4
+	rounded := int64(93600)
+
+	var cases = map[string]struct {
+		inputQuery         string
+		mocking            func(taskReportService *mock.MockTaskReportService)
+		expectedStatusCode int
+		expectedBody       dto.TaskReportResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should report tasks": {
+			inputQuery: "from=1992-08-17&to=1992-08-23&interval=week",
+			mocking: func(taskReportService *mock.MockTaskReportService) {
+				taskReportService.EXPECT().ReportTasks(gomock.Any(), dto.TaskReportFilterDto{
+					From: "1992-08-17", To: "1992-08-23", Interval: model.TaskReportIntervalWeek,
+				}).Return(&model.TaskReport{
+					From:     from,
+					To:       from.AddDate(0, 0, 6),
+					Interval: model.TaskReportIntervalWeek,
+					Statuses: []model.TaskStatusCount{{Status: model.TaskStatusClosed, Total: 2}},
+					Periods:  []model.TaskPeriodCount{{Period: "1992-08-17", Created: 3, Closed: 2}},
+					Closed:   model.TaskCloseStats{Total: 2, MeanSeconds: &mean},
+					Technicians: []model.TechnicianTaskStats{
+						{UserID: 2, Username: "technician", Assigned: 3, Closed: 2, MeanSeconds: &mean, LoggedSeconds: 27000},
+						{UserID: 3, Username: "other"},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskReportResponse{Data: dto.TaskReportDto{
+				From:     "1992-08-17",
+				To:       "1992-08-23",
+				Interval: model.TaskReportIntervalWeek,
+				Statuses: []dto.TaskStatusCountDto{{Status: model.TaskStatusClosed, Total: 2}},
+				Periods:  []dto.TaskPeriodCountDto{{Period: "1992-08-17", Created: 3, Closed: 2}},
+				Closed:   dto.TaskCloseStatsDto{Total: 2, MeanSecondsToClose: &rounded},
+				Technicians: []dto.TechnicianTaskStatsDto{
+					{User: dto.UserDto{ID: 2, Username: "technician"}, Assigned: 3, Closed: 2, MeanSecondsToClose: &rounded, LoggedSeconds: 27000},
+					{User: dto.UserDto{ID: 3, Username: "other"}},
+				},
+			}},
+		},
+		"should throw bad request when filter is invalid": {
+			inputQuery:         "from=17-08-1992&interval=month",
+			mocking:            func(taskReportService *mock.MockTaskReportService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/reports/tasks",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "from", Code: "datetime", Message: "from must match the format 2006-01-02"},
+					{Field: "interval", Code: "oneof", Message: "interval has an invalid value"},
+				},
+			},
+		},
+		"should throw bad request when from is after to": {
+			inputQuery: "from=1992-08-24&to=1992-08-23",
+			mocking: func(taskReportService *mock.MockTaskReportService) {
+				taskReportService.EXPECT().ReportTasks(gomock.Any(), gomock.Any()).Return(nil, &exception.ValidationException{
+					Message: "invalid fields",
+					Fields:  []exception.FieldError{{Field: "from", Tag: "ltefield", Message: "from must not be after to"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/reports/tasks",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "from", Code: "ltefield", Message: "from must not be after to"}},
+			},
+		},
+		"should throw internal server error on report tasks": {
+			mocking: func(taskReportService *mock.MockTaskReportService) {
+				taskReportService.EXPECT().ReportTasks(gomock.Any(), dto.TaskReportFilterDto{}).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/reports/tasks",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/reports/tasks?"+cs.inputQuery, nil)
+
+			taskReportServiceMock := mock.NewMockTaskReportService(ctrl)
+			reportController := controller.NewReportController(r.Group("/api"), taskReportServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskReportServiceMock)
+
+			// when
+			reportController.ReportTasks(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskReportResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
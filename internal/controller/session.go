@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type SessionController interface {
+	ListSessions(ctx *gin.Context)
+	RevokeSession(ctx *gin.Context)
+	RevokeUserSessions(ctx *gin.Context)
+}
+
+type sessionController struct {
+	sessionService service.SessionService
+}
+
+func NewSessionController(router *gin.RouterGroup, sessionService service.SessionService,
+	middlewareAccessToken, middlewareUserManager func(ctx *gin.Context)) SessionController {
+	impl := &sessionController{
+		sessionService: sessionService,
+	}
+
+	router.GET("/me/sessions", middlewareAccessToken, impl.ListSessions)
+	router.DELETE("/me/sessions/:id", middlewareAccessToken, impl.RevokeSession)
+	router.DELETE("/users/:id/sessions", middlewareAccessToken, middlewareUserManager, impl.RevokeUserSessions)
+
+	return impl
+}
+
+// @Summary list active sessions
+// @Schemes
+// @Tags session
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} dto.SessionsResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/sessions [get]
+func (impl *sessionController) ListSessions(ctx *gin.Context) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+	paramSessionID, _ := ctx.Params.Get(model.SessionIDClaim)
+	sessionID, _ := strconv.Atoi(paramSessionID)
+
+	sessions, err := impl.sessionService.ListSessions(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.SessionDto{}
+	for _, s := range sessions {
+		session := impl.ParseSessionDto(&s)
+		session.Current = s.ID == sessionID
+		data = append(data, session)
+	}
+
+	ctx.JSON(http.StatusOK, dto.SessionsResponse{Data: data})
+}
+
+// @Summary revoke session
+// @Schemes
+// @Tags session
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "session id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/sessions/{id} [delete]
+func (impl *sessionController) RevokeSession(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	if err := impl.sessionService.RevokeSession(ctx, userID, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary revoke all sessions of a user
+// @Schemes
+// @Tags session
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "user id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/{id}/sessions [delete]
+func (impl *sessionController) RevokeUserSessions(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	if err := impl.sessionService.RevokeUserSessions(ctx, userID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *sessionController) ParseSessionDto(session *model.Session) dto.SessionDto {
+	return dto.SessionDto{
+		ID:         session.ID,
+		CreatedAt:  session.CreatedAt.Format("2006-01-02 15:04:05"),
+		ExpiresAt:  session.ExpiresAt.Format("2006-01-02 15:04:05"),
+		LastSeenAt: session.LastSeenAt.Format("2006-01-02 15:04:05"),
+		Device:     session.Device,
+		IP:         session.IP,
+		UserAgent:  session.UserAgent,
+	}
+}
@@ -0,0 +1,260 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestSessionControllerListSessions(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		mocking            func(sessionService *mock.MockSessionService)
+		expectedStatusCode int
+		expectedBody       dto.SessionsResponse
+	}{
+		"should list sessions marking the current one": {
+			mocking: func(sessionService *mock.MockSessionService) {
+				sessionService.EXPECT().ListSessions(gomock.Any(), 1).Return([]model.Session{
+					{ID: 7, CreatedAt: now, ExpiresAt: now, LastSeenAt: now, UserID: 1, Device: "Chrome on macOS", IP: "127.0.0.1", UserAgent: "Chrome/105.0"},
+					{ID: 8, CreatedAt: now, ExpiresAt: now, LastSeenAt: now, UserID: 1, Device: "curl", IP: "10.0.0.1", UserAgent: "curl/7.79.1"},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.SessionsResponse{Data: []dto.SessionDto{
+				{
+					ID:         7,
+					CreatedAt:  now.Format("2006-01-02 15:04:05"),
+					ExpiresAt:  now.Format("2006-01-02 15:04:05"),
+					LastSeenAt: now.Format("2006-01-02 15:04:05"),
+					Device:     "Chrome on macOS",
+					IP:         "127.0.0.1",
+					UserAgent:  "Chrome/105.0",
+					Current:    true,
+				},
+				{
+					ID:         8,
+					CreatedAt:  now.Format("2006-01-02 15:04:05"),
+					ExpiresAt:  now.Format("2006-01-02 15:04:05"),
+					LastSeenAt: now.Format("2006-01-02 15:04:05"),
+					Device:     "curl",
+					IP:         "10.0.0.1",
+					UserAgent:  "curl/7.79.1",
+				},
+			}},
+		},
+		"should list empty sessions": {
+			mocking: func(sessionService *mock.MockSessionService) {
+				sessionService.EXPECT().ListSessions(gomock.Any(), 1).Return([]model.Session{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.SessionsResponse{Data: []dto.SessionDto{}},
+		},
+		"should throw internal server error": {
+			mocking: func(sessionService *mock.MockSessionService) {
+				sessionService.EXPECT().ListSessions(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"}, gin.Param{Key: model.SessionIDClaim, Value: "7"})
+			ctx.Request = httptest.NewRequest("GET", "/api/me/sessions", nil)
+
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			sessionController := controller.NewSessionController(r.Group("/api"), sessionServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(sessionServiceMock)
+
+			// when
+			sessionController.ListSessions(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.SessionsResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestSessionControllerRevokeSession(t *testing.T) {
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(sessionService *mock.MockSessionService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should revoke session": {
+			inputID: "7",
+			mocking: func(sessionService *mock.MockSessionService) {
+				sessionService.EXPECT().RevokeSession(gomock.Any(), 1, 7).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw not found": {
+			inputID: "8",
+			mocking: func(sessionService *mock.MockSessionService) {
+				sessionService.EXPECT().RevokeSession(gomock.Any(), 1, 8).
+					Return(&exception.NotFoundException{Message: "session not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "session not found",
+				Instance: "/api/me/sessions/8",
+				Code:     "not_found",
+			},
+		},
+		"should throw bad request when id is invalid": {
+			inputID:            "abc",
+			mocking:            func(sessionService *mock.MockSessionService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/me/sessions/abc",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "id", Code: "numeric", Message: "id must be a number"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/me/sessions/"+cs.inputID, nil)
+
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			sessionController := controller.NewSessionController(r.Group("/api"), sessionServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(sessionServiceMock)
+
+			// when
+			sessionController.RevokeSession(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestSessionControllerRevokeUserSessions(t *testing.T) {
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(sessionService *mock.MockSessionService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should revoke user sessions": {
+			inputID: "2",
+			mocking: func(sessionService *mock.MockSessionService) {
+				sessionService.EXPECT().RevokeUserSessions(gomock.Any(), 2).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw bad request when id is invalid": {
+			inputID:            "abc",
+			mocking:            func(sessionService *mock.MockSessionService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/users/abc/sessions",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "id", Code: "numeric", Message: "id must be a number"},
+				},
+			},
+		},
+		"should throw internal server error": {
+			inputID: "2",
+			mocking: func(sessionService *mock.MockSessionService) {
+				sessionService.EXPECT().RevokeUserSessions(gomock.Any(), 2).Return(fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/users/2/sessions",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/users/"+cs.inputID+"/sessions", nil)
+
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			sessionController := controller.NewSessionController(r.Group("/api"), sessionServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(sessionServiceMock)
+
+			// when
+			sessionController.RevokeUserSessions(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
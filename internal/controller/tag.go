@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type TagController interface {
+	CreateTag(ctx *gin.Context)
+	ListTags(ctx *gin.Context)
+	UpdateTag(ctx *gin.Context)
+	DeleteTag(ctx *gin.Context)
+}
+
+type tagController struct {
+	tagService service.TagService
+}
+
+func NewTagController(router *gin.RouterGroup, tagService service.TagService,
+	middlewareAccessToken, middlewareUserManager func(ctx *gin.Context)) TagController {
+	impl := &tagController{
+		tagService: tagService,
+	}
+
+	router.POST("/tags", middlewareAccessToken, middlewareUserManager, impl.CreateTag)
+	router.GET("/tags", middlewareAccessToken, impl.ListTags)
+	router.PUT("/tags/:id", middlewareAccessToken, middlewareUserManager, impl.UpdateTag)
+	router.DELETE("/tags/:id", middlewareAccessToken, middlewareUserManager, impl.DeleteTag)
+
+	return impl
+}
+
+// @Summary create tag
+// @Schemes
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.CreateTagDto true "tag"
+// @Success 201 {object} dto.TagResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tags [post]
+func (impl *tagController) CreateTag(ctx *gin.Context) {
+	var data dto.CreateTagDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	tag, err := impl.tagService.CreateTag(ctx, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.TagResponse{Data: impl.ParseTagDto(tag)})
+}
+
+// @Summary list tags
+// @Description Every tag with the number of tasks using it
+// @Schemes
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} dto.TagsResponse
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tags [get]
+func (impl *tagController) ListTags(ctx *gin.Context) {
+	tags, err := impl.tagService.ListTags(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.TagDto{}
+	for _, t := range tags {
+		data = append(data, impl.ParseTagDto(&t))
+	}
+
+	ctx.JSON(http.StatusOK, dto.TagsResponse{Data: data})
+}
+
+// @Summary update tag
+// @Description Renames the tag on every task using it
+// @Schemes
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "tag id"
+// @Param request body dto.UpdateTagDto true "tag"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tags/{id} [put]
+func (impl *tagController) UpdateTag(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var data dto.UpdateTagDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	tag, err := impl.tagService.UpdateTag(ctx, id, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TagResponse{Data: impl.ParseTagDto(tag)})
+}
+
+// @Summary delete tag
+// @Description Removes the tag from every task using it
+// @Schemes
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "tag id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tags/{id} [delete]
+func (impl *tagController) DeleteTag(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := impl.tagService.DeleteTag(ctx, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *tagController) ParseTagDto(tag *model.Tag) dto.TagDto {
+	return dto.TagDto{
+		ID:         tag.ID,
+		CreatedAt:  tag.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:  tag.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Name:       tag.Name,
+		UsageCount: tag.UsageCount,
+	}
+}
+
+func (impl *tagController) parseID(ctx *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,291 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTagControllerCreateTag(t *testing.T) {
+	now := time.Now()
+	tag := &model.Tag{ID: 1, CreatedAt: now, UpdatedAt: now, Name: "maintenance"}
+
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(tagService *mock.MockTagService)
+		expectedStatusCode int
+		expectedBody       dto.TagResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should create tag": {
+			inputPayload: `{"name": "maintenance"}`,
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().CreateTag(gomock.Any(), dto.CreateTagDto{Name: "maintenance"}).Return(tag, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TagResponse{Data: dto.TagDto{
+				ID:        1,
+				CreatedAt: now.Format("2006-01-02 15:04:05"),
+				UpdatedAt: now.Format("2006-01-02 15:04:05"),
+				Name:      "maintenance",
+			}},
+		},
+		"should throw bad request when name is missing": {
+			inputPayload:       `{}`,
+			mocking:            func(tagService *mock.MockTagService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tags",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "name", Code: "required", Message: "name is required"}},
+			},
+		},
+		"should throw conflict when tag already exists": {
+			inputPayload: `{"name": "maintenance"}`,
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().CreateTag(gomock.Any(), dto.CreateTagDto{Name: "maintenance"}).
+					Return(nil, &exception.ConflictException{Message: "tag already exists"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "tag already exists",
+				Instance: "/api/tags",
+				Code:     "conflict",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/tags", strings.NewReader(cs.inputPayload))
+
+			tagServiceMock := mock.NewMockTagService(ctrl)
+			tagController := controller.NewTagController(r.Group("/api"), tagServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(tagServiceMock)
+
+			// when
+			tagController.CreateTag(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TagResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTagControllerListTags(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		mocking            func(tagService *mock.MockTagService)
+		expectedStatusCode int
+		expectedBody       dto.TagsResponse
+	}{
+		"should list tags with usage count": {
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().ListTags(gomock.Any()).Return([]model.Tag{
+					{ID: 1, CreatedAt: now, UpdatedAt: now, Name: "maintenance", UsageCount: 3},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TagsResponse{Data: []dto.TagDto{{
+				ID:         1,
+				CreatedAt:  now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:  now.Format("2006-01-02 15:04:05"),
+				Name:       "maintenance",
+				UsageCount: 3,
+			}}},
+		},
+		"should throw internal server error": {
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().ListTags(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/tags", nil)
+
+			tagServiceMock := mock.NewMockTagService(ctrl)
+			tagController := controller.NewTagController(r.Group("/api"), tagServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(tagServiceMock)
+
+			// when
+			tagController.ListTags(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TagsResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTagControllerUpdateTag(t *testing.T) {
+	now := time.Now()
+	tag := &model.Tag{ID: 1, CreatedAt: now, UpdatedAt: now, Name: "equipment", UsageCount: 2}
+
+	var cases = map[string]struct {
+		inputID            string
+		inputPayload       string
+		mocking            func(tagService *mock.MockTagService)
+		expectedStatusCode int
+		expectedBody       dto.TagResponse
+	}{
+		"should update tag": {
+			inputID:      "1",
+			inputPayload: `{"name": "equipment"}`,
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().UpdateTag(gomock.Any(), 1, dto.UpdateTagDto{Name: "equipment"}).Return(tag, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TagResponse{Data: dto.TagDto{
+				ID:         1,
+				CreatedAt:  now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:  now.Format("2006-01-02 15:04:05"),
+				Name:       "equipment",
+				UsageCount: 2,
+			}},
+		},
+		"should throw bad request when id is not a number": {
+			inputID:            "abc",
+			inputPayload:       `{"name": "equipment"}`,
+			mocking:            func(tagService *mock.MockTagService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"should throw not found": {
+			inputID:      "1",
+			inputPayload: `{"name": "equipment"}`,
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().UpdateTag(gomock.Any(), 1, dto.UpdateTagDto{Name: "equipment"}).
+					Return(nil, &exception.NotFoundException{Message: "tag not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID})
+			ctx.Request = httptest.NewRequest("PUT", "/api/tags/"+cs.inputID, strings.NewReader(cs.inputPayload))
+
+			tagServiceMock := mock.NewMockTagService(ctrl)
+			tagController := controller.NewTagController(r.Group("/api"), tagServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(tagServiceMock)
+
+			// when
+			tagController.UpdateTag(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TagResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTagControllerDeleteTag(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(tagService *mock.MockTagService)
+		expectedStatusCode int
+	}{
+		"should delete tag": {
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().DeleteTag(gomock.Any(), 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw not found": {
+			mocking: func(tagService *mock.MockTagService) {
+				tagService.EXPECT().DeleteTag(gomock.Any(), 1).Return(&exception.NotFoundException{Message: "tag not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/tags/1", nil)
+
+			tagServiceMock := mock.NewMockTagService(ctrl)
+			tagController := controller.NewTagController(r.Group("/api"), tagServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(tagServiceMock)
+
+			// when
+			tagController.DeleteTag(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+		})
+	}
+}
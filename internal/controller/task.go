@@ -1,76 +1,137 @@
 package controller
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/viniosilva/swordhealth-api/internal/dto"
 	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
 	"github.com/viniosilva/swordhealth-api/internal/service"
+	"golang.org/x/exp/slices"
 )
 
 type TaskController interface {
 	CreateTask(ctx *gin.Context)
 	ListTasks(ctx *gin.Context)
+	ExportTasks(ctx *gin.Context)
+	UpdateTask(ctx *gin.Context)
+	AssignTask(ctx *gin.Context)
+	TransitionTask(ctx *gin.Context)
+	ListTaskTransitions(ctx *gin.Context)
+	AddTaskDependency(ctx *gin.Context)
+	RemoveTaskDependency(ctx *gin.Context)
+	GetTaskTree(ctx *gin.Context)
+	DeleteTask(ctx *gin.Context)
+	BulkTasks(ctx *gin.Context)
+	ImportTasks(ctx *gin.Context)
 }
 
 type taskController struct {
 	taskService         service.TaskService
 	userService         service.UserService
 	notificationService service.NotificationService
+	taskTemplateService service.TaskTemplateService
+	taskBulkService     service.TaskBulkService
+	taskImportService   service.TaskImportService
+	auditService        service.AuditService
+	importMaxRows       int
 }
 
+// taskImportRowSize is the room given to each row of an imported file when
+// capping its size.
+const taskImportRowSize = 4 << 10
+
 func NewTaskController(router *gin.RouterGroup, taskService service.TaskService, userService service.UserService, notificationService service.NotificationService,
-	middlewareAccessToken func(ctx *gin.Context)) TaskController {
+	taskTemplateService service.TaskTemplateService, taskBulkService service.TaskBulkService, taskImportService service.TaskImportService,
+	auditService service.AuditService, importMaxRows int, middlewareAccessToken, middlewareUserManager func(ctx *gin.Context)) TaskController {
 	impl := &taskController{
 		taskService:         taskService,
 		userService:         userService,
 		notificationService: notificationService,
+		taskTemplateService: taskTemplateService,
+		taskBulkService:     taskBulkService,
+		taskImportService:   taskImportService,
+		auditService:        auditService,
+		importMaxRows:       importMaxRows,
 	}
 
 	router.POST("/tasks", middlewareAccessToken, impl.CreateTask)
 	router.GET("/tasks", middlewareAccessToken, impl.ListTasks)
+	router.GET("/tasks/export", middlewareAccessToken, impl.ExportTasks)
+	router.PUT("/tasks/:id", middlewareAccessToken, impl.UpdateTask)
+	router.PUT("/tasks/:id/assignee", middlewareAccessToken, middlewareUserManager, impl.AssignTask)
+	router.POST("/tasks/:id/transitions", middlewareAccessToken, impl.TransitionTask)
+	router.GET("/tasks/:id/transitions", middlewareAccessToken, impl.ListTaskTransitions)
+	router.POST("/tasks/:id/dependencies", middlewareAccessToken, impl.AddTaskDependency)
+	router.DELETE("/tasks/:id/dependencies/:blockedByID", middlewareAccessToken, impl.RemoveTaskDependency)
+	router.GET("/tasks/:id/tree", middlewareAccessToken, impl.GetTaskTree)
+	router.DELETE("/tasks/:id", middlewareAccessToken, middlewareUserManager, impl.DeleteTask)
+	router.POST("/tasks/bulk", middlewareAccessToken, impl.BulkTasks)
+	router.POST("/tasks/import", middlewareAccessToken, middlewareUserManager, impl.ImportTasks)
 
 	return impl
 }
 
 // @Summary create task
+// @Description With template_id the body is a dto.CreateTaskFromTemplateDto and the summary is the rendered template
 // @Schemes
 // @Tags task
 // @Accept json
 // @Produce json
 // @Security JwtAuth
+// @Param template_id query int false "task template id"
 // @Param request body dto.CreateTaskDto true "task"
 // @Success 201 {object} dto.TaskResponse
-// @Failure 400 {object} dto.ApiError
-// @Failure 403 {object} dto.ApiError
-// @Failure 500 {object} dto.ApiError
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /tasks [post]
 func (impl *taskController) CreateTask(ctx *gin.Context) {
 	var data dto.CreateTaskDto
-	err := ctx.ShouldBindJSON(&data)
+	var err error
+	if ctx.Query("template_id") != "" {
+		data, err = impl.bindTaskFromTemplate(ctx)
+	} else {
+		err = ctx.ShouldBindJSON(&data)
+		if err != nil {
+			err = exception.ParseBindingErrors(err)
+		}
+	}
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: exception.FormatBindingErrors(err)})
+		ctx.Error(err)
 		return
 	}
 
 	paramUserID, _ := ctx.Params.Get("sub")
 	userID, _ := strconv.Atoi(paramUserID)
 
-	task, err := impl.taskService.CreateTask(ctx, userID, data.Summary)
+	user, err := impl.userService.GetUserByID(ctx, userID)
 	if err != nil {
-		if _, ok := err.(*exception.ForeignKeyConstraintException); ok {
-			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: err.Error()})
-			return
-		}
+		ctx.Error(err)
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+	task, err := impl.taskService.CreateTask(ctx, user, data)
+	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
 	go impl.notificationService.NotifyAdminUserOnSaveTask(ctx, task, task.UserID)
+	if task.AssigneeID != task.UserID {
+		go impl.notificationService.NotifyTaskAssigned(ctx.Copy(), task)
+	}
 
 	ctx.JSON(http.StatusCreated, dto.TaskResponse{Data: impl.ParseTaskDto(task)})
 }
@@ -83,9 +144,21 @@ func (impl *taskController) CreateTask(ctx *gin.Context) {
 // @Security JwtAuth
 // @Param limit query int false "limit"
 // @Param offset query int false "offset"
+// @Param status query string false "status"
+// @Param priority query string false "priority" Enums(low, normal, high, urgent)
+// @Param assignee_id query int false "assignee id"
+// @Param due_after query string false "due at or after (2006-01-02 15:04:05)"
+// @Param due_before query string false "due at or before (2006-01-02 15:04:05)"
+// @Param overdue query bool false "only overdue tasks"
+// @Param sort query string false "sort" Enums(created_at, -created_at, due_at, -due_at, priority, -priority)
+// @Param tags query string false "comma separated tag names"
+// @Param tag_match query string false "match any (default) or all of the tags" Enums(any, all)
 // @Success 200 {array} []dto.TasksResponse
-// @Failure 403 {object} dto.ApiError
-// @Failure 500 {object} dto.ApiError
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /tasks [get]
 func (impl *taskController) ListTasks(ctx *gin.Context) {
 	limit, err := strconv.Atoi(ctx.Query("limit"))
@@ -97,18 +170,24 @@ func (impl *taskController) ListTasks(ctx *gin.Context) {
 		offset = 0
 	}
 
+	var filter dto.ListTasksFilterDto
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
 	paramUserID, _ := ctx.Params.Get("sub")
 	userID, _ := strconv.Atoi(paramUserID)
 
 	user, err := impl.userService.GetUserByID(ctx, userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+		ctx.Error(err)
 		return
 	}
 
-	tasks, total, err := impl.taskService.ListTasks(ctx, limit, offset, user)
+	tasks, total, err := impl.taskService.ListTasks(ctx, limit, offset, user, filter)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+		ctx.Error(err)
 		return
 	}
 
@@ -126,15 +205,893 @@ func (impl *taskController) ListTasks(ctx *gin.Context) {
 	})
 }
 
+// @Summary export tasks
+// @Description Streams the tasks matching the list filters as CSV or newline delimited JSON, oldest first unless sorted
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce text/csv,application/x-ndjson
+// @Security JwtAuth
+// @Param format query string true "format" Enums(csv, ndjson)
+// @Param include_owner query bool false "include the username and email of the task owner"
+// @Param status query string false "status"
+// @Param priority query string false "priority" Enums(low, normal, high, urgent)
+// @Param assignee_id query int false "assignee id"
+// @Param due_after query string false "due at or after (2006-01-02 15:04:05)"
+// @Param due_before query string false "due at or before (2006-01-02 15:04:05)"
+// @Param overdue query bool false "only overdue tasks"
+// @Param sort query string false "sort" Enums(created_at, -created_at, due_at, -due_at, priority, -priority)
+// @Param tags query string false "comma separated tag names"
+// @Param tag_match query string false "match any (default) or all of the tags" Enums(any, all)
+// @Success 200 {string} string
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/export [get]
+func (impl *taskController) ExportTasks(ctx *gin.Context) {
+	var filter dto.ExportTasksFilterDto
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	export := &taskExportWriter{ctx: ctx, format: filter.Format, includeOwner: filter.IncludeOwner, parse: impl.ParseTaskDto}
+	err = impl.taskService.ExportTasks(ctx, user, filter.ListTasksFilterDto, export.Write)
+	if err != nil {
+		// once rows were sent the status is out and the error is only logged
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Type")
+			ctx.Writer.Header().Del("Content-Disposition")
+		}
+		ctx.Error(err)
+		return
+	}
+
+	if err := export.Close(); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// @Summary update task
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param request body dto.UpdateTaskDto true "task"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id} [put]
+func (impl *taskController) UpdateTask(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	var data dto.UpdateTaskDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	task, err := impl.taskService.UpdateTask(ctx, user, id, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskResponse{Data: impl.ParseTaskDto(task)})
+}
+
+// @Summary assign task
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param request body dto.AssignTaskDto true "assignee"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/assignee [put]
+func (impl *taskController) AssignTask(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	var data dto.AssignTaskDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	task, err := impl.taskService.AssignTask(ctx, id, data.AssigneeID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	go impl.notificationService.NotifyTaskAssigned(ctx.Copy(), task)
+
+	ctx.JSON(http.StatusOK, dto.TaskResponse{Data: impl.ParseTaskDto(task)})
+}
+
+// @Summary transition task status
+// @Description Closing a task with open subtasks requires force
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param request body dto.TransitionTaskDto true "target status"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/transitions [post]
+func (impl *taskController) TransitionTask(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	var data dto.TransitionTaskDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	task, transition, err := impl.taskService.TransitionTask(ctx, user, id, data.To, data.Force)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	go impl.notificationService.NotifyTaskTransitioned(ctx.Copy(), task, transition)
+
+	ctx.JSON(http.StatusOK, dto.TaskResponse{Data: impl.ParseTaskDto(task)})
+}
+
+// @Summary list task status transitions
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Success 200 {object} dto.TaskTransitionsResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/transitions [get]
+func (impl *taskController) ListTaskTransitions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transitions, err := impl.taskService.ListTaskTransitions(ctx, user, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.TaskTransitionDto{}
+	for _, t := range transitions {
+		data = append(data, dto.TaskTransitionDto{
+			ID:        t.ID,
+			CreatedAt: t.CreatedAt.Format("2006-01-02 15:04:05"),
+			User:      dto.UserDto{ID: t.UserID},
+			From:      t.FromStatus,
+			To:        t.ToStatus,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskTransitionsResponse{Data: data})
+}
+
+// @Summary add task dependency
+// @Description Marks the task as blocked by another task
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param request body dto.CreateTaskDependencyDto true "blocking task"
+// @Success 201 {object} dto.TaskResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/dependencies [post]
+func (impl *taskController) AddTaskDependency(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	var data dto.CreateTaskDependencyDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	task, err := impl.taskService.AddTaskDependency(ctx, user, id, data.BlockedByID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.TaskResponse{Data: impl.ParseTaskDto(task)})
+}
+
+// @Summary remove task dependency
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param blockedByID path int true "blocking task id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/dependencies/{blockedByID} [delete]
+func (impl *taskController) RemoveTaskDependency(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+	blockedByID, err := strconv.Atoi(ctx.Param("blockedByID"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "blockedByID", Tag: "numeric", Message: "blockedByID must be a number"}},
+		})
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := impl.taskService.RemoveTaskDependency(ctx, user, id, blockedByID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary get task tree
+// @Description The task with its subtasks, recursively
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Success 200 {object} dto.TaskTreeResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/tree [get]
+func (impl *taskController) GetTaskTree(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tree, err := impl.taskService.GetTaskTree(ctx, user, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskTreeResponse{Data: impl.ParseTaskTreeDto(tree)})
+}
+
+// @Summary delete task
+// @Description Tasks with subtasks can not be deleted
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id} [delete]
+func (impl *taskController) DeleteTask(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := impl.taskService.DeleteTask(ctx, user, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary run an action on many tasks
+// @Description Close, reopen, reassign, tag or delete the tasks in ids, or the tasks matching filter, up to task_bulk.max_items. Each task runs on its own with the checks of its single task endpoint and gets its own status in the results
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.BulkTasksDto true "action and tasks"
+// @Success 200 {object} dto.TasksBulkResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/bulk [post]
+func (impl *taskController) BulkTasks(ctx *gin.Context) {
+	var data dto.BulkTasksDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	results, err := impl.taskBulkService.BulkTasks(ctx, user, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	res := dto.TasksBulkResponse{Data: []dto.TaskBulkResultDto{}}
+	for _, result := range results {
+		method, path := impl.bulkTaskEndpoint(ctx, data.Action, result.TaskID)
+
+		item := dto.TaskBulkResultDto{ID: result.TaskID, Status: http.StatusOK}
+		if result.Err != nil {
+			problem := parseProblemDetails(ctx, result.Err)
+			problem.Instance = path
+			item.Status = problem.Status
+			item.Error = &problem
+			res.Failed++
+		} else {
+			if result.Task != nil {
+				task := impl.ParseTaskDto(result.Task)
+				item.Data = &task
+			} else {
+				item.Status = http.StatusNoContent
+			}
+			res.Succeeded++
+			impl.notifyBulkTask(ctx, data.Action, result)
+		}
+
+		impl.auditBulkTask(ctx, user, method, path, item.Status)
+		res.Data = append(res.Data, item)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Summary import tasks
+// @Description Creates a task for each row of a CSV file, all or none. The header names the columns: summary (required), status, assignee (username), priority, tags (comma separated), due_at and created_at. Row errors are keyed by rows[line]
+// @Schemes
+// @Tags task
+// @Accept mpfd
+// @Produce json
+// @Security JwtAuth
+// @Param file formData file true "csv file"
+// @Param dry_run query bool false "only validate the file"
+// @Success 200 {object} dto.TasksImportResponse
+// @Success 201 {object} dto.TasksImportResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/import [post]
+func (impl *taskController) ImportTasks(ctx *gin.Context) {
+	dryRun, _ := strconv.ParseBool(ctx.Query("dry_run"))
+
+	rows, err := impl.bindImportTasks(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	user, err := impl.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tasks, err := impl.taskImportService.ImportTasks(ctx, user, rows, dryRun)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	res := dto.TasksImportResponse{DryRun: dryRun, Imported: len(tasks), Data: []dto.TaskDto{}}
+	for _, t := range tasks {
+		res.Data = append(res.Data, impl.ParseTaskDto(&t))
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	ctx.JSON(status, res)
+}
+
+// bulkTaskEndpoint is the single task endpoint doing the bulk action.
+func (impl *taskController) bulkTaskEndpoint(ctx *gin.Context, action model.TaskBulkAction, id int) (string, string) {
+	path := fmt.Sprintf("%s/%d", strings.TrimSuffix(ctx.Request.URL.Path, "/bulk"), id)
+	switch action {
+	case model.TaskBulkActionClose, model.TaskBulkActionReopen:
+		return http.MethodPost, path + "/transitions"
+	case model.TaskBulkActionReassign:
+		return http.MethodPut, path + "/assignee"
+	case model.TaskBulkActionTag:
+		return http.MethodPut, path
+	default:
+		return http.MethodDelete, path
+	}
+}
+
+func (impl *taskController) notifyBulkTask(ctx *gin.Context, action model.TaskBulkAction, result service.TaskBulkResult) {
+	switch action {
+	case model.TaskBulkActionClose, model.TaskBulkActionReopen:
+		go impl.notificationService.NotifyTaskTransitioned(ctx.Copy(), result.Task, result.Transition)
+	case model.TaskBulkActionReassign:
+		go impl.notificationService.NotifyTaskAssigned(ctx.Copy(), result.Task)
+	}
+}
+
+// auditBulkTask records each task of an impersonated bulk request as its
+// single task request would have been.
+func (impl *taskController) auditBulkTask(ctx *gin.Context, user *model.User, method, path string, status int) {
+	paramActorID, ok := ctx.Params.Get(model.ActorClaim)
+	if !ok {
+		return
+	}
+	actorID, _ := strconv.Atoi(paramActorID)
+
+	impl.auditService.Record(ctx, model.AuditLog{
+		RequestID: ctx.GetString(RequestIDKey),
+		ActorID:   actorID,
+		UserID:    user.ID,
+		Action:    model.AuditActionImpersonationRequest,
+		Method:    method,
+		Path:      path,
+		Status:    status,
+	})
+}
+
+func (impl *taskController) ParseTaskTreeDto(tree *model.TaskTree) dto.TaskTreeDto {
+	res := dto.TaskTreeDto{TaskDto: impl.ParseTaskDto(&tree.Task), Subtasks: []dto.TaskTreeDto{}}
+	for _, subtask := range tree.Subtasks {
+		res.Subtasks = append(res.Subtasks, impl.ParseTaskTreeDto(&subtask))
+	}
+
+	return res
+}
+
+// bindImportTasks reads the uploaded CSV into rows, each validated as if it
+// had been sent to create a task. The errors of a row are kept on it, for
+// the import to report them along with its own.
+// The body is capped by the number of rows allowed, and reading stops as
+// soon as the file goes past it.
+func (impl *taskController) bindImportTasks(ctx *gin.Context) ([]dto.ImportTaskDto, error) {
+	invalidFile := func(tag, message string) error {
+		return &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "file", Tag: tag, Message: message}},
+		}
+	}
+
+	maxSize := int64(impl.importMaxRows+1) * taskImportRowSize
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+multipartOverhead)
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, invalidFile("max", fmt.Sprintf("file must have at most %d bytes", maxSize))
+		}
+		return nil, invalidFile("required", "file is required")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	columns, err := reader.Read()
+	if err != nil {
+		return nil, invalidFile("csv", "file must be a csv with a header")
+	}
+	for i, column := range columns {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !slices.Contains(taskImportColumns, column) {
+			return nil, invalidFile("csv", "file has an unknown column: "+column)
+		}
+		columns[i] = column
+	}
+	if !slices.Contains(columns, "summary") {
+		return nil, invalidFile("csv", "file must have a summary column")
+	}
+
+	rows := []dto.ImportTaskDto{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, invalidFile("csv", "file is not a valid csv: "+err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := dto.ImportTaskDto{Line: line}
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			switch columns[i] {
+			case "summary":
+				row.Summary = value
+			case "status":
+				row.Status = model.TaskStatus(value)
+			case "assignee":
+				row.AssigneeUsername = value
+			case "priority":
+				row.Priority = model.TaskPriority(value)
+			case "tags":
+				row.Tags = parseImportTags(value)
+			case "due_at":
+				row.DueAt = value
+			case "created_at":
+				row.CreatedAt = value
+			}
+		}
+
+		if err := binding.Validator.ValidateStruct(&row); err != nil {
+			row.Errors = exception.ParseBindingErrors(err).Fields
+		}
+		rows = append(rows, row)
+		if len(rows) > impl.importMaxRows {
+			return nil, invalidFile("max", fmt.Sprintf("file must have at most %d tasks", impl.importMaxRows))
+		}
+	}
+
+	return rows, nil
+}
+
+// bindTaskFromTemplate renders the template into the task to create, which
+// is then validated as if it had been sent as is, so the rendered summary is
+// held to the same limits.
+func (impl *taskController) bindTaskFromTemplate(ctx *gin.Context) (dto.CreateTaskDto, error) {
+	templateID, err := strconv.Atoi(ctx.Query("template_id"))
+	if err != nil {
+		return dto.CreateTaskDto{}, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "template_id", Tag: "numeric", Message: "template_id must be a number"}},
+		}
+	}
+
+	var body dto.CreateTaskFromTemplateDto
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		return dto.CreateTaskDto{}, exception.ParseBindingErrors(err)
+	}
+
+	data, err := impl.taskTemplateService.RenderTaskTemplate(ctx, templateID, body)
+	if err != nil {
+		return dto.CreateTaskDto{}, err
+	}
+
+	if err := binding.Validator.ValidateStruct(&data); err != nil {
+		return dto.CreateTaskDto{}, exception.ParseBindingErrors(err)
+	}
+
+	return data, nil
+}
+
 func (impl *taskController) ParseTaskDto(task *model.Task) dto.TaskDto {
 	dto := dto.TaskDto{
 		ID:        task.ID,
 		CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
 		User:      dto.UserDto{ID: task.UserID},
+		Assignee:  dto.UserDto{ID: task.AssigneeID},
 		Summary:   task.Summary,
 		Status:    task.Status,
+		Priority:  task.Priority,
+		Tags:      task.Tags,
+		Dependencies: dto.TaskDependenciesDto{
+			Subtasks:     task.SubtaskCount,
+			OpenSubtasks: task.OpenSubtaskCount,
+			BlockedBy:    task.BlockedBy,
+			Blocks:       task.Blocks,
+		},
+	}
+	if task.ParentID != nil {
+		dto.ParentID = *task.ParentID
+	}
+	if task.DueAt != nil {
+		dto.DueAt = task.DueAt.Format("2006-01-02 15:04:05")
 	}
 
 	return dto
 }
+
+// taskExportWriter writes the exported tasks to the response as they come.
+// Headers are only sent with the first task, so an error before it can
+// still be answered with problem details. CSV rows are flushed one by one
+// so the response is marked as written as soon as a task went out.
+type taskExportWriter struct {
+	ctx          *gin.Context
+	format       string
+	includeOwner bool
+	parse        func(task *model.Task) dto.TaskDto
+
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+func (impl *taskExportWriter) Write(task model.TaskExport) error {
+	impl.start()
+
+	if impl.json != nil {
+		data := impl.parse(&task.Task)
+		if impl.includeOwner {
+			data.User.Username = task.OwnerUsername
+			data.User.Email = task.OwnerEmail
+		}
+		return impl.json.Encode(data)
+	}
+
+	record := []string{
+		strconv.Itoa(task.ID),
+		task.CreatedAt.Format("2006-01-02 15:04:05"),
+		task.UpdatedAt.Format("2006-01-02 15:04:05"),
+		strconv.Itoa(task.UserID),
+		strconv.Itoa(task.AssigneeID),
+		"",
+		escapeCsvFormula(task.Summary),
+		string(task.Status),
+		string(task.Priority),
+		"",
+		escapeCsvFormula(strings.Join(task.Tags, ",")),
+	}
+	if task.ParentID != nil {
+		record[5] = strconv.Itoa(*task.ParentID)
+	}
+	if task.DueAt != nil {
+		record[9] = task.DueAt.Format("2006-01-02 15:04:05")
+	}
+	if impl.includeOwner {
+		record = append(record, escapeCsvFormula(task.OwnerUsername), escapeCsvFormula(task.OwnerEmail))
+	}
+
+	if err := impl.csv.Write(record); err != nil {
+		return err
+	}
+	impl.csv.Flush()
+
+	return impl.csv.Error()
+}
+
+func (impl *taskExportWriter) Close() error {
+	impl.start()
+
+	if impl.csv != nil {
+		impl.csv.Flush()
+		if err := impl.csv.Error(); err != nil {
+			return err
+		}
+	}
+	impl.ctx.Writer.Flush()
+
+	return nil
+}
+
+func (impl *taskExportWriter) start() {
+	if impl.started {
+		return
+	}
+	impl.started = true
+
+	contentType, filename := "text/csv", "tasks.csv"
+	if impl.format == "ndjson" {
+		contentType, filename = "application/x-ndjson", "tasks.ndjson"
+	}
+	impl.ctx.Header("Content-Type", contentType)
+	impl.ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	impl.ctx.Status(http.StatusOK)
+
+	if impl.format == "ndjson" {
+		impl.json = json.NewEncoder(impl.ctx.Writer)
+		return
+	}
+
+	impl.csv = csv.NewWriter(impl.ctx.Writer)
+	header := []string{"id", "created_at", "updated_at", "user_id", "assignee_id", "parent_id", "summary", "status", "priority", "due_at", "tags"}
+	if impl.includeOwner {
+		header = append(header, "owner_username", "owner_email")
+	}
+	impl.csv.Write(header)
+}
+
+// escapeCsvFormula prefixes user typed cells that spreadsheets would run as
+// formulas with a quote.
+func escapeCsvFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+var taskImportColumns = []string{"summary", "status", "assignee", "priority", "tags", "due_at", "created_at"}
+
+func parseImportTags(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return tags
+}
@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type TaskAttachmentController interface {
+	UploadAttachment(ctx *gin.Context)
+	ListAttachments(ctx *gin.Context)
+	DownloadAttachment(ctx *gin.Context)
+	DeleteAttachment(ctx *gin.Context)
+}
+
+// multipartOverhead leaves room for the multipart boundaries and part headers
+// around the file when capping the upload body.
+const multipartOverhead = 64 << 10
+
+type taskAttachmentController struct {
+	taskAttachmentService service.TaskAttachmentService
+	userService           service.UserService
+	maxSize               int64
+}
+
+func NewTaskAttachmentController(router *gin.RouterGroup, taskAttachmentService service.TaskAttachmentService,
+	userService service.UserService, maxSize int64, middlewareAccessToken func(ctx *gin.Context)) TaskAttachmentController {
+	impl := &taskAttachmentController{
+		taskAttachmentService: taskAttachmentService,
+		userService:           userService,
+		maxSize:               maxSize,
+	}
+
+	router.POST("/tasks/:id/attachments", middlewareAccessToken, impl.UploadAttachment)
+	router.GET("/tasks/:id/attachments", middlewareAccessToken, impl.ListAttachments)
+	router.GET("/tasks/:id/attachments/:attachmentID", middlewareAccessToken, impl.DownloadAttachment)
+	router.DELETE("/tasks/:id/attachments/:attachmentID", middlewareAccessToken, impl.DeleteAttachment)
+
+	return impl
+}
+
+// @Summary upload task attachment
+// @Schemes
+// @Tags task
+// @Accept mpfd
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param file formData file true "photo or pdf"
+// @Success 201 {object} dto.TaskAttachmentResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/attachments [post]
+func (impl *taskAttachmentController) UploadAttachment(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, impl.maxSize+multipartOverhead)
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.Error(&exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{{
+					Field: "file", Tag: "max", Message: fmt.Sprintf("file must have between 1 and %d bytes", impl.maxSize),
+				}},
+			})
+			return
+		}
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "file", Tag: "required", Message: "file is required"}},
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer file.Close()
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	attachment, err := impl.taskAttachmentService.UploadAttachment(ctx, user, taskID, header.Filename, header.Size, file)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.TaskAttachmentResponse{Data: impl.ParseTaskAttachmentDto(attachment)})
+}
+
+// @Summary list task attachments
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Success 200 {object} dto.TaskAttachmentsResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/attachments [get]
+func (impl *taskAttachmentController) ListAttachments(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	attachments, err := impl.taskAttachmentService.ListAttachments(ctx, user, taskID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.TaskAttachmentDto{}
+	for _, a := range attachments {
+		data = append(data, impl.ParseTaskAttachmentDto(&a))
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskAttachmentsResponse{Data: data})
+}
+
+// @Summary download task attachment
+// @Schemes
+// @Tags task
+// @Produce octet-stream
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param attachmentID path int true "attachment id"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/attachments/{attachmentID} [get]
+func (impl *taskAttachmentController) DownloadAttachment(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	id, ok := impl.parseID(ctx, "attachmentID")
+	if !ok {
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	attachment, content, err := impl.taskAttachmentService.GetAttachment(ctx, user, taskID, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// @Summary delete task attachment
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param attachmentID path int true "attachment id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/attachments/{attachmentID} [delete]
+func (impl *taskAttachmentController) DeleteAttachment(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	id, ok := impl.parseID(ctx, "attachmentID")
+	if !ok {
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := impl.taskAttachmentService.DeleteAttachment(ctx, user, taskID, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *taskAttachmentController) ParseTaskAttachmentDto(attachment *model.TaskAttachment) dto.TaskAttachmentDto {
+	return dto.TaskAttachmentDto{
+		ID:          attachment.ID,
+		CreatedAt:   attachment.CreatedAt.Format("2006-01-02 15:04:05"),
+		User:        dto.UserDto{ID: attachment.UserID},
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+	}
+}
+
+func (impl *taskAttachmentController) parseID(ctx *gin.Context, param string) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param(param))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: param, Tag: "numeric", Message: param + " must be a number"}},
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (impl *taskAttachmentController) getUser(ctx *gin.Context) (*model.User, error) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	return impl.userService.GetUserByID(ctx, userID)
+}
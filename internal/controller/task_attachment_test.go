@@ -0,0 +1,367 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskAttachmentControllerUploadAttachment(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	attachment := &model.TaskAttachment{ID: 1, CreatedAt: now, TaskID: 1, UserID: 2, Filename: "photo.png",
+		ContentType: "image/png", Size: 4}
+
+	var cases = map[string]struct {
+		inputWithFile      bool
+		inputFileSize      int
+		mocking            func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskAttachmentResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should upload attachment": {
+			inputWithFile: true,
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().UploadAttachment(gomock.Any(), user, 1, "photo.png", int64(4), gomock.Any()).
+					Return(attachment, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TaskAttachmentResponse{Data: dto.TaskAttachmentDto{
+				ID:          1,
+				CreatedAt:   now.Format("2006-01-02 15:04:05"),
+				User:        dto.UserDto{ID: 2},
+				Filename:    "photo.png",
+				ContentType: "image/png",
+				Size:        4,
+			}},
+		},
+		"should throw bad request when file is missing": {
+			mocking:            func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/attachments",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "required", Message: "file is required"}},
+			},
+		},
+		"should throw bad request when body is over the max size": {
+			inputWithFile:      true,
+			inputFileSize:      1 << 17,
+			mocking:            func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/attachments",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "max", Message: "file must have between 1 and 1024 bytes"}},
+			},
+		},
+		"should throw bad request when file type is not allowed": {
+			inputWithFile: true,
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().UploadAttachment(gomock.Any(), user, 1, "photo.png", int64(4), gomock.Any()).
+					Return(nil, &exception.ValidationException{
+						Message: "invalid fields",
+						Fields:  []exception.FieldError{{Field: "file", Tag: "mime", Message: "file type is not allowed"}},
+					})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/attachments",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "mime", Message: "file type is not allowed"}},
+			},
+		},
+		"should throw internal server error": {
+			inputWithFile: true,
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().UploadAttachment(gomock.Any(), user, 1, "photo.png", int64(4), gomock.Any()).
+					Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/tasks/1/attachments",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			payload := &bytes.Buffer{}
+			writer := multipart.NewWriter(payload)
+			if cs.inputWithFile {
+				part, _ := writer.CreateFormFile("file", "photo.png")
+				if cs.inputFileSize > 0 {
+					part.Write(bytes.Repeat([]byte("a"), cs.inputFileSize))
+				} else {
+					part.Write([]byte("data"))
+				}
+			}
+			writer.Close()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks/1/attachments", payload)
+			ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			taskAttachmentServiceMock := mock.NewMockTaskAttachmentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskAttachmentController := controller.NewTaskAttachmentController(r.Group("/api"), taskAttachmentServiceMock, userServiceMock, 1024, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskAttachmentServiceMock, userServiceMock)
+
+			// when
+			taskAttachmentController.UploadAttachment(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskAttachmentResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskAttachmentControllerListAttachments(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		mocking            func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskAttachmentsResponse
+	}{
+		"should list attachments": {
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().ListAttachments(gomock.Any(), user, 1).Return([]model.TaskAttachment{
+					{ID: 1, CreatedAt: now, TaskID: 1, UserID: 3, Filename: "report.pdf", ContentType: "application/pdf", Size: 10},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskAttachmentsResponse{Data: []dto.TaskAttachmentDto{{
+				ID:          1,
+				CreatedAt:   now.Format("2006-01-02 15:04:05"),
+				User:        dto.UserDto{ID: 3},
+				Filename:    "report.pdf",
+				ContentType: "application/pdf",
+				Size:        10,
+			}}},
+		},
+		"should throw internal server error": {
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().ListAttachments(gomock.Any(), user, 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks/1/attachments", nil)
+
+			taskAttachmentServiceMock := mock.NewMockTaskAttachmentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskAttachmentController := controller.NewTaskAttachmentController(r.Group("/api"), taskAttachmentServiceMock, userServiceMock, 1024, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskAttachmentServiceMock, userServiceMock)
+
+			// when
+			taskAttachmentController.ListAttachments(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskAttachmentsResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskAttachmentControllerDownloadAttachment(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	attachment := &model.TaskAttachment{ID: 1, TaskID: 1, UserID: 2, Filename: "photo.png", ContentType: "image/png", Size: 4}
+
+	var cases = map[string]struct {
+		inputAttachmentID  string
+		mocking            func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       string
+		expectedHeaders    map[string]string
+	}{
+		"should download attachment": {
+			inputAttachmentID: "1",
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().GetAttachment(gomock.Any(), user, 1, 1).
+					Return(attachment, io.NopCloser(bytes.NewReader([]byte("data"))), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "data",
+			expectedHeaders: map[string]string{
+				"Content-Type":           "image/png",
+				"Content-Disposition":    "attachment; filename=photo.png",
+				"X-Content-Type-Options": "nosniff",
+			},
+		},
+		"should throw bad request when attachment id is not a number": {
+			inputAttachmentID:  "abc",
+			mocking:            func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"should throw not found": {
+			inputAttachmentID: "1",
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().GetAttachment(gomock.Any(), user, 1, 1).
+					Return(nil, nil, &exception.NotFoundException{Message: "attachment not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"},
+				gin.Param{Key: "attachmentID", Value: cs.inputAttachmentID}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks/1/attachments/"+cs.inputAttachmentID, nil)
+
+			taskAttachmentServiceMock := mock.NewMockTaskAttachmentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskAttachmentController := controller.NewTaskAttachmentController(r.Group("/api"), taskAttachmentServiceMock, userServiceMock, 1024, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskAttachmentServiceMock, userServiceMock)
+
+			// when
+			taskAttachmentController.DownloadAttachment(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			if cs.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, cs.expectedBody, res.Body.String())
+			}
+			for key, value := range cs.expectedHeaders {
+				assert.Equal(t, value, res.Header().Get(key))
+			}
+		})
+	}
+}
+
+func TestTaskAttachmentControllerDeleteAttachment(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		mocking            func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService)
+		expectedStatusCode int
+	}{
+		"should delete attachment": {
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().DeleteAttachment(gomock.Any(), user, 1, 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw unauthorized when user is not the uploader": {
+			mocking: func(taskAttachmentService *mock.MockTaskAttachmentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskAttachmentService.EXPECT().DeleteAttachment(gomock.Any(), user, 1, 1).
+					Return(&exception.UnauthorizedException{Message: "only the uploader or a manager can delete the attachment"})
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"},
+				gin.Param{Key: "attachmentID", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/tasks/1/attachments/1", nil)
+
+			taskAttachmentServiceMock := mock.NewMockTaskAttachmentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskAttachmentController := controller.NewTaskAttachmentController(r.Group("/api"), taskAttachmentServiceMock, userServiceMock, 1024, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskAttachmentServiceMock, userServiceMock)
+
+			// when
+			taskAttachmentController.DeleteAttachment(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+		})
+	}
+}
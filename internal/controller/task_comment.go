@@ -0,0 +1,258 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type TaskCommentController interface {
+	CreateComment(ctx *gin.Context)
+	ListComments(ctx *gin.Context)
+	UpdateComment(ctx *gin.Context)
+	DeleteComment(ctx *gin.Context)
+}
+
+type taskCommentController struct {
+	taskCommentService service.TaskCommentService
+	userService        service.UserService
+}
+
+func NewTaskCommentController(router *gin.RouterGroup, taskCommentService service.TaskCommentService, userService service.UserService,
+	middlewareAccessToken func(ctx *gin.Context)) TaskCommentController {
+	impl := &taskCommentController{
+		taskCommentService: taskCommentService,
+		userService:        userService,
+	}
+
+	router.POST("/tasks/:id/comments", middlewareAccessToken, impl.CreateComment)
+	router.GET("/tasks/:id/comments", middlewareAccessToken, impl.ListComments)
+	router.PUT("/tasks/:id/comments/:commentID", middlewareAccessToken, impl.UpdateComment)
+	router.DELETE("/tasks/:id/comments/:commentID", middlewareAccessToken, impl.DeleteComment)
+
+	return impl
+}
+
+// @Summary comment on task
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param request body dto.CreateTaskCommentDto true "comment"
+// @Success 201 {object} dto.TaskCommentResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/comments [post]
+func (impl *taskCommentController) CreateComment(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var data dto.CreateTaskCommentDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	comment, err := impl.taskCommentService.CreateComment(ctx, user, taskID, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.TaskCommentResponse{Data: impl.ParseTaskCommentDto(comment)})
+}
+
+// @Summary list task comments
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} dto.TaskCommentsResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/comments [get]
+func (impl *taskCommentController) ListComments(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil {
+		limit = 10
+	}
+	offset, err := strconv.Atoi(ctx.Query("offset"))
+	if err != nil {
+		offset = 0
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	comments, total, err := impl.taskCommentService.ListComments(ctx, user, taskID, limit, offset)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.TaskCommentDto{}
+	for _, c := range comments {
+		data = append(data, impl.ParseTaskCommentDto(&c))
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskCommentsResponse{
+		Pagination: dto.Pagination{
+			Count: len(data),
+			Total: total,
+		},
+		Data: data,
+	})
+}
+
+// @Summary edit task comment
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param commentID path int true "comment id"
+// @Param request body dto.UpdateTaskCommentDto true "comment"
+// @Success 200 {object} dto.TaskCommentResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/comments/{commentID} [put]
+func (impl *taskCommentController) UpdateComment(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	id, ok := impl.parseID(ctx, "commentID")
+	if !ok {
+		return
+	}
+
+	var data dto.UpdateTaskCommentDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	comment, err := impl.taskCommentService.UpdateComment(ctx, user, taskID, id, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskCommentResponse{Data: impl.ParseTaskCommentDto(comment)})
+}
+
+// @Summary delete task comment
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param commentID path int true "comment id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/comments/{commentID} [delete]
+func (impl *taskCommentController) DeleteComment(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	id, ok := impl.parseID(ctx, "commentID")
+	if !ok {
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := impl.taskCommentService.DeleteComment(ctx, user, taskID, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *taskCommentController) ParseTaskCommentDto(comment *model.TaskComment) dto.TaskCommentDto {
+	return dto.TaskCommentDto{
+		ID:        comment.ID,
+		CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+		User:      dto.UserDto{ID: comment.UserID},
+		Body:      comment.Body,
+	}
+}
+
+func (impl *taskCommentController) parseID(ctx *gin.Context, param string) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param(param))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: param, Tag: "numeric", Message: param + " must be a number"}},
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (impl *taskCommentController) getUser(ctx *gin.Context) (*model.User, error) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	return impl.userService.GetUserByID(ctx, userID)
+}
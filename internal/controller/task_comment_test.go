@@ -0,0 +1,357 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskCommentControllerCreateComment(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	comment := &model.TaskComment{ID: 1, CreatedAt: now, UpdatedAt: now, TaskID: 1, UserID: 2, Body: "body"}
+
+	var cases = map[string]struct {
+		inputID            string
+		inputPayload       string
+		mocking            func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskCommentResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should create comment": {
+			inputID:      "1",
+			inputPayload: `{"body": "body"}`,
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().CreateComment(gomock.Any(), user, 1, dto.CreateTaskCommentDto{Body: "body"}).
+					Return(comment, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TaskCommentResponse{Data: dto.TaskCommentDto{
+				ID:        1,
+				CreatedAt: now.Format("2006-01-02 15:04:05"),
+				UpdatedAt: now.Format("2006-01-02 15:04:05"),
+				User:      dto.UserDto{ID: 2},
+				Body:      "body",
+			}},
+		},
+		"should throw bad request when body is missing": {
+			inputID:            "1",
+			inputPayload:       `{}`,
+			mocking:            func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/comments",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "body", Code: "required", Message: "body is required"},
+				},
+			},
+		},
+		"should throw not found": {
+			inputID:      "9",
+			inputPayload: `{"body": "body"}`,
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().CreateComment(gomock.Any(), user, 9, gomock.Any()).
+					Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "task not found",
+				Instance: "/api/tasks/9/comments",
+				Code:     "not_found",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks/"+cs.inputID+"/comments", strings.NewReader(cs.inputPayload))
+
+			taskCommentServiceMock := mock.NewMockTaskCommentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskCommentController := controller.NewTaskCommentController(r.Group("/api"), taskCommentServiceMock, userServiceMock, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskCommentServiceMock, userServiceMock)
+
+			// when
+			taskCommentController.CreateComment(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskCommentResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskCommentControllerListComments(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		mocking            func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskCommentsResponse
+	}{
+		"should list comments": {
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().ListComments(gomock.Any(), user, 1, 10, 0).Return([]model.TaskComment{
+					{ID: 1, CreatedAt: now, UpdatedAt: now, TaskID: 1, UserID: 3, Body: "body"},
+				}, 1, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskCommentsResponse{
+				Pagination: dto.Pagination{Count: 1, Total: 1},
+				Data: []dto.TaskCommentDto{{
+					ID:        1,
+					CreatedAt: now.Format("2006-01-02 15:04:05"),
+					UpdatedAt: now.Format("2006-01-02 15:04:05"),
+					User:      dto.UserDto{ID: 3},
+					Body:      "body",
+				}},
+			},
+		},
+		"should throw internal server error": {
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().ListComments(gomock.Any(), user, 1, 10, 0).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks/1/comments", nil)
+
+			taskCommentServiceMock := mock.NewMockTaskCommentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskCommentController := controller.NewTaskCommentController(r.Group("/api"), taskCommentServiceMock, userServiceMock, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskCommentServiceMock, userServiceMock)
+
+			// when
+			taskCommentController.ListComments(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskCommentsResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskCommentControllerUpdateComment(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputCommentID     string
+		mocking            func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskCommentResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should update comment": {
+			inputCommentID: "4",
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().UpdateComment(gomock.Any(), user, 1, 4, dto.UpdateTaskCommentDto{Body: "new body"}).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now, UpdatedAt: now, TaskID: 1, UserID: 2, Body: "new body"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskCommentResponse{Data: dto.TaskCommentDto{
+				ID:        4,
+				CreatedAt: now.Format("2006-01-02 15:04:05"),
+				UpdatedAt: now.Format("2006-01-02 15:04:05"),
+				User:      dto.UserDto{ID: 2},
+				Body:      "new body",
+			}},
+		},
+		"should throw bad request when comment id is invalid": {
+			inputCommentID:     "abc",
+			mocking:            func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/comments/abc",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "commentID", Code: "numeric", Message: "commentID must be a number"},
+				},
+			},
+		},
+		"should throw conflict when edit window has passed": {
+			inputCommentID: "4",
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().UpdateComment(gomock.Any(), user, 1, 4, gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "comment can no longer be changed"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "comment can no longer be changed",
+				Instance: "/api/tasks/1/comments/4",
+				Code:     "conflict",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "commentID", Value: cs.inputCommentID},
+				gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("PUT", "/api/tasks/1/comments/"+cs.inputCommentID, strings.NewReader(`{"body": "new body"}`))
+
+			taskCommentServiceMock := mock.NewMockTaskCommentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskCommentController := controller.NewTaskCommentController(r.Group("/api"), taskCommentServiceMock, userServiceMock, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskCommentServiceMock, userServiceMock)
+
+			// when
+			taskCommentController.UpdateComment(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskCommentResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskCommentControllerDeleteComment(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		mocking            func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should delete comment": {
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().DeleteComment(gomock.Any(), user, 1, 4).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw unauthorized when user is not the author": {
+			mocking: func(taskCommentService *mock.MockTaskCommentService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskCommentService.EXPECT().DeleteComment(gomock.Any(), user, 1, 4).
+					Return(&exception.UnauthorizedException{Message: "only the author can change the comment"})
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:unauthorized",
+				Title:    "Unauthorized",
+				Status:   http.StatusUnauthorized,
+				Detail:   "only the author can change the comment",
+				Instance: "/api/tasks/1/comments/4",
+				Code:     "unauthorized",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "commentID", Value: "4"},
+				gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/tasks/1/comments/4", nil)
+
+			taskCommentServiceMock := mock.NewMockTaskCommentService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskCommentController := controller.NewTaskCommentController(r.Group("/api"), taskCommentServiceMock, userServiceMock, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskCommentServiceMock, userServiceMock)
+
+			// when
+			taskCommentController.DeleteComment(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type TaskScheduleController interface {
+	CreateTaskSchedule(ctx *gin.Context)
+	ListTaskSchedules(ctx *gin.Context)
+	GetTaskSchedule(ctx *gin.Context)
+	UpdateTaskSchedule(ctx *gin.Context)
+	DeleteTaskSchedule(ctx *gin.Context)
+}
+
+type taskScheduleController struct {
+	taskScheduleService service.TaskScheduleService
+	userService         service.UserService
+}
+
+func NewTaskScheduleController(router *gin.RouterGroup, taskScheduleService service.TaskScheduleService,
+	userService service.UserService, middlewareAccessToken, middlewareUserManager func(ctx *gin.Context)) TaskScheduleController {
+	impl := &taskScheduleController{
+		taskScheduleService: taskScheduleService,
+		userService:         userService,
+	}
+
+	router.POST("/task-schedules", middlewareAccessToken, middlewareUserManager, impl.CreateTaskSchedule)
+	router.GET("/task-schedules", middlewareAccessToken, middlewareUserManager, impl.ListTaskSchedules)
+	router.GET("/task-schedules/:id", middlewareAccessToken, middlewareUserManager, impl.GetTaskSchedule)
+	router.PUT("/task-schedules/:id", middlewareAccessToken, middlewareUserManager, impl.UpdateTaskSchedule)
+	router.DELETE("/task-schedules/:id", middlewareAccessToken, middlewareUserManager, impl.DeleteTaskSchedule)
+
+	return impl
+}
+
+// @Summary create task schedule
+// @Description Creates a task from the summary template whenever the cron expression matches
+// @Schemes
+// @Tags task-schedule
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.CreateTaskScheduleDto true "task schedule"
+// @Success 201 {object} dto.TaskScheduleResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-schedules [post]
+func (impl *taskScheduleController) CreateTaskSchedule(ctx *gin.Context) {
+	var data dto.CreateTaskScheduleDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	schedule, err := impl.taskScheduleService.CreateTaskSchedule(ctx, user, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.TaskScheduleResponse{Data: impl.ParseTaskScheduleDto(schedule)})
+}
+
+// @Summary list task schedules
+// @Schemes
+// @Tags task-schedule
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} dto.TaskSchedulesResponse
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-schedules [get]
+func (impl *taskScheduleController) ListTaskSchedules(ctx *gin.Context) {
+	schedules, err := impl.taskScheduleService.ListTaskSchedules(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.TaskScheduleDto{}
+	for _, s := range schedules {
+		data = append(data, impl.ParseTaskScheduleDto(&s))
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskSchedulesResponse{Data: data})
+}
+
+// @Summary get task schedule
+// @Schemes
+// @Tags task-schedule
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task schedule id"
+// @Success 200 {object} dto.TaskScheduleResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-schedules/{id} [get]
+func (impl *taskScheduleController) GetTaskSchedule(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	schedule, err := impl.taskScheduleService.GetTaskSchedule(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskScheduleResponse{Data: impl.ParseTaskScheduleDto(schedule)})
+}
+
+// @Summary update task schedule
+// @Description Replaces the schedule and moves its next run after now
+// @Schemes
+// @Tags task-schedule
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task schedule id"
+// @Param request body dto.UpdateTaskScheduleDto true "task schedule"
+// @Success 200 {object} dto.TaskScheduleResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-schedules/{id} [put]
+func (impl *taskScheduleController) UpdateTaskSchedule(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var data dto.UpdateTaskScheduleDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	schedule, err := impl.taskScheduleService.UpdateTaskSchedule(ctx, id, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskScheduleResponse{Data: impl.ParseTaskScheduleDto(schedule)})
+}
+
+// @Summary delete task schedule
+// @Description Stops creating tasks, the tasks already created are kept
+// @Schemes
+// @Tags task-schedule
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task schedule id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-schedules/{id} [delete]
+func (impl *taskScheduleController) DeleteTaskSchedule(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := impl.taskScheduleService.DeleteTaskSchedule(ctx, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *taskScheduleController) ParseTaskScheduleDto(schedule *model.TaskSchedule) dto.TaskScheduleDto {
+	res := dto.TaskScheduleDto{
+		ID:         schedule.ID,
+		CreatedAt:  schedule.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:  schedule.UpdatedAt.Format("2006-01-02 15:04:05"),
+		UserID:     schedule.UserID,
+		AssigneeID: schedule.AssigneeID,
+		Summary:    schedule.Summary,
+		Priority:   schedule.Priority,
+		Cron:       schedule.Cron,
+		Enabled:    schedule.Enabled,
+		NextRunAt:  schedule.NextRunAt.Format("2006-01-02 15:04:05"),
+	}
+	if schedule.LastRunAt != nil {
+		res.LastRunAt = schedule.LastRunAt.Format("2006-01-02 15:04:05")
+	}
+
+	return res
+}
+
+func (impl *taskScheduleController) parseID(ctx *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (impl *taskScheduleController) getUser(ctx *gin.Context) (*model.User, error) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	return impl.userService.GetUserByID(ctx, userID)
+}
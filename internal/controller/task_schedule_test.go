@@ -0,0 +1,332 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskScheduleControllerCreateTaskSchedule(t *testing.T) {
+	now := time.Now()
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	assigneeID := 2
+	schedule := &model.TaskSchedule{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, AssigneeID: 2,
+		Summary: "weekly check {{date}}", Priority: model.TaskPriorityNormal, Cron: "0 9 * * 1", Enabled: true, NextRunAt: now}
+
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(taskScheduleService *mock.MockTaskScheduleService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskScheduleResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should create task schedule": {
+			inputPayload: `{"summary": "weekly check {{date}}", "assignee_id": 2, "cron": "0 9 * * 1"}`,
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskScheduleService.EXPECT().CreateTaskSchedule(gomock.Any(), manager, dto.CreateTaskScheduleDto{
+					Summary: "weekly check {{date}}", AssigneeID: &assigneeID, Cron: "0 9 * * 1",
+				}).Return(schedule, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TaskScheduleResponse{Data: dto.TaskScheduleDto{
+				ID:         1,
+				CreatedAt:  now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:  now.Format("2006-01-02 15:04:05"),
+				UserID:     1,
+				AssigneeID: 2,
+				Summary:    "weekly check {{date}}",
+				Priority:   model.TaskPriorityNormal,
+				Cron:       "0 9 * * 1",
+				Enabled:    true,
+				NextRunAt:  now.Format("2006-01-02 15:04:05"),
+			}},
+		},
+		"should throw bad request when cron is missing": {
+			inputPayload:       `{"summary": "check"}`,
+			mocking:            func(taskScheduleService *mock.MockTaskScheduleService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/task-schedules",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "cron", Code: "required", Message: "cron is required"}},
+			},
+		},
+		"should throw bad request when cron is invalid": {
+			inputPayload: `{"summary": "check", "cron": "0 9 * *"}`,
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskScheduleService.EXPECT().CreateTaskSchedule(gomock.Any(), manager, gomock.Any()).
+					Return(nil, &exception.ValidationException{
+						Message: "invalid fields",
+						Fields:  []exception.FieldError{{Field: "cron", Tag: "cron", Message: "cron must have 5 fields, got 4"}},
+					})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/task-schedules",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "cron", Code: "cron", Message: "cron must have 5 fields, got 4"}},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/task-schedules", strings.NewReader(cs.inputPayload))
+			ctx.Params = []gin.Param{{Key: "sub", Value: "1"}}
+
+			taskScheduleServiceMock := mock.NewMockTaskScheduleService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskScheduleController := controller.NewTaskScheduleController(r.Group("/api"), taskScheduleServiceMock,
+				userServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskScheduleServiceMock, userServiceMock)
+
+			// when
+			taskScheduleController.CreateTaskSchedule(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskScheduleResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskScheduleControllerListTaskSchedules(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		mocking            func(taskScheduleService *mock.MockTaskScheduleService)
+		expectedStatusCode int
+		expectedBody       dto.TaskSchedulesResponse
+	}{
+		"should list task schedules": {
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService) {
+				taskScheduleService.EXPECT().ListTaskSchedules(gomock.Any()).Return([]model.TaskSchedule{
+					{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, AssigneeID: 2, Summary: "check",
+						Priority: model.TaskPriorityLow, Cron: "@monthly", NextRunAt: now, LastRunAt: &now},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskSchedulesResponse{Data: []dto.TaskScheduleDto{{
+				ID:         1,
+				CreatedAt:  now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:  now.Format("2006-01-02 15:04:05"),
+				UserID:     1,
+				AssigneeID: 2,
+				Summary:    "check",
+				Priority:   model.TaskPriorityLow,
+				Cron:       "@monthly",
+				NextRunAt:  now.Format("2006-01-02 15:04:05"),
+				LastRunAt:  now.Format("2006-01-02 15:04:05"),
+			}}},
+		},
+		"should throw internal server error": {
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService) {
+				taskScheduleService.EXPECT().ListTaskSchedules(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/task-schedules", nil)
+
+			taskScheduleServiceMock := mock.NewMockTaskScheduleService(ctrl)
+			taskScheduleController := controller.NewTaskScheduleController(r.Group("/api"), taskScheduleServiceMock,
+				nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskScheduleServiceMock)
+
+			// when
+			taskScheduleController.ListTaskSchedules(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskSchedulesResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskScheduleControllerUpdateTaskSchedule(t *testing.T) {
+	now := time.Now()
+	schedule := &model.TaskSchedule{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, AssigneeID: 1,
+		Summary: "daily check", Priority: model.TaskPriorityNormal, Cron: "@daily", Enabled: true, NextRunAt: now}
+
+	var cases = map[string]struct {
+		inputID            string
+		inputPayload       string
+		mocking            func(taskScheduleService *mock.MockTaskScheduleService)
+		expectedStatusCode int
+		expectedBody       dto.TaskScheduleResponse
+	}{
+		"should update task schedule": {
+			inputID:      "1",
+			inputPayload: `{"summary": "daily check", "cron": "@daily"}`,
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService) {
+				taskScheduleService.EXPECT().UpdateTaskSchedule(gomock.Any(), 1, dto.UpdateTaskScheduleDto{
+					Summary: "daily check", Cron: "@daily",
+				}).Return(schedule, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskScheduleResponse{Data: dto.TaskScheduleDto{
+				ID:         1,
+				CreatedAt:  now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:  now.Format("2006-01-02 15:04:05"),
+				UserID:     1,
+				AssigneeID: 1,
+				Summary:    "daily check",
+				Priority:   model.TaskPriorityNormal,
+				Cron:       "@daily",
+				Enabled:    true,
+				NextRunAt:  now.Format("2006-01-02 15:04:05"),
+			}},
+		},
+		"should throw bad request when id is invalid": {
+			inputID:            "a",
+			inputPayload:       `{"summary": "daily check", "cron": "@daily"}`,
+			mocking:            func(taskScheduleService *mock.MockTaskScheduleService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"should throw not found when task schedule does not exist": {
+			inputID:      "2",
+			inputPayload: `{"summary": "daily check", "cron": "@daily"}`,
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService) {
+				taskScheduleService.EXPECT().UpdateTaskSchedule(gomock.Any(), 2, gomock.Any()).
+					Return(nil, &exception.NotFoundException{Message: "task schedule not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("PUT", "/api/task-schedules/"+cs.inputID, strings.NewReader(cs.inputPayload))
+			ctx.Params = []gin.Param{{Key: "id", Value: cs.inputID}}
+
+			taskScheduleServiceMock := mock.NewMockTaskScheduleService(ctrl)
+			taskScheduleController := controller.NewTaskScheduleController(r.Group("/api"), taskScheduleServiceMock,
+				nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskScheduleServiceMock)
+
+			// when
+			taskScheduleController.UpdateTaskSchedule(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskScheduleResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskScheduleControllerDeleteTaskSchedule(t *testing.T) {
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(taskScheduleService *mock.MockTaskScheduleService)
+		expectedStatusCode int
+	}{
+		"should delete task schedule": {
+			inputID: "1",
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService) {
+				taskScheduleService.EXPECT().DeleteTaskSchedule(gomock.Any(), 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw not found when task schedule does not exist": {
+			inputID: "2",
+			mocking: func(taskScheduleService *mock.MockTaskScheduleService) {
+				taskScheduleService.EXPECT().DeleteTaskSchedule(gomock.Any(), 2).
+					Return(&exception.NotFoundException{Message: "task schedule not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("DELETE", "/api/task-schedules/"+cs.inputID, nil)
+			ctx.Params = []gin.Param{{Key: "id", Value: cs.inputID}}
+
+			taskScheduleServiceMock := mock.NewMockTaskScheduleService(ctrl)
+			taskScheduleController := controller.NewTaskScheduleController(r.Group("/api"), taskScheduleServiceMock,
+				nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskScheduleServiceMock)
+
+			// when
+			taskScheduleController.DeleteTaskSchedule(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type TaskTemplateController interface {
+	CreateTaskTemplate(ctx *gin.Context)
+	ListTaskTemplates(ctx *gin.Context)
+	GetTaskTemplate(ctx *gin.Context)
+	UpdateTaskTemplate(ctx *gin.Context)
+	DeleteTaskTemplate(ctx *gin.Context)
+}
+
+type taskTemplateController struct {
+	taskTemplateService service.TaskTemplateService
+	userService         service.UserService
+}
+
+func NewTaskTemplateController(router *gin.RouterGroup, taskTemplateService service.TaskTemplateService,
+	userService service.UserService, middlewareAccessToken, middlewareUserManager func(ctx *gin.Context)) TaskTemplateController {
+	impl := &taskTemplateController{
+		taskTemplateService: taskTemplateService,
+		userService:         userService,
+	}
+
+	router.POST("/task-templates", middlewareAccessToken, middlewareUserManager, impl.CreateTaskTemplate)
+	router.GET("/task-templates", middlewareAccessToken, impl.ListTaskTemplates)
+	router.GET("/task-templates/:id", middlewareAccessToken, impl.GetTaskTemplate)
+	router.PATCH("/task-templates/:id", middlewareAccessToken, middlewareUserManager, impl.UpdateTaskTemplate)
+	router.DELETE("/task-templates/:id", middlewareAccessToken, middlewareUserManager, impl.DeleteTaskTemplate)
+
+	return impl
+}
+
+// @Summary create task template
+// @Description Placeholders are written as {{name}} in the summary and filled on POST /tasks?template_id=
+// @Schemes
+// @Tags task-template
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.CreateTaskTemplateDto true "task template"
+// @Success 201 {object} dto.TaskTemplateResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-templates [post]
+func (impl *taskTemplateController) CreateTaskTemplate(ctx *gin.Context) {
+	var data dto.CreateTaskTemplateDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	template, err := impl.taskTemplateService.CreateTaskTemplate(ctx, user, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.TaskTemplateResponse{Data: impl.ParseTaskTemplateDto(template)})
+}
+
+// @Summary list task templates
+// @Schemes
+// @Tags task-template
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} dto.TaskTemplatesResponse
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-templates [get]
+func (impl *taskTemplateController) ListTaskTemplates(ctx *gin.Context) {
+	templates, err := impl.taskTemplateService.ListTaskTemplates(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.TaskTemplateDto{}
+	for _, s := range templates {
+		data = append(data, impl.ParseTaskTemplateDto(&s))
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskTemplatesResponse{Data: data})
+}
+
+// @Summary get task template
+// @Schemes
+// @Tags task-template
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task template id"
+// @Success 200 {object} dto.TaskTemplateResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-templates/{id} [get]
+func (impl *taskTemplateController) GetTaskTemplate(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	template, err := impl.taskTemplateService.GetTaskTemplate(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskTemplateResponse{Data: impl.ParseTaskTemplateDto(template)})
+}
+
+// @Summary update task template
+// @Description Only changes the fields sent
+// @Schemes
+// @Tags task-template
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task template id"
+// @Param request body dto.UpdateTaskTemplateDto true "task template"
+// @Success 200 {object} dto.TaskTemplateResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-templates/{id} [patch]
+func (impl *taskTemplateController) UpdateTaskTemplate(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var data dto.UpdateTaskTemplateDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	template, err := impl.taskTemplateService.UpdateTaskTemplate(ctx, id, data)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.TaskTemplateResponse{Data: impl.ParseTaskTemplateDto(template)})
+}
+
+// @Summary delete task template
+// @Description The tasks created from the template are kept
+// @Schemes
+// @Tags task-template
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task template id"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /task-templates/{id} [delete]
+func (impl *taskTemplateController) DeleteTaskTemplate(ctx *gin.Context) {
+	id, ok := impl.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := impl.taskTemplateService.DeleteTaskTemplate(ctx, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (impl *taskTemplateController) ParseTaskTemplateDto(template *model.TaskTemplate) dto.TaskTemplateDto {
+	return dto.TaskTemplateDto{
+		ID:           template.ID,
+		CreatedAt:    template.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    template.UpdatedAt.Format("2006-01-02 15:04:05"),
+		UserID:       template.UserID,
+		Name:         template.Name,
+		Summary:      template.Summary,
+		Priority:     template.Priority,
+		Placeholders: template.Placeholders(),
+	}
+}
+
+func (impl *taskTemplateController) parseID(ctx *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "id", Tag: "numeric", Message: "id must be a number"}},
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (impl *taskTemplateController) getUser(ctx *gin.Context) (*model.User, error) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	return impl.userService.GetUserByID(ctx, userID)
+}
@@ -0,0 +1,321 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskTemplateControllerCreateTaskTemplate(t *testing.T) {
+	now := time.Now()
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	template := &model.TaskTemplate{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, Name: "equipment check",
+		Summary: "check the {{equipment}} in room {{room}}", Priority: model.TaskPriorityNormal}
+
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(taskTemplateService *mock.MockTaskTemplateService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskTemplateResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should create task template": {
+			inputPayload: `{"name": "equipment check", "summary": "check the {{equipment}} in room {{room}}"}`,
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskTemplateService.EXPECT().CreateTaskTemplate(gomock.Any(), manager, dto.CreateTaskTemplateDto{
+					Name: "equipment check", Summary: "check the {{equipment}} in room {{room}}",
+				}).Return(template, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TaskTemplateResponse{Data: dto.TaskTemplateDto{
+				ID:           1,
+				CreatedAt:    now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:    now.Format("2006-01-02 15:04:05"),
+				UserID:       1,
+				Name:         "equipment check",
+				Summary:      "check the {{equipment}} in room {{room}}",
+				Priority:     model.TaskPriorityNormal,
+				Placeholders: []string{"equipment", "room"},
+			}},
+		},
+		"should throw bad request when summary is missing": {
+			inputPayload:       `{"name": "equipment check"}`,
+			mocking:            func(taskTemplateService *mock.MockTaskTemplateService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/task-templates",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "summary", Code: "required", Message: "summary is required"}},
+			},
+		},
+		"should throw conflict when task template already exists": {
+			inputPayload: `{"name": "equipment check", "summary": "check"}`,
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskTemplateService.EXPECT().CreateTaskTemplate(gomock.Any(), manager, gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "task template already exists"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "task template already exists",
+				Instance: "/api/task-templates",
+				Code:     "conflict",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/task-templates", strings.NewReader(cs.inputPayload))
+			ctx.Params = []gin.Param{{Key: "sub", Value: "1"}}
+
+			taskTemplateServiceMock := mock.NewMockTaskTemplateService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskTemplateController := controller.NewTaskTemplateController(r.Group("/api"), taskTemplateServiceMock,
+				userServiceMock, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskTemplateServiceMock, userServiceMock)
+
+			// when
+			taskTemplateController.CreateTaskTemplate(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskTemplateResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskTemplateControllerListTaskTemplates(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		mocking            func(taskTemplateService *mock.MockTaskTemplateService)
+		expectedStatusCode int
+		expectedBody       dto.TaskTemplatesResponse
+	}{
+		"should list task templates": {
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService) {
+				taskTemplateService.EXPECT().ListTaskTemplates(gomock.Any()).Return([]model.TaskTemplate{
+					{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, Name: "fridge", Summary: "clean the fridge",
+						Priority: model.TaskPriorityLow},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskTemplatesResponse{Data: []dto.TaskTemplateDto{{
+				ID:           1,
+				CreatedAt:    now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:    now.Format("2006-01-02 15:04:05"),
+				UserID:       1,
+				Name:         "fridge",
+				Summary:      "clean the fridge",
+				Priority:     model.TaskPriorityLow,
+				Placeholders: []string{},
+			}}},
+		},
+		"should throw internal server error": {
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService) {
+				taskTemplateService.EXPECT().ListTaskTemplates(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("GET", "/api/task-templates", nil)
+
+			taskTemplateServiceMock := mock.NewMockTaskTemplateService(ctrl)
+			taskTemplateController := controller.NewTaskTemplateController(r.Group("/api"), taskTemplateServiceMock,
+				nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskTemplateServiceMock)
+
+			// when
+			taskTemplateController.ListTaskTemplates(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskTemplatesResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskTemplateControllerUpdateTaskTemplate(t *testing.T) {
+	now := time.Now()
+	name := "fridge"
+	template := &model.TaskTemplate{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, Name: "fridge",
+		Summary: "clean the fridge", Priority: model.TaskPriorityLow}
+
+	var cases = map[string]struct {
+		inputID            string
+		inputPayload       string
+		mocking            func(taskTemplateService *mock.MockTaskTemplateService)
+		expectedStatusCode int
+		expectedBody       dto.TaskTemplateResponse
+	}{
+		"should update task template": {
+			inputID:      "1",
+			inputPayload: `{"name": "fridge"}`,
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService) {
+				taskTemplateService.EXPECT().UpdateTaskTemplate(gomock.Any(), 1, dto.UpdateTaskTemplateDto{Name: &name}).
+					Return(template, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskTemplateResponse{Data: dto.TaskTemplateDto{
+				ID:           1,
+				CreatedAt:    now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:    now.Format("2006-01-02 15:04:05"),
+				UserID:       1,
+				Name:         "fridge",
+				Summary:      "clean the fridge",
+				Priority:     model.TaskPriorityLow,
+				Placeholders: []string{},
+			}},
+		},
+		"should throw bad request when priority is invalid": {
+			inputID:            "1",
+			inputPayload:       `{"priority": "whenever"}`,
+			mocking:            func(taskTemplateService *mock.MockTaskTemplateService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"should throw not found when task template does not exist": {
+			inputID:      "2",
+			inputPayload: `{"name": "fridge"}`,
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService) {
+				taskTemplateService.EXPECT().UpdateTaskTemplate(gomock.Any(), 2, gomock.Any()).
+					Return(nil, &exception.NotFoundException{Message: "task template not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("PATCH", "/api/task-templates/"+cs.inputID, strings.NewReader(cs.inputPayload))
+			ctx.Params = []gin.Param{{Key: "id", Value: cs.inputID}}
+
+			taskTemplateServiceMock := mock.NewMockTaskTemplateService(ctrl)
+			taskTemplateController := controller.NewTaskTemplateController(r.Group("/api"), taskTemplateServiceMock,
+				nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskTemplateServiceMock)
+
+			// when
+			taskTemplateController.UpdateTaskTemplate(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskTemplateResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskTemplateControllerDeleteTaskTemplate(t *testing.T) {
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(taskTemplateService *mock.MockTaskTemplateService)
+		expectedStatusCode int
+	}{
+		"should delete task template": {
+			inputID: "1",
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService) {
+				taskTemplateService.EXPECT().DeleteTaskTemplate(gomock.Any(), 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw not found when task template does not exist": {
+			inputID: "2",
+			mocking: func(taskTemplateService *mock.MockTaskTemplateService) {
+				taskTemplateService.EXPECT().DeleteTaskTemplate(gomock.Any(), 2).
+					Return(&exception.NotFoundException{Message: "task template not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("DELETE", "/api/task-templates/"+cs.inputID, nil)
+			ctx.Params = []gin.Param{{Key: "id", Value: cs.inputID}}
+
+			taskTemplateServiceMock := mock.NewMockTaskTemplateService(ctrl)
+			taskTemplateController := controller.NewTaskTemplateController(r.Group("/api"), taskTemplateServiceMock,
+				nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskTemplateServiceMock)
+
+			// when
+			taskTemplateController.DeleteTaskTemplate(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package controller_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -16,6 +19,7 @@ import (
 	"github.com/viniosilva/swordhealth-api/internal/dto"
 	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
 	"github.com/viniosilva/swordhealth-api/mock"
 )
 
@@ -29,22 +33,35 @@ func TestTaskControllerCreateTask(t *testing.T) {
 		Summary:   "summary",
 		Status:    model.TaskStatusOpened,
 	}
+	task.AssigneeID = task.UserID
+	assignedTask := &model.Task{
+		ID:         2,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UserID:     3,
+		AssigneeID: 2,
+		Summary:    "summary",
+		Status:     model.TaskStatusOpened,
+	}
+	technician := &model.User{ID: 1, Role: model.UserRoleTechnician}
+	assigneeID := 2
 
 	var cases = map[string]struct {
 		inputUserID        int
 		inputPayload       string
-		mocking            func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool)
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool)
 		expectedStatusCode int
 		expectedBody       dto.TaskResponse
-		expectedErrorBody  dto.ApiError
+		expectedErrorBody  dto.ProblemDetails
 	}{
 		"should create task": {
 			inputUserID: 1,
 			inputPayload: `{
 				"summary": "summary"
 			}`,
-			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
-				taskService.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(task, nil)
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(technician, nil)
+				taskService.EXPECT().CreateTask(gomock.Any(), technician, dto.CreateTaskDto{Summary: "summary"}).Return(task, nil)
 				notificationService.EXPECT().NotifyAdminUserOnSaveTask(gomock.Any(), gomock.Any(), gomock.Any()).
 					Do(func(arg interface{}, arg2 interface{}, arg3 interface{}) {
 						async <- true
@@ -56,56 +73,143 @@ func TestTaskControllerCreateTask(t *testing.T) {
 				CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
 				UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
 				User:      dto.UserDto{ID: task.UserID},
+				Assignee:  dto.UserDto{ID: task.AssigneeID},
 				Summary:   task.Summary,
 				Status:    task.Status,
 			}},
 		},
+		"should create task assigned to technician and notify assignee": {
+			inputUserID: 3,
+			inputPayload: `{
+				"summary": "summary",
+				"assignee_id": 2
+			}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				manager := &model.User{ID: 3, Role: model.UserRoleManager}
+				userService.EXPECT().GetUserByID(gomock.Any(), 3).Return(manager, nil)
+				taskService.EXPECT().CreateTask(gomock.Any(), manager, dto.CreateTaskDto{Summary: "summary", AssigneeID: &assigneeID}).
+					Return(assignedTask, nil)
+				notificationService.EXPECT().NotifyAdminUserOnSaveTask(gomock.Any(), assignedTask, 3).
+					Do(func(arg interface{}, arg2 interface{}, arg3 interface{}) {
+						async <- true
+					})
+				notificationService.EXPECT().NotifyTaskAssigned(gomock.Any(), assignedTask).
+					Do(func(arg interface{}, arg2 interface{}) {
+						async <- true
+					})
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TaskResponse{Data: dto.TaskDto{
+				ID:        assignedTask.ID,
+				CreatedAt: assignedTask.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: assignedTask.UpdatedAt.Format("2006-01-02 15:04:05"),
+				User:      dto.UserDto{ID: 3},
+				Assignee:  dto.UserDto{ID: 2},
+				Summary:   assignedTask.Summary,
+				Status:    assignedTask.Status,
+			}},
+		},
+		"should throw unauthorized when technician assigns another user": {
+			inputUserID: 1,
+			inputPayload: `{
+				"summary": "summary",
+				"assignee_id": 2
+			}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(technician, nil)
+				taskService.EXPECT().CreateTask(gomock.Any(), technician, gomock.Any()).
+					Return(nil, &exception.UnauthorizedException{Message: "only managers can assign tasks"})
+				async <- true
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:unauthorized",
+				Title:    "Unauthorized",
+				Status:   http.StatusUnauthorized,
+				Detail:   "only managers can assign tasks",
+				Instance: "/api/tasks",
+				Code:     "unauthorized",
+			},
+		},
 		"should throw bad request when payload is invalid": {
 			inputUserID: 1,
 			inputPayload: `{
 				"summary": 123
 			}`,
-			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
 				async <- true
 			},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedErrorBody:  dto.ApiError{Error: "invalid payload"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:invalid_payload",
+				Title:    "Invalid payload",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid payload",
+				Instance: "/api/tasks",
+				Code:     "invalid_payload",
+			},
 		},
 		"should throw bad request when payload data is invalid": {
 			inputUserID: 1,
 			inputPayload: `{
 				"summary": ""
 			}`,
-			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
 				async <- true
 			},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedErrorBody:  dto.ApiError{Error: "Key: 'CreateTaskDto.Summary' Error:Field validation for 'Summary' failed on the 'required' tag"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "summary", Code: "required", Message: "summary is required"},
+				},
+			},
 		},
 		"should throw bad request when user not found": {
 			inputUserID: 1,
 			inputPayload: `{
 				"summary": "summary"
 			}`,
-			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(technician, nil)
 				taskService.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, &exception.ForeignKeyConstraintException{Message: "user not found"})
 				async <- true
 			},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedErrorBody:  dto.ApiError{Error: "user not found"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:foreign_key_constraint",
+				Title:    "Foreign key constraint",
+				Status:   http.StatusBadRequest,
+				Detail:   "user not found",
+				Instance: "/api/tasks",
+				Code:     "foreign_key_constraint",
+			},
 		},
 		"should throw internal server error": {
 			inputUserID: 1,
 			inputPayload: `{
 				"summary": "summary"
 			}`,
-			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(technician, nil)
 				taskService.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
 				async <- true
 			},
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedErrorBody:  dto.ApiError{Error: "internal server error"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/tasks",
+				Code:     "internal_error",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -121,20 +225,185 @@ func TestTaskControllerCreateTask(t *testing.T) {
 			ctx.Request = httptest.NewRequest("POST", "/api/tasks", strings.NewReader(cs.inputPayload))
 
 			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock,
+				notificationServiceMock, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			async := make(chan bool, 2)
+			cs.mocking(taskServiceMock, userServiceMock, notificationServiceMock, async)
+
+			// when
+			taskController.CreateTask(ctx)
+			middlewareController.HandleErrors(ctx)
+			<-async
+			if cs.expectedBody.Data.Assignee.ID != cs.expectedBody.Data.User.ID {
+				<-async
+			}
+
+			var body dto.TaskResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskControllerCreateTaskFromTemplate(t *testing.T) {
+	now := time.Now()
+	task := &model.Task{
+		ID:         1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UserID:     1,
+		AssigneeID: 1,
+		Summary:    "check the defibrillator",
+		Status:     model.TaskStatusOpened,
+		Priority:   model.TaskPriorityHigh,
+	}
+	technician := &model.User{ID: 1, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputTemplateID    string
+		inputPayload       string
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService, taskTemplateService *mock.MockTaskTemplateService, notificationService *mock.MockNotificationService, async chan bool)
+		expectedStatusCode int
+		expectedBody       dto.TaskResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should create task from template": {
+			inputTemplateID: "1",
+			inputPayload:    `{"variables": {"equipment": "defibrillator"}}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, taskTemplateService *mock.MockTaskTemplateService, notificationService *mock.MockNotificationService, async chan bool) {
+				taskTemplateService.EXPECT().RenderTaskTemplate(gomock.Any(), 1, dto.CreateTaskFromTemplateDto{
+					Variables: map[string]string{"equipment": "defibrillator"},
+				}).Return(dto.CreateTaskDto{Summary: "check the defibrillator", Priority: model.TaskPriorityHigh}, nil)
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(technician, nil)
+				taskService.EXPECT().CreateTask(gomock.Any(), technician, dto.CreateTaskDto{
+					Summary: "check the defibrillator", Priority: model.TaskPriorityHigh,
+				}).Return(task, nil)
+				notificationService.EXPECT().NotifyAdminUserOnSaveTask(gomock.Any(), task, 1).
+					Do(func(arg interface{}, arg2 interface{}, arg3 interface{}) {
+						async <- true
+					})
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TaskResponse{Data: dto.TaskDto{
+				ID:        task.ID,
+				CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
+				User:      dto.UserDto{ID: task.UserID},
+				Assignee:  dto.UserDto{ID: task.AssigneeID},
+				Summary:   task.Summary,
+				Status:    task.Status,
+				Priority:  task.Priority,
+			}},
+		},
+		"should throw bad request when rendered summary is too long": {
+			inputTemplateID: "1",
+			inputPayload:    `{"variables": {"equipment": "defibrillator"}}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, taskTemplateService *mock.MockTaskTemplateService, notificationService *mock.MockNotificationService, async chan bool) {
+				taskTemplateService.EXPECT().RenderTaskTemplate(gomock.Any(), 1, gomock.Any()).
+					Return(dto.CreateTaskDto{Summary: strings.Repeat("a", 2501)}, nil)
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "summary", Code: "max", Message: "summary must have at most 2500 characters"},
+				},
+			},
+		},
+		"should throw bad request when template does not exist": {
+			inputTemplateID: "2",
+			inputPayload:    `{}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, taskTemplateService *mock.MockTaskTemplateService, notificationService *mock.MockNotificationService, async chan bool) {
+				taskTemplateService.EXPECT().RenderTaskTemplate(gomock.Any(), 2, gomock.Any()).
+					Return(dto.CreateTaskDto{}, &exception.ValidationException{
+						Message: "invalid fields",
+						Fields:  []exception.FieldError{{Field: "template_id", Tag: "exists", Message: "template_id must exist"}},
+					})
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "template_id", Code: "exists", Message: "template_id must exist"},
+				},
+			},
+		},
+		"should throw bad request when template id is invalid": {
+			inputTemplateID: "a",
+			inputPayload:    `{}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, taskTemplateService *mock.MockTaskTemplateService, notificationService *mock.MockNotificationService, async chan bool) {
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "template_id", Code: "numeric", Message: "template_id must be a number"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks?template_id="+cs.inputTemplateID,
+				strings.NewReader(cs.inputPayload))
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskTemplateServiceMock := mock.NewMockTaskTemplateService(ctrl)
 			notificationServiceMock := mock.NewMockNotificationService(ctrl)
-			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, nil, notificationServiceMock, nil)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock,
+				notificationServiceMock, taskTemplateServiceMock, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
 
 			async := make(chan bool, 1)
-			cs.mocking(taskServiceMock, notificationServiceMock, async)
+			cs.mocking(taskServiceMock, userServiceMock, taskTemplateServiceMock, notificationServiceMock, async)
 
 			// when
 			taskController.CreateTask(ctx)
+			middlewareController.HandleErrors(ctx)
 			<-async
 
 			var body dto.TaskResponse
 			json.Unmarshal(res.Body.Bytes(), &body)
 
-			var errorBody dto.ApiError
+			var errorBody dto.ProblemDetails
 			json.Unmarshal(res.Body.Bytes(), &errorBody)
 
 			// then
@@ -160,10 +429,11 @@ func TestTaskControllerListTasks(t *testing.T) {
 		inputUserID        string
 		inputLimit         int
 		inputOffset        int
+		inputQuery         string
 		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService)
 		expectedStatusCode int
 		expectedBody       dto.TasksResponse
-		expectedErrorBody  dto.ApiError
+		expectedErrorBody  dto.ProblemDetails
 	}{
 		"should list tasks": {
 			inputUserID: "1",
@@ -173,7 +443,7 @@ func TestTaskControllerListTasks(t *testing.T) {
 						ID:   1,
 						Role: model.UserRoleManager,
 					}, nil)
-				taskService.EXPECT().ListTasks(gomock.Any(), 10, 0, gomock.Any()).Return([]model.Task{task}, 1, nil)
+				taskService.EXPECT().ListTasks(gomock.Any(), 10, 0, gomock.Any(), dto.ListTasksFilterDto{}).Return([]model.Task{task}, 1, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedBody: dto.TasksResponse{
@@ -187,6 +457,7 @@ func TestTaskControllerListTasks(t *testing.T) {
 						CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
 						UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
 						User:      dto.UserDto{ID: task.UserID},
+						Assignee:  dto.UserDto{ID: task.AssigneeID},
 						Summary:   task.Summary,
 						Status:    task.Status,
 					},
@@ -202,7 +473,7 @@ func TestTaskControllerListTasks(t *testing.T) {
 						ID:   1,
 						Role: model.UserRoleTechnician,
 					}, nil)
-				taskService.EXPECT().ListTasks(gomock.Any(), 1, 2, gomock.Any()).Return([]model.Task{task}, 10, nil)
+				taskService.EXPECT().ListTasks(gomock.Any(), 1, 2, gomock.Any(), dto.ListTasksFilterDto{}).Return([]model.Task{task}, 10, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedBody: dto.TasksResponse{
@@ -216,11 +487,49 @@ func TestTaskControllerListTasks(t *testing.T) {
 						CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
 						UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
 						User:      dto.UserDto{ID: task.UserID},
+						Assignee:  dto.UserDto{ID: task.AssigneeID},
 						Summary:   task.Summary,
 						Status:    task.Status,
 					},
 				}},
 		},
+		"should list tasks filtered and sorted": {
+			inputUserID: "1",
+			inputQuery:  "priority=urgent&due_before=1992-08-28+18%3A00%3A00&overdue=true&sort=-due_at",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).
+					Return(&model.User{
+						ID:   1,
+						Role: model.UserRoleManager,
+					}, nil)
+				taskService.EXPECT().ListTasks(gomock.Any(), 10, 0, gomock.Any(), dto.ListTasksFilterDto{
+					Priority:  model.TaskPriorityUrgent,
+					DueBefore: "1992-08-28 18:00:00",
+					Overdue:   true,
+					Sort:      "-due_at",
+				}).Return([]model.Task{}, 0, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.TasksResponse{Data: []dto.TaskDto{}},
+		},
+		"should throw bad request when filter is invalid": {
+			inputUserID:        "1",
+			inputQuery:         "priority=asap&sort=summary",
+			mocking:            func(taskService *mock.MockTaskService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "priority", Code: "oneof", Message: "priority has an invalid value"},
+					{Field: "sort", Code: "oneof", Message: "sort has an invalid value"},
+				},
+			},
+		},
 		"should throw internal server error on get user by id": {
 			inputUserID: "1",
 			inputLimit:  10,
@@ -230,9 +539,16 @@ func TestTaskControllerListTasks(t *testing.T) {
 					Return(nil, fmt.Errorf("error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedErrorBody:  dto.ApiError{Error: "internal server error"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/tasks",
+				Code:     "internal_error",
+			},
 		},
-		"should throw internal server error on user not found": {
+		"should throw not found on user not found": {
 			inputUserID: "1",
 			inputLimit:  10,
 			inputOffset: 0,
@@ -240,8 +556,15 @@ func TestTaskControllerListTasks(t *testing.T) {
 				userService.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).
 					Return(nil, &exception.NotFoundException{Message: "user not found"})
 			},
-			expectedStatusCode: http.StatusInternalServerError,
-			expectedErrorBody:  dto.ApiError{Error: "internal server error"},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "user not found",
+				Instance: "/api/tasks",
+				Code:     "not_found",
+			},
 		},
 		"should throw internal server error on list tasks": {
 			inputUserID: "1",
@@ -253,10 +576,17 @@ func TestTaskControllerListTasks(t *testing.T) {
 						ID:   1,
 						Role: model.UserRoleManager,
 					}, nil)
-				taskService.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
+				taskService.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedErrorBody:  dto.ApiError{Error: "internal server error"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/tasks",
+				Code:     "internal_error",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -276,6 +606,9 @@ func TestTaskControllerListTasks(t *testing.T) {
 			if cs.inputOffset > 0 {
 				query = append(query, fmt.Sprintf("offset=%d", cs.inputOffset))
 			}
+			if cs.inputQuery != "" {
+				query = append(query, cs.inputQuery)
+			}
 
 			url := strings.Join([]string{
 				"/api/tasks",
@@ -287,17 +620,1510 @@ func TestTaskControllerListTasks(t *testing.T) {
 
 			taskServiceMock := mock.NewMockTaskService(ctrl)
 			userServiceMock := mock.NewMockUserService(ctrl)
-			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
 
 			cs.mocking(taskServiceMock, userServiceMock)
 
 			// when
 			taskController.ListTasks(ctx)
+			middlewareController.HandleErrors(ctx)
 
 			var body dto.TasksResponse
 			json.Unmarshal(res.Body.Bytes(), &body)
 
-			var errorBody dto.ApiError
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskControllerExportTasks(t *testing.T) {
+	now := time.Date(1992, 8, 21, 12, 3, 43, 0, time.Local)
+	parentID := 3
+	task := model.TaskExport{
+		Task: model.Task{
+			ID:         4,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			UserID:     1,
+			AssigneeID: 2,
+			ParentID:   &parentID,
+			Summary:    "summary, with comma",
+			Status:     model.TaskStatusOpened,
+			DueAt:      &now,
+			Priority:   model.TaskPriorityHigh,
+			Tags:       model.TaskTags{"maintenance", "equipment"},
+		},
+		OwnerUsername: "username",
+		OwnerEmail:    "email@email.com",
+	}
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+
+	var cases = map[string]struct {
+		inputQuery          string
+		mocking             func(taskService *mock.MockTaskService, userService *mock.MockUserService)
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+		expectedErrorBody   dto.ProblemDetails
+	}{
+		"should export tasks as csv": {
+			inputQuery: "format=csv&status=opened",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), manager, dto.ListTasksFilterDto{Status: model.TaskStatusOpened}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error {
+						return fn(task)
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody: "id,created_at,updated_at,user_id,assignee_id,parent_id,summary,status,priority,due_at,tags\n" +
+				"4,1992-08-21 12:03:43,1992-08-21 12:03:43,1,2,3,\"summary, with comma\",opened,high,1992-08-21 12:03:43,\"maintenance,equipment\"\n",
+		},
+		"should escape formulas in csv cells": {
+			inputQuery: "format=csv",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), manager, dto.ListTasksFilterDto{}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error {
+						formula := task
+						formula.Summary = "=HYPERLINK(\"http://evil\")"
+						formula.Tags = model.TaskTags{"@tag"}
+						return fn(formula)
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody: "id,created_at,updated_at,user_id,assignee_id,parent_id,summary,status,priority,due_at,tags\n" +
+				"4,1992-08-21 12:03:43,1992-08-21 12:03:43,1,2,3,\"'=HYPERLINK(\"\"http://evil\"\")\",opened,high,1992-08-21 12:03:43,'@tag\n",
+		},
+		"should export tasks as csv with the owner": {
+			inputQuery: "format=csv&include_owner=true",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), manager, dto.ListTasksFilterDto{}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error {
+						return fn(task)
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody: "id,created_at,updated_at,user_id,assignee_id,parent_id,summary,status,priority,due_at,tags,owner_username,owner_email\n" +
+				"4,1992-08-21 12:03:43,1992-08-21 12:03:43,1,2,3,\"summary, with comma\",opened,high,1992-08-21 12:03:43,\"maintenance,equipment\",username,email@email.com\n",
+		},
+		"should escape formulas in csv owner cells": {
+			inputQuery: "format=csv&include_owner=true",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), manager, dto.ListTasksFilterDto{}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error {
+						formula := task
+						formula.OwnerUsername = "=cmd"
+						formula.OwnerEmail = "+email@email.com"
+						return fn(formula)
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody: "id,created_at,updated_at,user_id,assignee_id,parent_id,summary,status,priority,due_at,tags,owner_username,owner_email\n" +
+				"4,1992-08-21 12:03:43,1992-08-21 12:03:43,1,2,3,\"summary, with comma\",opened,high,1992-08-21 12:03:43,\"maintenance,equipment\",'=cmd,'+email@email.com\n",
+		},
+		"should export the csv header when no task matches": {
+			inputQuery: "format=csv",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), manager, dto.ListTasksFilterDto{}, gomock.Any()).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        "id,created_at,updated_at,user_id,assignee_id,parent_id,summary,status,priority,due_at,tags\n",
+		},
+		"should export tasks as ndjson with the owner": {
+			inputQuery: "format=ndjson&include_owner=true",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), manager, dto.ListTasksFilterDto{}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error {
+						fn(task)
+						return fn(task)
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody: strings.Repeat(`{"id":4,"created_at":"1992-08-21 12:03:43","updated_at":"1992-08-21 12:03:43",`+
+				`"user":{"id":1,"email":"email@email.com","username":"username"},"assignee":{"id":2},"summary":"summary, with comma",`+
+				`"status":"opened","due_at":"1992-08-21 12:03:43","priority":"high","tags":["maintenance","equipment"],"parent_id":3,`+
+				`"dependencies":{"subtasks":0,"open_subtasks":0}}`+"\n", 2),
+		},
+		"should only log the error once csv rows were sent": {
+			inputQuery: "format=csv",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), manager, dto.ListTasksFilterDto{}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error {
+						fn(task)
+						return fmt.Errorf("error")
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody: "id,created_at,updated_at,user_id,assignee_id,parent_id,summary,status,priority,due_at,tags\n" +
+				"4,1992-08-21 12:03:43,1992-08-21 12:03:43,1,2,3,\"summary, with comma\",opened,high,1992-08-21 12:03:43,\"maintenance,equipment\"\n",
+		},
+		"should throw bad request when format is invalid": {
+			inputQuery:         "format=xml",
+			mocking:            func(taskService *mock.MockTaskService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/export",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "format", Code: "oneof", Message: "format has an invalid value"}},
+			},
+		},
+		"should throw bad request when format is missing": {
+			mocking:            func(taskService *mock.MockTaskService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/export",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "format", Code: "required", Message: "format is required"}},
+			},
+		},
+		"should throw not found on user not found": {
+			inputQuery: "format=csv",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(nil, &exception.NotFoundException{Message: "user not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "user not found",
+				Instance: "/api/tasks/export",
+				Code:     "not_found",
+			},
+		},
+		"should throw internal server error on export tasks": {
+			inputQuery: "format=csv",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/tasks/export",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks/export?"+cs.inputQuery, nil)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskServiceMock, userServiceMock)
+
+			// when
+			taskController.ExportTasks(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			if cs.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, cs.expectedContentType, res.Header().Get("Content-Type"))
+				assert.Equal(t, cs.expectedBody, res.Body.String())
+			} else {
+				assert.Equal(t, cs.expectedErrorBody, errorBody)
+				assert.Equal(t, "application/problem+json", res.Header().Get("Content-Type"))
+				assert.Empty(t, res.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func TestTaskControllerAssignTask(t *testing.T) {
+	now := time.Now()
+	task := &model.Task{
+		ID:         1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UserID:     3,
+		AssigneeID: 2,
+		Summary:    "summary",
+		Status:     model.TaskStatusOpened,
+	}
+
+	var cases = map[string]struct {
+		inputID            string
+		inputPayload       string
+		mocking            func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool)
+		expectedStatusCode int
+		expectedBody       dto.TaskResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should assign task and notify assignee": {
+			inputID:      "1",
+			inputPayload: `{"assignee_id": 2}`,
+			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+				taskService.EXPECT().AssignTask(gomock.Any(), 1, 2).Return(task, nil)
+				notificationService.EXPECT().NotifyTaskAssigned(gomock.Any(), task).
+					Do(func(arg interface{}, arg2 interface{}) {
+						async <- true
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskResponse{Data: dto.TaskDto{
+				ID:        task.ID,
+				CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
+				User:      dto.UserDto{ID: 3},
+				Assignee:  dto.UserDto{ID: 2},
+				Summary:   task.Summary,
+				Status:    task.Status,
+			}},
+		},
+		"should throw bad request when id is invalid": {
+			inputID:      "abc",
+			inputPayload: `{"assignee_id": 2}`,
+			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/abc/assignee",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "id", Code: "numeric", Message: "id must be a number"},
+				},
+			},
+		},
+		"should throw bad request when assignee is missing": {
+			inputID:      "1",
+			inputPayload: `{}`,
+			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/assignee",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "assignee_id", Code: "required", Message: "assignee_id is required"},
+				},
+			},
+		},
+		"should throw bad request when assignee is not an active technician": {
+			inputID:      "1",
+			inputPayload: `{"assignee_id": 3}`,
+			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+				taskService.EXPECT().AssignTask(gomock.Any(), 1, 3).Return(nil, &exception.ValidationException{
+					Message: "invalid fields",
+					Fields: []exception.FieldError{
+						{Field: "assignee_id", Tag: "technician", Message: "assignee_id must be an active technician"},
+					},
+				})
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/assignee",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "assignee_id", Code: "technician", Message: "assignee_id must be an active technician"},
+				},
+			},
+		},
+		"should throw not found": {
+			inputID:      "9",
+			inputPayload: `{"assignee_id": 2}`,
+			mocking: func(taskService *mock.MockTaskService, notificationService *mock.MockNotificationService, async chan bool) {
+				taskService.EXPECT().AssignTask(gomock.Any(), 9, 2).
+					Return(nil, &exception.NotFoundException{Message: "task not found"})
+				async <- true
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "task not found",
+				Instance: "/api/tasks/9/assignee",
+				Code:     "not_found",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "3"})
+			ctx.Request = httptest.NewRequest("PUT", "/api/tasks/"+cs.inputID+"/assignee", strings.NewReader(cs.inputPayload))
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, nil, notificationServiceMock,
+				nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			async := make(chan bool, 1)
+			cs.mocking(taskServiceMock, notificationServiceMock, async)
+
+			// when
+			taskController.AssignTask(ctx)
+			middlewareController.HandleErrors(ctx)
+			<-async
+
+			var body dto.TaskResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskControllerTransitionTask(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	task := &model.Task{
+		ID:         1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UserID:     3,
+		AssigneeID: 2,
+		Summary:    "summary",
+		Status:     model.TaskStatusInProgress,
+	}
+	transition := &model.TaskTransition{
+		ID: 1, CreatedAt: now, TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress,
+	}
+
+	var cases = map[string]struct {
+		inputID            string
+		inputPayload       string
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool)
+		expectedStatusCode int
+		expectedBody       dto.TaskResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should transition task and notify": {
+			inputID:      "1",
+			inputPayload: `{"to": "in_progress"}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().TransitionTask(gomock.Any(), user, 1, model.TaskStatusInProgress, false).Return(task, transition, nil)
+				notificationService.EXPECT().NotifyTaskTransitioned(gomock.Any(), task, transition).
+					Do(func(arg, arg2, arg3 interface{}) {
+						async <- true
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskResponse{Data: dto.TaskDto{
+				ID:        task.ID,
+				CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
+				User:      dto.UserDto{ID: 3},
+				Assignee:  dto.UserDto{ID: 2},
+				Summary:   task.Summary,
+				Status:    model.TaskStatusInProgress,
+			}},
+		},
+		"should throw bad request when to is missing": {
+			inputID:      "1",
+			inputPayload: `{}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/transitions",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "to", Code: "required", Message: "to is required"},
+				},
+			},
+		},
+		"should throw conflict when transition is not allowed": {
+			inputID:      "1",
+			inputPayload: `{"to": "closed"}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().TransitionTask(gomock.Any(), user, 1, model.TaskStatusClosed, false).
+					Return(nil, nil, &exception.ConflictException{Message: "can not move task from in_progress to closed, allowed: blocked, in_review"})
+				async <- true
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "can not move task from in_progress to closed, allowed: blocked, in_review",
+				Instance: "/api/tasks/1/transitions",
+				Code:     "conflict",
+			},
+		},
+		"should throw unauthorized when role is not allowed": {
+			inputID:      "1",
+			inputPayload: `{"to": "opened"}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService, notificationService *mock.MockNotificationService, async chan bool) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().TransitionTask(gomock.Any(), user, 1, model.TaskStatusOpened, false).
+					Return(nil, nil, &exception.UnauthorizedException{Message: "technician can not move task from closed to opened"})
+				async <- true
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:unauthorized",
+				Title:    "Unauthorized",
+				Status:   http.StatusUnauthorized,
+				Detail:   "technician can not move task from closed to opened",
+				Instance: "/api/tasks/1/transitions",
+				Code:     "unauthorized",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks/"+cs.inputID+"/transitions", strings.NewReader(cs.inputPayload))
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock,
+				notificationServiceMock, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			async := make(chan bool, 1)
+			cs.mocking(taskServiceMock, userServiceMock, notificationServiceMock, async)
+
+			// when
+			taskController.TransitionTask(ctx)
+			middlewareController.HandleErrors(ctx)
+			<-async
+
+			var body dto.TaskResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskControllerListTaskTransitions(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskTransitionsResponse
+	}{
+		"should list task transitions": {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().ListTaskTransitions(gomock.Any(), user, 1).Return([]model.TaskTransition{
+					{ID: 1, CreatedAt: now, TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskTransitionsResponse{Data: []dto.TaskTransitionDto{
+				{
+					ID:        1,
+					CreatedAt: now.Format("2006-01-02 15:04:05"),
+					User:      dto.UserDto{ID: 2},
+					From:      model.TaskStatusOpened,
+					To:        model.TaskStatusInProgress,
+				},
+			}},
+		},
+		"should throw not found": {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().ListTaskTransitions(gomock.Any(), user, 1).
+					Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks/1/transitions", nil)
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskServiceMock, userServiceMock)
+
+			// when
+			taskController.ListTaskTransitions(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskTransitionsResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskControllerUpdateTask(t *testing.T) {
+	now := time.Now()
+	dueAt := time.Date(1992, 8, 28, 18, 0, 0, 0, time.Local)
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	task := &model.Task{
+		ID:         1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UserID:     2,
+		AssigneeID: 2,
+		Summary:    "new summary",
+		Status:     model.TaskStatusOpened,
+		DueAt:      &dueAt,
+		Priority:   model.TaskPriorityHigh,
+	}
+
+	var cases = map[string]struct {
+		inputID            string
+		inputPayload       string
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should update task": {
+			inputID:      "1",
+			inputPayload: `{"summary": "new summary", "due_at": "1992-08-28 18:00:00", "priority": "high"}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().UpdateTask(gomock.Any(), user, 1, dto.UpdateTaskDto{
+					Summary:  "new summary",
+					DueAt:    "1992-08-28 18:00:00",
+					Priority: model.TaskPriorityHigh,
+				}).Return(task, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskResponse{Data: dto.TaskDto{
+				ID:        task.ID,
+				CreatedAt: task.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: task.UpdatedAt.Format("2006-01-02 15:04:05"),
+				User:      dto.UserDto{ID: 2},
+				Assignee:  dto.UserDto{ID: 2},
+				Summary:   "new summary",
+				Status:    model.TaskStatusOpened,
+				DueAt:     "1992-08-28 18:00:00",
+				Priority:  model.TaskPriorityHigh,
+			}},
+		},
+		"should throw bad request when payload is invalid": {
+			inputID:            "1",
+			inputPayload:       `{"summary": "new summary", "due_at": "28/08/1992", "priority": "asap"}`,
+			mocking:            func(taskService *mock.MockTaskService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "due_at", Code: "datetime", Message: "due_at must match the format 2006-01-02 15:04:05"},
+					{Field: "priority", Code: "oneof", Message: "priority has an invalid value"},
+				},
+			},
+		},
+		"should throw not found": {
+			inputID:      "9",
+			inputPayload: `{"summary": "new summary"}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().UpdateTask(gomock.Any(), user, 9, dto.UpdateTaskDto{Summary: "new summary"}).
+					Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "task not found",
+				Instance: "/api/tasks/9",
+				Code:     "not_found",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("PUT", "/api/tasks/"+cs.inputID, strings.NewReader(cs.inputPayload))
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskServiceMock, userServiceMock)
+
+			// when
+			taskController.UpdateTask(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskControllerAddTaskDependency(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	task := &model.Task{
+		ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 2, AssigneeID: 2, Summary: "summary",
+		Status: model.TaskStatusOpened, Priority: model.TaskPriorityNormal, BlockedBy: model.TaskIDs{5},
+	}
+
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should add task dependency": {
+			inputPayload: `{"blocked_by_id": 5}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().AddTaskDependency(gomock.Any(), user, 1, 5).Return(task, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: dto.TaskResponse{Data: dto.TaskDto{
+				ID:           1,
+				CreatedAt:    now.Format("2006-01-02 15:04:05"),
+				UpdatedAt:    now.Format("2006-01-02 15:04:05"),
+				User:         dto.UserDto{ID: 2},
+				Assignee:     dto.UserDto{ID: 2},
+				Summary:      "summary",
+				Status:       model.TaskStatusOpened,
+				Priority:     model.TaskPriorityNormal,
+				Dependencies: dto.TaskDependenciesDto{BlockedBy: []int{5}},
+			}},
+		},
+		"should throw bad request when blocked by id is missing": {
+			inputPayload:       `{}`,
+			mocking:            func(taskService *mock.MockTaskService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/dependencies",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "blocked_by_id", Code: "required", Message: "blocked_by_id is required"},
+				},
+			},
+		},
+		"should throw conflict when dependency creates a cycle": {
+			inputPayload: `{"blocked_by_id": 5}`,
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().AddTaskDependency(gomock.Any(), user, 1, 5).
+					Return(nil, &exception.ConflictException{Message: "dependency would create a cycle"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "dependency would create a cycle",
+				Instance: "/api/tasks/1/dependencies",
+				Code:     "conflict",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks/1/dependencies", strings.NewReader(cs.inputPayload))
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskServiceMock, userServiceMock)
+
+			// when
+			taskController.AddTaskDependency(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskControllerRemoveTaskDependency(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputBlockedByID   string
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService)
+		expectedStatusCode int
+	}{
+		"should remove task dependency": {
+			inputBlockedByID: "5",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().RemoveTaskDependency(gomock.Any(), user, 1, 5).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw bad request when blocked by id is not a number": {
+			inputBlockedByID:   "abc",
+			mocking:            func(taskService *mock.MockTaskService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"should throw not found": {
+			inputBlockedByID: "5",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().RemoveTaskDependency(gomock.Any(), user, 1, 5).
+					Return(&exception.NotFoundException{Message: "dependency not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"},
+				gin.Param{Key: "blockedByID", Value: cs.inputBlockedByID}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/tasks/1/dependencies/"+cs.inputBlockedByID, nil)
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskServiceMock, userServiceMock)
+
+			// when
+			taskController.RemoveTaskDependency(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+		})
+	}
+}
+
+func TestTaskControllerGetTaskTree(t *testing.T) {
+	now := time.Now()
+	one := 1
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	root := model.Task{
+		ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 2, AssigneeID: 2, Summary: "parent",
+		Status: model.TaskStatusOpened, SubtaskCount: 1, OpenSubtaskCount: 1,
+	}
+	child := model.Task{
+		ID: 2, CreatedAt: now, UpdatedAt: now, UserID: 2, AssigneeID: 2, ParentID: &one, Summary: "child",
+		Status: model.TaskStatusOpened,
+	}
+
+	var cases = map[string]struct {
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskTreeResponse
+	}{
+		"should get task tree": {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().GetTaskTree(gomock.Any(), user, 1).Return(&model.TaskTree{
+					Task: root, Subtasks: []model.TaskTree{{Task: child, Subtasks: []model.TaskTree{}}},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskTreeResponse{Data: dto.TaskTreeDto{
+				TaskDto: dto.TaskDto{
+					ID:           1,
+					CreatedAt:    now.Format("2006-01-02 15:04:05"),
+					UpdatedAt:    now.Format("2006-01-02 15:04:05"),
+					User:         dto.UserDto{ID: 2},
+					Assignee:     dto.UserDto{ID: 2},
+					Summary:      "parent",
+					Status:       model.TaskStatusOpened,
+					Dependencies: dto.TaskDependenciesDto{Subtasks: 1, OpenSubtasks: 1},
+				},
+				Subtasks: []dto.TaskTreeDto{{
+					TaskDto: dto.TaskDto{
+						ID:        2,
+						CreatedAt: now.Format("2006-01-02 15:04:05"),
+						UpdatedAt: now.Format("2006-01-02 15:04:05"),
+						User:      dto.UserDto{ID: 2},
+						Assignee:  dto.UserDto{ID: 2},
+						Summary:   "child",
+						Status:    model.TaskStatusOpened,
+						ParentID:  1,
+					},
+					Subtasks: []dto.TaskTreeDto{},
+				}},
+			}},
+		},
+		"should throw not found": {
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskService.EXPECT().GetTaskTree(gomock.Any(), user, 1).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks/1/tree", nil)
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskServiceMock, userServiceMock)
+
+			// when
+			taskController.GetTaskTree(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskTreeResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+		})
+	}
+}
+
+func TestTaskControllerDeleteTask(t *testing.T) {
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(taskService *mock.MockTaskService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should delete task": {
+			inputID: "1",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().DeleteTask(gomock.Any(), manager, 1).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw conflict when task has subtasks": {
+			inputID: "1",
+			mocking: func(taskService *mock.MockTaskService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().DeleteTask(gomock.Any(), manager, 1).
+					Return(&exception.ConflictException{Message: "task has 2 subtasks, delete them first"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "task has 2 subtasks, delete them first",
+				Instance: "/api/tasks/1",
+				Code:     "conflict",
+			},
+		},
+		"should throw bad request when id is not a number": {
+			inputID:            "a",
+			mocking:            func(taskService *mock.MockTaskService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/a",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "id", Code: "numeric", Message: "id must be a number"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("DELETE", "/api/tasks/"+cs.inputID, nil)
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), taskServiceMock, userServiceMock, nil, nil, nil, nil, nil, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskServiceMock, userServiceMock)
+
+			// when
+			taskController.DeleteTask(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskControllerBulkTasks(t *testing.T) {
+	now := time.Now()
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	closed := &model.Task{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, AssigneeID: 2, Summary: "summary", Status: model.TaskStatusClosed}
+	transition := &model.TaskTransition{ID: 1, TaskID: 1, UserID: 1, FromStatus: model.TaskStatusInReview, ToStatus: model.TaskStatusClosed}
+
+	var cases = map[string]struct {
+		inputPayload       string
+		inputActorID       string
+		mocking            func(taskBulkService *mock.MockTaskBulkService, notificationService *mock.MockNotificationService, auditService *mock.MockAuditService, async chan bool)
+		expectedStatusCode int
+		expectedBody       dto.TasksBulkResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should close tasks and report each result": {
+			inputPayload: `{"action": "close", "ids": [1, 2]}`,
+			mocking: func(taskBulkService *mock.MockTaskBulkService, notificationService *mock.MockNotificationService, auditService *mock.MockAuditService, async chan bool) {
+				taskBulkService.EXPECT().BulkTasks(gomock.Any(), manager, dto.BulkTasksDto{Action: model.TaskBulkActionClose, IDs: []int{1, 2}}).
+					Return([]service.TaskBulkResult{
+						{TaskID: 1, Task: closed, Transition: transition},
+						{TaskID: 2, Err: &exception.NotFoundException{Message: "task not found"}},
+					}, nil)
+				notificationService.EXPECT().NotifyTaskTransitioned(gomock.Any(), closed, transition).
+					Do(func(arg interface{}, arg2 interface{}, arg3 interface{}) {
+						async <- true
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TasksBulkResponse{
+				Succeeded: 1,
+				Failed:    1,
+				Data: []dto.TaskBulkResultDto{
+					{ID: 1, Status: http.StatusOK, Data: &dto.TaskDto{
+						ID:        1,
+						CreatedAt: now.Format("2006-01-02 15:04:05"),
+						UpdatedAt: now.Format("2006-01-02 15:04:05"),
+						User:      dto.UserDto{ID: 1},
+						Assignee:  dto.UserDto{ID: 2},
+						Summary:   "summary",
+						Status:    model.TaskStatusClosed,
+					}},
+					{ID: 2, Status: http.StatusNotFound, Error: &dto.ProblemDetails{
+						Type:     "urn:swordhealth:problem:not_found",
+						Title:    "Not found",
+						Status:   http.StatusNotFound,
+						Detail:   "task not found",
+						Instance: "/api/tasks/2/transitions",
+						Code:     "not_found",
+					}},
+				},
+			},
+		},
+		"should audit each task of an impersonated request": {
+			inputPayload: `{"action": "delete", "ids": [1]}`,
+			inputActorID: "9",
+			mocking: func(taskBulkService *mock.MockTaskBulkService, notificationService *mock.MockNotificationService, auditService *mock.MockAuditService, async chan bool) {
+				taskBulkService.EXPECT().BulkTasks(gomock.Any(), manager, gomock.Any()).
+					Return([]service.TaskBulkResult{{TaskID: 1}}, nil)
+				auditService.EXPECT().Record(gomock.Any(), model.AuditLog{
+					ActorID: 9,
+					UserID:  1,
+					Action:  model.AuditActionImpersonationRequest,
+					Method:  http.MethodDelete,
+					Path:    "/api/tasks/1",
+					Status:  http.StatusNoContent,
+				}).Return(&model.AuditLog{ID: 1}, nil)
+				async <- true
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TasksBulkResponse{
+				Succeeded: 1,
+				Data:      []dto.TaskBulkResultDto{{ID: 1, Status: http.StatusNoContent}},
+			},
+		},
+		"should throw bad request when action is invalid": {
+			inputPayload: `{"action": "archive", "ids": [1]}`,
+			mocking: func(taskBulkService *mock.MockTaskBulkService, notificationService *mock.MockNotificationService, auditService *mock.MockAuditService, async chan bool) {
+				async <- true
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/bulk",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "action", Code: "oneof", Message: "action has an invalid value"},
+				},
+			},
+		},
+		"should throw unauthorized": {
+			inputPayload: `{"action": "reassign", "ids": [1], "assignee_id": 2}`,
+			mocking: func(taskBulkService *mock.MockTaskBulkService, notificationService *mock.MockNotificationService, auditService *mock.MockAuditService, async chan bool) {
+				taskBulkService.EXPECT().BulkTasks(gomock.Any(), manager, gomock.Any()).
+					Return(nil, &exception.UnauthorizedException{Message: "unauthorized user role"})
+				async <- true
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:unauthorized",
+				Title:    "Unauthorized",
+				Status:   http.StatusUnauthorized,
+				Detail:   "unauthorized user role",
+				Instance: "/api/tasks/bulk",
+				Code:     "unauthorized",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			if cs.inputActorID != "" {
+				ctx.Params = append(ctx.Params, gin.Param{Key: model.ActorClaim, Value: cs.inputActorID})
+			}
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks/bulk", strings.NewReader(cs.inputPayload))
+
+			taskBulkServiceMock := mock.NewMockTaskBulkService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			auditServiceMock := mock.NewMockAuditService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), nil, userServiceMock, notificationServiceMock,
+				nil, taskBulkServiceMock, nil, auditServiceMock, 0, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			userServiceMock.EXPECT().GetUserByID(gomock.Any(), 1).AnyTimes().Return(manager, nil)
+			async := make(chan bool, 1)
+			cs.mocking(taskBulkServiceMock, notificationServiceMock, auditServiceMock, async)
+
+			// when
+			taskController.BulkTasks(ctx)
+			middlewareController.HandleErrors(ctx)
+			<-async
+
+			var body dto.TasksBulkResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+func TestTaskControllerImportTasks(t *testing.T) {
+	now := time.Date(1992, 8, 21, 12, 3, 43, 0, time.Local)
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	task := model.Task{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, AssigneeID: 2, Summary: "summary",
+		Status: model.TaskStatusClosed, Priority: model.TaskPriorityNormal}
+	taskDto := dto.TaskDto{
+		ID:        1,
+		CreatedAt: "1992-08-21 12:03:43",
+		UpdatedAt: "1992-08-21 12:03:43",
+		User:      dto.UserDto{ID: 1},
+		Assignee:  dto.UserDto{ID: 2},
+		Summary:   "summary",
+		Status:    model.TaskStatusClosed,
+		Priority:  model.TaskPriorityNormal,
+	}
+
+	var cases = map[string]struct {
+		inputQuery         string
+		inputFile          string
+		mocking            func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TasksImportResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should import tasks": {
+			inputFile: "\ufeffSummary,Status,Assignee,Tags,Due_at,Created_at\n" +
+				"summary,closed,technician,\"maintenance, equipment\",1992-08-28 18:00:00,1992-08-21 12:03:43\n",
+			mocking: func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskImportService.EXPECT().ImportTasks(gomock.Any(), manager, []dto.ImportTaskDto{{
+					CreateTaskDto: dto.CreateTaskDto{
+						Summary: "summary",
+						DueAt:   "1992-08-28 18:00:00",
+						Tags:    []string{"maintenance", "equipment"},
+					},
+					Line:             2,
+					Status:           model.TaskStatusClosed,
+					AssigneeUsername: "technician",
+					CreatedAt:        "1992-08-21 12:03:43",
+				}}, false).Return([]model.Task{task}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       dto.TasksImportResponse{Imported: 1, Data: []dto.TaskDto{taskDto}},
+		},
+		"should validate tasks on dry run": {
+			inputQuery: "dry_run=true",
+			inputFile:  "summary\nsummary\n",
+			mocking: func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskImportService.EXPECT().ImportTasks(gomock.Any(), manager, []dto.ImportTaskDto{{
+					CreateTaskDto: dto.CreateTaskDto{Summary: "summary"},
+					Line:          2,
+				}}, true).Return([]model.Task{{UserID: 1, AssigneeID: 1, Summary: "summary", Status: model.TaskStatusOpened}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TasksImportResponse{DryRun: true, Imported: 1, Data: []dto.TaskDto{{
+				CreatedAt: "0001-01-01 00:00:00",
+				UpdatedAt: "0001-01-01 00:00:00",
+				User:      dto.UserDto{ID: 1},
+				Assignee:  dto.UserDto{ID: 1},
+				Summary:   "summary",
+				Status:    model.TaskStatusOpened,
+			}}},
+		},
+		"should throw bad request with the errors of every row": {
+			inputFile: "summary,status,priority,due_at\n" +
+				",opened,normal,\n" +
+				"summary,done,asap,1992-08-28\n",
+			mocking: func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskImportService.EXPECT().ImportTasks(gomock.Any(), manager, []dto.ImportTaskDto{
+					{
+						CreateTaskDto: dto.CreateTaskDto{Priority: model.TaskPriorityNormal},
+						Line:          2,
+						Errors:        []exception.FieldError{{Field: "summary", Tag: "required", Message: "summary is required"}},
+						Status:        model.TaskStatusOpened,
+					},
+					{
+						CreateTaskDto: dto.CreateTaskDto{Summary: "summary", DueAt: "1992-08-28", Priority: "asap"},
+						Line:          3,
+						Errors: []exception.FieldError{
+							{Field: "due_at", Tag: "datetime", Message: "due_at must match the format 2006-01-02 15:04:05"},
+							{Field: "priority", Tag: "oneof", Message: "priority has an invalid value"},
+						},
+						Status: "done",
+					},
+				}, false).Return(nil, &exception.ValidationException{
+					Message: "invalid rows",
+					Fields: []exception.FieldError{
+						{Field: "rows[2].summary", Tag: "required", Message: "summary is required"},
+						{Field: "rows[3].due_at", Tag: "datetime", Message: "due_at must match the format 2006-01-02 15:04:05"},
+						{Field: "rows[3].priority", Tag: "oneof", Message: "priority has an invalid value"},
+						{Field: "rows[3].status", Tag: "status", Message: "status must be a workflow status"},
+					},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid rows",
+				Instance: "/api/tasks/import",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "rows[2].summary", Code: "required", Message: "summary is required"},
+					{Field: "rows[3].due_at", Code: "datetime", Message: "due_at must match the format 2006-01-02 15:04:05"},
+					{Field: "rows[3].priority", Code: "oneof", Message: "priority has an invalid value"},
+					{Field: "rows[3].status", Code: "status", Message: "status must be a workflow status"},
+				},
+			},
+		},
+		"should throw bad request when a column is unknown": {
+			inputFile:          "summary,owner\nsummary,username\n",
+			mocking:            func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/import",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "csv", Message: "file has an unknown column: owner"}},
+			},
+		},
+		"should throw bad request when the summary column is missing": {
+			inputFile:          "status\nopened\n",
+			mocking:            func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/import",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "csv", Message: "file must have a summary column"}},
+			},
+		},
+		"should throw bad request when a row has the wrong number of columns": {
+			inputFile:          "summary,status\nsummary\n",
+			mocking:            func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/import",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{{Field: "file", Code: "csv",
+					Message: "file is not a valid csv: record on line 2: wrong number of fields"}},
+			},
+		},
+		"should throw bad request when file has more rows than allowed": {
+			inputFile:          "summary\nfirst\nsecond\nthird\n",
+			mocking:            func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/import",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "max", Message: "file must have at most 2 tasks"}},
+			},
+		},
+		"should throw bad request when file is over the max size": {
+			inputFile:          "summary\n" + strings.Repeat("a", 1<<17) + "\n",
+			mocking:            func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/import",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "max", Message: "file must have at most 12288 bytes"}},
+			},
+		},
+		"should throw bad request when file is missing": {
+			mocking:            func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/import",
+				Code:     "validation_failed",
+				Errors:   []dto.ProblemFieldError{{Field: "file", Code: "required", Message: "file is required"}},
+			},
+		},
+		"should throw internal server error on import tasks": {
+			inputFile: "summary\nsummary\n",
+			mocking: func(taskImportService *mock.MockTaskImportService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskImportService.EXPECT().ImportTasks(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/tasks/import",
+				Code:     "internal_error",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			payload := &bytes.Buffer{}
+			writer := multipart.NewWriter(payload)
+			if cs.inputFile != "" {
+				part, _ := writer.CreateFormFile("file", "tasks.csv")
+				part.Write([]byte(cs.inputFile))
+			}
+			writer.Close()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks/import?"+cs.inputQuery, payload)
+			ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			taskImportServiceMock := mock.NewMockTaskImportService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskController := controller.NewTaskController(r.Group("/api"), nil, userServiceMock, nil, nil, nil,
+				taskImportServiceMock, nil, 2, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskImportServiceMock, userServiceMock)
+
+			// when
+			taskController.ImportTasks(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TasksImportResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
 			json.Unmarshal(res.Body.Bytes(), &errorBody)
 
 			// then
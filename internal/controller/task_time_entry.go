@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+type TaskTimeEntryController interface {
+	CreateTimeEntry(ctx *gin.Context)
+	ListTimeEntries(ctx *gin.Context)
+	SummarizeTimeEntries(ctx *gin.Context)
+}
+
+type taskTimeEntryController struct {
+	taskTimeEntryService service.TaskTimeEntryService
+	userService          service.UserService
+}
+
+func NewTaskTimeEntryController(router *gin.RouterGroup, taskTimeEntryService service.TaskTimeEntryService, userService service.UserService,
+	middlewareAccessToken func(ctx *gin.Context)) TaskTimeEntryController {
+	impl := &taskTimeEntryController{
+		taskTimeEntryService: taskTimeEntryService,
+		userService:          userService,
+	}
+
+	router.POST("/tasks/:id/time-entries", middlewareAccessToken, impl.CreateTimeEntry)
+	router.GET("/tasks/:id/time-entries", middlewareAccessToken, impl.ListTimeEntries)
+	router.GET("/time-entries/summary", middlewareAccessToken, impl.SummarizeTimeEntries)
+
+	return impl
+}
+
+// @Summary track time on task
+// @Description start and stop the user timer, or log a manual entry from started_at to ended_at. A user can only have one timer running and closed tasks take no new timers
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Param request body dto.CreateTaskTimeEntryDto true "time entry"
+// @Success 200 {object} dto.TaskTimeEntryResponse "timer stopped"
+// @Success 201 {object} dto.TaskTimeEntryResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/time-entries [post]
+func (impl *taskTimeEntryController) CreateTimeEntry(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var data dto.CreateTaskTimeEntryDto
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	status := http.StatusCreated
+	var entry *model.TaskTimeEntry
+	switch data.Action {
+	case "start":
+		entry, err = impl.taskTimeEntryService.StartTimer(ctx, user, taskID, data)
+	case "stop":
+		status = http.StatusOK
+		entry, err = impl.taskTimeEntryService.StopTimer(ctx, user, taskID)
+	default:
+		entry, err = impl.taskTimeEntryService.CreateTimeEntry(ctx, user, taskID, data)
+	}
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(status, dto.TaskTimeEntryResponse{Data: impl.ParseTaskTimeEntryDto(entry, time.Now())})
+}
+
+// @Summary list task time entries
+// @Description running timers count up to now in total_seconds
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path int true "task id"
+// @Success 200 {object} dto.TaskTimeEntriesResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /tasks/{id}/time-entries [get]
+func (impl *taskTimeEntryController) ListTimeEntries(ctx *gin.Context) {
+	taskID, ok := impl.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	entries, err := impl.taskTimeEntryService.ListTimeEntries(ctx, user, taskID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	now := time.Now()
+	res := dto.TaskTimeEntriesResponse{Data: []dto.TaskTimeEntryDto{}}
+	for _, e := range entries {
+		entry := impl.ParseTaskTimeEntryDto(&e, now)
+		res.TotalSeconds += entry.Seconds
+		res.Data = append(res.Data, entry)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Summary summarize time entries
+// @Description time logged per user and period (week by default), technicians only get their own
+// @Schemes
+// @Tags task
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param user_id query int false "user id"
+// @Param from query string false "started on or after (2006-01-02)"
+// @Param to query string false "started on or before (2006-01-02)"
+// @Param period query string false "period" Enums(day, week, month)
+// @Success 200 {object} dto.TimeEntrySummariesResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /time-entries/summary [get]
+func (impl *taskTimeEntryController) SummarizeTimeEntries(ctx *gin.Context) {
+	var filter dto.TimeEntrySummaryFilterDto
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	user, err := impl.getUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	summaries, err := impl.taskTimeEntryService.SummarizeTimeEntries(ctx, user, filter)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	data := []dto.TimeEntrySummaryDto{}
+	for _, s := range summaries {
+		data = append(data, dto.TimeEntrySummaryDto{
+			UserID:  s.UserID,
+			Period:  s.Period,
+			Seconds: s.Seconds,
+			Entries: s.Entries,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, dto.TimeEntrySummariesResponse{Data: data})
+}
+
+func (impl *taskTimeEntryController) ParseTaskTimeEntryDto(entry *model.TaskTimeEntry, now time.Time) dto.TaskTimeEntryDto {
+	res := dto.TaskTimeEntryDto{
+		ID:        entry.ID,
+		CreatedAt: entry.CreatedAt.Format("2006-01-02 15:04:05"),
+		User:      dto.UserDto{ID: entry.UserID},
+		StartedAt: entry.StartedAt.Format("2006-01-02 15:04:05"),
+		Running:   entry.EndedAt == nil,
+		Seconds:   int64(entry.Duration(now).Seconds()),
+		Note:      entry.Note,
+	}
+	if entry.EndedAt != nil {
+		res.EndedAt = entry.EndedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return res
+}
+
+func (impl *taskTimeEntryController) parseID(ctx *gin.Context, param string) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param(param))
+	if err != nil {
+		ctx.Error(&exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: param, Tag: "numeric", Message: param + " must be a number"}},
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (impl *taskTimeEntryController) getUser(ctx *gin.Context) (*model.User, error) {
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	return impl.userService.GetUserByID(ctx, userID)
+}
@@ -0,0 +1,314 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskTimeEntryControllerCreateTimeEntry(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	createdAt := time.Date(2022, 8, 21, 10, 30, 0, 0, time.Local)
+	startedAt := time.Date(2022, 8, 21, 9, 0, 0, 0, time.Local)
+	endedAt := time.Date(2022, 8, 21, 10, 30, 0, 0, time.Local)
+	entry := &model.TaskTimeEntry{ID: 1, CreatedAt: createdAt, UpdatedAt: createdAt, TaskID: 1, UserID: 2,
+		StartedAt: startedAt, EndedAt: &endedAt, Note: "note"}
+	entryDto := dto.TaskTimeEntryDto{
+		ID:        1,
+		CreatedAt: "2022-08-21 10:30:00",
+		User:      dto.UserDto{ID: 2},
+		StartedAt: "2022-08-21 09:00:00",
+		EndedAt:   "2022-08-21 10:30:00",
+		Seconds:   5400,
+		Note:      "note",
+	}
+
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskTimeEntryResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should create manual time entry": {
+			inputPayload: `{"action": "manual", "started_at": "2022-08-21 09:00:00", "ended_at": "2022-08-21 10:30:00", "note": "note"}`,
+			mocking: func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskTimeEntryService.EXPECT().CreateTimeEntry(gomock.Any(), user, 1, dto.CreateTaskTimeEntryDto{
+					Action: "manual", StartedAt: "2022-08-21 09:00:00", EndedAt: "2022-08-21 10:30:00", Note: "note",
+				}).Return(entry, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       dto.TaskTimeEntryResponse{Data: entryDto},
+		},
+		"should stop timer": {
+			inputPayload: `{"action": "stop"}`,
+			mocking: func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskTimeEntryService.EXPECT().StopTimer(gomock.Any(), user, 1).Return(entry, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       dto.TaskTimeEntryResponse{Data: entryDto},
+		},
+		"should throw bad request when action is invalid": {
+			inputPayload:       `{"action": "pause"}`,
+			mocking:            func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/1/time-entries",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "action", Code: "oneof", Message: "action has an invalid value"},
+				},
+			},
+		},
+		"should throw conflict when a timer is already running": {
+			inputPayload: `{"action": "start"}`,
+			mocking: func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskTimeEntryService.EXPECT().StartTimer(gomock.Any(), user, 1, dto.CreateTaskTimeEntryDto{Action: "start"}).
+					Return(nil, &exception.ConflictException{Message: "a timer is already running"})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:conflict",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "a timer is already running",
+				Instance: "/api/tasks/1/time-entries",
+				Code:     "conflict",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("POST", "/api/tasks/1/time-entries", strings.NewReader(cs.inputPayload))
+
+			taskTimeEntryServiceMock := mock.NewMockTaskTimeEntryService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskTimeEntryController := controller.NewTaskTimeEntryController(r.Group("/api"), taskTimeEntryServiceMock, userServiceMock, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskTimeEntryServiceMock, userServiceMock)
+
+			// when
+			taskTimeEntryController.CreateTimeEntry(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskTimeEntryResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskTimeEntryControllerListTimeEntries(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	startedAt := time.Date(2022, 8, 21, 9, 0, 0, 0, time.Local)
+	endedAt := time.Date(2022, 8, 21, 10, 30, 0, 0, time.Local)
+
+	var cases = map[string]struct {
+		inputID            string
+		mocking            func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TaskTimeEntriesResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should list time entries": {
+			inputID: "1",
+			mocking: func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskTimeEntryService.EXPECT().ListTimeEntries(gomock.Any(), user, 1).Return([]model.TaskTimeEntry{
+					{ID: 1, CreatedAt: endedAt, TaskID: 1, UserID: 2, StartedAt: startedAt, EndedAt: &endedAt},
+					{ID: 2, CreatedAt: endedAt, TaskID: 1, UserID: 3, StartedAt: startedAt, EndedAt: &endedAt},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TaskTimeEntriesResponse{
+				TotalSeconds: 10800,
+				Data: []dto.TaskTimeEntryDto{
+					{ID: 1, CreatedAt: "2022-08-21 10:30:00", User: dto.UserDto{ID: 2}, StartedAt: "2022-08-21 09:00:00",
+						EndedAt: "2022-08-21 10:30:00", Seconds: 5400},
+					{ID: 2, CreatedAt: "2022-08-21 10:30:00", User: dto.UserDto{ID: 3}, StartedAt: "2022-08-21 09:00:00",
+						EndedAt: "2022-08-21 10:30:00", Seconds: 5400},
+				},
+			},
+		},
+		"should throw bad request when id is not a number": {
+			inputID:            "a",
+			mocking:            func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/tasks/a/time-entries",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "id", Code: "numeric", Message: "id must be a number"},
+				},
+			},
+		},
+		"should throw not found": {
+			inputID: "9",
+			mocking: func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskTimeEntryService.EXPECT().ListTimeEntries(gomock.Any(), user, 9).
+					Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:not_found",
+				Title:    "Not found",
+				Status:   http.StatusNotFound,
+				Detail:   "task not found",
+				Instance: "/api/tasks/9/time-entries",
+				Code:     "not_found",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: cs.inputID}, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("GET", "/api/tasks/"+cs.inputID+"/time-entries", nil)
+
+			taskTimeEntryServiceMock := mock.NewMockTaskTimeEntryService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskTimeEntryController := controller.NewTaskTimeEntryController(r.Group("/api"), taskTimeEntryServiceMock, userServiceMock, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskTimeEntryServiceMock, userServiceMock)
+
+			// when
+			taskTimeEntryController.ListTimeEntries(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TaskTimeEntriesResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
+
+func TestTaskTimeEntryControllerSummarizeTimeEntries(t *testing.T) {
+	user := &model.User{ID: 1, Role: model.UserRoleManager}
+
+	var cases = map[string]struct {
+		inputQuery         string
+		mocking            func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService)
+		expectedStatusCode int
+		expectedBody       dto.TimeEntrySummariesResponse
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should summarize time entries": {
+			inputQuery: "?user_id=2&from=2022-08-01&period=day",
+			mocking: func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {
+				userService.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil)
+				taskTimeEntryService.EXPECT().SummarizeTimeEntries(gomock.Any(), user, dto.TimeEntrySummaryFilterDto{
+					UserID: 2, From: "2022-08-01", Period: model.TimeEntryPeriodDay,
+				}).Return([]model.TimeEntrySummary{{UserID: 2, Period: "2022-08-21", Seconds: 5400, Entries: 2}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: dto.TimeEntrySummariesResponse{Data: []dto.TimeEntrySummaryDto{
+				{UserID: 2, Period: "2022-08-21", Seconds: 5400, Entries: 2},
+			}},
+		},
+		"should throw bad request when period is invalid": {
+			inputQuery:         "?period=year",
+			mocking:            func(taskTimeEntryService *mock.MockTaskTimeEntryService, userService *mock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/time-entries/summary",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "period", Code: "oneof", Message: "period has an invalid value"},
+				},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "2"})
+			ctx.Request = httptest.NewRequest("GET", "/api/time-entries/summary"+cs.inputQuery, nil)
+
+			taskTimeEntryServiceMock := mock.NewMockTaskTimeEntryService(ctrl)
+			userServiceMock := mock.NewMockUserService(ctrl)
+			taskTimeEntryController := controller.NewTaskTimeEntryController(r.Group("/api"), taskTimeEntryServiceMock, userServiceMock, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(taskTimeEntryServiceMock, userServiceMock)
+
+			// when
+			taskTimeEntryController.SummarizeTimeEntries(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var body dto.TimeEntrySummariesResponse
+			json.Unmarshal(res.Body.Bytes(), &body)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedBody, body)
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -15,21 +16,26 @@ import (
 
 type UserController interface {
 	CreateUser(ctx *gin.Context)
+	ChangePassword(ctx *gin.Context)
 }
 
 type userController struct {
-	userService   service.UserService
-	cryptoService service.CryptoService
+	userService           service.UserService
+	cryptoService         service.CryptoService
+	passwordPolicyService service.PasswordPolicyService
 }
 
 func NewUserController(router *gin.RouterGroup, userService service.UserService, cryptoService service.CryptoService,
-	middlewareAccessToken, middlewareUserManager func(ctx *gin.Context)) UserController {
+	passwordPolicyService service.PasswordPolicyService,
+	middlewareAccessToken, middlewareUserManager, middlewareNotImpersonated func(ctx *gin.Context)) UserController {
 	impl := &userController{
-		userService:   userService,
-		cryptoService: cryptoService,
+		userService:           userService,
+		cryptoService:         cryptoService,
+		passwordPolicyService: passwordPolicyService,
 	}
 
-	router.POST("/users", middlewareAccessToken, middlewareUserManager, impl.CreateUser)
+	router.POST("/users", middlewareAccessToken, middlewareUserManager, middlewareNotImpersonated, impl.CreateUser)
+	router.PUT("/me/password", middlewareAccessToken, middlewareNotImpersonated, impl.ChangePassword)
 
 	return impl
 }
@@ -42,10 +48,11 @@ func NewUserController(router *gin.RouterGroup, userService service.UserService,
 // @Security JwtAuth
 // @Param request body dto.CreateUserDto true "user"
 // @Success 201 {object} dto.UserResponse
-// @Failure 400 {object} dto.ApiError
-// @Failure 401 {object} dto.ApiError
-// @Failure 403 {object} dto.ApiError
-// @Failure 500 {object} dto.ApiError
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /users [post]
 func (impl *userController) CreateUser(ctx *gin.Context) {
 	impl.RegisterValidationUserEnum()
@@ -53,20 +60,65 @@ func (impl *userController) CreateUser(ctx *gin.Context) {
 	var data dto.CreateUserDto
 	err := ctx.ShouldBindJSON(&data)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: exception.FormatBindingErrors(err)})
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	if err := impl.passwordPolicyService.Validate(ctx, 0, data.Password); err != nil {
+		ctx.Error(err)
 		return
 	}
 
 	data.Password = impl.cryptoService.Hash(data.Password)
 	user, err := impl.userService.CreateUser(ctx, data)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, dto.UserResponse{Data: impl.ParseUserDto(user)})
 }
 
+// @Summary change password
+// @Schemes
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body dto.ChangePasswordDto true "current and new password"
+// @Success 204
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 429 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /me/password [put]
+func (impl *userController) ChangePassword(ctx *gin.Context) {
+	var data dto.ChangePasswordDto
+	err := ctx.ShouldBindJSON(&data)
+	if err != nil {
+		ctx.Error(exception.ParseBindingErrors(err))
+		return
+	}
+
+	paramUserID, _ := ctx.Params.Get("sub")
+	userID, _ := strconv.Atoi(paramUserID)
+
+	if err := impl.passwordPolicyService.Validate(ctx, userID, data.Password); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = impl.userService.ChangePassword(ctx, userID,
+		impl.cryptoService.Hash(data.CurrentPassword), impl.cryptoService.Hash(data.Password))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func (impl *userController) ParseUserDto(user *model.User) dto.UserDto {
 	dto := dto.UserDto{
 		ID:        user.ID,
@@ -76,12 +128,17 @@ func (impl *userController) ParseUserDto(user *model.User) dto.UserDto {
 		Username: user.Username,
 		Email:    user.Email,
 		Role:     user.Role,
+		Status:   user.Status,
 	}
 
 	return dto
 }
 
 func (impl *userController) RegisterValidationUserEnum() {
+	registerValidationUserEnum()
+}
+
+func registerValidationUserEnum() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
 			if v := fl.Field().Interface().(model.UserRole); v != "" {
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/viniosilva/swordhealth-api/internal/controller"
 	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
 	"github.com/viniosilva/swordhealth-api/mock"
 )
@@ -32,10 +33,10 @@ func TestUserControllerCreateUser(t *testing.T) {
 
 	var cases = map[string]struct {
 		inputPayload       string
-		mocking            func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService)
+		mocking            func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService)
 		expectedStatusCode int
 		expectedBody       dto.UserResponse
-		expectedErrorBody  dto.ApiError
+		expectedErrorBody  dto.ProblemDetails
 	}{
 		"should create user": {
 			inputPayload: `{
@@ -43,7 +44,8 @@ func TestUserControllerCreateUser(t *testing.T) {
 				"email": "email@email.com",
 				"password": "1122334455"
 			}`,
-			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService) {
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 0, "1122334455").Return(nil)
 				cryptoService.EXPECT().Hash(gomock.Any()).Return("aabbccddee")
 				userService.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(user, nil)
 			},
@@ -64,9 +66,17 @@ func TestUserControllerCreateUser(t *testing.T) {
 				"password": "a",
 				"role": "unknown"
 			}`,
-			mocking:            func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService) {},
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+			},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedErrorBody:  dto.ApiError{Error: "invalid payload"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:invalid_payload",
+				Title:    "Invalid payload",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid payload",
+				Instance: "/api/users",
+				Code:     "invalid_payload",
+			},
 		},
 		"should throw bad request when payload data is invalid": {
 			inputPayload: `{
@@ -75,14 +85,51 @@ func TestUserControllerCreateUser(t *testing.T) {
 				"password": "a",
 				"role": "unknown"
 			}`,
-			mocking:            func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService) {},
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/users",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "username", Code: "min", Message: "username must have at least 4 characters"},
+					{Field: "email", Code: "email", Message: "email must be a valid email"},
+					{Field: "role", Code: "enum", Message: "role has an invalid value"},
+				},
+			},
+		},
+		"should throw bad request when password does not match the policy": {
+			inputPayload: `{
+				"username": "username",
+				"email": "email@email.com",
+				"password": "password"
+			}`,
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 0, "password").Return(&exception.ValidationException{
+					Message: "invalid fields",
+					Fields: []exception.FieldError{
+						{Field: "password", Tag: "digit", Message: "password must contain a digit"},
+						{Field: "password", Tag: "breached", Message: "password was found in a data breach"},
+					},
+				})
+			},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedErrorBody: dto.ApiError{Error: strings.Join([]string{
-				"Key: 'CreateUserDto.Username' Error:Field validation for 'Username' failed on the 'min' tag",
-				"Key: 'CreateUserDto.Email' Error:Field validation for 'Email' failed on the 'email' tag",
-				"Key: 'CreateUserDto.Password' Error:Field validation for 'Password' failed on the 'min' tag",
-				"Key: 'CreateUserDto.Role' Error:Field validation for 'Role' failed on the 'enum' tag",
-			}, "; ")},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/users",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "password", Code: "digit", Message: "password must contain a digit"},
+					{Field: "password", Code: "breached", Message: "password was found in a data breach"},
+				},
+			},
 		},
 		"should throw internal server error": {
 			inputPayload: `{
@@ -91,12 +138,20 @@ func TestUserControllerCreateUser(t *testing.T) {
 				"password": "1122334455",
 				"role": "technician"
 			}`,
-			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService) {
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 0, "1122334455").Return(nil)
 				cryptoService.EXPECT().Hash(gomock.Any()).Return("aabbccddee")
 				userService.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedErrorBody:  dto.ApiError{Error: "internal server error"},
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:internal_error",
+				Title:    "Internal server error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "internal server error",
+				Instance: "/api/users",
+				Code:     "internal_error",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -112,17 +167,21 @@ func TestUserControllerCreateUser(t *testing.T) {
 
 			userServiceMock := mock.NewMockUserService(ctrl)
 			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
-			userController := controller.NewUserController(r.Group("/api"), userServiceMock, cryptoServiceMock, nil, nil)
+			passwordPolicyServiceMock := mock.NewMockPasswordPolicyService(ctrl)
+			userController := controller.NewUserController(r.Group("/api"), userServiceMock, cryptoServiceMock,
+				passwordPolicyServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
 
-			cs.mocking(userServiceMock, cryptoServiceMock)
+			cs.mocking(userServiceMock, cryptoServiceMock, passwordPolicyServiceMock)
 
 			// when
 			userController.CreateUser(ctx)
+			middlewareController.HandleErrors(ctx)
 
 			var body dto.UserResponse
 			json.Unmarshal(res.Body.Bytes(), &body)
 
-			var errorBody dto.ApiError
+			var errorBody dto.ProblemDetails
 			json.Unmarshal(res.Body.Bytes(), &errorBody)
 
 			// then
@@ -132,3 +191,115 @@ func TestUserControllerCreateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserControllerChangePassword(t *testing.T) {
+	var cases = map[string]struct {
+		inputPayload       string
+		mocking            func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService)
+		expectedStatusCode int
+		expectedErrorBody  dto.ProblemDetails
+	}{
+		"should change password": {
+			inputPayload: `{"current_password": "Current-p4ss", "password": "N3w-p4ssword"}`,
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "N3w-p4ssword").Return(nil)
+				cryptoService.EXPECT().Hash("Current-p4ss").Return("current-hash")
+				cryptoService.EXPECT().Hash("N3w-p4ssword").Return("new-hash")
+				userService.EXPECT().ChangePassword(gomock.Any(), 1, "current-hash", "new-hash").Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw bad request when password was recently used": {
+			inputPayload: `{"current_password": "Current-p4ss", "password": "Current-p4ss"}`,
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "Current-p4ss").Return(&exception.ValidationException{
+					Message: "invalid fields",
+					Fields: []exception.FieldError{
+						{Field: "password", Tag: "reused", Message: "password must not match the last 5 passwords"},
+					},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/me/password",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "password", Code: "reused", Message: "password must not match the last 5 passwords"},
+				},
+			},
+		},
+		"should throw bad request when current password is missing": {
+			inputPayload: `{"password": "N3w-p4ssword"}`,
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:validation_failed",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid fields",
+				Instance: "/api/me/password",
+				Code:     "validation_failed",
+				Errors: []dto.ProblemFieldError{
+					{Field: "current_password", Code: "required", Message: "current_password is required"},
+				},
+			},
+		},
+		"should throw forbidden when current password is invalid": {
+			inputPayload: `{"current_password": "wrong", "password": "N3w-p4ssword"}`,
+			mocking: func(userService *mock.MockUserService, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "N3w-p4ssword").Return(nil)
+				cryptoService.EXPECT().Hash("wrong").Return("wrong-hash")
+				cryptoService.EXPECT().Hash("N3w-p4ssword").Return("new-hash")
+				userService.EXPECT().ChangePassword(gomock.Any(), 1, "wrong-hash", "new-hash").
+					Return(&exception.ForbiddenException{Message: "invalid current password"})
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorBody: dto.ProblemDetails{
+				Type:     "urn:swordhealth:problem:forbidden",
+				Title:    "Forbidden",
+				Status:   http.StatusForbidden,
+				Detail:   "invalid current password",
+				Instance: "/api/me/password",
+				Code:     "forbidden",
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Params = append(ctx.Params, gin.Param{Key: "sub", Value: "1"})
+			ctx.Request = httptest.NewRequest("PUT", "/api/me/password", strings.NewReader(cs.inputPayload))
+
+			userServiceMock := mock.NewMockUserService(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			passwordPolicyServiceMock := mock.NewMockPasswordPolicyService(ctrl)
+			userController := controller.NewUserController(r.Group("/api"), userServiceMock, cryptoServiceMock,
+				passwordPolicyServiceMock, nil, nil, nil)
+			middlewareController := controller.NewMiddlewareController(nil, nil, nil, nil, nil)
+
+			cs.mocking(userServiceMock, cryptoServiceMock, passwordPolicyServiceMock)
+
+			// when
+			userController.ChangePassword(ctx)
+			middlewareController.HandleErrors(ctx)
+
+			var errorBody dto.ProblemDetails
+			json.Unmarshal(res.Body.Bytes(), &errorBody)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, ctx.Writer.Status())
+			assert.Equal(t, cs.expectedErrorBody, errorBody)
+		})
+	}
+}
@@ -1,5 +0,0 @@
-package dto
-
-type ApiError struct {
-	Error string `json:"error"`
-}
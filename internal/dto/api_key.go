@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+type ApiKeyDto struct {
+	ID         int                 `json:"id" example:"1"`
+	CreatedAt  string              `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	Name       string              `json:"name" example:"integration"`
+	Prefix     string              `json:"prefix" example:"sh_1a2b3c4d"`
+	Scopes     []model.ApiKeyScope `json:"scopes" example:"tasks:read"`
+	ExpiresAt  string              `json:"expires_at,omitempty" example:"1992-08-21 12:03:43"`
+	LastUsedAt string              `json:"last_used_at,omitempty" example:"1992-08-21 12:03:43"`
+	Key        string              `json:"key,omitempty" example:"sh_1a2b3c4d_5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d"`
+}
+
+type ApiKeyResponse struct {
+	Data ApiKeyDto `json:"data"`
+}
+
+type ApiKeysResponse struct {
+	Data []ApiKeyDto `json:"data"`
+}
+
+type CreateApiKeyDto struct {
+	Name      string              `json:"name" binding:"required,min=1,max=100" example:"integration"`
+	Scopes    []model.ApiKeyScope `json:"scopes" binding:"required,min=1,dive,oneof=tasks:read tasks:write users:read users:write" enums:"tasks:read,tasks:write,users:read,users:write" example:"tasks:read"`
+	ExpiresAt *time.Time          `json:"expires_at" example:"2030-08-21T12:03:43Z"`
+}
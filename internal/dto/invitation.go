@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+type InvitationDto struct {
+	ID        int                    `json:"id" example:"1"`
+	CreatedAt string                 `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	ExpiresAt string                 `json:"expires_at,omitempty" example:"1992-08-28 12:03:43"`
+	Status    model.InvitationStatus `json:"status" example:"pending"`
+	User      UserDto                `json:"user"`
+	InvitedBy UserDto                `json:"invited_by"`
+}
+
+type InvitationResponse struct {
+	Data InvitationDto `json:"data"`
+}
+
+type InvitationsResponse struct {
+	Data []InvitationDto `json:"data"`
+}
+
+type CreateInvitationDto struct {
+	Username string         `json:"username" binding:"required,min=4,max=20" example:"username"`
+	Email    string         `json:"email" binding:"required,email" example:"email@email.com"`
+	Role     model.UserRole `json:"role" binding:"enum" enums:"technician,manager" example:"technician"`
+}
+
+type AcceptInvitationDto struct {
+	Token    string `json:"token" binding:"required" example:"dGhpcyBpcyBhbiBpbnZpdGF0aW9uIHRva2VuIGV4YW1wbGU"`
+	Password string `json:"password" binding:"required" example:"S3cR31-p4ssw0rd"`
+}
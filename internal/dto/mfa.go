@@ -0,0 +1,22 @@
+package dto
+
+type TotpEnrollmentDto struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OtpauthURI string `json:"otpauth_uri" example:"otpauth://totp/Sword%20Health:admin?algorithm=SHA1&digits=6&issuer=Sword+Health&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+type TotpEnrollmentResponse struct {
+	Data TotpEnrollmentDto `json:"data"`
+}
+
+type ConfirmTotpDto struct {
+	Code string `json:"code" binding:"required,len=6,numeric" example:"123456"`
+}
+
+type RecoveryCodesDto struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"a1b2c-3d4e5"`
+}
+
+type RecoveryCodesResponse struct {
+	Data RecoveryCodesDto `json:"data"`
+}
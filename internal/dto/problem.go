@@ -0,0 +1,20 @@
+package dto
+
+const ProblemContentType = "application/problem+json"
+
+type ProblemFieldError struct {
+	Field   string `json:"field" example:"summary"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"summary is required"`
+}
+
+type ProblemDetails struct {
+	Type      string              `json:"type" example:"urn:swordhealth:problem:validation_failed"`
+	Title     string              `json:"title" example:"Validation failed"`
+	Status    int                 `json:"status" example:"400"`
+	Detail    string              `json:"detail,omitempty" example:"invalid fields"`
+	Instance  string              `json:"instance,omitempty" example:"/api/tasks"`
+	Code      string              `json:"code" example:"validation_failed"`
+	RequestID string              `json:"request_id,omitempty" example:"5f1b7c0e9d3a4b2c8e6f0a1b2c3d4e5f"`
+	Errors    []ProblemFieldError `json:"errors,omitempty"`
+}
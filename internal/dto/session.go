@@ -0,0 +1,16 @@
+package dto
+
+type SessionDto struct {
+	ID         int    `json:"id" example:"1"`
+	CreatedAt  string `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	ExpiresAt  string `json:"expires_at,omitempty" example:"1992-08-21 12:18:43"`
+	LastSeenAt string `json:"last_seen_at,omitempty" example:"1992-08-21 12:05:10"`
+	Device     string `json:"device" example:"Chrome on macOS"`
+	IP         string `json:"ip" example:"127.0.0.1"`
+	UserAgent  string `json:"user_agent" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) Chrome/105.0.0.0 Safari/537.36"`
+	Current    bool   `json:"current" example:"true"`
+}
+
+type SessionsResponse struct {
+	Data []SessionDto `json:"data"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type TagDto struct {
+	ID         int    `json:"id" example:"1"`
+	CreatedAt  string `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	UpdatedAt  string `json:"updated_at,omitempty" example:"1992-08-21 12:03:43"`
+	Name       string `json:"name" example:"maintenance"`
+	UsageCount int    `json:"usage_count" example:"12"`
+}
+
+type TagResponse struct {
+	Data TagDto `json:"data"`
+}
+
+type TagsResponse struct {
+	Data []TagDto `json:"data"`
+}
+
+type CreateTagDto struct {
+	Name string `json:"name" binding:"required,min=1,max=50" example:"maintenance"`
+}
+
+type UpdateTagDto struct {
+	Name string `json:"name" binding:"required,min=1,max=50" example:"maintenance"`
+}
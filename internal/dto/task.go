@@ -1,16 +1,40 @@
 package dto
 
 import (
+	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
 )
 
 type TaskDto struct {
-	ID        int              `json:"id" example:"1"`
-	CreatedAt string           `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
-	UpdatedAt string           `json:"updated_at,omitempty" example:"1992-08-21 12:03:43"`
-	User      UserDto          `json:"user,omitempty"`
-	Summary   string           `json:"summary,omitempty" example:"summary"`
-	Status    model.TaskStatus `json:"status,omitempty" example:"opened"`
+	ID        int                `json:"id" example:"1"`
+	CreatedAt string             `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	UpdatedAt string             `json:"updated_at,omitempty" example:"1992-08-21 12:03:43"`
+	User      UserDto            `json:"user,omitempty"`
+	Assignee  UserDto            `json:"assignee,omitempty"`
+	Summary   string             `json:"summary,omitempty" example:"summary"`
+	Status    model.TaskStatus   `json:"status,omitempty" example:"opened"`
+	DueAt     string             `json:"due_at,omitempty" example:"1992-08-28 18:00:00"`
+	Priority  model.TaskPriority `json:"priority,omitempty" example:"normal"`
+	Tags      []string           `json:"tags,omitempty" example:"maintenance"`
+	ParentID  int                `json:"parent_id,omitempty" example:"1"`
+
+	Dependencies TaskDependenciesDto `json:"dependencies"`
+}
+
+type TaskDependenciesDto struct {
+	Subtasks     int   `json:"subtasks" example:"3"`
+	OpenSubtasks int   `json:"open_subtasks" example:"1"`
+	BlockedBy    []int `json:"blocked_by,omitempty" example:"4"`
+	Blocks       []int `json:"blocks,omitempty" example:"5"`
+}
+
+type TaskTreeDto struct {
+	TaskDto
+	Subtasks []TaskTreeDto `json:"subtasks"`
+}
+
+type TaskTreeResponse struct {
+	Data TaskTreeDto `json:"data"`
 }
 
 type TaskResponse struct {
@@ -23,5 +47,239 @@ type TasksResponse struct {
 }
 
 type CreateTaskDto struct {
-	Summary string `json:"summary" binding:"required,min=1,max=2500" example:"summary"`
+	Summary    string             `json:"summary" binding:"required,min=1,max=2500" example:"summary"`
+	AssigneeID *int               `json:"assignee_id" binding:"omitempty,min=1" example:"2"`
+	ParentID   *int               `json:"parent_id" binding:"omitempty,min=1" example:"1"`
+	DueAt      string             `json:"due_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"1992-08-28 18:00:00"`
+	Priority   model.TaskPriority `json:"priority" binding:"omitempty,oneof=low normal high urgent" enums:"low,normal,high,urgent" example:"normal"`
+	Tags       []string           `json:"tags" binding:"omitempty,max=10,dive,min=1,max=50" example:"maintenance"`
+}
+
+type UpdateTaskDto struct {
+	Summary  string             `json:"summary" binding:"required,min=1,max=2500" example:"summary"`
+	ParentID *int               `json:"parent_id" binding:"omitempty,min=1" example:"1"`
+	DueAt    string             `json:"due_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"1992-08-28 18:00:00"`
+	Priority model.TaskPriority `json:"priority" binding:"omitempty,oneof=low normal high urgent" enums:"low,normal,high,urgent" example:"normal"`
+	Tags     []string           `json:"tags" binding:"omitempty,max=10,dive,min=1,max=50" example:"maintenance"`
+}
+
+type ListTasksFilterDto struct {
+	Status     model.TaskStatus   `form:"status" json:"status"`
+	Priority   model.TaskPriority `form:"priority" json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	AssigneeID int                `form:"assignee_id" json:"assignee_id" binding:"omitempty,min=1"`
+	DueAfter   string             `form:"due_after" json:"due_after" binding:"omitempty,datetime=2006-01-02 15:04:05"`
+	DueBefore  string             `form:"due_before" json:"due_before" binding:"omitempty,datetime=2006-01-02 15:04:05"`
+	Overdue    bool               `form:"overdue" json:"overdue"`
+	Sort       string             `form:"sort" json:"sort" binding:"omitempty,oneof=created_at -created_at due_at -due_at priority -priority"`
+	Tags       string             `form:"tags" json:"tags" example:"maintenance,equipment"`
+	TagMatch   string             `form:"tag_match" json:"tag_match" binding:"omitempty,oneof=any all" enums:"any,all"`
+}
+
+// ExportTasksFilterDto takes the ListTasksFilterDto filters. include_owner
+// adds the username and email of the user who created each task.
+type ExportTasksFilterDto struct {
+	ListTasksFilterDto
+	Format       string `form:"format" json:"format" binding:"required,oneof=csv ndjson" enums:"csv,ndjson"`
+	IncludeOwner bool   `form:"include_owner" json:"include_owner"`
+}
+
+type AssignTaskDto struct {
+	AssigneeID int `json:"assignee_id" binding:"required,min=1" example:"2"`
+}
+
+type TransitionTaskDto struct {
+	To    model.TaskStatus `json:"to" binding:"required" example:"in_progress"`
+	Force bool             `json:"force" example:"false"`
+}
+
+// BulkTasksDto runs the action on the tasks in ids, or on the tasks matching
+// filter. assignee_id is required to reassign, tags to tag and force closes
+// tasks with open subtasks.
+type BulkTasksDto struct {
+	Action     model.TaskBulkAction `json:"action" binding:"required,oneof=close reopen reassign tag delete" enums:"close,reopen,reassign,tag,delete" example:"close"`
+	IDs        []int                `json:"ids" binding:"omitempty,dive,min=1" example:"1,2,3"`
+	Filter     *ListTasksFilterDto  `json:"filter"`
+	AssigneeID int                  `json:"assignee_id" binding:"omitempty,min=1" example:"2"`
+	Tags       []string             `json:"tags" binding:"omitempty,max=10,dive,min=1,max=50" example:"maintenance"`
+	Force      bool                 `json:"force" example:"false"`
+}
+
+type TaskBulkResultDto struct {
+	ID     int             `json:"id" example:"1"`
+	Status int             `json:"status" example:"200"`
+	Data   *TaskDto        `json:"data,omitempty"`
+	Error  *ProblemDetails `json:"error,omitempty"`
+}
+
+type TasksBulkResponse struct {
+	Succeeded int                 `json:"succeeded" example:"2"`
+	Failed    int                 `json:"failed" example:"1"`
+	Data      []TaskBulkResultDto `json:"data"`
+}
+
+// ImportTaskDto is a row of a task import, held to the CreateTaskDto rules.
+// Line is the line of the row in the imported file and Errors the binding
+// errors of the row, reported along with the ones found when importing it.
+type ImportTaskDto struct {
+	CreateTaskDto
+	Line             int                    `json:"-"`
+	Errors           []exception.FieldError `json:"-"`
+	Status           model.TaskStatus       `json:"status"`
+	AssigneeUsername string                 `json:"assignee"`
+	CreatedAt        string                 `json:"created_at" binding:"omitempty,datetime=2006-01-02 15:04:05"`
+}
+
+type TasksImportResponse struct {
+	DryRun   bool      `json:"dry_run" example:"false"`
+	Imported int       `json:"imported" example:"2"`
+	Data     []TaskDto `json:"data"`
+}
+
+type CreateTaskDependencyDto struct {
+	BlockedByID int `json:"blocked_by_id" binding:"required,min=1" example:"2"`
+}
+
+type TaskTransitionDto struct {
+	ID        int              `json:"id" example:"1"`
+	CreatedAt string           `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	User      UserDto          `json:"user,omitempty"`
+	From      model.TaskStatus `json:"from" example:"opened"`
+	To        model.TaskStatus `json:"to" example:"in_progress"`
+}
+
+type TaskTransitionsResponse struct {
+	Data []TaskTransitionDto `json:"data"`
+}
+
+type TaskCommentDto struct {
+	ID        int     `json:"id" example:"1"`
+	CreatedAt string  `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	UpdatedAt string  `json:"updated_at,omitempty" example:"1992-08-21 12:03:43"`
+	User      UserDto `json:"user,omitempty"`
+	Body      string  `json:"body" example:"waiting for the part"`
+}
+
+type TaskCommentResponse struct {
+	Data TaskCommentDto `json:"data"`
+}
+
+type TaskCommentsResponse struct {
+	Pagination
+	Data []TaskCommentDto `json:"data"`
+}
+
+type CreateTaskCommentDto struct {
+	Body string `json:"body" binding:"required,min=1,max=5000" example:"waiting for the part"`
+}
+
+type UpdateTaskCommentDto struct {
+	Body string `json:"body" binding:"required,min=1,max=5000" example:"part arrived"`
+}
+
+type TaskAttachmentDto struct {
+	ID          int     `json:"id" example:"1"`
+	CreatedAt   string  `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	User        UserDto `json:"user,omitempty"`
+	Filename    string  `json:"filename" example:"photo.jpg"`
+	ContentType string  `json:"content_type" example:"image/jpeg"`
+	Size        int64   `json:"size" example:"204800"`
+}
+
+type TaskAttachmentResponse struct {
+	Data TaskAttachmentDto `json:"data"`
+}
+
+type TaskAttachmentsResponse struct {
+	Data []TaskAttachmentDto `json:"data"`
+}
+
+type TaskTimeEntryDto struct {
+	ID        int     `json:"id" example:"1"`
+	CreatedAt string  `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	User      UserDto `json:"user,omitempty"`
+	StartedAt string  `json:"started_at" example:"1992-08-21 09:00:00"`
+	EndedAt   string  `json:"ended_at,omitempty" example:"1992-08-21 10:30:00"`
+	Running   bool    `json:"running" example:"false"`
+	Seconds   int64   `json:"seconds" example:"5400"`
+	Note      string  `json:"note,omitempty" example:"replaced the battery"`
+}
+
+type TaskTimeEntryResponse struct {
+	Data TaskTimeEntryDto `json:"data"`
+}
+
+type TaskTimeEntriesResponse struct {
+	TotalSeconds int64              `json:"total_seconds" example:"5400"`
+	Data         []TaskTimeEntryDto `json:"data"`
+}
+
+// CreateTaskTimeEntryDto starts or stops the user timer on the task, or logs
+// a manual entry from started_at to ended_at.
+type CreateTaskTimeEntryDto struct {
+	Action    string `json:"action" binding:"required,oneof=start stop manual" enums:"start,stop,manual" example:"manual"`
+	StartedAt string `json:"started_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"1992-08-21 09:00:00"`
+	EndedAt   string `json:"ended_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"1992-08-21 10:30:00"`
+	Note      string `json:"note" binding:"max=500" example:"replaced the battery"`
+}
+
+type TimeEntrySummaryFilterDto struct {
+	UserID int                   `form:"user_id" json:"user_id" binding:"omitempty,min=1"`
+	From   string                `form:"from" json:"from" binding:"omitempty,datetime=2006-01-02"`
+	To     string                `form:"to" json:"to" binding:"omitempty,datetime=2006-01-02"`
+	Period model.TimeEntryPeriod `form:"period" json:"period" binding:"omitempty,oneof=day week month" enums:"day,week,month"`
+}
+
+type TimeEntrySummaryDto struct {
+	UserID  int    `json:"user_id" example:"2"`
+	Period  string `json:"period" example:"1992-08-17"`
+	Seconds int64  `json:"seconds" example:"27000"`
+	Entries int    `json:"entries" example:"6"`
+}
+
+type TimeEntrySummariesResponse struct {
+	Data []TimeEntrySummaryDto `json:"data"`
+}
+
+type TaskReportFilterDto struct {
+	From     string                   `form:"from" json:"from" binding:"omitempty,datetime=2006-01-02"`
+	To       string                   `form:"to" json:"to" binding:"omitempty,datetime=2006-01-02"`
+	Interval model.TaskReportInterval `form:"interval" json:"interval" binding:"omitempty,oneof=day week" enums:"day,week"`
+}
+
+type TaskReportDto struct {
+	From        string                   `json:"from" example:"1992-08-17"`
+	To          string                   `json:"to" example:"1992-08-23"`
+	Interval    model.TaskReportInterval `json:"interval" example:"day"`
+	Statuses    []TaskStatusCountDto     `json:"statuses"`
+	Periods     []TaskPeriodCountDto     `json:"periods"`
+	Closed      TaskCloseStatsDto        `json:"closed"`
+	Technicians []TechnicianTaskStatsDto `json:"technicians"`
+}
+
+type TaskStatusCountDto struct {
+	Status model.TaskStatus `json:"status" example:"opened"`
+	Total  int              `json:"total" example:"4"`
+}
+
+type TaskPeriodCountDto struct {
+	Period  string `json:"period" example:"1992-08-17"`
+	Created int    `json:"created" example:"6"`
+	Closed  int    `json:"closed" example:"3"`
+}
+
+type TaskCloseStatsDto struct {
+	Total              int    `json:"total" example:"3"`
+	MeanSecondsToClose *int64 `json:"mean_seconds_to_close" example:"93600"`
+}
+
+type TechnicianTaskStatsDto struct {
+	User               UserDto `json:"user"`
+	Assigned           int     `json:"assigned" example:"5"`
+	Closed             int     `json:"closed" example:"3"`
+	MeanSecondsToClose *int64  `json:"mean_seconds_to_close" example:"93600"`
+	LoggedSeconds      int64   `json:"logged_seconds" example:"27000"`
+}
+
+type TaskReportResponse struct {
+	Data TaskReportDto `json:"data"`
 }
@@ -0,0 +1,41 @@
+package dto
+
+import "github.com/viniosilva/swordhealth-api/internal/model"
+
+type TaskScheduleDto struct {
+	ID         int                `json:"id" example:"1"`
+	CreatedAt  string             `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	UpdatedAt  string             `json:"updated_at,omitempty" example:"1992-08-21 12:03:43"`
+	UserID     int                `json:"user_id" example:"1"`
+	AssigneeID int                `json:"assignee_id" example:"2"`
+	Summary    string             `json:"summary" example:"weekly maintenance check {{date}}"`
+	Priority   model.TaskPriority `json:"priority" enums:"low,normal,high,urgent" example:"normal"`
+	Cron       string             `json:"cron" example:"0 9 * * 1"`
+	Enabled    bool               `json:"enabled" example:"true"`
+	NextRunAt  string             `json:"next_run_at" example:"1992-08-24 09:00:00"`
+	LastRunAt  string             `json:"last_run_at,omitempty" example:"1992-08-17 09:00:00"`
+}
+
+type TaskScheduleResponse struct {
+	Data TaskScheduleDto `json:"data"`
+}
+
+type TaskSchedulesResponse struct {
+	Data []TaskScheduleDto `json:"data"`
+}
+
+type CreateTaskScheduleDto struct {
+	Summary    string             `json:"summary" binding:"required,min=1,max=2500" example:"weekly maintenance check {{date}}"`
+	AssigneeID *int               `json:"assignee_id" binding:"omitempty,min=1" example:"2"`
+	Priority   model.TaskPriority `json:"priority" binding:"omitempty,oneof=low normal high urgent" enums:"low,normal,high,urgent" example:"normal"`
+	Cron       string             `json:"cron" binding:"required,max=100" example:"0 9 * * 1"`
+	Enabled    *bool              `json:"enabled" example:"true"`
+}
+
+type UpdateTaskScheduleDto struct {
+	Summary    string             `json:"summary" binding:"required,min=1,max=2500" example:"weekly maintenance check {{date}}"`
+	AssigneeID *int               `json:"assignee_id" binding:"omitempty,min=1" example:"2"`
+	Priority   model.TaskPriority `json:"priority" binding:"omitempty,oneof=low normal high urgent" enums:"low,normal,high,urgent" example:"normal"`
+	Cron       string             `json:"cron" binding:"required,max=100" example:"0 9 * * 1"`
+	Enabled    *bool              `json:"enabled" example:"true"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "github.com/viniosilva/swordhealth-api/internal/model"
+
+type TaskTemplateDto struct {
+	ID           int                `json:"id" example:"1"`
+	CreatedAt    string             `json:"created_at,omitempty" example:"1992-08-21 12:03:43"`
+	UpdatedAt    string             `json:"updated_at,omitempty" example:"1992-08-21 12:03:43"`
+	UserID       int                `json:"user_id" example:"1"`
+	Name         string             `json:"name" example:"equipment check"`
+	Summary      string             `json:"summary" example:"check the {{equipment}} in room {{room}}"`
+	Priority     model.TaskPriority `json:"priority" enums:"low,normal,high,urgent" example:"normal"`
+	Placeholders []string           `json:"placeholders" example:"equipment,room"`
+}
+
+type TaskTemplateResponse struct {
+	Data TaskTemplateDto `json:"data"`
+}
+
+type TaskTemplatesResponse struct {
+	Data []TaskTemplateDto `json:"data"`
+}
+
+type CreateTaskTemplateDto struct {
+	Name     string             `json:"name" binding:"required,min=1,max=100" example:"equipment check"`
+	Summary  string             `json:"summary" binding:"required,min=1,max=2500" example:"check the {{equipment}} in room {{room}}"`
+	Priority model.TaskPriority `json:"priority" binding:"omitempty,oneof=low normal high urgent" enums:"low,normal,high,urgent" example:"normal"`
+}
+
+type UpdateTaskTemplateDto struct {
+	Name     *string             `json:"name" binding:"omitempty,min=1,max=100" example:"equipment check"`
+	Summary  *string             `json:"summary" binding:"omitempty,min=1,max=2500" example:"check the {{equipment}} in room {{room}}"`
+	Priority *model.TaskPriority `json:"priority" binding:"omitempty,oneof=low normal high urgent" enums:"low,normal,high,urgent" example:"normal"`
+}
+
+// CreateTaskFromTemplateDto is the body of POST /tasks?template_id=, the
+// summary is the template rendered with the variables.
+type CreateTaskFromTemplateDto struct {
+	Variables  map[string]string  `json:"variables"`
+	AssigneeID *int               `json:"assignee_id" binding:"omitempty,min=1" example:"2"`
+	ParentID   *int               `json:"parent_id" binding:"omitempty,min=1" example:"1"`
+	DueAt      string             `json:"due_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"1992-08-28 18:00:00"`
+	Priority   model.TaskPriority `json:"priority" binding:"omitempty,oneof=low normal high urgent" enums:"low,normal,high,urgent" example:"normal"`
+	Tags       []string           `json:"tags" binding:"omitempty,max=10,dive,min=1,max=50" example:"maintenance"`
+}
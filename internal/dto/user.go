@@ -10,9 +10,10 @@ type UserDto struct {
 	UpdatedAt string `json:"updated_at,omitempty" example:"1992-08-21 12:03:43"`
 	DeletedAt string `json:"deleted_at,omitempty" example:"1992-08-21 12:03:43"`
 
-	Email    string         `json:"email,omitempty" example:"email@email.com"`
-	Username string         `json:"username,omitempty" example:"username"`
-	Role     model.UserRole `json:"role,omitempty" example:"technician"`
+	Email    string           `json:"email,omitempty" example:"email@email.com"`
+	Username string           `json:"username,omitempty" example:"username"`
+	Role     model.UserRole   `json:"role,omitempty" example:"technician"`
+	Status   model.UserStatus `json:"status,omitempty" example:"active"`
 }
 
 type UserResponse struct {
@@ -22,6 +23,11 @@ type UserResponse struct {
 type CreateUserDto struct {
 	Username string         `json:"username" binding:"required,min=4,max=20" example:"username"`
 	Email    string         `json:"email" binding:"required,email" example:"email@email.com"`
-	Password string         `json:"password" binding:"required,min=4,max=20" example:"12345"`
+	Password string         `json:"password" binding:"required" example:"S3cR31-p4ssw0rd"`
 	Role     model.UserRole `json:"role" binding:"enum" enums:"technician,manager" example:"technician"`
 }
+
+type ChangePasswordDto struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"S3cR31-p4ssw0rd"`
+	Password        string `json:"password" binding:"required" example:"N3w-S3cR31-p4ssw0rd"`
+}
@@ -1,7 +1,10 @@
 package exception
 
 import (
+	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -34,15 +37,134 @@ func (impl *ExpiredTokenException) Error() string {
 	return impl.Message
 }
 
-func FormatBindingErrors(err error) string {
-	if validationErrors, ok := err.(validator.ValidationErrors); !ok {
-		return "invalid payload"
-	} else {
-		errors := []string{}
-		for _, e := range validationErrors {
-			errors = append(errors, e.Error())
-		}
+type InvalidAuthorizationException struct {
+	Message string
+}
+
+func (impl *InvalidAuthorizationException) Error() string {
+	return impl.Message
+}
+
+type UnauthorizedException struct {
+	Message string
+}
+
+func (impl *UnauthorizedException) Error() string {
+	return impl.Message
+}
+
+type ForbiddenException struct {
+	Message string
+}
+
+func (impl *ForbiddenException) Error() string {
+	return impl.Message
+}
+
+type ConflictException struct {
+	Message string
+}
+
+func (impl *ConflictException) Error() string {
+	return impl.Message
+}
+
+type InvalidMfaCodeException struct {
+	Message string
+}
+
+func (impl *InvalidMfaCodeException) Error() string {
+	return impl.Message
+}
+
+type TooManyRequestsException struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (impl *TooManyRequestsException) Error() string {
+	return impl.Message
+}
+
+type AccountLockedException struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (impl *AccountLockedException) Error() string {
+	return impl.Message
+}
+
+type FieldError struct {
+	Field   string
+	Tag     string
+	Message string
+}
 
-		return strings.Join(errors, "; ")
+type ValidationException struct {
+	Message string
+	Fields  []FieldError
+}
+
+func (impl *ValidationException) Error() string {
+	return impl.Message
+}
+
+func ParseBindingErrors(err error) *ValidationException {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return &ValidationException{Message: "invalid payload"}
+	}
+
+	fields := []FieldError{}
+	for _, e := range validationErrors {
+		fields = append(fields, FieldError{
+			Field:   e.Field(),
+			Tag:     e.Tag(),
+			Message: formatFieldError(e),
+		})
+	}
+
+	return &ValidationException{Message: "invalid fields", Fields: fields}
+}
+
+func JSONTagName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func formatFieldError(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", e.Field())
+	case "min":
+		if e.Kind() == reflect.String {
+			return fmt.Sprintf("%s must have at least %s characters", e.Field(), e.Param())
+		}
+		return fmt.Sprintf("%s must be at least %s", e.Field(), e.Param())
+	case "max":
+		if e.Kind() == reflect.String {
+			return fmt.Sprintf("%s must have at most %s characters", e.Field(), e.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", e.Field(), e.Param())
+	case "len":
+		return fmt.Sprintf("%s must have exactly %s characters", e.Field(), e.Param())
+	case "numeric":
+		return fmt.Sprintf("%s must be numeric", e.Field())
+	case "email":
+		return fmt.Sprintf("%s must be a valid email", e.Field())
+	case "datetime":
+		return fmt.Sprintf("%s must match the format %s", e.Field(), e.Param())
+	case "enum", "oneof":
+		return fmt.Sprintf("%s has an invalid value", e.Field())
+	default:
+		return fmt.Sprintf("%s failed on the '%s' validation", e.Field(), e.Tag())
 	}
 }
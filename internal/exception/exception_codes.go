@@ -0,0 +1,19 @@
+package exception
+
+type ErrorCode string
+
+const (
+	ErrorCodeInternal             ErrorCode = "internal_error"
+	ErrorCodeInvalidPayload       ErrorCode = "invalid_payload"
+	ErrorCodeValidationFailed     ErrorCode = "validation_failed"
+	ErrorCodeInvalidAuthorization ErrorCode = "invalid_authorization"
+	ErrorCodeUnauthorized         ErrorCode = "unauthorized"
+	ErrorCodeForbidden            ErrorCode = "forbidden"
+	ErrorCodeExpiredToken         ErrorCode = "expired_token"
+	ErrorCodeNotFound             ErrorCode = "not_found"
+	ErrorCodeForeignKeyConstraint ErrorCode = "foreign_key_constraint"
+	ErrorCodeConflict             ErrorCode = "conflict"
+	ErrorCodeInvalidMfaCode       ErrorCode = "invalid_mfa_code"
+	ErrorCodeTooManyRequests      ErrorCode = "too_many_requests"
+	ErrorCodeAccountLocked        ErrorCode = "account_locked"
+)
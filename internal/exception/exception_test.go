@@ -2,7 +2,6 @@ package exception_test
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin/binding"
@@ -12,44 +11,48 @@ import (
 	"github.com/viniosilva/swordhealth-api/internal/exception"
 )
 
-func TestExceptionFormatBindingErrors(t *testing.T) {
+func TestExceptionParseBindingErrors(t *testing.T) {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("enum", func(fl validator.FieldLevel) bool { return true })
+		v.RegisterTagNameFunc(exception.JSONTagName)
 	}
 
 	var cases = map[string]struct {
-		inputError     error
-		expectedErrors string
+		inputError    error
+		expectedError *exception.ValidationException
 	}{
 		`should return "invalid payload"`: {
-			inputError:     fmt.Errorf("error"),
-			expectedErrors: "invalid payload",
+			inputError:    fmt.Errorf("error"),
+			expectedError: &exception.ValidationException{Message: "invalid payload"},
 		},
-		"should return list errors": {
+		"should return field errors": {
 			inputError: binding.Validator.ValidateStruct(&dto.CreateUserDto{
-				Username: "",
+				Username: "abc",
 				Email:    "email",
-				Password: "0",
+				Password: "",
 			}),
-			expectedErrors: strings.Join([]string{
-				"Key: 'CreateUserDto.Username' Error:Field validation for 'Username' failed on the 'required' tag",
-				"Key: 'CreateUserDto.Email' Error:Field validation for 'Email' failed on the 'email' tag",
-				"Key: 'CreateUserDto.Password' Error:Field validation for 'Password' failed on the 'min' tag",
-			}, "; "),
+			expectedError: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "username", Tag: "min", Message: "username must have at least 4 characters"},
+					{Field: "email", Tag: "email", Message: "email must be a valid email"},
+					{Field: "password", Tag: "required", Message: "password is required"},
+				},
+			},
 		},
 	}
 	for name, cs := range cases {
 		t.Run(name, func(t *testing.T) {
 			// when
-			errors := exception.FormatBindingErrors(cs.inputError)
+			err := exception.ParseBindingErrors(cs.inputError)
 
 			// then
-			assert.Equal(t, cs.expectedErrors, errors)
+			assert.Equal(t, cs.expectedError, err)
 		})
 	}
 }
 
-func BenchmarkExceptionFormatBindingErrors(b *testing.B) {
+func BenchmarkExceptionParseBindingErrors(b *testing.B) {
 	// given
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("enum", func(fl validator.FieldLevel) bool { return true })
@@ -63,7 +66,7 @@ func BenchmarkExceptionFormatBindingErrors(b *testing.B) {
 
 	// when
 	for i := 0; i < b.N; i++ {
-		exception.FormatBindingErrors(inputErrors)
+		exception.ParseBindingErrors(inputErrors)
 	}
 }
 
@@ -129,3 +132,45 @@ func TestExpiredTokenException(t *testing.T) {
 		})
 	}
 }
+
+func TestTooManyRequestsException(t *testing.T) {
+	var cases = map[string]struct {
+		inputErrorMessage    string
+		expectedErrorMessage string
+	}{
+		"should return error message": {
+			inputErrorMessage:    "error",
+			expectedErrorMessage: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			error := exception.TooManyRequestsException{Message: cs.inputErrorMessage}
+
+			// then
+			assert.Equal(t, cs.expectedErrorMessage, error.Error())
+		})
+	}
+}
+
+func TestAccountLockedException(t *testing.T) {
+	var cases = map[string]struct {
+		inputErrorMessage    string
+		expectedErrorMessage string
+	}{
+		"should return error message": {
+			inputErrorMessage:    "error",
+			expectedErrorMessage: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			error := exception.AccountLockedException{Message: cs.inputErrorMessage}
+
+			// then
+			assert.Equal(t, cs.expectedErrorMessage, error.Error())
+		})
+	}
+}
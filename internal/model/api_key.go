@@ -0,0 +1,56 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+type ApiKeyScope string
+
+const (
+	ApiKeyScopeTasksRead  ApiKeyScope = "tasks:read"
+	ApiKeyScopeTasksWrite ApiKeyScope = "tasks:write"
+	ApiKeyScopeUsersRead  ApiKeyScope = "users:read"
+	ApiKeyScopeUsersWrite ApiKeyScope = "users:write"
+)
+
+type ApiKey struct {
+	ID        int        `db:"id"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+
+	UserID int `db:"user_id"`
+
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`
+	KeyHash    string     `db:"key_hash"`
+	Scopes     string     `db:"scopes"`
+	ExpiresAt  *time.Time `db:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+}
+
+func (m ApiKey) ScopeList() []ApiKeyScope {
+	scopes := []ApiKeyScope{}
+	for _, s := range strings.Split(m.Scopes, ",") {
+		if s != "" {
+			scopes = append(scopes, ApiKeyScope(s))
+		}
+	}
+
+	return scopes
+}
+
+func (m ApiKey) HasScope(scope ApiKeyScope) bool {
+	for _, s := range m.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m ApiKey) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionImpersonationStart   AuditAction = "impersonation.start"
+	AuditActionImpersonationRequest AuditAction = "impersonation.request"
+)
+
+type AuditLog struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	RequestID string    `db:"request_id"`
+
+	ActorID int `db:"actor_id"`
+	UserID  int `db:"user_id"`
+
+	Action AuditAction `db:"action"`
+	Method string      `db:"method"`
+	Path   string      `db:"path"`
+	Status int         `db:"status"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending InvitationStatus = "pending"
+	InvitationStatusExpired InvitationStatus = "expired"
+)
+
+type Invitation struct {
+	ID         int        `db:"id"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	AcceptedAt *time.Time `db:"accepted_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+
+	InvitedBy int `db:"invited_by"`
+	UserID    int `db:"user_id"`
+
+	TokenHash string   `db:"token_hash"`
+	Username  string   `db:"username"`
+	Email     string   `db:"email"`
+	Role      UserRole `db:"role"`
+}
+
+func (m Invitation) Status(now time.Time) InvitationStatus {
+	if !now.Before(m.ExpiresAt) {
+		return InvitationStatusExpired
+	}
+
+	return InvitationStatusPending
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type LoginAttemptReason string
+
+const (
+	LoginAttemptReasonInvalidCredentials LoginAttemptReason = "invalid_credentials"
+	LoginAttemptReasonThrottled          LoginAttemptReason = "throttled"
+)
+
+type LoginAttempt struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+
+	Username  string             `db:"username"`
+	IP        string             `db:"ip"`
+	UserAgent string             `db:"user_agent"`
+	Reason    LoginAttemptReason `db:"reason"`
+}
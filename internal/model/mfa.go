@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type UserTotp struct {
+	UserID    int       `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	Secret       string     `db:"secret"`
+	ConfirmedAt  *time.Time `db:"confirmed_at"`
+	LastUsedStep int64      `db:"last_used_step"`
+}
+
+type TotpEnrollment struct {
+	Secret     string
+	OtpauthURI string
+}
+
+type MfaStatus struct {
+	Enrolled bool
+	Required bool
+}
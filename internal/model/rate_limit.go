@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type RateLimit struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+func (impl *RateLimit) Allowed() bool {
+	return impl.RetryAfter <= 0
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Tag struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	Name       string `db:"name"`
+	UsageCount int    `db:"usage_count"`
+}
+
+// TaskTags holds the names of the tags of a task, scanned from a comma
+// separated GROUP_CONCAT column. Tag names never contain commas.
+type TaskTags []string
+
+func (t *TaskTags) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = TaskTags{}
+	case []byte:
+		*t = strings.Split(string(v), ",")
+	case string:
+		*t = strings.Split(v, ",")
+	default:
+		return fmt.Errorf("can not scan %T into TaskTags", src)
+	}
+
+	return nil
+}
@@ -1,12 +1,46 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type TaskStatus string
 
 const (
-	TaskStatusOpened TaskStatus = "opened"
-	TaskStatusClosed TaskStatus = "closed"
+	TaskStatusOpened     TaskStatus = "opened"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusBlocked    TaskStatus = "blocked"
+	TaskStatusInReview   TaskStatus = "in_review"
+	TaskStatusClosed     TaskStatus = "closed"
+)
+
+type TaskPriority string
+
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityNormal TaskPriority = "normal"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+type TaskBulkAction string
+
+const (
+	TaskBulkActionClose    TaskBulkAction = "close"
+	TaskBulkActionReopen   TaskBulkAction = "reopen"
+	TaskBulkActionReassign TaskBulkAction = "reassign"
+	TaskBulkActionTag      TaskBulkAction = "tag"
+	TaskBulkActionDelete   TaskBulkAction = "delete"
+)
+
+type TaskReminderThreshold string
+
+const (
+	TaskReminderThresholdDueSoon TaskReminderThreshold = "due_soon"
+	TaskReminderThresholdOverdue TaskReminderThreshold = "overdue"
 )
 
 type Task struct {
@@ -15,8 +49,90 @@ type Task struct {
 	UpdatedAt time.Time  `db:"updated_at"`
 	DeletedAt *time.Time `db:"deleted_at"`
 
+	UserID     int  `db:"user_id"`
+	AssigneeID int  `db:"assignee_id"`
+	ParentID   *int `db:"parent_id"`
+
+	Summary  string       `db:"summary"`
+	Status   TaskStatus   `db:"status"`
+	DueAt    *time.Time   `db:"due_at"`
+	Priority TaskPriority `db:"priority"`
+	Tags     TaskTags     `db:"tags"`
+
+	SubtaskCount     int     `db:"subtask_count"`
+	OpenSubtaskCount int     `db:"open_subtask_count"`
+	BlockedBy        TaskIDs `db:"blocked_by"`
+	Blocks           TaskIDs `db:"blocks"`
+}
+
+// TaskTree is a task with its subtasks, recursively.
+type TaskTree struct {
+	Task     Task
+	Subtasks []TaskTree
+}
+
+// TaskIDs holds task ids scanned from a comma separated GROUP_CONCAT column.
+type TaskIDs []int
+
+func (t *TaskIDs) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("can not scan %T into TaskIDs", src)
+	}
+
+	ids := TaskIDs{}
+	for _, s := range strings.Split(value, ",") {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	*t = ids
+
+	return nil
+}
+
+// TaskExport is a task along with its owner, the user who created it.
+type TaskExport struct {
+	Task
+	OwnerUsername string `db:"owner_username"`
+	OwnerEmail    string `db:"owner_email"`
+}
+
+type TaskTransition struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+
+	TaskID int `db:"task_id"`
 	UserID int `db:"user_id"`
 
-	Summary string     `db:"summary"`
-	Status  TaskStatus `db:"status"`
+	FromStatus TaskStatus `db:"from_status"`
+	ToStatus   TaskStatus `db:"to_status"`
+}
+
+type TaskDependency struct {
+	CreatedAt time.Time `db:"created_at"`
+
+	TaskID      int `db:"task_id"`
+	BlockedByID int `db:"blocked_by_id"`
+	UserID      int `db:"user_id"`
+}
+
+type TaskReminder struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+
+	TaskID int `db:"task_id"`
+
+	Threshold TaskReminderThreshold `db:"threshold"`
+	DueAt     time.Time             `db:"due_at"`
 }
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type TaskAttachment struct {
+	ID        int        `db:"id"`
+	CreatedAt time.Time  `db:"created_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+
+	TaskID int `db:"task_id"`
+	UserID int `db:"user_id"`
+
+	Filename    string `db:"filename"`
+	ContentType string `db:"content_type"`
+	Size        int64  `db:"size"`
+	StorageKey  string `db:"storage_key"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type TaskComment struct {
+	ID        int        `db:"id"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+
+	TaskID int `db:"task_id"`
+	UserID int `db:"user_id"`
+
+	Body string `db:"body"`
+}
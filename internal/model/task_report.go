@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+type TaskReportInterval string
+
+const (
+	TaskReportIntervalDay  TaskReportInterval = "day"
+	TaskReportIntervalWeek TaskReportInterval = "week"
+)
+
+// TaskReport sums up the tasks between From and To, both days included.
+// Tasks count by the day they were created and closes by the day of the
+// transition to closed, so a task closed twice counts twice.
+type TaskReport struct {
+	From     time.Time
+	To       time.Time
+	Interval TaskReportInterval
+
+	Statuses    []TaskStatusCount
+	Periods     []TaskPeriodCount
+	Closed      TaskCloseStats
+	Technicians []TechnicianTaskStats
+}
+
+type TaskStatusCount struct {
+	Status TaskStatus `db:"status"`
+	Total  int        `db:"total"`
+}
+
+// TaskPeriodCount is what happened in a period, which starts on Period (a
+// day or a monday).
+type TaskPeriodCount struct {
+	Period  string `db:"period"`
+	Created int    `db:"created"`
+	Closed  int    `db:"closed"`
+}
+
+// TaskCloseStats are the closes and the mean seconds from the creation of a
+// task to its close, nil without closes.
+type TaskCloseStats struct {
+	Total       int      `db:"total"`
+	MeanSeconds *float64 `db:"mean_seconds"`
+}
+
+// TechnicianTaskStats is the work of a technician: the tasks assigned to them
+// that were created and closed, and the time they logged.
+type TechnicianTaskStats struct {
+	UserID        int      `db:"user_id"`
+	Username      string   `db:"username"`
+	Assigned      int      `db:"assigned"`
+	Closed        int      `db:"closed"`
+	MeanSeconds   *float64 `db:"mean_seconds"`
+	LoggedSeconds int64    `db:"logged_seconds"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type TaskSchedule struct {
+	ID        int        `db:"id"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+
+	UserID     int `db:"user_id"`
+	AssigneeID int `db:"assignee_id"`
+
+	Summary  string       `db:"summary"`
+	Priority TaskPriority `db:"priority"`
+	Cron     string       `db:"cron"`
+	Enabled  bool         `db:"enabled"`
+
+	NextRunAt time.Time  `db:"next_run_at"`
+	LastRunAt *time.Time `db:"last_run_at"`
+}
+
+// TaskScheduleRun is the claim of one occurrence of a schedule, so the
+// occurrence creates at most one task.
+type TaskScheduleRun struct {
+	CreatedAt time.Time `db:"created_at"`
+
+	ScheduleID int       `db:"schedule_id"`
+	RunAt      time.Time `db:"run_at"`
+	TaskID     *int      `db:"task_id"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"regexp"
+	"time"
+)
+
+var taskTemplatePlaceholderPattern = regexp.MustCompile(`\{\{([a-z][a-z0-9_]*)\}\}`)
+
+type TaskTemplate struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	UserID int `db:"user_id"`
+
+	Name     string       `db:"name"`
+	Summary  string       `db:"summary"`
+	Priority TaskPriority `db:"priority"`
+}
+
+// Placeholders returns the names of the {{name}} placeholders in the
+// summary, once each and in the order they first appear.
+func (t *TaskTemplate) Placeholders() []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, match := range taskTemplatePlaceholderPattern.FindAllStringSubmatch(t.Summary, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+
+	return names
+}
+
+// Render replaces the placeholders in the summary with their values.
+func (t *TaskTemplate) Render(values map[string]string) string {
+	return taskTemplatePlaceholderPattern.ReplaceAllStringFunc(t.Summary, func(placeholder string) string {
+		return values[placeholder[2:len(placeholder)-2]]
+	})
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+type TimeEntryPeriod string
+
+const (
+	TimeEntryPeriodDay   TimeEntryPeriod = "day"
+	TimeEntryPeriodWeek  TimeEntryPeriod = "week"
+	TimeEntryPeriodMonth TimeEntryPeriod = "month"
+)
+
+type TaskTimeEntry struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	TaskID int `db:"task_id"`
+	UserID int `db:"user_id"`
+
+	StartedAt time.Time  `db:"started_at"`
+	EndedAt   *time.Time `db:"ended_at"`
+	Note      string     `db:"note"`
+}
+
+// Duration is the time logged so far, up to now for a running timer.
+func (e *TaskTimeEntry) Duration(now time.Time) time.Duration {
+	if e.EndedAt != nil {
+		return e.EndedAt.Sub(e.StartedAt)
+	}
+
+	return now.Sub(e.StartedAt)
+}
+
+// TimeEntrySummary is the time a user logged in a period, which starts on
+// Period (a day, a monday or the first day of a month).
+type TimeEntrySummary struct {
+	UserID  int    `db:"user_id"`
+	Period  string `db:"period"`
+	Seconds int64  `db:"seconds"`
+	Entries int    `db:"entries"`
+}
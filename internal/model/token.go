@@ -0,0 +1,13 @@
+package model
+
+type TokenUse string
+
+const (
+	TokenUseClaim  = "token_use"
+	SessionIDClaim = "sid"
+	ActorClaim     = "act"
+
+	TokenUseAccess        TokenUse = ""
+	TokenUseMfaChallenge  TokenUse = "mfa_challenge"
+	TokenUseMfaEnrollment TokenUse = "mfa_enrollment"
+)
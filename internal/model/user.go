@@ -9,14 +9,22 @@ const (
 	UserRoleTechnician UserRole = "technician"
 )
 
+type UserStatus string
+
+const (
+	UserStatusActive  UserStatus = "active"
+	UserStatusPending UserStatus = "pending"
+)
+
 type User struct {
 	ID        int        `db:"id"`
 	CreatedAt time.Time  `db:"created_at"`
 	UpdatedAt time.Time  `db:"updated_at"`
 	DeletedAt *time.Time `db:"deleted_at"`
 
-	Email    string   `db:"email"`
-	Username string   `db:"username"`
-	Password string   `db:"password"`
-	Role     UserRole `db:"role"`
+	Email    string     `db:"email"`
+	Username string     `db:"username"`
+	Password string     `db:"password"`
+	Role     UserRole   `db:"role"`
+	Status   UserStatus `db:"status"`
 }
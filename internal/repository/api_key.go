@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/api_key_repository_mock.go -package=mock . ApiKeyRepository
+type ApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, apiKey model.ApiKey) (*model.ApiKey, error)
+	ListApiKeysByUserID(ctx context.Context, userID int) ([]model.ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (*model.ApiKey, error)
+	DeleteApiKey(ctx context.Context, userID, id int) error
+	TouchApiKey(ctx context.Context, id int, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewApiKeyRepository(db *sqlx.DB) ApiKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (impl *apiKeyRepository) CreateApiKey(ctx context.Context, apiKey model.ApiKey) (*model.ApiKey, error) {
+	now := time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO api_keys
+			(created_at, updated_at, user_id, name, prefix, key_hash, scopes, expires_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+		now, now, apiKey.UserID, apiKey.Name, apiKey.Prefix, apiKey.KeyHash, apiKey.Scopes, apiKey.ExpiresAt)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeForeignKeyConstraint) {
+			err = &exception.ForeignKeyConstraintException{Message: "user not found"}
+		}
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey.ID = int(id)
+	apiKey.CreatedAt = now
+	apiKey.UpdatedAt = now
+
+	return &apiKey, nil
+}
+
+func (impl *apiKeyRepository) ListApiKeysByUserID(ctx context.Context, userID int) ([]model.ApiKey, error) {
+	apiKeys := []model.ApiKey{}
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			deleted_at,
+			user_id,
+			name,
+			prefix,
+			key_hash,
+			scopes,
+			expires_at,
+			last_used_at
+		FROM api_keys
+		WHERE user_id = ?
+			AND deleted_at IS NULL
+		ORDER BY id
+	`
+	err := impl.db.SelectContext(ctx, &apiKeys, query, userID)
+
+	return apiKeys, err
+}
+
+func (impl *apiKeyRepository) GetApiKeyByHash(ctx context.Context, keyHash string) (*model.ApiKey, error) {
+	var apiKeys []model.ApiKey
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			deleted_at,
+			user_id,
+			name,
+			prefix,
+			key_hash,
+			scopes,
+			expires_at,
+			last_used_at
+		FROM api_keys
+		WHERE key_hash = ?
+			AND deleted_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &apiKeys, query, keyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiKeys) == 0 {
+		return nil, &exception.NotFoundException{Message: "api key not found"}
+	}
+
+	return &apiKeys[0], nil
+}
+
+func (impl *apiKeyRepository) DeleteApiKey(ctx context.Context, userID, id int) error {
+	now := time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `UPDATE api_keys
+			SET updated_at = ?, deleted_at = ?
+			WHERE id = ?
+				AND user_id = ?
+				AND deleted_at IS NULL;`,
+		now, now, id, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "api key not found"}
+	}
+
+	return nil
+}
+
+func (impl *apiKeyRepository) TouchApiKey(ctx context.Context, id int, usedAt time.Time) error {
+	_, err := impl.db.ExecContext(ctx, `UPDATE api_keys
+			SET last_used_at = ?
+			WHERE id = ?;`,
+		usedAt, id)
+
+	return err
+}
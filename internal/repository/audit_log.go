@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/audit_log_repository_mock.go -package=mock . AuditLogRepository
+type AuditLogRepository interface {
+	CreateAuditLog(ctx context.Context, auditLog model.AuditLog) (*model.AuditLog, error)
+	UpdateAuditLogStatus(ctx context.Context, id, status int) error
+}
+
+type auditLogRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditLogRepository(db *sqlx.DB) AuditLogRepository {
+	return &auditLogRepository{
+		db: db,
+	}
+}
+
+func (impl *auditLogRepository) CreateAuditLog(ctx context.Context, auditLog model.AuditLog) (*model.AuditLog, error) {
+	auditLog.CreatedAt = time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO audit_logs
+			(created_at, request_id, actor_id, user_id, action, method, path, status)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+		auditLog.CreatedAt, auditLog.RequestID, auditLog.ActorID, auditLog.UserID, auditLog.Action,
+		auditLog.Method, auditLog.Path, auditLog.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	auditLog.ID = int(id)
+
+	return &auditLog, nil
+}
+
+func (impl *auditLogRepository) UpdateAuditLogStatus(ctx context.Context, id, status int) error {
+	_, err := impl.db.ExecContext(ctx, `UPDATE audit_logs
+			SET status = ?
+			WHERE id = ?;`,
+		status, id)
+
+	return err
+}
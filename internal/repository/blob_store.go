@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+)
+
+//go:generate mockgen -destination=../../mock/blob_store_mock.go -package=mock . BlobStore
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type localBlobStore struct {
+	dir string
+}
+
+// NewLocalBlobStore keeps blobs as files under dir, keys being slash
+// separated paths relative to it.
+func NewLocalBlobStore(dir string) BlobStore {
+	return &localBlobStore{
+		dir: dir,
+	}
+}
+
+func (impl *localBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := impl.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return file.Close()
+}
+
+func (impl *localBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := impl.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, &exception.NotFoundException{Message: "blob not found"}
+	}
+
+	return file, err
+}
+
+func (impl *localBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := impl.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (impl *localBlobStore) path(key string) (string, error) {
+	path := filepath.Join(impl.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(impl.dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return path, nil
+}
+
+type s3BlobStore struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// NewS3BlobStore talks to an S3 compatible API (AWS S3, MinIO) with path style
+// requests signed with AWS signature version 4.
+func NewS3BlobStore(endpoint, region, bucket, accessKey, secretKey string) BlobStore {
+	return &s3BlobStore{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (impl *s3BlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := impl.request(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := impl.do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+func (impl *s3BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := impl.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := impl.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Body, nil
+}
+
+func (impl *s3BlobStore) Delete(ctx context.Context, key string) error {
+	req, err := impl.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := impl.do(req)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil
+		}
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+func (impl *s3BlobStore) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method,
+		fmt.Sprintf("%s/%s/%s", impl.endpoint, url.PathEscape(impl.bucket), strings.Join(segments, "/")), body)
+	if err != nil {
+		return nil, err
+	}
+	impl.sign(req, time.Now().UTC())
+
+	return req, nil
+}
+
+func (impl *s3BlobStore) do(req *http.Request) (*http.Response, error) {
+	res, err := impl.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, &exception.NotFoundException{Message: "blob not found"}
+	}
+	if res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		res.Body.Close()
+		return nil, fmt.Errorf("s3 %s %s: %d %s", req.Method, req.URL.Path, res.StatusCode, msg)
+	}
+
+	return res, nil
+}
+
+// sign adds the AWS signature version 4 headers. The payload is left
+// unsigned so uploads can be streamed.
+func (impl *s3BlobStore) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := "UNSIGNED-PAYLOAD"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, impl.region)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+impl.secretKey), date)
+	key = hmacSHA256(key, impl.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		impl.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(value))
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"os"
+	"strings"
+)
+
+//go:generate mockgen -destination=../../mock/breached_password_repository_mock.go -package=mock . BreachedPasswordRepository
+type BreachedPasswordRepository interface {
+	Contains(password string) bool
+}
+
+// breachedPasswordFilter is a bloom filter over a breached-password list, so
+// large lists fit in memory at the cost of rare false positives.
+type breachedPasswordFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+const breachedPasswordFalsePositiveRate = 0.001
+
+// NewBreachedPasswordList loads a file with one password per line. Matching
+// is case insensitive. An empty path disables the check.
+func NewBreachedPasswordList(path string) (BreachedPasswordRepository, error) {
+	if path == "" {
+		return newBreachedPasswordFilter(0), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	passwords := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			passwords = append(passwords, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	filter := newBreachedPasswordFilter(len(passwords))
+	for _, p := range passwords {
+		filter.add(p)
+	}
+
+	return filter, nil
+}
+
+func newBreachedPasswordFilter(n int) *breachedPasswordFilter {
+	if n == 0 {
+		return &breachedPasswordFilter{}
+	}
+
+	size := uint64(math.Ceil(-float64(n) * math.Log(breachedPasswordFalsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/float64(n)*math.Ln2)))
+
+	return &breachedPasswordFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+func (impl *breachedPasswordFilter) Contains(password string) bool {
+	if impl.size == 0 {
+		return false
+	}
+
+	h1, h2 := impl.hash(password)
+	for i := uint64(0); i < impl.hashes; i++ {
+		bit := (h1 + i*h2) % impl.size
+		if impl.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (impl *breachedPasswordFilter) add(password string) {
+	h1, h2 := impl.hash(password)
+	for i := uint64(0); i < impl.hashes; i++ {
+		bit := (h1 + i*h2) % impl.size
+		impl.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (impl *breachedPasswordFilter) hash(password string) (uint64, uint64) {
+	sum := sha256.Sum256([]byte(strings.ToLower(password)))
+
+	return binary.BigEndian.Uint64(sum[0:8]), binary.BigEndian.Uint64(sum[8:16]) | 1
+}
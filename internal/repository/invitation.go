@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/invitation_repository_mock.go -package=mock . InvitationRepository
+type InvitationRepository interface {
+	CreateInvitation(ctx context.Context, data dto.CreateInvitationDto, invitedBy int, tokenHash string, expiresAt time.Time) (*model.Invitation, error)
+	ListInvitations(ctx context.Context) ([]model.Invitation, error)
+	GetInvitationByID(ctx context.Context, id int) (*model.Invitation, error)
+	RenewInvitation(ctx context.Context, id int, tokenHash string, expiresAt time.Time) error
+	RevokeInvitation(ctx context.Context, id int) error
+	AcceptInvitation(ctx context.Context, tokenHash, password string) (int, error)
+}
+
+type invitationRepository struct {
+	db *sqlx.DB
+}
+
+func NewInvitationRepository(db *sqlx.DB) InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+const invitationSelect = `
+		SELECT i.id,
+			i.created_at,
+			i.updated_at,
+			i.expires_at,
+			i.accepted_at,
+			i.revoked_at,
+			i.invited_by,
+			i.user_id,
+			i.token_hash,
+			u.username,
+			u.email,
+			u.role
+		FROM user_invitations i
+		INNER JOIN users u ON u.id = i.user_id
+`
+
+func (impl *invitationRepository) CreateInvitation(ctx context.Context, data dto.CreateInvitationDto, invitedBy int,
+	tokenHash string, expiresAt time.Time) (*model.Invitation, error) {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO users
+			(created_at, updated_at, username, email, password, role, status)
+			VALUES (?, ?, ?, ?, '', ?, ?);`,
+		now, now, data.Username, data.Email, data.Role, model.UserStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = tx.ExecContext(ctx, `INSERT INTO user_invitations
+			(created_at, updated_at, expires_at, invited_by, user_id, token_hash)
+			VALUES (?, ?, ?, ?, ?, ?);`,
+		now, now, expiresAt, invitedBy, userID, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &model.Invitation{
+		ID:        int(id),
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: expiresAt,
+		InvitedBy: invitedBy,
+		UserID:    int(userID),
+		TokenHash: tokenHash,
+		Username:  data.Username,
+		Email:     data.Email,
+		Role:      data.Role,
+	}, nil
+}
+
+func (impl *invitationRepository) ListInvitations(ctx context.Context) ([]model.Invitation, error) {
+	invitations := []model.Invitation{}
+	query := invitationSelect + `
+		WHERE i.accepted_at IS NULL
+			AND i.revoked_at IS NULL
+		ORDER BY i.id
+	`
+	err := impl.db.SelectContext(ctx, &invitations, query)
+
+	return invitations, err
+}
+
+func (impl *invitationRepository) GetInvitationByID(ctx context.Context, id int) (*model.Invitation, error) {
+	var invitations []model.Invitation
+	query := invitationSelect + `
+		WHERE i.id = ?
+	`
+	err := impl.db.SelectContext(ctx, &invitations, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invitations) == 0 {
+		return nil, &exception.NotFoundException{Message: "invitation not found"}
+	}
+
+	return &invitations[0], nil
+}
+
+func (impl *invitationRepository) RenewInvitation(ctx context.Context, id int, tokenHash string, expiresAt time.Time) error {
+	res, err := impl.db.ExecContext(ctx, `UPDATE user_invitations
+			SET updated_at = ?, expires_at = ?, token_hash = ?
+			WHERE id = ?
+				AND accepted_at IS NULL
+				AND revoked_at IS NULL;`,
+		time.Now(), expiresAt, tokenHash, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "invitation not found"}
+	}
+
+	return nil
+}
+
+func (impl *invitationRepository) RevokeInvitation(ctx context.Context, id int) error {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE user_invitations
+			SET updated_at = ?, revoked_at = ?
+			WHERE id = ?
+				AND accepted_at IS NULL
+				AND revoked_at IS NULL;`,
+		now, now, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "invitation not found"}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users u
+			INNER JOIN user_invitations i ON i.user_id = u.id
+			SET u.updated_at = ?, u.deleted_at = ?
+			WHERE i.id = ?
+				AND u.status = ?;`,
+		now, now, id, model.UserStatusPending)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (impl *invitationRepository) AcceptInvitation(ctx context.Context, tokenHash, password string) (int, error) {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var userIDs []int
+	err = tx.SelectContext(ctx, &userIDs, `
+		SELECT user_id
+		FROM user_invitations
+		WHERE token_hash = ?
+			AND accepted_at IS NULL
+			AND revoked_at IS NULL
+			AND expires_at > ?
+		FOR UPDATE
+	`, tokenHash, now)
+	if err != nil {
+		return 0, err
+	}
+	if len(userIDs) == 0 {
+		return 0, &exception.NotFoundException{Message: "invitation not found"}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE user_invitations
+			SET updated_at = ?, accepted_at = ?
+			WHERE token_hash = ?;`,
+		now, now, tokenHash)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users
+			SET updated_at = ?, password = ?, status = ?
+			WHERE id = ?;`,
+		now, password, model.UserStatusActive, userIDs[0])
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertPasswordHistory(ctx, tx, userIDs[0], password); err != nil {
+		return 0, err
+	}
+
+	return userIDs[0], tx.Commit()
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mock/limiter_mock.go -package=mock . Limiter
+type Limiter interface {
+	Hit(ctx context.Context, key string, at time.Time) error
+	Hits(ctx context.Context, key string, since time.Time) ([]time.Time, error)
+	Reset(ctx context.Context, key string) error
+}
+
+type memoryLimiter struct {
+	mu   sync.Mutex
+	hits map[string][]time.Time
+}
+
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		hits: map[string][]time.Time{},
+	}
+}
+
+func (impl *memoryLimiter) Hit(ctx context.Context, key string, at time.Time) error {
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	impl.hits[key] = append(impl.hits[key], at)
+
+	return nil
+}
+
+func (impl *memoryLimiter) Hits(ctx context.Context, key string, since time.Time) ([]time.Time, error) {
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	hits := []time.Time{}
+	for _, h := range impl.hits[key] {
+		if !h.Before(since) {
+			hits = append(hits, h)
+		}
+	}
+	impl.hits[key] = hits
+
+	return append([]time.Time{}, hits...), nil
+}
+
+func (impl *memoryLimiter) Reset(ctx context.Context, key string) error {
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	delete(impl.hits, key)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type mysqlLimiter struct {
+	db *sqlx.DB
+}
+
+func NewMySQLLimiter(db *sqlx.DB) Limiter {
+	return &mysqlLimiter{
+		db: db,
+	}
+}
+
+func (impl *mysqlLimiter) Hit(ctx context.Context, key string, at time.Time) error {
+	_, err := impl.db.ExecContext(ctx, `INSERT INTO limiter_hits
+			(created_at, limiter_key)
+			VALUES (?, ?);`,
+		at, key)
+
+	return err
+}
+
+func (impl *mysqlLimiter) Hits(ctx context.Context, key string, since time.Time) ([]time.Time, error) {
+	_, err := impl.db.ExecContext(ctx, `DELETE FROM limiter_hits WHERE limiter_key = ? AND created_at < ?;`, key, since)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := []time.Time{}
+	query := `
+		SELECT created_at
+		FROM limiter_hits
+		WHERE limiter_key = ?
+			AND created_at >= ?
+		ORDER BY created_at
+	`
+	err = impl.db.SelectContext(ctx, &hits, query, key, since)
+
+	return hits, err
+}
+
+func (impl *mysqlLimiter) Reset(ctx context.Context, key string) error {
+	_, err := impl.db.ExecContext(ctx, `DELETE FROM limiter_hits WHERE limiter_key = ?;`, key)
+
+	return err
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/login_attempt_repository_mock.go -package=mock . LoginAttemptRepository
+type LoginAttemptRepository interface {
+	CreateLoginAttempt(ctx context.Context, attempt model.LoginAttempt) (*model.LoginAttempt, error)
+}
+
+type loginAttemptRepository struct {
+	db *sqlx.DB
+}
+
+func NewLoginAttemptRepository(db *sqlx.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{
+		db: db,
+	}
+}
+
+func (impl *loginAttemptRepository) CreateLoginAttempt(ctx context.Context, attempt model.LoginAttempt) (*model.LoginAttempt, error) {
+	attempt.CreatedAt = time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO login_attempts
+			(created_at, username, ip, user_agent, reason)
+			VALUES (?, ?, ?, ?, ?);`,
+		attempt.CreatedAt, attempt.Username, attempt.IP, attempt.UserAgent, attempt.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	attempt.ID = int(id)
+
+	return &attempt, nil
+}
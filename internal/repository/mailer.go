@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/mailer_mock.go -package=mock . Mailer
+type Mailer interface {
+	Send(ctx context.Context, mail model.Mail) error
+}
+
+type logMailer struct{}
+
+// NewLogMailer writes mails to the application log instead of delivering them,
+// meant for local environments only.
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (impl *logMailer) Send(ctx context.Context, mail model.Mail) error {
+	log.WithContext(ctx).WithFields(log.Fields{
+		"trace":   "internal.repository.mailer.send",
+		"to":      mail.To,
+		"subject": mail.Subject,
+	}).Info(mail.Body)
+
+	return nil
+}
+
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(host, port, username, password, from string) Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpMailer{
+		addr: fmt.Sprintf("%s:%s", host, port),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (impl *smtpMailer) Send(ctx context.Context, mail model.Mail) error {
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", impl.from))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", mail.To))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mail.Subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	msg.WriteString(mail.Body)
+
+	return smtp.SendMail(impl.addr, impl.auth, impl.from, []string{mail.To}, []byte(msg.String()))
+}
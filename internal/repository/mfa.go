@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/mfa_repository_mock.go -package=mock . MfaRepository
+type MfaRepository interface {
+	GetTotpByUserID(ctx context.Context, userID int) (*model.UserTotp, error)
+	SaveTotp(ctx context.Context, userID int, secret string) error
+	ConfirmTotp(ctx context.Context, userID int, step int64, recoveryCodes []string) error
+	UseTotpStep(ctx context.Context, userID int, step int64) error
+	UseRecoveryCode(ctx context.Context, userID int, code string) error
+}
+
+type mfaRepository struct {
+	db *sqlx.DB
+}
+
+func NewMfaRepository(db *sqlx.DB) MfaRepository {
+	return &mfaRepository{
+		db: db,
+	}
+}
+
+func (impl *mfaRepository) GetTotpByUserID(ctx context.Context, userID int) (*model.UserTotp, error) {
+	var totps []model.UserTotp
+	query := `
+		SELECT user_id,
+			created_at,
+			updated_at,
+			secret,
+			confirmed_at,
+			last_used_step
+		FROM user_totps
+		WHERE user_id = ?
+	`
+	err := impl.db.SelectContext(ctx, &totps, query, userID)
+
+	if len(totps) == 0 {
+		return nil, &exception.NotFoundException{Message: "totp not found"}
+	}
+
+	return &totps[0], err
+}
+
+func (impl *mfaRepository) SaveTotp(ctx context.Context, userID int, secret string) error {
+	now := time.Now()
+
+	_, err := impl.db.ExecContext(ctx, `INSERT INTO user_totps
+			(user_id, created_at, updated_at, secret, confirmed_at, last_used_step)
+			VALUES (?, ?, ?, ?, NULL, 0)
+			ON DUPLICATE KEY UPDATE updated_at = VALUES(updated_at),
+				secret = VALUES(secret),
+				confirmed_at = NULL,
+				last_used_step = 0;`,
+		userID, now, now, secret)
+
+	return err
+}
+
+func (impl *mfaRepository) ConfirmTotp(ctx context.Context, userID int, step int64, recoveryCodes []string) error {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `UPDATE user_totps
+			SET updated_at = ?, confirmed_at = ?, last_used_step = ?
+			WHERE user_id = ?;`,
+		now, now, step, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?;`, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, code := range recoveryCodes {
+		_, err = tx.ExecContext(ctx, `INSERT INTO mfa_recovery_codes
+				(created_at, user_id, code)
+				VALUES (?, ?, ?);`,
+			now, userID, code)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (impl *mfaRepository) UseTotpStep(ctx context.Context, userID int, step int64) error {
+	res, err := impl.db.ExecContext(ctx, `UPDATE user_totps
+			SET updated_at = ?, last_used_step = ?
+			WHERE user_id = ?
+				AND last_used_step < ?;`,
+		time.Now(), step, userID, step)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "totp step already used"}
+	}
+
+	return nil
+}
+
+func (impl *mfaRepository) UseRecoveryCode(ctx context.Context, userID int, code string) error {
+	res, err := impl.db.ExecContext(ctx, `UPDATE mfa_recovery_codes
+			SET used_at = ?
+			WHERE user_id = ?
+				AND code = ?
+				AND used_at IS NULL;`,
+		time.Now(), userID, code)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "recovery code not found"}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/oidc_client_mock.go -package=mock . OidcClient
+type OidcClient interface {
+	Discover(ctx context.Context) (*model.OidcProviderMetadata, error)
+	ExchangeCode(ctx context.Context, tokenEndpoint, code, codeVerifier string) (*model.OidcTokens, error)
+	GetJwks(ctx context.Context, jwksURI string) ([]model.OidcJwk, error)
+}
+
+type OidcClientError struct {
+	StatusCode int
+	Message    string
+}
+
+func (impl *OidcClientError) Error() string {
+	return impl.Message
+}
+
+type oidcClient struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	httpClient   *http.Client
+}
+
+func NewOidcClient(issuer, clientID, clientSecret, redirectURL string) OidcClient {
+	return &oidcClient{
+		issuer:       strings.TrimRight(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (impl *oidcClient) Discover(ctx context.Context) (*model.OidcProviderMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, impl.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata model.OidcProviderMetadata
+	if err := impl.do(req, &metadata); err != nil {
+		return nil, err
+	}
+
+	return &metadata, nil
+}
+
+func (impl *oidcClient) ExchangeCode(ctx context.Context, tokenEndpoint, code, codeVerifier string) (*model.OidcTokens, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", impl.redirectURL)
+	form.Set("client_id", impl.clientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if impl.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(impl.clientID), url.QueryEscape(impl.clientSecret))
+	}
+
+	var tokens model.OidcTokens
+	if err := impl.do(req, &tokens); err != nil {
+		return nil, err
+	}
+
+	return &tokens, nil
+}
+
+func (impl *oidcClient) GetJwks(ctx context.Context, jwksURI string) ([]model.OidcJwk, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []model.OidcJwk `json:"keys"`
+	}
+	if err := impl.do(req, &jwks); err != nil {
+		return nil, err
+	}
+
+	return jwks.Keys, nil
+}
+
+func (impl *oidcClient) do(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	res, err := impl.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &OidcClientError{
+			StatusCode: res.StatusCode,
+			Message:    fmt.Sprintf("oidc provider responded %d: %s", res.StatusCode, strings.TrimSpace(string(body))),
+		}
+	}
+
+	return json.Unmarshal(body, v)
+}
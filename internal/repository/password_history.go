@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:generate mockgen -destination=../../mock/password_history_repository_mock.go -package=mock . PasswordHistoryRepository
+type PasswordHistoryRepository interface {
+	ListPasswordHistory(ctx context.Context, userID, limit int) ([]string, error)
+}
+
+type passwordHistoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewPasswordHistoryRepository(db *sqlx.DB) PasswordHistoryRepository {
+	return &passwordHistoryRepository{
+		db: db,
+	}
+}
+
+func (impl *passwordHistoryRepository) ListPasswordHistory(ctx context.Context, userID, limit int) ([]string, error) {
+	passwords := []string{}
+	err := impl.db.SelectContext(ctx, &passwords, `
+		SELECT password
+		FROM password_history
+		WHERE user_id = ?
+		ORDER BY id DESC
+		LIMIT ?
+	`, userID, limit)
+
+	return passwords, err
+}
+
+func insertPasswordHistory(ctx context.Context, tx *sqlx.Tx, userID int, password string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO password_history
+			(created_at, user_id, password)
+			VALUES (NOW(), ?, ?);`,
+		userID, password)
+
+	return err
+}
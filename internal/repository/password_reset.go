@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+)
+
+//go:generate mockgen -destination=../../mock/password_reset_repository_mock.go -package=mock . PasswordResetRepository
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error
+	GetPasswordResetUserID(ctx context.Context, tokenHash string) (int, error)
+	ResetPassword(ctx context.Context, tokenHash, password string) (int, error)
+}
+
+type passwordResetRepository struct {
+	db *sqlx.DB
+}
+
+func NewPasswordResetRepository(db *sqlx.DB) PasswordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+func (impl *passwordResetRepository) CreatePasswordResetToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `UPDATE password_reset_tokens
+			SET used_at = ?
+			WHERE user_id = ?
+				AND used_at IS NULL;`,
+		now, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO password_reset_tokens
+			(created_at, expires_at, user_id, token_hash)
+			VALUES (?, ?, ?, ?);`,
+		now, expiresAt, userID, tokenHash)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (impl *passwordResetRepository) GetPasswordResetUserID(ctx context.Context, tokenHash string) (int, error) {
+	var userIDs []int
+	err := impl.db.SelectContext(ctx, &userIDs, `
+		SELECT user_id
+		FROM password_reset_tokens
+		WHERE token_hash = ?
+			AND used_at IS NULL
+			AND expires_at > ?
+	`, tokenHash, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if len(userIDs) == 0 {
+		return 0, &exception.NotFoundException{Message: "password reset token not found"}
+	}
+
+	return userIDs[0], nil
+}
+
+func (impl *passwordResetRepository) ResetPassword(ctx context.Context, tokenHash, password string) (int, error) {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var userIDs []int
+	err = tx.SelectContext(ctx, &userIDs, `
+		SELECT user_id
+		FROM password_reset_tokens
+		WHERE token_hash = ?
+			AND used_at IS NULL
+			AND expires_at > ?
+		FOR UPDATE
+	`, tokenHash, now)
+	if err != nil {
+		return 0, err
+	}
+	if len(userIDs) == 0 {
+		return 0, &exception.NotFoundException{Message: "password reset token not found"}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE password_reset_tokens
+			SET used_at = ?
+			WHERE token_hash = ?;`,
+		now, tokenHash)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users
+			SET updated_at = ?, password = ?
+			WHERE id = ?;`,
+		now, password, userIDs[0])
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertPasswordHistory(ctx, tx, userIDs[0], password); err != nil {
+		return 0, err
+	}
+
+	return userIDs[0], tx.Commit()
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/rate_limit_store_mock.go -package=mock . RateLimitStore
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, capacity int, refillEvery time.Duration, now time.Time) (*model.RateLimit, error)
+}
+
+type tokenBucket struct {
+	tokens      float64
+	capacity    int
+	refillEvery time.Duration
+	updatedAt   time.Time
+}
+
+func (impl *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(impl.updatedAt)
+	if elapsed > 0 {
+		impl.tokens = math.Min(float64(impl.capacity), impl.tokens+float64(elapsed)/float64(impl.refillEvery))
+		impl.updatedAt = now
+	}
+}
+
+type memoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	takes   int
+}
+
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+func (impl *memoryRateLimitStore) Take(ctx context.Context, key string, capacity int, refillEvery time.Duration, now time.Time) (*model.RateLimit, error) {
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	impl.takes++
+	if impl.takes%1000 == 0 {
+		impl.sweep(now)
+	}
+
+	bucket, ok := impl.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(capacity), updatedAt: now}
+		impl.buckets[key] = bucket
+	}
+	bucket.capacity = capacity
+	bucket.refillEvery = refillEvery
+	bucket.refill(now)
+
+	var retryAfter time.Duration
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+	} else {
+		retryAfter = time.Duration((1 - bucket.tokens) * float64(refillEvery))
+	}
+
+	return &model.RateLimit{
+		Limit:      capacity,
+		Remaining:  int(math.Floor(bucket.tokens)),
+		Reset:      time.Duration((float64(capacity) - bucket.tokens) * float64(refillEvery)),
+		RetryAfter: retryAfter,
+	}, nil
+}
+
+func (impl *memoryRateLimitStore) sweep(now time.Time) {
+	for key, bucket := range impl.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= float64(bucket.capacity) {
+			delete(impl.buckets, key)
+		}
+	}
+}
@@ -3,5 +3,6 @@ package repository
 type MySQLErrorCode int
 
 const (
+	MySQLErrorCodeDuplicateEntry       MySQLErrorCode = 1062
 	MySQLErrorCodeForeignKeyConstraint MySQLErrorCode = 1452
 )
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/session_repository_mock.go -package=mock . SessionRepository
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session model.Session) (*model.Session, error)
+	ListSessionsByUserID(ctx context.Context, userID int) ([]model.Session, error)
+	GetSessionByID(ctx context.Context, id int) (*model.Session, error)
+	TouchSession(ctx context.Context, id int, seenAt time.Time) error
+	RevokeSession(ctx context.Context, userID, id int) error
+	RevokeUserSessions(ctx context.Context, userID int) error
+}
+
+type sessionRepository struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (impl *sessionRepository) CreateSession(ctx context.Context, session model.Session) (*model.Session, error) {
+	now := time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO user_sessions
+			(created_at, expires_at, last_seen_at, user_id, device, ip, user_agent)
+			VALUES (?, ?, ?, ?, ?, ?, ?);`,
+		now, session.ExpiresAt, now, session.UserID, session.Device, session.IP, session.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	session.ID = int(id)
+	session.CreatedAt = now
+	session.LastSeenAt = now
+
+	return &session, nil
+}
+
+func (impl *sessionRepository) ListSessionsByUserID(ctx context.Context, userID int) ([]model.Session, error) {
+	sessions := []model.Session{}
+	query := `
+		SELECT id,
+			created_at,
+			expires_at,
+			last_seen_at,
+			revoked_at,
+			user_id,
+			device,
+			ip,
+			user_agent
+		FROM user_sessions
+		WHERE user_id = ?
+			AND revoked_at IS NULL
+			AND expires_at > ?
+		ORDER BY last_seen_at DESC
+	`
+	err := impl.db.SelectContext(ctx, &sessions, query, userID, time.Now())
+
+	return sessions, err
+}
+
+func (impl *sessionRepository) GetSessionByID(ctx context.Context, id int) (*model.Session, error) {
+	var sessions []model.Session
+	query := `
+		SELECT id,
+			created_at,
+			expires_at,
+			last_seen_at,
+			revoked_at,
+			user_id,
+			device,
+			ip,
+			user_agent
+		FROM user_sessions
+		WHERE id = ?
+	`
+	err := impl.db.SelectContext(ctx, &sessions, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sessions) == 0 {
+		return nil, &exception.NotFoundException{Message: "session not found"}
+	}
+
+	return &sessions[0], nil
+}
+
+func (impl *sessionRepository) TouchSession(ctx context.Context, id int, seenAt time.Time) error {
+	_, err := impl.db.ExecContext(ctx, `UPDATE user_sessions
+			SET last_seen_at = ?
+			WHERE id = ?;`,
+		seenAt, id)
+
+	return err
+}
+
+func (impl *sessionRepository) RevokeSession(ctx context.Context, userID, id int) error {
+	res, err := impl.db.ExecContext(ctx, `UPDATE user_sessions
+			SET revoked_at = ?
+			WHERE id = ?
+				AND user_id = ?
+				AND revoked_at IS NULL;`,
+		time.Now(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "session not found"}
+	}
+
+	return nil
+}
+
+func (impl *sessionRepository) RevokeUserSessions(ctx context.Context, userID int) error {
+	_, err := impl.db.ExecContext(ctx, `UPDATE user_sessions
+			SET revoked_at = ?
+			WHERE user_id = ?
+				AND revoked_at IS NULL;`,
+		time.Now(), userID)
+
+	return err
+}
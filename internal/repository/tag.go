@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/tag_repository_mock.go -package=mock . TagRepository
+type TagRepository interface {
+	CreateTag(ctx context.Context, name string) (*model.Tag, error)
+	ListTags(ctx context.Context) ([]model.Tag, error)
+	ListTagsByNames(ctx context.Context, names []string) ([]model.Tag, error)
+	GetTagByID(ctx context.Context, id int) (*model.Tag, error)
+	UpdateTag(ctx context.Context, id int, name string) (*model.Tag, error)
+	DeleteTag(ctx context.Context, id int) error
+}
+
+type tagRepository struct {
+	db *sqlx.DB
+}
+
+func NewTagRepository(db *sqlx.DB) TagRepository {
+	return &tagRepository{
+		db: db,
+	}
+}
+
+func (impl *tagRepository) CreateTag(ctx context.Context, name string) (*model.Tag, error) {
+	now := time.Now()
+	tag := model.Tag{CreatedAt: now, UpdatedAt: now, Name: name}
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO tags
+			(created_at, updated_at, name)
+			VALUES (?, ?, ?);`,
+		tag.CreatedAt, tag.UpdatedAt, tag.Name)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "tag already exists"}
+		}
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	tag.ID = int(id)
+
+	return &tag, nil
+}
+
+// ListTags returns every tag with the number of tasks using it.
+func (impl *tagRepository) ListTags(ctx context.Context) ([]model.Tag, error) {
+	tags := []model.Tag{}
+	query := `
+		SELECT tags.id,
+			tags.created_at,
+			tags.updated_at,
+			tags.name,
+			COUNT(tasks.id) AS usage_count
+		FROM tags
+		LEFT JOIN task_tags ON task_tags.tag_id = tags.id
+		LEFT JOIN tasks ON tasks.id = task_tags.task_id
+			AND tasks.deleted_at IS NULL
+		GROUP BY tags.id
+		ORDER BY tags.name
+	`
+	err := impl.db.SelectContext(ctx, &tags, query)
+
+	return tags, err
+}
+
+func (impl *tagRepository) ListTagsByNames(ctx context.Context, names []string) ([]model.Tag, error) {
+	tags := []model.Tag{}
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	args := []interface{}{}
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			name
+		FROM tags
+		WHERE name IN (?` + strings.Repeat(", ?", len(names)-1) + `)
+		ORDER BY name
+	`
+	err := impl.db.SelectContext(ctx, &tags, query, args...)
+
+	return tags, err
+}
+
+func (impl *tagRepository) GetTagByID(ctx context.Context, id int) (*model.Tag, error) {
+	var tags []model.Tag
+	query := `
+		SELECT tags.id,
+			tags.created_at,
+			tags.updated_at,
+			tags.name,
+			COUNT(tasks.id) AS usage_count
+		FROM tags
+		LEFT JOIN task_tags ON task_tags.tag_id = tags.id
+		LEFT JOIN tasks ON tasks.id = task_tags.task_id
+			AND tasks.deleted_at IS NULL
+		WHERE tags.id = ?
+		GROUP BY tags.id
+	`
+	err := impl.db.SelectContext(ctx, &tags, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, &exception.NotFoundException{Message: "tag not found"}
+	}
+
+	return &tags[0], nil
+}
+
+// UpdateTag leaves GetTagByID to report a missing tag, as renaming a tag to its
+// own name affects no row.
+func (impl *tagRepository) UpdateTag(ctx context.Context, id int, name string) (*model.Tag, error) {
+	_, err := impl.db.ExecContext(ctx, `UPDATE tags
+			SET name = ?,
+				updated_at = ?
+			WHERE id = ?;`,
+		name, time.Now(), id)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "tag already exists"}
+		}
+		return nil, err
+	}
+
+	return impl.GetTagByID(ctx, id)
+}
+
+// DeleteTag removes the tag from every task too.
+func (impl *tagRepository) DeleteTag(ctx context.Context, id int) error {
+	res, err := impl.db.ExecContext(ctx, `DELETE FROM tags WHERE id = ?;`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "tag not found"}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTagRepositoryUpdateTag(t *testing.T) {
+	var cases = map[string]struct {
+		script      []mock.SqlStatement
+		expectedTag *model.Tag
+		expectedErr error
+	}{
+		"should update tag when nothing changes": {
+			script: []mock.SqlStatement{
+				{Query: "UPDATE tags", RowsAffected: 0},
+				{Query: "FROM tags", Columns: []string{"id", "name", "usage_count"}, Rows: [][]driver.Value{{int64(1), "maintenance", int64(2)}}},
+			},
+			expectedTag: &model.Tag{ID: 1, Name: "maintenance", UsageCount: 2},
+		},
+		"should throw not found when tag does not exist": {
+			script: []mock.SqlStatement{
+				{Query: "UPDATE tags", RowsAffected: 0},
+				{Query: "FROM tags", Columns: []string{"id", "name", "usage_count"}},
+			},
+			expectedErr: &exception.NotFoundException{Message: "tag not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			db := mock.NewSqlDB(cs.script...)
+			tagRepository := repository.NewTagRepository(db.DB)
+
+			// when
+			tag, err := tagRepository.UpdateTag(ctx, 1, "maintenance")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTag, tag)
+			assert.Empty(t, db.Remaining())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"bytes"
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
@@ -13,10 +14,69 @@ import (
 
 //go:generate mockgen -destination=../../mock/task_repository_mock.go -package=mock . TaskRepository
 type TaskRepository interface {
-	CreateTask(ctx context.Context, userID int, summary string) (*model.Task, error)
-	ListTasks(ctx context.Context, limit, offset int, opts ...WhereOpt) ([]model.Task, int, error)
+	CreateTask(ctx context.Context, task model.Task) (*model.Task, error)
+	ImportTasks(ctx context.Context, tasks []model.Task) ([]model.Task, error)
+	ListTasks(ctx context.Context, limit, offset int, orderBy string, opts ...WhereOpt) ([]model.Task, int, error)
+	ExportTasks(ctx context.Context, orderBy string, fn func(task model.TaskExport) error, opts ...WhereOpt) error
+	GetTaskByID(ctx context.Context, id int) (*model.Task, error)
+	UpdateTask(ctx context.Context, task model.Task) (*model.Task, error)
+	UpdateTaskAssignee(ctx context.Context, id, assigneeID int) (*model.Task, error)
+	DeleteTask(ctx context.Context, id int) error
+	TransitionTask(ctx context.Context, transition model.TaskTransition) (*model.TaskTransition, error)
+	ListTaskTransitions(ctx context.Context, taskID int) ([]model.TaskTransition, error)
+	IsTaskAncestor(ctx context.Context, ancestorID, id int) (bool, error)
+	CreateTaskDependency(ctx context.Context, dependency model.TaskDependency) (*model.TaskDependency, error)
+	DeleteTaskDependency(ctx context.Context, taskID, blockedByID int) error
+	IsTaskBlockedBy(ctx context.Context, id, blockedByID int) (bool, error)
 }
 
+// taskColumns are the task columns with their tags and dependency summary.
+const taskColumns = `id,
+			created_at,
+			updated_at,
+			deleted_at,
+			user_id,
+			assignee_id,
+			parent_id,
+			summary,
+			status,
+			due_at,
+			priority,
+			(SELECT GROUP_CONCAT(tags.name ORDER BY tags.name)
+				FROM task_tags
+				JOIN tags ON tags.id = task_tags.tag_id
+				WHERE task_tags.task_id = tasks.id) AS tags,
+			(SELECT COUNT(subtasks.id)
+				FROM tasks AS subtasks
+				WHERE subtasks.parent_id = tasks.id
+					AND subtasks.deleted_at IS NULL) AS subtask_count,
+			(SELECT COUNT(subtasks.id)
+				FROM tasks AS subtasks
+				WHERE subtasks.parent_id = tasks.id
+					AND subtasks.status <> 'closed'
+					AND subtasks.deleted_at IS NULL) AS open_subtask_count,
+			(SELECT GROUP_CONCAT(task_dependencies.blocked_by_id ORDER BY task_dependencies.blocked_by_id)
+				FROM task_dependencies
+				WHERE task_dependencies.task_id = tasks.id) AS blocked_by,
+			(SELECT GROUP_CONCAT(task_dependencies.task_id ORDER BY task_dependencies.task_id)
+				FROM task_dependencies
+				WHERE task_dependencies.blocked_by_id = tasks.id) AS blocks`
+
+// selectTasks reads the tasks with their tags and dependency summary.
+const selectTasks = `
+		SELECT ` + taskColumns + `
+		FROM tasks
+`
+
+// selectTaskExports reads the tasks along with the username and email of
+// their owner.
+const selectTaskExports = `
+		SELECT ` + taskColumns + `,
+			COALESCE((SELECT users.username FROM users WHERE users.id = tasks.user_id), '') AS owner_username,
+			COALESCE((SELECT users.email FROM users WHERE users.id = tasks.user_id), '') AS owner_email
+		FROM tasks
+`
+
 type taskRepository struct {
 	db *sqlx.DB
 }
@@ -27,13 +87,26 @@ func NewTaskRepository(db *sqlx.DB) TaskRepository {
 	}
 }
 
-func (impl *taskRepository) CreateTask(ctx context.Context, userID int, summary string) (*model.Task, error) {
+func (impl *taskRepository) CreateTask(ctx context.Context, task model.Task) (*model.Task, error) {
 	now := time.Now()
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	task.Status = model.TaskStatusOpened
 
-	res, err := impl.db.ExecContext(ctx, `INSERT INTO tasks
-			(created_at, updated_at, user_id, summary, status)
-			VALUES (?, ?, ?, ?, ?);`,
-		now, now, userID, summary, model.TaskStatusOpened)
+	if task.Tags == nil {
+		task.Tags = model.TaskTags{}
+	}
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO tasks
+			(created_at, updated_at, user_id, assignee_id, parent_id, summary, status, due_at, priority)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		task.CreatedAt, task.UpdatedAt, task.UserID, task.AssigneeID, task.ParentID, task.Summary, task.Status, task.DueAt, task.Priority)
 	if err != nil {
 		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeForeignKeyConstraint) {
 			err = &exception.ForeignKeyConstraintException{Message: "user not found"}
@@ -46,37 +119,98 @@ func (impl *taskRepository) CreateTask(ctx context.Context, userID int, summary
 		return nil, err
 	}
 
-	return &model.Task{
-		ID:        int(id),
-		CreatedAt: now,
-		UpdatedAt: now,
-		UserID:    userID,
-		Summary:   summary,
-		Status:    model.TaskStatusOpened,
-	}, nil
+	task.ID = int(id)
+
+	if err := impl.setTaskTags(ctx, tx, task.ID, task.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// ImportTasks creates the tasks as they are, keeping their status and
+// created_at, in a single transaction: either every task is created or none.
+// A task imported in another status than opened gets the transition to it, as
+// if it had been moved there.
+func (impl *taskRepository) ImportTasks(ctx context.Context, tasks []model.Task) ([]model.Task, error) {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	imported := []model.Task{}
+	for _, task := range tasks {
+		if task.CreatedAt.IsZero() {
+			task.CreatedAt = now
+		}
+		task.UpdatedAt = now
+		if task.Tags == nil {
+			task.Tags = model.TaskTags{}
+		}
+
+		res, err := tx.ExecContext(ctx, `INSERT INTO tasks
+				(created_at, updated_at, user_id, assignee_id, parent_id, summary, status, due_at, priority)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+			task.CreatedAt, task.UpdatedAt, task.UserID, task.AssigneeID, task.ParentID, task.Summary, task.Status, task.DueAt, task.Priority)
+		if err != nil {
+			if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeForeignKeyConstraint) {
+				err = &exception.ForeignKeyConstraintException{Message: "user not found"}
+			}
+			return nil, err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		task.ID = int(id)
+
+		if err := impl.setTaskTags(ctx, tx, task.ID, task.Tags); err != nil {
+			return nil, err
+		}
+
+		if task.Status != model.TaskStatusOpened {
+			_, err = tx.ExecContext(ctx, `INSERT INTO task_transitions
+					(created_at, task_id, user_id, from_status, to_status)
+					VALUES (?, ?, ?, ?, ?);`,
+				now, task.ID, task.UserID, model.TaskStatusOpened, task.Status)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		imported = append(imported, task)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return imported, nil
 }
 
-func (impl *taskRepository) ListTasks(ctx context.Context, limit, offset int, opts ...WhereOpt) ([]model.Task, int, error) {
+func (impl *taskRepository) ListTasks(ctx context.Context, limit, offset int, orderBy string, opts ...WhereOpt) ([]model.Task, int, error) {
 	var tasks []model.Task
 	total := 0
 
 	var query bytes.Buffer
-	query.WriteString(`
-		SELECT id,
-			created_at,
-			updated_at,
-			deleted_at,
-			user_id,
-			summary,
-			status
-		FROM tasks
-	`)
+	query.WriteString(selectTasks)
 
 	args := []interface{}{}
 	if len(opts) > 0 {
 		query.WriteString(opts[0].Query())
 		args = append(args, opts[0].Values()...)
 	}
+	if orderBy != "" {
+		query.WriteString("\nORDER BY " + orderBy)
+	}
 	if limit > 0 {
 		query.WriteString("\nLIMIT ?")
 		args = append(args, limit)
@@ -109,3 +243,340 @@ func (impl *taskRepository) ListTasks(ctx context.Context, limit, offset int, op
 
 	return tasks, total, err
 }
+
+// ExportTasks hands the tasks to fn as they are read, one row at a time, so
+// an export never holds every task in memory. An error from fn stops it.
+func (impl *taskRepository) ExportTasks(ctx context.Context, orderBy string, fn func(task model.TaskExport) error, opts ...WhereOpt) error {
+	var query bytes.Buffer
+	query.WriteString(selectTaskExports)
+
+	args := []interface{}{}
+	if len(opts) > 0 {
+		query.WriteString(opts[0].Query())
+		args = append(args, opts[0].Values()...)
+	}
+	if orderBy != "" {
+		query.WriteString("\nORDER BY " + orderBy)
+	}
+
+	rows, err := impl.db.QueryxContext(ctx, query.String(), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task model.TaskExport
+		if err := rows.StructScan(&task); err != nil {
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (impl *taskRepository) GetTaskByID(ctx context.Context, id int) (*model.Task, error) {
+	var tasks []model.Task
+	query := selectTasks + `
+		WHERE id = ?
+			AND deleted_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &tasks, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, &exception.NotFoundException{Message: "task not found"}
+	}
+
+	return &tasks[0], nil
+}
+
+// UpdateTaskAssignee leaves GetTaskByID to report a missing task, as assigning
+// the same user again affects no row.
+func (impl *taskRepository) UpdateTaskAssignee(ctx context.Context, id, assigneeID int) (*model.Task, error) {
+	_, err := impl.db.ExecContext(ctx, `UPDATE tasks
+			SET assignee_id = ?,
+				updated_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		assigneeID, time.Now(), id)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeForeignKeyConstraint) {
+			err = &exception.ForeignKeyConstraintException{Message: "user not found"}
+		}
+		return nil, err
+	}
+
+	return impl.GetTaskByID(ctx, id)
+}
+
+// UpdateTask replaces the task tags with task.Tags. The task is locked before
+// the update, as an update that changes nothing affects no row and can not
+// tell a missing task apart.
+func (impl *taskRepository) UpdateTask(ctx context.Context, task model.Task) (*model.Task, error) {
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var ids []int
+	err = tx.SelectContext(ctx, &ids, `SELECT id
+			FROM tasks
+			WHERE id = ?
+				AND deleted_at IS NULL
+			FOR UPDATE;`,
+		task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, &exception.NotFoundException{Message: "task not found"}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE tasks
+			SET summary = ?,
+				parent_id = ?,
+				due_at = ?,
+				priority = ?,
+				updated_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		task.Summary, task.ParentID, task.DueAt, task.Priority, time.Now(), task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := impl.setTaskTags(ctx, tx, task.ID, task.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return impl.GetTaskByID(ctx, task.ID)
+}
+
+// DeleteTask soft deletes the task and drops its dependencies, so it no
+// longer blocks other tasks.
+func (impl *taskRepository) DeleteTask(ctx context.Context, id int) error {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE tasks
+			SET deleted_at = ?,
+				updated_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		now, now, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "task not found"}
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM task_dependencies
+			WHERE task_id = ?
+				OR blocked_by_id = ?;`,
+		id, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TransitionTask moves the task from transition.FromStatus to
+// transition.ToStatus and records it. It fails with a conflict when the task
+// is no longer in FromStatus.
+func (impl *taskRepository) TransitionTask(ctx context.Context, transition model.TaskTransition) (*model.TaskTransition, error) {
+	transition.CreatedAt = time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE tasks
+			SET status = ?,
+				updated_at = ?
+			WHERE id = ?
+				AND status = ?
+				AND deleted_at IS NULL;`,
+		transition.ToStatus, transition.CreatedAt, transition.TaskID, transition.FromStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, &exception.ConflictException{Message: "task status has changed"}
+	}
+
+	res, err = tx.ExecContext(ctx, `INSERT INTO task_transitions
+			(created_at, task_id, user_id, from_status, to_status)
+			VALUES (?, ?, ?, ?, ?);`,
+		transition.CreatedAt, transition.TaskID, transition.UserID, transition.FromStatus, transition.ToStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	transition.ID = int(id)
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &transition, nil
+}
+
+func (impl *taskRepository) ListTaskTransitions(ctx context.Context, taskID int) ([]model.TaskTransition, error) {
+	transitions := []model.TaskTransition{}
+	query := `
+		SELECT id,
+			created_at,
+			task_id,
+			user_id,
+			from_status,
+			to_status
+		FROM task_transitions
+		WHERE task_id = ?
+		ORDER BY created_at, id
+	`
+	err := impl.db.SelectContext(ctx, &transitions, query, taskID)
+
+	return transitions, err
+}
+
+// setTaskTags links the task to the tags with the given names, dropping the
+// links to any other tag. Unknown names are ignored.
+func (impl *taskRepository) setTaskTags(ctx context.Context, tx *sqlx.Tx, taskID int, names []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_tags WHERE task_id = ?;`, taskID); err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	args := []interface{}{taskID}
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO task_tags
+			(task_id, tag_id)
+			SELECT ?, id
+			FROM tags
+			WHERE name IN (?`+strings.Repeat(", ?", len(names)-1)+`);`,
+		args...)
+
+	return err
+}
+
+// IsTaskAncestor tells whether ancestorID is the parent of id, or the parent
+// of one of its ancestors.
+func (impl *taskRepository) IsTaskAncestor(ctx context.Context, ancestorID, id int) (bool, error) {
+	total := 0
+	err := impl.db.GetContext(ctx, &total, `
+		WITH RECURSIVE ancestors (id) AS (
+			SELECT parent_id
+			FROM tasks
+			WHERE id = ?
+				AND parent_id IS NOT NULL
+			UNION
+			SELECT tasks.parent_id
+			FROM tasks
+			JOIN ancestors ON tasks.id = ancestors.id
+			WHERE tasks.parent_id IS NOT NULL
+		)
+		SELECT COUNT(id) AS total
+		FROM ancestors
+		WHERE id = ?
+	`, id, ancestorID)
+
+	return total > 0, err
+}
+
+func (impl *taskRepository) CreateTaskDependency(ctx context.Context, dependency model.TaskDependency) (*model.TaskDependency, error) {
+	dependency.CreatedAt = time.Now()
+
+	_, err := impl.db.ExecContext(ctx, `INSERT INTO task_dependencies
+			(task_id, blocked_by_id, created_at, user_id)
+			VALUES (?, ?, ?, ?);`,
+		dependency.TaskID, dependency.BlockedByID, dependency.CreatedAt, dependency.UserID)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "dependency already exists"}
+		}
+		return nil, err
+	}
+
+	return &dependency, nil
+}
+
+func (impl *taskRepository) DeleteTaskDependency(ctx context.Context, taskID, blockedByID int) error {
+	res, err := impl.db.ExecContext(ctx, `DELETE FROM task_dependencies
+			WHERE task_id = ?
+				AND blocked_by_id = ?;`,
+		taskID, blockedByID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "dependency not found"}
+	}
+
+	return nil
+}
+
+// IsTaskBlockedBy tells whether id is blocked by blockedByID, directly or
+// through other dependencies.
+func (impl *taskRepository) IsTaskBlockedBy(ctx context.Context, id, blockedByID int) (bool, error) {
+	total := 0
+	err := impl.db.GetContext(ctx, &total, `
+		WITH RECURSIVE blockers (id) AS (
+			SELECT blocked_by_id
+			FROM task_dependencies
+			WHERE task_id = ?
+			UNION
+			SELECT task_dependencies.blocked_by_id
+			FROM task_dependencies
+			JOIN blockers ON task_dependencies.task_id = blockers.id
+		)
+		SELECT COUNT(id) AS total
+		FROM blockers
+		WHERE id = ?
+	`, id, blockedByID)
+
+	return total > 0, err
+}
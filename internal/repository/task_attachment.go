@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/task_attachment_repository_mock.go -package=mock . TaskAttachmentRepository
+type TaskAttachmentRepository interface {
+	CreateTaskAttachment(ctx context.Context, attachment model.TaskAttachment) (*model.TaskAttachment, error)
+	ListTaskAttachments(ctx context.Context, taskID int) ([]model.TaskAttachment, error)
+	GetTaskAttachmentByID(ctx context.Context, taskID, id int) (*model.TaskAttachment, error)
+	DeleteTaskAttachment(ctx context.Context, id int) error
+}
+
+type taskAttachmentRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaskAttachmentRepository(db *sqlx.DB) TaskAttachmentRepository {
+	return &taskAttachmentRepository{
+		db: db,
+	}
+}
+
+func (impl *taskAttachmentRepository) CreateTaskAttachment(ctx context.Context, attachment model.TaskAttachment) (*model.TaskAttachment, error) {
+	attachment.CreatedAt = time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO task_attachments
+			(created_at, task_id, user_id, filename, content_type, size, storage_key)
+			VALUES (?, ?, ?, ?, ?, ?, ?);`,
+		attachment.CreatedAt, attachment.TaskID, attachment.UserID, attachment.Filename, attachment.ContentType,
+		attachment.Size, attachment.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	attachment.ID = int(id)
+
+	return &attachment, nil
+}
+
+func (impl *taskAttachmentRepository) ListTaskAttachments(ctx context.Context, taskID int) ([]model.TaskAttachment, error) {
+	attachments := []model.TaskAttachment{}
+	query := `
+		SELECT id,
+			created_at,
+			deleted_at,
+			task_id,
+			user_id,
+			filename,
+			content_type,
+			size,
+			storage_key
+		FROM task_attachments
+		WHERE task_id = ?
+			AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+	err := impl.db.SelectContext(ctx, &attachments, query, taskID)
+
+	return attachments, err
+}
+
+func (impl *taskAttachmentRepository) GetTaskAttachmentByID(ctx context.Context, taskID, id int) (*model.TaskAttachment, error) {
+	var attachments []model.TaskAttachment
+	query := `
+		SELECT id,
+			created_at,
+			deleted_at,
+			task_id,
+			user_id,
+			filename,
+			content_type,
+			size,
+			storage_key
+		FROM task_attachments
+		WHERE id = ?
+			AND task_id = ?
+			AND deleted_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &attachments, query, id, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) == 0 {
+		return nil, &exception.NotFoundException{Message: "attachment not found"}
+	}
+
+	return &attachments[0], nil
+}
+
+func (impl *taskAttachmentRepository) DeleteTaskAttachment(ctx context.Context, id int) error {
+	res, err := impl.db.ExecContext(ctx, `UPDATE task_attachments
+			SET deleted_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "attachment not found"}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/task_comment_repository_mock.go -package=mock . TaskCommentRepository
+type TaskCommentRepository interface {
+	CreateTaskComment(ctx context.Context, comment model.TaskComment) (*model.TaskComment, error)
+	ListTaskComments(ctx context.Context, taskID, limit, offset int) ([]model.TaskComment, int, error)
+	GetTaskCommentByID(ctx context.Context, taskID, id int) (*model.TaskComment, error)
+	UpdateTaskComment(ctx context.Context, id int, body string) (*model.TaskComment, error)
+	DeleteTaskComment(ctx context.Context, id int) error
+	ListTaskCommenterIDs(ctx context.Context, taskID int) ([]int, error)
+}
+
+type taskCommentRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaskCommentRepository(db *sqlx.DB) TaskCommentRepository {
+	return &taskCommentRepository{
+		db: db,
+	}
+}
+
+func (impl *taskCommentRepository) CreateTaskComment(ctx context.Context, comment model.TaskComment) (*model.TaskComment, error) {
+	now := time.Now()
+	comment.CreatedAt = now
+	comment.UpdatedAt = now
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO task_comments
+			(created_at, updated_at, task_id, user_id, body)
+			VALUES (?, ?, ?, ?, ?);`,
+		comment.CreatedAt, comment.UpdatedAt, comment.TaskID, comment.UserID, comment.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	comment.ID = int(id)
+
+	return &comment, nil
+}
+
+func (impl *taskCommentRepository) ListTaskComments(ctx context.Context, taskID, limit, offset int) ([]model.TaskComment, int, error) {
+	comments := []model.TaskComment{}
+	total := 0
+
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			deleted_at,
+			task_id,
+			user_id,
+			body
+		FROM task_comments
+		WHERE task_id = ?
+			AND deleted_at IS NULL
+		ORDER BY created_at, id
+		LIMIT ?
+		OFFSET ?
+	`
+	err := impl.db.SelectContext(ctx, &comments, query, taskID, limit, offset)
+	if err != nil {
+		return comments, total, err
+	}
+
+	row := impl.db.QueryRowContext(ctx, `
+		SELECT COUNT(id) as total
+		FROM task_comments
+		WHERE task_id = ?
+			AND deleted_at IS NULL
+	`, taskID)
+	err = row.Err()
+	row.Scan(&total)
+
+	return comments, total, err
+}
+
+func (impl *taskCommentRepository) GetTaskCommentByID(ctx context.Context, taskID, id int) (*model.TaskComment, error) {
+	var comments []model.TaskComment
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			deleted_at,
+			task_id,
+			user_id,
+			body
+		FROM task_comments
+		WHERE id = ?
+			AND task_id = ?
+			AND deleted_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &comments, query, id, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, &exception.NotFoundException{Message: "comment not found"}
+	}
+
+	return &comments[0], nil
+}
+
+// UpdateTaskComment reports a missing comment from the read that follows the
+// update, as saving a comment unchanged affects no row.
+func (impl *taskCommentRepository) UpdateTaskComment(ctx context.Context, id int, body string) (*model.TaskComment, error) {
+	_, err := impl.db.ExecContext(ctx, `UPDATE task_comments
+			SET body = ?,
+				updated_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		body, time.Now(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []model.TaskComment
+	err = impl.db.SelectContext(ctx, &comments, `
+		SELECT id,
+			created_at,
+			updated_at,
+			deleted_at,
+			task_id,
+			user_id,
+			body
+		FROM task_comments
+		WHERE id = ?
+			AND deleted_at IS NULL
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, &exception.NotFoundException{Message: "comment not found"}
+	}
+
+	return &comments[0], nil
+}
+
+func (impl *taskCommentRepository) DeleteTaskComment(ctx context.Context, id int) error {
+	now := time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `UPDATE task_comments
+			SET updated_at = ?, deleted_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		now, now, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "comment not found"}
+	}
+
+	return nil
+}
+
+func (impl *taskCommentRepository) ListTaskCommenterIDs(ctx context.Context, taskID int) ([]int, error) {
+	ids := []int{}
+	query := `
+		SELECT DISTINCT user_id
+		FROM task_comments
+		WHERE task_id = ?
+			AND deleted_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &ids, query, taskID)
+
+	return ids, err
+}
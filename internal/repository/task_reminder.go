@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/task_reminder_repository_mock.go -package=mock . TaskReminderRepository
+type TaskReminderRepository interface {
+	ListTasksToRemind(ctx context.Context, threshold model.TaskReminderThreshold, dueAfter, dueBefore time.Time) ([]model.Task, error)
+	CreateTaskReminder(ctx context.Context, reminder model.TaskReminder) (*model.TaskReminder, error)
+}
+
+type taskReminderRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaskReminderRepository(db *sqlx.DB) TaskReminderRepository {
+	return &taskReminderRepository{
+		db: db,
+	}
+}
+
+// ListTasksToRemind lists the unfinished tasks due in (dueAfter, dueBefore]
+// that have not been reminded about threshold for their current due date.
+func (impl *taskReminderRepository) ListTasksToRemind(ctx context.Context, threshold model.TaskReminderThreshold, dueAfter, dueBefore time.Time) ([]model.Task, error) {
+	tasks := []model.Task{}
+	query := `
+		SELECT t.id,
+			t.created_at,
+			t.updated_at,
+			t.deleted_at,
+			t.user_id,
+			t.assignee_id,
+			t.summary,
+			t.status,
+			t.due_at,
+			t.priority
+		FROM tasks t
+		LEFT JOIN task_reminders r
+			ON r.task_id = t.id
+				AND r.threshold = ?
+				AND r.due_at = t.due_at
+		WHERE t.due_at > ?
+			AND t.due_at <= ?
+			AND t.status <> ?
+			AND t.deleted_at IS NULL
+			AND r.id IS NULL
+		ORDER BY t.due_at, t.id
+	`
+	err := impl.db.SelectContext(ctx, &tasks, query, threshold, dueAfter, dueBefore, model.TaskStatusClosed)
+
+	return tasks, err
+}
+
+// CreateTaskReminder claims the reminder before it is sent, so a reminder
+// already claimed by another run fails with a conflict.
+func (impl *taskReminderRepository) CreateTaskReminder(ctx context.Context, reminder model.TaskReminder) (*model.TaskReminder, error) {
+	reminder.CreatedAt = time.Now()
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO task_reminders
+			(created_at, task_id, threshold, due_at)
+			VALUES (?, ?, ?, ?);`,
+		reminder.CreatedAt, reminder.TaskID, reminder.Threshold, reminder.DueAt)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "task reminder already sent"}
+		}
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	reminder.ID = int(id)
+
+	return &reminder, nil
+}
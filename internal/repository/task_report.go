@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+var taskReportIntervals = map[model.TaskReportInterval]string{
+	model.TaskReportIntervalDay:  "DATE_FORMAT(%s, '%%Y-%%m-%%d')",
+	model.TaskReportIntervalWeek: "DATE_FORMAT(DATE_SUB(%[1]s, INTERVAL WEEKDAY(%[1]s) DAY), '%%Y-%%m-%%d')",
+}
+
+//go:generate mockgen -destination=../../mock/task_report_repository_mock.go -package=mock . TaskReportRepository
+type TaskReportRepository interface {
+	ReportTasks(ctx context.Context, from, to time.Time, interval model.TaskReportInterval) (*model.TaskReport, error)
+}
+
+type taskReportRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaskReportRepository(db *sqlx.DB) TaskReportRepository {
+	return &taskReportRepository{
+		db: db,
+	}
+}
+
+// ReportTasks aggregates the tasks between from and to, to excluded, in a
+// read only transaction so every figure comes from the same snapshot.
+func (impl *taskReportRepository) ReportTasks(ctx context.Context, from, to time.Time, interval model.TaskReportInterval) (*model.TaskReport, error) {
+	tx, err := impl.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	report := &model.TaskReport{
+		Statuses:    []model.TaskStatusCount{},
+		Periods:     []model.TaskPeriodCount{},
+		Technicians: []model.TechnicianTaskStats{},
+	}
+
+	err = tx.SelectContext(ctx, &report.Statuses, `
+		SELECT status,
+			COUNT(*) AS total
+		FROM tasks
+		WHERE deleted_at IS NULL
+			AND created_at >= ?
+			AND created_at < ?
+		GROUP BY status
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.SelectContext(ctx, &report.Periods, `
+		SELECT period,
+			SUM(created) AS created,
+			SUM(closed) AS closed
+		FROM (
+			SELECT `+fmt.Sprintf(taskReportIntervals[interval], "created_at")+` AS period,
+				COUNT(*) AS created,
+				0 AS closed
+			FROM tasks
+			WHERE deleted_at IS NULL
+				AND created_at >= ?
+				AND created_at < ?
+			GROUP BY period
+			UNION ALL
+			SELECT `+fmt.Sprintf(taskReportIntervals[interval], "task_transitions.created_at")+` AS period,
+				0 AS created,
+				COUNT(*) AS closed
+			FROM task_transitions
+			JOIN tasks ON tasks.id = task_transitions.task_id
+			WHERE task_transitions.to_status = ?
+				AND task_transitions.created_at >= ?
+				AND task_transitions.created_at < ?
+				AND tasks.deleted_at IS NULL
+			GROUP BY period
+		) AS events
+		GROUP BY period
+		ORDER BY period
+	`, from, to, model.TaskStatusClosed, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.GetContext(ctx, &report.Closed, `
+		SELECT COUNT(*) AS total,
+			AVG(TIMESTAMPDIFF(SECOND, tasks.created_at, task_transitions.created_at)) AS mean_seconds
+		FROM task_transitions
+		JOIN tasks ON tasks.id = task_transitions.task_id
+		WHERE task_transitions.to_status = ?
+			AND task_transitions.created_at >= ?
+			AND task_transitions.created_at < ?
+			AND tasks.deleted_at IS NULL
+	`, model.TaskStatusClosed, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.SelectContext(ctx, &report.Technicians, `
+		SELECT users.id AS user_id,
+			users.username,
+			COALESCE(assigned.total, 0) AS assigned,
+			COALESCE(closed.total, 0) AS closed,
+			closed.mean_seconds,
+			COALESCE(logged.seconds, 0) AS logged_seconds
+		FROM users
+		LEFT JOIN (
+			SELECT assignee_id,
+				COUNT(*) AS total
+			FROM tasks
+			WHERE deleted_at IS NULL
+				AND created_at >= ?
+				AND created_at < ?
+			GROUP BY assignee_id
+		) AS assigned ON assigned.assignee_id = users.id
+		LEFT JOIN (
+			SELECT tasks.assignee_id,
+				COUNT(*) AS total,
+				AVG(TIMESTAMPDIFF(SECOND, tasks.created_at, task_transitions.created_at)) AS mean_seconds
+			FROM task_transitions
+			JOIN tasks ON tasks.id = task_transitions.task_id
+			WHERE task_transitions.to_status = ?
+				AND task_transitions.created_at >= ?
+				AND task_transitions.created_at < ?
+				AND tasks.deleted_at IS NULL
+			GROUP BY tasks.assignee_id
+		) AS closed ON closed.assignee_id = users.id
+		LEFT JOIN (
+			SELECT user_id,
+				SUM(TIMESTAMPDIFF(SECOND, started_at, ended_at)) AS seconds
+			FROM task_time_entries
+			WHERE ended_at IS NOT NULL
+				AND started_at >= ?
+				AND started_at < ?
+			GROUP BY user_id
+		) AS logged ON logged.user_id = users.id
+		WHERE users.role = ?
+			AND users.deleted_at IS NULL
+		ORDER BY users.id
+	`, from, to, model.TaskStatusClosed, from, to, from, to, model.UserRoleTechnician)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, tx.Commit()
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+const selectTaskSchedules = `
+	SELECT id,
+		created_at,
+		updated_at,
+		deleted_at,
+		user_id,
+		assignee_id,
+		summary,
+		priority,
+		cron,
+		enabled,
+		next_run_at,
+		last_run_at
+	FROM task_schedules
+`
+
+//go:generate mockgen -destination=../../mock/task_schedule_repository_mock.go -package=mock . TaskScheduleRepository
+type TaskScheduleRepository interface {
+	CreateTaskSchedule(ctx context.Context, schedule model.TaskSchedule) (*model.TaskSchedule, error)
+	ListTaskSchedules(ctx context.Context) ([]model.TaskSchedule, error)
+	GetTaskScheduleByID(ctx context.Context, id int) (*model.TaskSchedule, error)
+	UpdateTaskSchedule(ctx context.Context, schedule model.TaskSchedule) (*model.TaskSchedule, error)
+	DeleteTaskSchedule(ctx context.Context, id int) error
+
+	AcquireDueTaskSchedules(ctx context.Context, owner string, now, leaseUntil time.Time, limit int) ([]model.TaskSchedule, error)
+	ReleaseTaskSchedule(ctx context.Context, id int, owner string, nextRunAt time.Time, lastRunAt *time.Time) error
+	CreateTaskScheduleRun(ctx context.Context, run model.TaskScheduleRun) error
+	SetTaskScheduleRunTask(ctx context.Context, scheduleID int, runAt time.Time, taskID int) error
+}
+
+type taskScheduleRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaskScheduleRepository(db *sqlx.DB) TaskScheduleRepository {
+	return &taskScheduleRepository{
+		db: db,
+	}
+}
+
+func (impl *taskScheduleRepository) CreateTaskSchedule(ctx context.Context, schedule model.TaskSchedule) (*model.TaskSchedule, error) {
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO task_schedules
+			(created_at, updated_at, user_id, assignee_id, summary, priority, cron, enabled, next_run_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		schedule.CreatedAt, schedule.UpdatedAt, schedule.UserID, schedule.AssigneeID, schedule.Summary,
+		schedule.Priority, schedule.Cron, schedule.Enabled, schedule.NextRunAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	schedule.ID = int(id)
+
+	return &schedule, nil
+}
+
+func (impl *taskScheduleRepository) ListTaskSchedules(ctx context.Context) ([]model.TaskSchedule, error) {
+	schedules := []model.TaskSchedule{}
+	query := selectTaskSchedules + `
+		WHERE deleted_at IS NULL
+		ORDER BY id
+	`
+	err := impl.db.SelectContext(ctx, &schedules, query)
+
+	return schedules, err
+}
+
+func (impl *taskScheduleRepository) GetTaskScheduleByID(ctx context.Context, id int) (*model.TaskSchedule, error) {
+	var schedules []model.TaskSchedule
+	query := selectTaskSchedules + `
+		WHERE id = ?
+			AND deleted_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &schedules, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedules) == 0 {
+		return nil, &exception.NotFoundException{Message: "task schedule not found"}
+	}
+
+	return &schedules[0], nil
+}
+
+// UpdateTaskSchedule leaves GetTaskScheduleByID to report a missing schedule,
+// as saving a schedule unchanged affects no row.
+func (impl *taskScheduleRepository) UpdateTaskSchedule(ctx context.Context, schedule model.TaskSchedule) (*model.TaskSchedule, error) {
+	_, err := impl.db.ExecContext(ctx, `UPDATE task_schedules
+			SET assignee_id = ?,
+				summary = ?,
+				priority = ?,
+				cron = ?,
+				enabled = ?,
+				next_run_at = ?,
+				updated_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		schedule.AssigneeID, schedule.Summary, schedule.Priority, schedule.Cron, schedule.Enabled,
+		schedule.NextRunAt, time.Now(), schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return impl.GetTaskScheduleByID(ctx, schedule.ID)
+}
+
+func (impl *taskScheduleRepository) DeleteTaskSchedule(ctx context.Context, id int) error {
+	res, err := impl.db.ExecContext(ctx, `UPDATE task_schedules
+			SET deleted_at = ?
+			WHERE id = ?
+				AND deleted_at IS NULL;`,
+		time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "task schedule not found"}
+	}
+
+	return nil
+}
+
+// AcquireDueTaskSchedules leases up to limit due schedules to owner until
+// leaseUntil. A schedule leased by another owner is skipped until its lease
+// expires, so replicas never run the same schedule at the same time.
+func (impl *taskScheduleRepository) AcquireDueTaskSchedules(ctx context.Context, owner string, now, leaseUntil time.Time, limit int) ([]model.TaskSchedule, error) {
+	_, err := impl.db.ExecContext(ctx, `UPDATE task_schedules
+			SET locked_by = ?,
+				locked_until = ?
+			WHERE enabled = TRUE
+				AND deleted_at IS NULL
+				AND next_run_at <= ?
+				AND (locked_until IS NULL OR locked_until < ?)
+			ORDER BY next_run_at
+			LIMIT ?;`,
+		owner, leaseUntil, now, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []model.TaskSchedule{}
+	query := selectTaskSchedules + `
+		WHERE locked_by = ?
+			AND locked_until >= ?
+			AND deleted_at IS NULL
+		ORDER BY next_run_at, id
+	`
+	err = impl.db.SelectContext(ctx, &schedules, query, owner, now)
+
+	return schedules, err
+}
+
+// ReleaseTaskSchedule moves the schedule to its next run and drops the
+// lease, unless the lease was lost to another owner in the meantime.
+func (impl *taskScheduleRepository) ReleaseTaskSchedule(ctx context.Context, id int, owner string, nextRunAt time.Time, lastRunAt *time.Time) error {
+	_, err := impl.db.ExecContext(ctx, `UPDATE task_schedules
+			SET next_run_at = ?,
+				last_run_at = COALESCE(?, last_run_at),
+				locked_by = NULL,
+				locked_until = NULL
+			WHERE id = ?
+				AND locked_by = ?;`,
+		nextRunAt, lastRunAt, id, owner)
+
+	return err
+}
+
+// CreateTaskScheduleRun claims an occurrence before its task is created, so
+// an occurrence already claimed by another run fails with a conflict.
+func (impl *taskScheduleRepository) CreateTaskScheduleRun(ctx context.Context, run model.TaskScheduleRun) error {
+	run.CreatedAt = time.Now()
+
+	_, err := impl.db.ExecContext(ctx, `INSERT INTO task_schedule_runs
+			(schedule_id, run_at, created_at)
+			VALUES (?, ?, ?);`,
+		run.ScheduleID, run.RunAt, run.CreatedAt)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "task schedule run already claimed"}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (impl *taskScheduleRepository) SetTaskScheduleRunTask(ctx context.Context, scheduleID int, runAt time.Time, taskID int) error {
+	_, err := impl.db.ExecContext(ctx, `UPDATE task_schedule_runs
+			SET task_id = ?
+			WHERE schedule_id = ?
+				AND run_at = ?;`,
+		taskID, scheduleID, runAt)
+
+	return err
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+//go:generate mockgen -destination=../../mock/task_template_repository_mock.go -package=mock . TaskTemplateRepository
+type TaskTemplateRepository interface {
+	CreateTaskTemplate(ctx context.Context, template model.TaskTemplate) (*model.TaskTemplate, error)
+	ListTaskTemplates(ctx context.Context) ([]model.TaskTemplate, error)
+	GetTaskTemplateByID(ctx context.Context, id int) (*model.TaskTemplate, error)
+	UpdateTaskTemplate(ctx context.Context, template model.TaskTemplate) (*model.TaskTemplate, error)
+	DeleteTaskTemplate(ctx context.Context, id int) error
+}
+
+type taskTemplateRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaskTemplateRepository(db *sqlx.DB) TaskTemplateRepository {
+	return &taskTemplateRepository{
+		db: db,
+	}
+}
+
+func (impl *taskTemplateRepository) CreateTaskTemplate(ctx context.Context, template model.TaskTemplate) (*model.TaskTemplate, error) {
+	now := time.Now()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO task_templates
+			(created_at, updated_at, user_id, name, summary, priority)
+			VALUES (?, ?, ?, ?, ?, ?);`,
+		template.CreatedAt, template.UpdatedAt, template.UserID, template.Name, template.Summary, template.Priority)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "task template already exists"}
+		}
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	template.ID = int(id)
+
+	return &template, nil
+}
+
+func (impl *taskTemplateRepository) ListTaskTemplates(ctx context.Context) ([]model.TaskTemplate, error) {
+	templates := []model.TaskTemplate{}
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			user_id,
+			name,
+			summary,
+			priority
+		FROM task_templates
+		ORDER BY name
+	`
+	err := impl.db.SelectContext(ctx, &templates, query)
+
+	return templates, err
+}
+
+func (impl *taskTemplateRepository) GetTaskTemplateByID(ctx context.Context, id int) (*model.TaskTemplate, error) {
+	var templates []model.TaskTemplate
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			user_id,
+			name,
+			summary,
+			priority
+		FROM task_templates
+		WHERE id = ?
+	`
+	err := impl.db.SelectContext(ctx, &templates, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) == 0 {
+		return nil, &exception.NotFoundException{Message: "task template not found"}
+	}
+
+	return &templates[0], nil
+}
+
+// UpdateTaskTemplate leaves GetTaskTemplateByID to report a missing template,
+// as saving a template unchanged affects no row.
+func (impl *taskTemplateRepository) UpdateTaskTemplate(ctx context.Context, template model.TaskTemplate) (*model.TaskTemplate, error) {
+	_, err := impl.db.ExecContext(ctx, `UPDATE task_templates
+			SET name = ?,
+				summary = ?,
+				priority = ?,
+				updated_at = ?
+			WHERE id = ?;`,
+		template.Name, template.Summary, template.Priority, time.Now(), template.ID)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "task template already exists"}
+		}
+		return nil, err
+	}
+
+	return impl.GetTaskTemplateByID(ctx, template.ID)
+}
+
+// DeleteTaskTemplate keeps the tasks created from the template.
+func (impl *taskTemplateRepository) DeleteTaskTemplate(ctx context.Context, id int) error {
+	res, err := impl.db.ExecContext(ctx, `DELETE FROM task_templates WHERE id = ?;`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "task template not found"}
+	}
+
+	return nil
+}
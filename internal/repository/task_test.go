@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskRepositoryUpdateTask(t *testing.T) {
+	var cases = map[string]struct {
+		script       []mock.SqlStatement
+		expectedTask *model.Task
+		expectedErr  error
+	}{
+		"should update task when nothing changes": {
+			script: []mock.SqlStatement{
+				{Query: "FOR UPDATE", Columns: []string{"id"}, Rows: [][]driver.Value{{int64(1)}}},
+				{Query: "UPDATE tasks", RowsAffected: 0},
+				{Query: "DELETE FROM task_tags"},
+				{Query: "INSERT INTO task_tags", RowsAffected: 1},
+				{Query: "FROM tasks", Columns: []string{"id", "summary"}, Rows: [][]driver.Value{{int64(1), "summary"}}},
+			},
+			expectedTask: &model.Task{ID: 1, Summary: "summary"},
+		},
+		"should throw not found when task does not exist": {
+			script: []mock.SqlStatement{
+				{Query: "FOR UPDATE", Columns: []string{"id"}},
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task can not be locked": {
+			script: []mock.SqlStatement{
+				{Query: "FOR UPDATE", Err: fmt.Errorf("error")},
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			db := mock.NewSqlDB(cs.script...)
+			taskRepository := repository.NewTaskRepository(db.DB)
+
+			// when
+			task, err := taskRepository.UpdateTask(ctx, model.Task{ID: 1, Summary: "summary", Tags: model.TaskTags{"maintenance"}})
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTask, task)
+			assert.Empty(t, db.Remaining())
+		})
+	}
+}
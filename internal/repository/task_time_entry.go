@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+var timeEntryPeriods = map[model.TimeEntryPeriod]string{
+	model.TimeEntryPeriodDay:   "DATE_FORMAT(started_at, '%Y-%m-%d')",
+	model.TimeEntryPeriodWeek:  "DATE_FORMAT(DATE_SUB(started_at, INTERVAL WEEKDAY(started_at) DAY), '%Y-%m-%d')",
+	model.TimeEntryPeriodMonth: "DATE_FORMAT(started_at, '%Y-%m-01')",
+}
+
+//go:generate mockgen -destination=../../mock/task_time_entry_repository_mock.go -package=mock . TaskTimeEntryRepository
+type TaskTimeEntryRepository interface {
+	CreateTaskTimeEntry(ctx context.Context, entry model.TaskTimeEntry) (*model.TaskTimeEntry, error)
+	StopTaskTimeEntry(ctx context.Context, taskID, userID int, endedAt time.Time) (*model.TaskTimeEntry, error)
+	ListTaskTimeEntries(ctx context.Context, taskID int) ([]model.TaskTimeEntry, error)
+	SummarizeTimeEntries(ctx context.Context, period model.TimeEntryPeriod, opts ...WhereOpt) ([]model.TimeEntrySummary, error)
+}
+
+type taskTimeEntryRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaskTimeEntryRepository(db *sqlx.DB) TaskTimeEntryRepository {
+	return &taskTimeEntryRepository{
+		db: db,
+	}
+}
+
+// CreateTaskTimeEntry starts a timer when the entry has no end. A user can
+// only have one running timer, a second one fails with a conflict.
+func (impl *taskTimeEntryRepository) CreateTaskTimeEntry(ctx context.Context, entry model.TaskTimeEntry) (*model.TaskTimeEntry, error) {
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	res, err := impl.db.ExecContext(ctx, `INSERT INTO task_time_entries
+			(created_at, updated_at, task_id, user_id, started_at, ended_at, note)
+			VALUES (?, ?, ?, ?, ?, ?, ?);`,
+		entry.CreatedAt, entry.UpdatedAt, entry.TaskID, entry.UserID, entry.StartedAt, entry.EndedAt, entry.Note)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "a timer is already running"}
+		}
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	entry.ID = int(id)
+
+	return &entry, nil
+}
+
+func (impl *taskTimeEntryRepository) StopTaskTimeEntry(ctx context.Context, taskID, userID int, endedAt time.Time) (*model.TaskTimeEntry, error) {
+	var entries []model.TaskTimeEntry
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			task_id,
+			user_id,
+			started_at,
+			ended_at,
+			note
+		FROM task_time_entries
+		WHERE task_id = ?
+			AND user_id = ?
+			AND ended_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &entries, query, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, &exception.NotFoundException{Message: "no timer is running on this task"}
+	}
+
+	entry := entries[0]
+	res, err := impl.db.ExecContext(ctx, `UPDATE task_time_entries
+			SET ended_at = ?,
+				updated_at = ?
+			WHERE id = ?
+				AND ended_at IS NULL;`,
+		endedAt, endedAt, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, &exception.NotFoundException{Message: "no timer is running on this task"}
+	}
+	entry.EndedAt = &endedAt
+	entry.UpdatedAt = endedAt
+
+	return &entry, nil
+}
+
+func (impl *taskTimeEntryRepository) ListTaskTimeEntries(ctx context.Context, taskID int) ([]model.TaskTimeEntry, error) {
+	entries := []model.TaskTimeEntry{}
+	query := `
+		SELECT id,
+			created_at,
+			updated_at,
+			task_id,
+			user_id,
+			started_at,
+			ended_at,
+			note
+		FROM task_time_entries
+		WHERE task_id = ?
+		ORDER BY started_at, id
+	`
+	err := impl.db.SelectContext(ctx, &entries, query, taskID)
+
+	return entries, err
+}
+
+// SummarizeTimeEntries sums the entries per user and period. An entry
+// counts in the period it started in.
+func (impl *taskTimeEntryRepository) SummarizeTimeEntries(ctx context.Context, period model.TimeEntryPeriod, opts ...WhereOpt) ([]model.TimeEntrySummary, error) {
+	var query bytes.Buffer
+	query.WriteString(`
+		SELECT user_id,
+			` + timeEntryPeriods[period] + ` AS period,
+			SUM(TIMESTAMPDIFF(SECOND, started_at, ended_at)) AS seconds,
+			COUNT(*) AS entries
+		FROM task_time_entries
+	`)
+
+	args := []interface{}{}
+	if len(opts) > 0 {
+		query.WriteString(opts[0].Query())
+		args = append(args, opts[0].Values()...)
+	}
+	query.WriteString(`
+		GROUP BY user_id, period
+		ORDER BY period, user_id
+	`)
+
+	summaries := []model.TimeEntrySummary{}
+	err := impl.db.SelectContext(ctx, &summaries, query.String(), args...)
+
+	return summaries, err
+}
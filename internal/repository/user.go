@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/viniosilva/swordhealth-api/internal/dto"
 	"github.com/viniosilva/swordhealth-api/internal/exception"
@@ -17,6 +18,10 @@ type UserRepository interface {
 	GetUserByID(ctx context.Context, id int) (*model.User, error)
 	GetUserByUsernameAndPassword(ctx context.Context, username, password string) (*model.User, error)
 	ListUsers(ctx context.Context, limit, offset int, opts ...WhereOpt) ([]model.User, int, error)
+	GetUserByIdentity(ctx context.Context, issuer, subject string) (*model.User, error)
+	CreateUserWithIdentity(ctx context.Context, data dto.CreateUserDto, issuer, subject string) (*model.User, error)
+	UpdateUserRole(ctx context.Context, id int, role model.UserRole) error
+	UpdateUserPassword(ctx context.Context, id int, currentPassword, password string) error
 }
 
 type userRepository struct {
@@ -32,7 +37,13 @@ func NewUserRepository(db *sqlx.DB) UserRepository {
 func (impl *userRepository) CreateUser(ctx context.Context, data dto.CreateUserDto) (*model.User, error) {
 	now := time.Now()
 
-	res, err := impl.db.ExecContext(ctx, `INSERT INTO users
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO users
 			(created_at, updated_at, username, email, password, role)
 			VALUES (?, ?, ?, ?, ?, ?);`,
 		now, now, data.Username, data.Email, data.Password, data.Role)
@@ -45,6 +56,14 @@ func (impl *userRepository) CreateUser(ctx context.Context, data dto.CreateUserD
 		return nil, err
 	}
 
+	if err := insertPasswordHistory(ctx, tx, int(id), data.Password); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return &model.User{
 		ID:        int(id),
 		CreatedAt: now,
@@ -52,6 +71,7 @@ func (impl *userRepository) CreateUser(ctx context.Context, data dto.CreateUserD
 		Username:  data.Username,
 		Email:     data.Email,
 		Role:      data.Role,
+		Status:    model.UserStatusActive,
 	}, nil
 }
 
@@ -64,7 +84,8 @@ func (impl *userRepository) GetUserByID(ctx context.Context, id int) (*model.Use
 			username,
 			email,
 			password,
-			role
+			role,
+			status
 		FROM users
 		WHERE id = ?
 	`
@@ -86,10 +107,13 @@ func (impl *userRepository) GetUserByUsernameAndPassword(ctx context.Context, us
 			username,
 			email,
 			password,
-			role
+			role,
+			status
 		FROM users
 		WHERE username = ?
 			AND password = ?
+			AND status = 'active'
+			AND deleted_at IS NULL
 	`
 	err := impl.db.SelectContext(ctx, &users, query, username, password)
 
@@ -112,7 +136,8 @@ func (impl *userRepository) ListUsers(ctx context.Context, limit, offset int, op
 			username,
 			email,
 			password,
-			role
+			role,
+			status
 		FROM users
 	`)
 
@@ -144,3 +169,119 @@ func (impl *userRepository) ListUsers(ctx context.Context, limit, offset int, op
 
 	return users, total, err
 }
+
+func (impl *userRepository) GetUserByIdentity(ctx context.Context, issuer, subject string) (*model.User, error) {
+	var users []model.User
+	query := `
+		SELECT u.id,
+			u.created_at,
+			u.updated_at,
+			u.username,
+			u.email,
+			u.password,
+			u.role,
+			u.status
+		FROM users u
+		INNER JOIN user_identities ui ON ui.user_id = u.id
+		WHERE ui.issuer = ?
+			AND ui.subject = ?
+			AND u.status = 'active'
+			AND u.deleted_at IS NULL
+	`
+	err := impl.db.SelectContext(ctx, &users, query, issuer, subject)
+
+	if len(users) == 0 {
+		return nil, &exception.NotFoundException{Message: "user not found"}
+	}
+
+	return &users[0], err
+}
+
+func (impl *userRepository) CreateUserWithIdentity(ctx context.Context, data dto.CreateUserDto, issuer, subject string) (*model.User, error) {
+	now := time.Now()
+
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO users
+			(created_at, updated_at, username, email, password, role)
+			VALUES (?, ?, ?, ?, ?, ?);`,
+		now, now, data.Username, data.Email, data.Password, data.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO user_identities
+			(created_at, user_id, issuer, subject)
+			VALUES (?, ?, ?, ?);`,
+		now, id, issuer, subject)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && int(e.Number) == int(MySQLErrorCodeDuplicateEntry) {
+			err = &exception.ConflictException{Message: "identity already linked to another user"}
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &model.User{
+		ID:        int(id),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Username:  data.Username,
+		Email:     data.Email,
+		Role:      data.Role,
+		Status:    model.UserStatusActive,
+	}, nil
+}
+
+func (impl *userRepository) UpdateUserRole(ctx context.Context, id int, role model.UserRole) error {
+	_, err := impl.db.ExecContext(ctx, `UPDATE users
+			SET updated_at = ?, role = ?
+			WHERE id = ?;`,
+		time.Now(), role, id)
+
+	return err
+}
+
+func (impl *userRepository) UpdateUserPassword(ctx context.Context, id int, currentPassword, password string) error {
+	tx, err := impl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE users
+			SET updated_at = ?, password = ?
+			WHERE id = ?
+				AND password = ?
+				AND deleted_at IS NULL;`,
+		time.Now(), password, id, currentPassword)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &exception.NotFoundException{Message: "user not found"}
+	}
+
+	if err := insertPasswordHistory(ctx, tx, id, password); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
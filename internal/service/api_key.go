@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+const ApiKeyPrefix = "sh"
+
+//go:generate mockgen -destination=../../mock/api_key_service_mock.go -package=mock . ApiKeyService
+type ApiKeyService interface {
+	CreateApiKey(ctx context.Context, userID int, data dto.CreateApiKeyDto) (*model.ApiKey, string, error)
+	ListApiKeys(ctx context.Context, userID int) ([]model.ApiKey, error)
+	DeleteApiKey(ctx context.Context, userID, id int) error
+	Authenticate(ctx context.Context, key string) (*model.ApiKey, *model.User, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepository repository.ApiKeyRepository
+	userRepository   repository.UserRepository
+	cryptoService    CryptoService
+}
+
+func NewApiKeyService(apiKeyRepository repository.ApiKeyRepository, userRepository repository.UserRepository,
+	cryptoService CryptoService) ApiKeyService {
+	return &apiKeyService{
+		apiKeyRepository: apiKeyRepository,
+		userRepository:   userRepository,
+		cryptoService:    cryptoService,
+	}
+}
+
+func (impl *apiKeyService) CreateApiKey(ctx context.Context, userID int, data dto.CreateApiKeyDto) (*model.ApiKey, string, error) {
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(time.Now()) {
+		return nil, "", &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{
+				{Field: "expires_at", Tag: "future", Message: "expires_at must be in the future"},
+			},
+		}
+	}
+
+	prefix, err := impl.randomHex(4)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := impl.randomHex(24)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix = fmt.Sprintf("%s_%s", ApiKeyPrefix, prefix)
+	key := fmt.Sprintf("%s_%s", prefix, secret)
+
+	scopes := []string{}
+	for _, s := range data.Scopes {
+		scopes = append(scopes, string(s))
+	}
+
+	apiKey, err := impl.apiKeyRepository.CreateApiKey(ctx, model.ApiKey{
+		UserID:    userID,
+		Name:      data.Name,
+		Prefix:    prefix,
+		KeyHash:   impl.cryptoService.Hash(key),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: data.ExpiresAt,
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.apikey.createapikey",
+		}).Error(err.Error())
+		return nil, "", err
+	}
+
+	return apiKey, key, nil
+}
+
+func (impl *apiKeyService) ListApiKeys(ctx context.Context, userID int) ([]model.ApiKey, error) {
+	apiKeys, err := impl.apiKeyRepository.ListApiKeysByUserID(ctx, userID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.apikey.listapikeys",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (impl *apiKeyService) DeleteApiKey(ctx context.Context, userID, id int) error {
+	err := impl.apiKeyRepository.DeleteApiKey(ctx, userID, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.apikey.deleteapikey",
+			}).Error(err.Error())
+		}
+	}
+
+	return err
+}
+
+func (impl *apiKeyService) Authenticate(ctx context.Context, key string) (*model.ApiKey, *model.User, error) {
+	now := time.Now()
+
+	apiKey, err := impl.apiKeyRepository.GetApiKeyByHash(ctx, impl.cryptoService.Hash(key))
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, nil, &exception.ForbiddenException{Message: "invalid api key"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.apikey.authenticate",
+		}).Error(err.Error())
+		return nil, nil, err
+	}
+
+	if apiKey.Expired(now) {
+		return nil, nil, &exception.ExpiredTokenException{Message: "api key expired"}
+	}
+
+	user, err := impl.userRepository.GetUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, nil, &exception.ForbiddenException{Message: "invalid api key"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.apikey.authenticate",
+		}).Error(err.Error())
+		return nil, nil, err
+	}
+
+	if err := impl.apiKeyRepository.TouchApiKey(ctx, apiKey.ID, now); err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.apikey.authenticate",
+		}).Error(err.Error())
+	}
+	apiKey.LastUsedAt = &now
+
+	return apiKey, user, nil
+}
+
+func (impl *apiKeyService) randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
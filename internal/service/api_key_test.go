@@ -0,0 +1,258 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestApiKeyServiceCreateApiKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	var cases = map[string]struct {
+		inputData   dto.CreateApiKeyDto
+		mocking     func(apiKeyRepository *mock.MockApiKeyRepository, cryptoService *mock.MockCryptoService)
+		expectedErr error
+	}{
+		"should create api key": {
+			inputData: dto.CreateApiKeyDto{
+				Name:   "integration",
+				Scopes: []model.ApiKeyScope{model.ApiKeyScopeTasksRead, model.ApiKeyScopeTasksWrite},
+			},
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, cryptoService *mock.MockCryptoService) {
+				cryptoService.EXPECT().Hash(gomock.Any()).Return("hash")
+				apiKeyRepository.EXPECT().CreateApiKey(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, apiKey model.ApiKey) (*model.ApiKey, error) {
+						assert.Equal(t, 1, apiKey.UserID)
+						assert.Equal(t, "hash", apiKey.KeyHash)
+						assert.Equal(t, "tasks:read,tasks:write", apiKey.Scopes)
+						assert.Regexp(t, "^sh_[0-9a-f]{8}$", apiKey.Prefix)
+						apiKey.ID = 1
+						return &apiKey, nil
+					})
+			},
+		},
+		"should throw validation error when expires at is in the past": {
+			inputData: dto.CreateApiKeyDto{
+				Name:      "integration",
+				Scopes:    []model.ApiKeyScope{model.ApiKeyScopeTasksRead},
+				ExpiresAt: &past,
+			},
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, cryptoService *mock.MockCryptoService) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "expires_at", Tag: "future", Message: "expires_at must be in the future"},
+				},
+			},
+		},
+		"should throw error when api key repository create api key": {
+			inputData: dto.CreateApiKeyDto{
+				Name:   "integration",
+				Scopes: []model.ApiKeyScope{model.ApiKeyScopeTasksRead},
+			},
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, cryptoService *mock.MockCryptoService) {
+				cryptoService.EXPECT().Hash(gomock.Any()).Return("hash")
+				apiKeyRepository.EXPECT().CreateApiKey(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyRepositoryMock := mock.NewMockApiKeyRepository(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			apiKeyService := service.NewApiKeyService(apiKeyRepositoryMock, nil, cryptoServiceMock)
+
+			cs.mocking(apiKeyRepositoryMock, cryptoServiceMock)
+
+			// when
+			apiKey, key, err := apiKeyService.CreateApiKey(ctx, 1, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Regexp(t, regexp.MustCompile("^"+apiKey.Prefix+"_[0-9a-f]{48}$"), key)
+			}
+		})
+	}
+}
+
+func TestApiKeyServiceListApiKeys(t *testing.T) {
+	apiKeys := []model.ApiKey{{ID: 1, UserID: 1, Name: "integration"}}
+
+	var cases = map[string]struct {
+		mocking         func(apiKeyRepository *mock.MockApiKeyRepository)
+		expectedApiKeys []model.ApiKey
+		expectedErr     error
+	}{
+		"should list api keys": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository) {
+				apiKeyRepository.EXPECT().ListApiKeysByUserID(gomock.Any(), 1).Return(apiKeys, nil)
+			},
+			expectedApiKeys: apiKeys,
+		},
+		"should throw error when api key repository list api keys": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository) {
+				apiKeyRepository.EXPECT().ListApiKeysByUserID(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyRepositoryMock := mock.NewMockApiKeyRepository(ctrl)
+			apiKeyService := service.NewApiKeyService(apiKeyRepositoryMock, nil, nil)
+
+			cs.mocking(apiKeyRepositoryMock)
+
+			// when
+			res, err := apiKeyService.ListApiKeys(ctx, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedApiKeys, res)
+		})
+	}
+}
+
+func TestApiKeyServiceDeleteApiKey(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(apiKeyRepository *mock.MockApiKeyRepository)
+		expectedErr error
+	}{
+		"should delete api key": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository) {
+				apiKeyRepository.EXPECT().DeleteApiKey(gomock.Any(), 1, 2).Return(nil)
+			},
+		},
+		"should throw not found": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository) {
+				apiKeyRepository.EXPECT().DeleteApiKey(gomock.Any(), 1, 2).
+					Return(&exception.NotFoundException{Message: "api key not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "api key not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyRepositoryMock := mock.NewMockApiKeyRepository(ctrl)
+			apiKeyService := service.NewApiKeyService(apiKeyRepositoryMock, nil, nil)
+
+			cs.mocking(apiKeyRepositoryMock)
+
+			// when
+			err := apiKeyService.DeleteApiKey(ctx, 1, 2)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyServiceAuthenticate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	user := &model.User{ID: 1, Username: "username", Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		mocking      func(apiKeyRepository *mock.MockApiKeyRepository, userRepository *mock.MockUserRepository)
+		expectedUser *model.User
+		expectedErr  error
+	}{
+		"should authenticate and track last use": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, userRepository *mock.MockUserRepository) {
+				apiKeyRepository.EXPECT().GetApiKeyByHash(gomock.Any(), "hash").Return(&model.ApiKey{ID: 2, UserID: 1}, nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil)
+				apiKeyRepository.EXPECT().TouchApiKey(gomock.Any(), 2, gomock.Any()).Return(nil)
+			},
+			expectedUser: user,
+		},
+		"should authenticate when track last use fails": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, userRepository *mock.MockUserRepository) {
+				apiKeyRepository.EXPECT().GetApiKeyByHash(gomock.Any(), "hash").Return(&model.ApiKey{ID: 2, UserID: 1}, nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil)
+				apiKeyRepository.EXPECT().TouchApiKey(gomock.Any(), 2, gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedUser: user,
+		},
+		"should throw forbidden when api key is unknown": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, userRepository *mock.MockUserRepository) {
+				apiKeyRepository.EXPECT().GetApiKeyByHash(gomock.Any(), "hash").
+					Return(nil, &exception.NotFoundException{Message: "api key not found"})
+			},
+			expectedErr: &exception.ForbiddenException{Message: "invalid api key"},
+		},
+		"should throw expired token when api key is expired": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, userRepository *mock.MockUserRepository) {
+				apiKeyRepository.EXPECT().GetApiKeyByHash(gomock.Any(), "hash").
+					Return(&model.ApiKey{ID: 2, UserID: 1, ExpiresAt: &past}, nil)
+			},
+			expectedErr: &exception.ExpiredTokenException{Message: "api key expired"},
+		},
+		"should throw forbidden when user is not found": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, userRepository *mock.MockUserRepository) {
+				apiKeyRepository.EXPECT().GetApiKeyByHash(gomock.Any(), "hash").Return(&model.ApiKey{ID: 2, UserID: 1}, nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "user not found"})
+			},
+			expectedErr: &exception.ForbiddenException{Message: "invalid api key"},
+		},
+		"should throw error when api key repository get api key": {
+			mocking: func(apiKeyRepository *mock.MockApiKeyRepository, userRepository *mock.MockUserRepository) {
+				apiKeyRepository.EXPECT().GetApiKeyByHash(gomock.Any(), "hash").Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyRepositoryMock := mock.NewMockApiKeyRepository(ctrl)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			apiKeyService := service.NewApiKeyService(apiKeyRepositoryMock, userRepositoryMock, cryptoServiceMock)
+
+			cryptoServiceMock.EXPECT().Hash("sh_1a2b3c4d_secret").Return("hash")
+			cs.mocking(apiKeyRepositoryMock, userRepositoryMock)
+
+			// when
+			apiKey, user, err := apiKeyService.Authenticate(ctx, "sh_1a2b3c4d_secret")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedUser, user)
+			if cs.expectedErr == nil {
+				assert.NotNil(t, apiKey.LastUsedAt)
+			}
+		})
+	}
+}
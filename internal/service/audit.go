@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/audit_service_mock.go -package=mock . AuditService
+type AuditService interface {
+	Record(ctx context.Context, auditLog model.AuditLog) (*model.AuditLog, error)
+	UpdateStatus(ctx context.Context, id, status int) error
+}
+
+type auditService struct {
+	auditLogRepository repository.AuditLogRepository
+}
+
+func NewAuditService(auditLogRepository repository.AuditLogRepository) AuditService {
+	return &auditService{
+		auditLogRepository: auditLogRepository,
+	}
+}
+
+// Record logs the entry and persists it, cut to the size of its columns. The
+// error is returned for callers that must not go on without the entry.
+func (impl *auditService) Record(ctx context.Context, auditLog model.AuditLog) (*model.AuditLog, error) {
+	auditLog.RequestID = truncateRunes(auditLog.RequestID, 64)
+	auditLog.Path = truncateRunes(auditLog.Path, 255)
+
+	fields := log.Fields{
+		"trace":      "internal.service.audit.record",
+		"request_id": auditLog.RequestID,
+		"actor_id":   auditLog.ActorID,
+		"user_id":    auditLog.UserID,
+		"action":     auditLog.Action,
+		"method":     auditLog.Method,
+		"path":       auditLog.Path,
+		"status":     auditLog.Status,
+	}
+	log.WithContext(ctx).WithFields(fields).Info(string(auditLog.Action))
+
+	res, err := impl.auditLogRepository.CreateAuditLog(ctx, auditLog)
+	if err != nil {
+		log.WithContext(ctx).WithFields(fields).Error(err.Error())
+	}
+
+	return res, err
+}
+
+func (impl *auditService) UpdateStatus(ctx context.Context, id, status int) error {
+	err := impl.auditLogRepository.UpdateAuditLogStatus(ctx, id, status)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace":  "internal.service.audit.updatestatus",
+			"id":     id,
+			"status": status,
+		}).Error(err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestAuditServiceRecord(t *testing.T) {
+	auditLog := model.AuditLog{
+		RequestID: "abc",
+		ActorID:   1,
+		UserID:    2,
+		Action:    model.AuditActionImpersonationRequest,
+		Method:    "GET",
+		Path:      "/api/tasks",
+		Status:    200,
+	}
+	longAuditLog := auditLog
+	longAuditLog.Path = "/api/" + strings.Repeat("é", 300)
+	truncatedAuditLog := auditLog
+	truncatedAuditLog.Path = "/api/" + strings.Repeat("é", 250)
+
+	var cases = map[string]struct {
+		inputAuditLog    model.AuditLog
+		mocking          func(auditLogRepository *mock.MockAuditLogRepository)
+		expectedAuditLog *model.AuditLog
+		expectedErr      error
+	}{
+		"should record audit log": {
+			inputAuditLog: auditLog,
+			mocking: func(auditLogRepository *mock.MockAuditLogRepository) {
+				auditLogRepository.EXPECT().CreateAuditLog(gomock.Any(), auditLog).Return(&auditLog, nil)
+			},
+			expectedAuditLog: &auditLog,
+		},
+		"should truncate the path to its column": {
+			inputAuditLog: longAuditLog,
+			mocking: func(auditLogRepository *mock.MockAuditLogRepository) {
+				auditLogRepository.EXPECT().CreateAuditLog(gomock.Any(), truncatedAuditLog).Return(&truncatedAuditLog, nil)
+			},
+			expectedAuditLog: &truncatedAuditLog,
+		},
+		"should throw error when audit log repository create audit log": {
+			inputAuditLog: auditLog,
+			mocking: func(auditLogRepository *mock.MockAuditLogRepository) {
+				auditLogRepository.EXPECT().CreateAuditLog(gomock.Any(), auditLog).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditLogRepositoryMock := mock.NewMockAuditLogRepository(ctrl)
+			auditService := service.NewAuditService(auditLogRepositoryMock)
+
+			cs.mocking(auditLogRepositoryMock)
+
+			// when
+			res, err := auditService.Record(ctx, cs.inputAuditLog)
+
+			// then
+			assert.Equal(t, cs.expectedAuditLog, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestAuditServiceUpdateStatus(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(auditLogRepository *mock.MockAuditLogRepository)
+		expectedErr error
+	}{
+		"should update audit log status": {
+			mocking: func(auditLogRepository *mock.MockAuditLogRepository) {
+				auditLogRepository.EXPECT().UpdateAuditLogStatus(gomock.Any(), 1, 200).Return(nil)
+			},
+		},
+		"should throw error when audit log repository update audit log status": {
+			mocking: func(auditLogRepository *mock.MockAuditLogRepository) {
+				auditLogRepository.EXPECT().UpdateAuditLogStatus(gomock.Any(), 1, 200).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditLogRepositoryMock := mock.NewMockAuditLogRepository(ctrl)
+			auditService := service.NewAuditService(auditLogRepositoryMock)
+
+			cs.mocking(auditLogRepositoryMock)
+
+			// when
+			err := auditService.UpdateStatus(ctx, 1, 200)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -3,8 +3,9 @@ package service
 import (
 	"context"
 	"encoding/base64"
-	"fmt"
 	"strings"
+
+	"github.com/viniosilva/swordhealth-api/internal/exception"
 )
 
 //go:generate mockgen -destination=../../mock/auth_service_mock.go -package=mock . AuthService
@@ -21,7 +22,7 @@ func NewAuthService() AuthService {
 func (impl *authService) DecodeBasicAuth(ctx context.Context, authorization string) (string, string, error) {
 	splitedBasicAuth := strings.Split(authorization, " ")
 	if strings.ToLower(splitedBasicAuth[0]) != "basic" || len(splitedBasicAuth) != 2 {
-		return "", "", fmt.Errorf("invalid authorization")
+		return "", "", &exception.InvalidAuthorizationException{Message: "invalid authorization"}
 	}
 
 	basicAuth := splitedBasicAuth[1]
@@ -29,7 +30,7 @@ func (impl *authService) DecodeBasicAuth(ctx context.Context, authorization stri
 
 	auth := strings.Split(string(decodedBasicAuth), ":")
 	if len(auth) != 2 {
-		return "", "", fmt.Errorf("invalid authorization")
+		return "", "", &exception.InvalidAuthorizationException{Message: "invalid authorization"}
 	}
 
 	return auth[0], auth[1], nil
@@ -2,10 +2,10 @@ package service_test
 
 import (
 	"context"
-	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/service"
 	"github.com/viniosilva/swordhealth-api/mock"
 )
@@ -25,11 +25,11 @@ func TestAuthServiceLogin(t *testing.T) {
 		},
 		"should throw invalid authorization error": {
 			inputBasicAuth: "auth",
-			expectedErr:    fmt.Errorf("invalid authorization"),
+			expectedErr:    &exception.InvalidAuthorizationException{Message: "invalid authorization"},
 		},
 		"should throw error on decode auth": {
 			inputBasicAuth: "Basic ",
-			expectedErr:    fmt.Errorf("invalid authorization"),
+			expectedErr:    &exception.InvalidAuthorizationException{Message: "invalid authorization"},
 		},
 	}
 	for name, cs := range cases {
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"time"
@@ -16,6 +20,8 @@ import (
 //go:generate mockgen -destination=../../mock/crypto_service_mock.go -package=mock . CryptoService
 type CryptoService interface {
 	Hash(value string) string
+	Encrypt(value string) (string, error)
+	Decrypt(value string) (string, error)
 	EncryptJwt(ctx context.Context, sub interface{}, claims map[string]interface{}) (string, error)
 	DecryptJwt(ctx context.Context, accessToken string) (map[string]interface{}, error)
 }
@@ -41,6 +47,52 @@ func (impl *cryptoService) Hash(value string) string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
+func (impl *cryptoService) Encrypt(value string) (string, error) {
+	gcm, err := impl.cipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(value), nil)), nil
+}
+
+func (impl *cryptoService) Decrypt(value string) (string, error) {
+	gcm, err := impl.cipher()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted value")
+	}
+
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+func (impl *cryptoService) cipher() (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(impl.hashKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
 func (impl *cryptoService) EncryptJwt(ctx context.Context, sub interface{}, claims map[string]interface{}) (string, error) {
 	jwtClaims := jwt.MapClaims{
 		"iat": jwt.NewNumericDate(time.Now()),
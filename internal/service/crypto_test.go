@@ -46,6 +46,46 @@ func BenchmarkCryptoServiceHash(b *testing.B) {
 	}
 }
 
+func TestCryptoServiceEncryptDecrypt(t *testing.T) {
+	var cases = map[string]struct {
+		injectEncryptKey string
+		injectDecryptKey string
+		inputValue       string
+		expectedValue    string
+		expectedErr      bool
+	}{
+		"should decrypt encrypted value": {
+			injectEncryptKey: "key",
+			injectDecryptKey: "key",
+			inputValue:       "JBSWY3DPEHPK3PXP",
+			expectedValue:    "JBSWY3DPEHPK3PXP",
+		},
+		"should throw error when key is different": {
+			injectEncryptKey: "key",
+			injectDecryptKey: "other",
+			inputValue:       "JBSWY3DPEHPK3PXP",
+			expectedErr:      true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			encryptService := service.NewCryptoService(cs.injectEncryptKey, "", 0)
+			decryptService := service.NewCryptoService(cs.injectDecryptKey, "", 0)
+
+			// when
+			encrypted, err := encryptService.Encrypt(cs.inputValue)
+			assert.Nil(t, err)
+			value, err := decryptService.Decrypt(encrypted)
+
+			// then
+			assert.NotEqual(t, cs.inputValue, encrypted)
+			assert.Equal(t, cs.expectedErr, err != nil)
+			assert.Equal(t, cs.expectedValue, value)
+		})
+	}
+}
+
 func TestCryptoServiceEncryptJwt(t *testing.T) {
 	var cases = map[string]struct {
 		injectKey       string
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/impersonation_service_mock.go -package=mock . ImpersonationService
+type ImpersonationService interface {
+	Impersonate(ctx context.Context, actorID, userID int) (*model.User, error)
+}
+
+type impersonationService struct {
+	userRepository repository.UserRepository
+}
+
+func NewImpersonationService(userRepository repository.UserRepository) ImpersonationService {
+	return &impersonationService{
+		userRepository: userRepository,
+	}
+}
+
+// Impersonate returns the user the actor may act as. Only active
+// technicians can be impersonated, so support never gains more rights
+// than it already has.
+func (impl *impersonationService) Impersonate(ctx context.Context, actorID, userID int) (*model.User, error) {
+	if actorID == userID {
+		return nil, &exception.ForbiddenException{Message: "can not impersonate yourself"}
+	}
+
+	user, err := impl.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.impersonation.impersonate",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	if user.Role != model.UserRoleTechnician {
+		return nil, &exception.ForbiddenException{Message: "only technicians can be impersonated"}
+	}
+	if user.Status == model.UserStatusPending {
+		return nil, &exception.ForbiddenException{Message: "user is not active"}
+	}
+
+	return user, nil
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestImpersonationServiceImpersonate(t *testing.T) {
+	technician := &model.User{ID: 2, Username: "technician", Role: model.UserRoleTechnician, Status: model.UserStatusActive}
+
+	var cases = map[string]struct {
+		inputUserID  int
+		mocking      func(userRepository *mock.MockUserRepository)
+		expectedUser *model.User
+		expectedErr  error
+	}{
+		"should impersonate technician": {
+			inputUserID: 2,
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(technician, nil)
+			},
+			expectedUser: technician,
+		},
+		"should throw forbidden when impersonating yourself": {
+			inputUserID: 1,
+			mocking:     func(userRepository *mock.MockUserRepository) {},
+			expectedErr: &exception.ForbiddenException{Message: "can not impersonate yourself"},
+		},
+		"should throw forbidden when user is a manager": {
+			inputUserID: 3,
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).
+					Return(&model.User{ID: 3, Role: model.UserRoleManager, Status: model.UserStatusActive}, nil)
+			},
+			expectedErr: &exception.ForbiddenException{Message: "only technicians can be impersonated"},
+		},
+		"should throw forbidden when user is pending": {
+			inputUserID: 4,
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 4).
+					Return(&model.User{ID: 4, Role: model.UserRoleTechnician, Status: model.UserStatusPending}, nil)
+			},
+			expectedErr: &exception.ForbiddenException{Message: "user is not active"},
+		},
+		"should throw not found": {
+			inputUserID: 5,
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 5).
+					Return(nil, &exception.NotFoundException{Message: "user not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "user not found"},
+		},
+		"should throw error when user repository get user by id": {
+			inputUserID: 2,
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			impersonationService := service.NewImpersonationService(userRepositoryMock)
+
+			cs.mocking(userRepositoryMock)
+
+			// when
+			res, err := impersonationService.Impersonate(ctx, 1, cs.inputUserID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedUser, res)
+		})
+	}
+}
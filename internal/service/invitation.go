@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/invitation_service_mock.go -package=mock . InvitationService
+type InvitationService interface {
+	Invite(ctx context.Context, invitedBy int, data dto.CreateInvitationDto) (*model.Invitation, error)
+	ListInvitations(ctx context.Context) ([]model.Invitation, error)
+	ResendInvitation(ctx context.Context, id int) (*model.Invitation, error)
+	RevokeInvitation(ctx context.Context, id int) error
+	AcceptInvitation(ctx context.Context, token, password string) error
+}
+
+type invitationService struct {
+	invitationRepository  repository.InvitationRepository
+	userRepository        repository.UserRepository
+	notificationService   NotificationService
+	cryptoService         CryptoService
+	passwordPolicyService PasswordPolicyService
+	inviteURL             string
+	expiresIn             time.Duration
+}
+
+func NewInvitationService(invitationRepository repository.InvitationRepository, userRepository repository.UserRepository,
+	notificationService NotificationService, cryptoService CryptoService, passwordPolicyService PasswordPolicyService,
+	inviteURL string, expiresIn time.Duration) InvitationService {
+	return &invitationService{
+		invitationRepository:  invitationRepository,
+		userRepository:        userRepository,
+		notificationService:   notificationService,
+		cryptoService:         cryptoService,
+		passwordPolicyService: passwordPolicyService,
+		inviteURL:             inviteURL,
+		expiresIn:             expiresIn,
+	}
+}
+
+func (impl *invitationService) Invite(ctx context.Context, invitedBy int, data dto.CreateInvitationDto) (*model.Invitation, error) {
+	if data.Role == "" {
+		data.Role = model.UserRoleTechnician
+	}
+
+	users, _, err := impl.userRepository.ListUsers(ctx, 1, 0,
+		repository.SetWhere("WHERE username = ? AND deleted_at IS NULL", []interface{}{data.Username}))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.invitation.invite",
+		}).Error(err.Error())
+		return nil, err
+	}
+	if len(users) > 0 {
+		return nil, &exception.ConflictException{Message: "username already taken"}
+	}
+
+	token, err := impl.generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := impl.invitationRepository.CreateInvitation(ctx, data, invitedBy,
+		impl.cryptoService.Hash(token), time.Now().Add(impl.expiresIn))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.invitation.invite",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	if err := impl.notificationService.NotifyInvitation(ctx, invitation, impl.inviteLink(token)); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (impl *invitationService) ListInvitations(ctx context.Context) ([]model.Invitation, error) {
+	invitations, err := impl.invitationRepository.ListInvitations(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.invitation.listinvitations",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (impl *invitationService) ResendInvitation(ctx context.Context, id int) (*model.Invitation, error) {
+	invitation, err := impl.invitationRepository.GetInvitationByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.invitation.resendinvitation",
+			}).Error(err.Error())
+		}
+
+		return nil, err
+	}
+	if invitation.AcceptedAt != nil {
+		return nil, &exception.ConflictException{Message: "invitation already accepted"}
+	}
+	if invitation.RevokedAt != nil {
+		return nil, &exception.ConflictException{Message: "invitation revoked"}
+	}
+
+	token, err := impl.generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.TokenHash = impl.cryptoService.Hash(token)
+	invitation.ExpiresAt = time.Now().Add(impl.expiresIn)
+
+	err = impl.invitationRepository.RenewInvitation(ctx, id, invitation.TokenHash, invitation.ExpiresAt)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.invitation.resendinvitation",
+			}).Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	if err := impl.notificationService.NotifyInvitation(ctx, invitation, impl.inviteLink(token)); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (impl *invitationService) RevokeInvitation(ctx context.Context, id int) error {
+	err := impl.invitationRepository.RevokeInvitation(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.invitation.revokeinvitation",
+			}).Error(err.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (impl *invitationService) AcceptInvitation(ctx context.Context, token, password string) error {
+	if err := impl.passwordPolicyService.Validate(ctx, 0, password); err != nil {
+		return err
+	}
+
+	_, err := impl.invitationRepository.AcceptInvitation(ctx, impl.cryptoService.Hash(token), impl.cryptoService.Hash(password))
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.ForbiddenException{Message: "invalid or expired invitation"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.invitation.acceptinvitation",
+		}).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (impl *invitationService) generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (impl *invitationService) inviteLink(token string) string {
+	separator := "?"
+	if strings.Contains(impl.inviteURL, "?") {
+		separator = "&"
+	}
+
+	return fmt.Sprintf("%s%stoken=%s", impl.inviteURL, separator, url.QueryEscape(token))
+}
@@ -0,0 +1,353 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestInvitationServiceInvite(t *testing.T) {
+	now := time.Now()
+	invitation := &model.Invitation{
+		ID:        1,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+		InvitedBy: 1,
+		UserID:    2,
+		Username:  "username",
+		Email:     "email@email.com",
+		Role:      model.UserRoleTechnician,
+	}
+
+	var cases = map[string]struct {
+		inputData          dto.CreateInvitationDto
+		mocking            func(invitationRepository *mock.MockInvitationRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService)
+		expectedInvitation *model.Invitation
+		expectedErr        error
+	}{
+		"should invite user as technician by default": {
+			inputData: dto.CreateInvitationDto{Username: "username", Email: "email@email.com"},
+			mocking: func(invitationRepository *mock.MockInvitationRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				var tokenHash string
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).Return([]model.User{}, 0, nil)
+				invitationRepository.EXPECT().CreateInvitation(gomock.Any(), dto.CreateInvitationDto{
+					Username: "username", Email: "email@email.com", Role: model.UserRoleTechnician,
+				}, 1, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data dto.CreateInvitationDto, invitedBy int, hash string, expiresAt time.Time) (*model.Invitation, error) {
+						tokenHash = hash
+						assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Second)
+						return invitation, nil
+					})
+				notificationService.EXPECT().NotifyInvitation(gomock.Any(), invitation, gomock.Any()).
+					Do(func(ctx context.Context, i *model.Invitation, inviteLink string) {
+						token := strings.TrimPrefix(inviteLink, "http://localhost/accept-invitation?token=")
+						assert.NotEqual(t, inviteLink, token)
+						assert.Equal(t, service.NewCryptoService("hash-key", "", 0).Hash(token), tokenHash)
+					})
+			},
+			expectedInvitation: invitation,
+		},
+		"should throw conflict when username is already taken": {
+			inputData: dto.CreateInvitationDto{Username: "username", Email: "email@email.com"},
+			mocking: func(invitationRepository *mock.MockInvitationRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).Return([]model.User{{ID: 3}}, 1, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "username already taken"},
+		},
+		"should throw error when invitation repository create invitation": {
+			inputData: dto.CreateInvitationDto{Username: "username", Email: "email@email.com", Role: model.UserRoleManager},
+			mocking: func(invitationRepository *mock.MockInvitationRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).Return([]model.User{}, 0, nil)
+				invitationRepository.EXPECT().CreateInvitation(gomock.Any(), gomock.Any(), 1, gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when notification service notify invitation": {
+			inputData: dto.CreateInvitationDto{Username: "username", Email: "email@email.com"},
+			mocking: func(invitationRepository *mock.MockInvitationRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).Return([]model.User{}, 0, nil)
+				invitationRepository.EXPECT().CreateInvitation(gomock.Any(), gomock.Any(), 1, gomock.Any(), gomock.Any()).
+					Return(invitation, nil)
+				notificationService.EXPECT().NotifyInvitation(gomock.Any(), invitation, gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when user repository list users": {
+			inputData: dto.CreateInvitationDto{Username: "username", Email: "email@email.com"},
+			mocking: func(invitationRepository *mock.MockInvitationRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitationRepositoryMock := mock.NewMockInvitationRepository(ctrl)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			invitationService := service.NewInvitationService(invitationRepositoryMock, userRepositoryMock,
+				notificationServiceMock, service.NewCryptoService("hash-key", "", 0), nil, "http://localhost/accept-invitation", time.Hour)
+
+			cs.mocking(invitationRepositoryMock, userRepositoryMock, notificationServiceMock)
+
+			// when
+			res, err := invitationService.Invite(ctx, 1, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedInvitation, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestInvitationServiceListInvitations(t *testing.T) {
+	var cases = map[string]struct {
+		mocking             func(invitationRepository *mock.MockInvitationRepository)
+		expectedInvitations []model.Invitation
+		expectedErr         error
+	}{
+		"should list invitations": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository) {
+				invitationRepository.EXPECT().ListInvitations(gomock.Any()).Return([]model.Invitation{{ID: 1}}, nil)
+			},
+			expectedInvitations: []model.Invitation{{ID: 1}},
+		},
+		"should throw error when invitation repository list invitations": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository) {
+				invitationRepository.EXPECT().ListInvitations(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitationRepositoryMock := mock.NewMockInvitationRepository(ctrl)
+			invitationService := service.NewInvitationService(invitationRepositoryMock, nil, nil, nil, nil, "", time.Hour)
+
+			cs.mocking(invitationRepositoryMock)
+
+			// when
+			res, err := invitationService.ListInvitations(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedInvitations, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestInvitationServiceResendInvitation(t *testing.T) {
+	now := time.Now()
+
+	var cases = map[string]struct {
+		mocking     func(invitationRepository *mock.MockInvitationRepository, notificationService *mock.MockNotificationService)
+		expectedID  int
+		expectedErr error
+	}{
+		"should resend invitation with a new token": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, notificationService *mock.MockNotificationService) {
+				var tokenHash string
+				invitationRepository.EXPECT().GetInvitationByID(gomock.Any(), 1).
+					Return(&model.Invitation{ID: 1, ExpiresAt: now.Add(-time.Hour), TokenHash: "old-hash"}, nil)
+				invitationRepository.EXPECT().RenewInvitation(gomock.Any(), 1, gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, id int, hash string, expiresAt time.Time) {
+						tokenHash = hash
+						assert.NotEqual(t, "old-hash", hash)
+						assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Second)
+					})
+				notificationService.EXPECT().NotifyInvitation(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, i *model.Invitation, inviteLink string) {
+						token := strings.TrimPrefix(inviteLink, "http://localhost/accept-invitation?token=")
+						assert.Equal(t, service.NewCryptoService("hash-key", "", 0).Hash(token), tokenHash)
+					})
+			},
+			expectedID: 1,
+		},
+		"should throw conflict when invitation was accepted": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, notificationService *mock.MockNotificationService) {
+				invitationRepository.EXPECT().GetInvitationByID(gomock.Any(), 1).
+					Return(&model.Invitation{ID: 1, AcceptedAt: &now}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "invitation already accepted"},
+		},
+		"should throw conflict when invitation was revoked": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, notificationService *mock.MockNotificationService) {
+				invitationRepository.EXPECT().GetInvitationByID(gomock.Any(), 1).
+					Return(&model.Invitation{ID: 1, RevokedAt: &now}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "invitation revoked"},
+		},
+		"should throw not found": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, notificationService *mock.MockNotificationService) {
+				invitationRepository.EXPECT().GetInvitationByID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "invitation not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "invitation not found"},
+		},
+		"should throw error when invitation repository renew invitation": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, notificationService *mock.MockNotificationService) {
+				invitationRepository.EXPECT().GetInvitationByID(gomock.Any(), 1).Return(&model.Invitation{ID: 1}, nil)
+				invitationRepository.EXPECT().RenewInvitation(gomock.Any(), 1, gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitationRepositoryMock := mock.NewMockInvitationRepository(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			invitationService := service.NewInvitationService(invitationRepositoryMock, nil,
+				notificationServiceMock, service.NewCryptoService("hash-key", "", 0), nil, "http://localhost/accept-invitation", time.Hour)
+
+			cs.mocking(invitationRepositoryMock, notificationServiceMock)
+
+			// when
+			res, err := invitationService.ResendInvitation(ctx, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Equal(t, cs.expectedID, res.ID)
+				assert.True(t, res.ExpiresAt.After(now))
+			}
+		})
+	}
+}
+
+func TestInvitationServiceRevokeInvitation(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(invitationRepository *mock.MockInvitationRepository)
+		expectedErr error
+	}{
+		"should revoke invitation": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository) {
+				invitationRepository.EXPECT().RevokeInvitation(gomock.Any(), 1).Return(nil)
+			},
+		},
+		"should throw not found": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository) {
+				invitationRepository.EXPECT().RevokeInvitation(gomock.Any(), 1).
+					Return(&exception.NotFoundException{Message: "invitation not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "invitation not found"},
+		},
+		"should throw error when invitation repository revoke invitation": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository) {
+				invitationRepository.EXPECT().RevokeInvitation(gomock.Any(), 1).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitationRepositoryMock := mock.NewMockInvitationRepository(ctrl)
+			invitationService := service.NewInvitationService(invitationRepositoryMock, nil, nil, nil, nil, "", time.Hour)
+
+			cs.mocking(invitationRepositoryMock)
+
+			// when
+			err := invitationService.RevokeInvitation(ctx, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestInvitationServiceAcceptInvitation(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(invitationRepository *mock.MockInvitationRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService)
+		expectedErr error
+	}{
+		"should accept invitation": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 0, "S3cR31-p4ss").Return(nil)
+				cryptoService.EXPECT().Hash("token").Return("token-hash")
+				cryptoService.EXPECT().Hash("S3cR31-p4ss").Return("password-hash")
+				invitationRepository.EXPECT().AcceptInvitation(gomock.Any(), "token-hash", "password-hash").Return(2, nil)
+			},
+		},
+		"should throw validation error when password does not match the policy": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 0, "S3cR31-p4ss").
+					Return(&exception.ValidationException{Message: "invalid fields"})
+			},
+			expectedErr: &exception.ValidationException{Message: "invalid fields"},
+		},
+		"should throw forbidden when invitation is invalid, expired or revoked": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 0, "S3cR31-p4ss").Return(nil)
+				cryptoService.EXPECT().Hash("token").Return("token-hash")
+				cryptoService.EXPECT().Hash("S3cR31-p4ss").Return("password-hash")
+				invitationRepository.EXPECT().AcceptInvitation(gomock.Any(), "token-hash", "password-hash").
+					Return(0, &exception.NotFoundException{Message: "invitation not found"})
+			},
+			expectedErr: &exception.ForbiddenException{Message: "invalid or expired invitation"},
+		},
+		"should throw error when invitation repository accept invitation": {
+			mocking: func(invitationRepository *mock.MockInvitationRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService) {
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 0, "S3cR31-p4ss").Return(nil)
+				cryptoService.EXPECT().Hash("token").Return("token-hash")
+				cryptoService.EXPECT().Hash("S3cR31-p4ss").Return("password-hash")
+				invitationRepository.EXPECT().AcceptInvitation(gomock.Any(), "token-hash", "password-hash").
+					Return(0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitationRepositoryMock := mock.NewMockInvitationRepository(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			passwordPolicyServiceMock := mock.NewMockPasswordPolicyService(ctrl)
+			invitationService := service.NewInvitationService(invitationRepositoryMock, nil, nil,
+				cryptoServiceMock, passwordPolicyServiceMock, "http://localhost/accept-invitation", time.Hour)
+
+			cs.mocking(invitationRepositoryMock, cryptoServiceMock, passwordPolicyServiceMock)
+
+			// when
+			err := invitationService.AcceptInvitation(ctx, "token", "S3cR31-p4ss")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
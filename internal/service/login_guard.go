@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+type LoginGuardPolicy struct {
+	Window                 time.Duration
+	MaxAttemptsPerUsername int
+	MaxAttemptsPerIP       int
+	LockoutThreshold       int
+	LockoutDuration        time.Duration
+	BaseDelay              time.Duration
+	MaxDelay               time.Duration
+}
+
+//go:generate mockgen -destination=../../mock/login_guard_service_mock.go -package=mock . LoginGuardService
+type LoginGuardService interface {
+	Check(ctx context.Context, username, ip, userAgent string) error
+	RegisterFailure(ctx context.Context, username, ip, userAgent string) error
+	RegisterSuccess(ctx context.Context, username string) error
+}
+
+type loginGuardService struct {
+	limiter                repository.Limiter
+	loginAttemptRepository repository.LoginAttemptRepository
+	policy                 LoginGuardPolicy
+}
+
+func NewLoginGuardService(limiter repository.Limiter, loginAttemptRepository repository.LoginAttemptRepository,
+	policy LoginGuardPolicy) LoginGuardService {
+	return &loginGuardService{
+		limiter:                limiter,
+		loginAttemptRepository: loginAttemptRepository,
+		policy:                 policy,
+	}
+}
+
+func (impl *loginGuardService) Check(ctx context.Context, username, ip, userAgent string) error {
+	now := time.Now()
+
+	lookback := impl.policy.Window
+	if impl.policy.LockoutDuration > lookback {
+		lookback = impl.policy.LockoutDuration
+	}
+
+	usernameHits, err := impl.limiter.Hits(ctx, impl.usernameKey(username), now.Add(-lookback))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.loginguard.check",
+		}).Error(err.Error())
+		return err
+	}
+
+	ipHits, err := impl.limiter.Hits(ctx, impl.ipKey(ip), now.Add(-impl.policy.Window))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.loginguard.check",
+		}).Error(err.Error())
+		return err
+	}
+
+	if lockedFor := impl.lockedFor(now, usernameHits); lockedFor > 0 {
+		impl.audit(ctx, username, ip, userAgent, model.LoginAttemptReasonThrottled)
+		return &exception.AccountLockedException{
+			Message:    "account temporarily locked",
+			RetryAfter: lockedFor,
+		}
+	}
+
+	windowHits := impl.since(usernameHits, now.Add(-impl.policy.Window))
+	retryAfter := impl.maxDuration(
+		impl.windowRetryAfter(now, windowHits, impl.policy.MaxAttemptsPerUsername),
+		impl.windowRetryAfter(now, ipHits, impl.policy.MaxAttemptsPerIP),
+		impl.delayRetryAfter(now, windowHits),
+	)
+	if retryAfter > 0 {
+		impl.audit(ctx, username, ip, userAgent, model.LoginAttemptReasonThrottled)
+		return &exception.TooManyRequestsException{
+			Message:    "too many login attempts",
+			RetryAfter: retryAfter,
+		}
+	}
+
+	return nil
+}
+
+func (impl *loginGuardService) RegisterFailure(ctx context.Context, username, ip, userAgent string) error {
+	now := time.Now()
+
+	for _, key := range []string{impl.usernameKey(username), impl.ipKey(ip)} {
+		if err := impl.limiter.Hit(ctx, key, now); err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.loginguard.registerfailure",
+			}).Error(err.Error())
+			return err
+		}
+	}
+
+	impl.audit(ctx, username, ip, userAgent, model.LoginAttemptReasonInvalidCredentials)
+
+	return nil
+}
+
+func (impl *loginGuardService) RegisterSuccess(ctx context.Context, username string) error {
+	err := impl.limiter.Reset(ctx, impl.usernameKey(username))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.loginguard.registersuccess",
+		}).Error(err.Error())
+	}
+
+	return err
+}
+
+// audit cuts username and userAgent to the size of their columns, counted in
+// characters so no rune is split.
+func (impl *loginGuardService) audit(ctx context.Context, username, ip, userAgent string, reason model.LoginAttemptReason) {
+	_, err := impl.loginAttemptRepository.CreateLoginAttempt(ctx, model.LoginAttempt{
+		Username:  truncateRunes(username, 100),
+		IP:        ip,
+		UserAgent: truncateRunes(userAgent, 500),
+		Reason:    reason,
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.loginguard.audit",
+		}).Error(err.Error())
+	}
+}
+
+func (impl *loginGuardService) lockedFor(now time.Time, hits []time.Time) time.Duration {
+	recent := impl.since(hits, now.Add(-impl.policy.LockoutDuration))
+	if impl.policy.LockoutThreshold <= 0 || len(recent) < impl.policy.LockoutThreshold {
+		return 0
+	}
+
+	return recent[len(recent)-1].Add(impl.policy.LockoutDuration).Sub(now)
+}
+
+func (impl *loginGuardService) windowRetryAfter(now time.Time, hits []time.Time, max int) time.Duration {
+	if max <= 0 || len(hits) < max {
+		return 0
+	}
+
+	return hits[len(hits)-max].Add(impl.policy.Window).Sub(now)
+}
+
+func (impl *loginGuardService) delayRetryAfter(now time.Time, hits []time.Time) time.Duration {
+	if len(hits) == 0 || impl.policy.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := impl.policy.BaseDelay
+	for i := 1; i < len(hits) && (impl.policy.MaxDelay <= 0 || delay < impl.policy.MaxDelay); i++ {
+		delay *= 2
+	}
+	if impl.policy.MaxDelay > 0 && delay > impl.policy.MaxDelay {
+		delay = impl.policy.MaxDelay
+	}
+
+	return hits[len(hits)-1].Add(delay).Sub(now)
+}
+
+func (impl *loginGuardService) since(hits []time.Time, since time.Time) []time.Time {
+	res := []time.Time{}
+	for _, h := range hits {
+		if !h.Before(since) {
+			res = append(res, h)
+		}
+	}
+
+	return res
+}
+
+func (impl *loginGuardService) maxDuration(durations ...time.Duration) time.Duration {
+	var max time.Duration
+	for _, d := range durations {
+		if d > max {
+			max = d
+		}
+	}
+
+	return max
+}
+
+// usernameKey hashes the username, which is as long as the client sent it, so
+// the key fits the limiter storage.
+func (impl *loginGuardService) usernameKey(username string) string {
+	return fmt.Sprintf("login:username:%x", sha256.Sum256([]byte(username)))
+}
+
+func (impl *loginGuardService) ipKey(ip string) string {
+	return fmt.Sprintf("login:ip:%s", ip)
+}
+
+func truncateRunes(value string, max int) string {
+	if utf8.RuneCountInString(value) <= max {
+		return value
+	}
+
+	return string([]rune(value)[:max])
+}
@@ -0,0 +1,260 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+var loginGuardPolicy = service.LoginGuardPolicy{
+	Window:                 15 * time.Minute,
+	MaxAttemptsPerUsername: 5,
+	MaxAttemptsPerIP:       20,
+	LockoutThreshold:       10,
+	LockoutDuration:        30 * time.Minute,
+	BaseDelay:              time.Second,
+	MaxDelay:               30 * time.Second,
+}
+
+// usernameKey is the limiter key of "username".
+const usernameKey = "login:username:16f78a7d6317f102bbd95fc9a4f3ff2e3249287690b8bdad6b7810f82b34ace3"
+
+func hitsAgo(ago ...time.Duration) []time.Time {
+	now := time.Now()
+	hits := []time.Time{}
+	for _, a := range ago {
+		hits = append(hits, now.Add(-a))
+	}
+
+	return hits
+}
+
+func TestLoginGuardServiceCheck(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository)
+		expectedErrType    error
+		expectedRetryAfter time.Duration
+		expectedErr        error
+	}{
+		"should allow login without failures": {
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hits(gomock.Any(), usernameKey, gomock.Any()).Return([]time.Time{}, nil)
+				limiter.EXPECT().Hits(gomock.Any(), "login:ip:127.0.0.1", gomock.Any()).Return([]time.Time{}, nil)
+			},
+		},
+		"should allow login after progressive delay": {
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hits(gomock.Any(), usernameKey, gomock.Any()).
+					Return(hitsAgo(time.Minute, 10*time.Second), nil)
+				limiter.EXPECT().Hits(gomock.Any(), "login:ip:127.0.0.1", gomock.Any()).
+					Return(hitsAgo(time.Minute, 10*time.Second), nil)
+			},
+		},
+		"should throw too many requests during progressive delay": {
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hits(gomock.Any(), usernameKey, gomock.Any()).
+					Return(hitsAgo(time.Minute, 30*time.Second, time.Second), nil)
+				limiter.EXPECT().Hits(gomock.Any(), "login:ip:127.0.0.1", gomock.Any()).Return([]time.Time{}, nil)
+				loginAttemptRepository.EXPECT().CreateLoginAttempt(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, attempt model.LoginAttempt) {
+						assert.Equal(t, model.LoginAttemptReasonThrottled, attempt.Reason)
+					})
+			},
+			expectedErrType:    &exception.TooManyRequestsException{},
+			expectedRetryAfter: 3 * time.Second,
+		},
+		"should throw too many requests when username window is exceeded": {
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hits(gomock.Any(), usernameKey, gomock.Any()).
+					Return(hitsAgo(10*time.Minute, 9*time.Minute, 8*time.Minute, 7*time.Minute, 6*time.Minute), nil)
+				limiter.EXPECT().Hits(gomock.Any(), "login:ip:127.0.0.1", gomock.Any()).Return([]time.Time{}, nil)
+				loginAttemptRepository.EXPECT().CreateLoginAttempt(gomock.Any(), gomock.Any())
+			},
+			expectedErrType:    &exception.TooManyRequestsException{},
+			expectedRetryAfter: 5 * time.Minute,
+		},
+		"should throw too many requests when ip window is exceeded": {
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				ago := []time.Duration{}
+				for i := 20; i > 0; i-- {
+					ago = append(ago, time.Duration(i)*30*time.Second)
+				}
+				limiter.EXPECT().Hits(gomock.Any(), usernameKey, gomock.Any()).Return([]time.Time{}, nil)
+				limiter.EXPECT().Hits(gomock.Any(), "login:ip:127.0.0.1", gomock.Any()).Return(hitsAgo(ago...), nil)
+				loginAttemptRepository.EXPECT().CreateLoginAttempt(gomock.Any(), gomock.Any())
+			},
+			expectedErrType:    &exception.TooManyRequestsException{},
+			expectedRetryAfter: 5 * time.Minute,
+		},
+		"should throw account locked when lockout threshold is reached": {
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				ago := []time.Duration{}
+				for i := 10; i > 0; i-- {
+					ago = append(ago, time.Duration(i)*2*time.Minute)
+				}
+				limiter.EXPECT().Hits(gomock.Any(), usernameKey, gomock.Any()).Return(hitsAgo(ago...), nil)
+				limiter.EXPECT().Hits(gomock.Any(), "login:ip:127.0.0.1", gomock.Any()).Return([]time.Time{}, nil)
+				loginAttemptRepository.EXPECT().CreateLoginAttempt(gomock.Any(), gomock.Any())
+			},
+			expectedErrType:    &exception.AccountLockedException{},
+			expectedRetryAfter: 28 * time.Minute,
+		},
+		"should throw error when limiter hits": {
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hits(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			limiterMock := mock.NewMockLimiter(ctrl)
+			loginAttemptRepositoryMock := mock.NewMockLoginAttemptRepository(ctrl)
+			loginGuardService := service.NewLoginGuardService(limiterMock, loginAttemptRepositoryMock, loginGuardPolicy)
+
+			cs.mocking(limiterMock, loginAttemptRepositoryMock)
+
+			// when
+			err := loginGuardService.Check(ctx, "username", "127.0.0.1", "curl")
+
+			// then
+			switch e := err.(type) {
+			case *exception.TooManyRequestsException:
+				assert.IsType(t, cs.expectedErrType, err)
+				assert.InDelta(t, cs.expectedRetryAfter, e.RetryAfter, float64(time.Second))
+			case *exception.AccountLockedException:
+				assert.IsType(t, cs.expectedErrType, err)
+				assert.InDelta(t, cs.expectedRetryAfter, e.RetryAfter, float64(time.Second))
+			default:
+				assert.Nil(t, cs.expectedErrType)
+				assert.Equal(t, cs.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLoginGuardServiceRegisterFailure(t *testing.T) {
+	var cases = map[string]struct {
+		inputUsername  string
+		inputUserAgent string
+		mocking        func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository)
+		expectedErr    error
+	}{
+		"should register failure": {
+			inputUsername:  "username",
+			inputUserAgent: "curl",
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hit(gomock.Any(), usernameKey, gomock.Any()).Return(nil)
+				limiter.EXPECT().Hit(gomock.Any(), "login:ip:127.0.0.1", gomock.Any()).Return(nil)
+				loginAttemptRepository.EXPECT().CreateLoginAttempt(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, attempt model.LoginAttempt) {
+						assert.Equal(t, model.LoginAttempt{
+							Username:  "username",
+							IP:        "127.0.0.1",
+							UserAgent: "curl",
+							Reason:    model.LoginAttemptReasonInvalidCredentials,
+						}, attempt)
+					})
+			},
+		},
+		"should fit username, user agent and limiter key to their columns": {
+			inputUsername:  strings.Repeat("é", 101),
+			inputUserAgent: strings.Repeat("ü", 501),
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hit(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, key string, at time.Time) {
+						assert.LessOrEqual(t, len(key), 200)
+					}).Return(nil).Times(2)
+				loginAttemptRepository.EXPECT().CreateLoginAttempt(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, attempt model.LoginAttempt) {
+						assert.Equal(t, model.LoginAttempt{
+							Username:  strings.Repeat("é", 100),
+							IP:        "127.0.0.1",
+							UserAgent: strings.Repeat("ü", 500),
+							Reason:    model.LoginAttemptReasonInvalidCredentials,
+						}, attempt)
+					})
+			},
+		},
+		"should throw error when limiter hit": {
+			inputUsername:  "username",
+			inputUserAgent: "curl",
+			mocking: func(limiter *mock.MockLimiter, loginAttemptRepository *mock.MockLoginAttemptRepository) {
+				limiter.EXPECT().Hit(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			limiterMock := mock.NewMockLimiter(ctrl)
+			loginAttemptRepositoryMock := mock.NewMockLoginAttemptRepository(ctrl)
+			loginGuardService := service.NewLoginGuardService(limiterMock, loginAttemptRepositoryMock, loginGuardPolicy)
+
+			cs.mocking(limiterMock, loginAttemptRepositoryMock)
+
+			// when
+			err := loginGuardService.RegisterFailure(ctx, cs.inputUsername, "127.0.0.1", cs.inputUserAgent)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestLoginGuardServiceRegisterSuccess(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(limiter *mock.MockLimiter)
+		expectedErr error
+	}{
+		"should reset username failures": {
+			mocking: func(limiter *mock.MockLimiter) {
+				limiter.EXPECT().Reset(gomock.Any(), usernameKey).Return(nil)
+			},
+		},
+		"should throw error when limiter reset": {
+			mocking: func(limiter *mock.MockLimiter) {
+				limiter.EXPECT().Reset(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			limiterMock := mock.NewMockLimiter(ctrl)
+			loginGuardService := service.NewLoginGuardService(limiterMock, nil, loginGuardPolicy)
+
+			cs.mocking(limiterMock)
+
+			// when
+			err := loginGuardService.RegisterSuccess(ctx, "username")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
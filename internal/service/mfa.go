@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+const (
+	TotpPeriod            = 30 * time.Second
+	TotpDigits            = 6
+	TotpSkew              = 1
+	MfaRecoveryCodesCount = 10
+	MfaTokenExpiresIn     = 5 * time.Minute
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+//go:generate mockgen -destination=../../mock/mfa_service_mock.go -package=mock . MfaService
+type MfaService interface {
+	GetStatus(ctx context.Context, user *model.User) (*model.MfaStatus, error)
+	EnrollTotp(ctx context.Context, user *model.User) (*model.TotpEnrollment, error)
+	ConfirmTotp(ctx context.Context, userID int, code string) ([]string, error)
+	VerifyCode(ctx context.Context, userID int, code string) error
+}
+
+type mfaService struct {
+	mfaRepository  repository.MfaRepository
+	cryptoService  CryptoService
+	issuer         string
+	enforceManager bool
+}
+
+func NewMfaService(mfaRepository repository.MfaRepository, cryptoService CryptoService, issuer string, enforceManager bool) MfaService {
+	return &mfaService{
+		mfaRepository:  mfaRepository,
+		cryptoService:  cryptoService,
+		issuer:         issuer,
+		enforceManager: enforceManager,
+	}
+}
+
+func (impl *mfaService) GetStatus(ctx context.Context, user *model.User) (*model.MfaStatus, error) {
+	status := &model.MfaStatus{
+		Required: impl.enforceManager && user.Role == model.UserRoleManager,
+	}
+
+	totp, err := impl.mfaRepository.GetTotpByUserID(ctx, user.ID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return status, nil
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.getstatus",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	if totp.ConfirmedAt != nil {
+		status.Enrolled = true
+		status.Required = true
+	}
+
+	return status, nil
+}
+
+func (impl *mfaService) EnrollTotp(ctx context.Context, user *model.User) (*model.TotpEnrollment, error) {
+	totp, err := impl.mfaRepository.GetTotpByUserID(ctx, user.ID)
+	if err == nil && totp.ConfirmedAt != nil {
+		return nil, &exception.ConflictException{Message: "totp already enrolled"}
+	}
+	if _, ok := err.(*exception.NotFoundException); err != nil && !ok {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.enrolltotp",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	secretBytes := make([]byte, 20)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, err
+	}
+	secret := totpEncoding.EncodeToString(secretBytes)
+
+	encryptedSecret, err := impl.cryptoService.Encrypt(secret)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.enrolltotp",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	if err := impl.mfaRepository.SaveTotp(ctx, user.ID, encryptedSecret); err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.enrolltotp",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	label := url.PathEscape(fmt.Sprintf("%s:%s", impl.issuer, user.Username))
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", impl.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TotpDigits))
+	query.Set("period", fmt.Sprint(int(TotpPeriod.Seconds())))
+
+	return &model.TotpEnrollment{
+		Secret:     secret,
+		OtpauthURI: fmt.Sprintf("otpauth://totp/%s?%s", label, query.Encode()),
+	}, nil
+}
+
+func (impl *mfaService) ConfirmTotp(ctx context.Context, userID int, code string) ([]string, error) {
+	totp, err := impl.mfaRepository.GetTotpByUserID(ctx, userID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, &exception.NotFoundException{Message: "totp enrollment not found"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.confirmtotp",
+		}).Error(err.Error())
+		return nil, err
+	}
+	if totp.ConfirmedAt != nil {
+		return nil, &exception.ConflictException{Message: "totp already enrolled"}
+	}
+
+	step, err := impl.matchTotpStep(totp, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := []string{}
+	hashedRecoveryCodes := []string{}
+	for i := 0; i < MfaRecoveryCodesCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		recoveryCode := hex.EncodeToString(b)
+		recoveryCode = fmt.Sprintf("%s-%s", recoveryCode[:5], recoveryCode[5:])
+
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+		hashedRecoveryCodes = append(hashedRecoveryCodes, impl.cryptoService.Hash(recoveryCode))
+	}
+
+	if err := impl.mfaRepository.ConfirmTotp(ctx, userID, step, hashedRecoveryCodes); err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.confirmtotp",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (impl *mfaService) VerifyCode(ctx context.Context, userID int, code string) error {
+	totp, err := impl.mfaRepository.GetTotpByUserID(ctx, userID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.InvalidMfaCodeException{Message: "invalid mfa code"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.verifycode",
+		}).Error(err.Error())
+		return err
+	}
+	if totp.ConfirmedAt == nil {
+		return &exception.InvalidMfaCodeException{Message: "invalid mfa code"}
+	}
+
+	if strings.Contains(code, "-") {
+		err = impl.mfaRepository.UseRecoveryCode(ctx, userID, impl.cryptoService.Hash(strings.ToLower(code)))
+	} else {
+		var step int64
+		if step, err = impl.matchTotpStep(totp, code); err != nil {
+			return err
+		}
+		err = impl.mfaRepository.UseTotpStep(ctx, userID, step)
+	}
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.InvalidMfaCodeException{Message: "invalid mfa code"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.mfa.verifycode",
+		}).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (impl *mfaService) matchTotpStep(totp *model.UserTotp, code string) (int64, error) {
+	secret, err := impl.cryptoService.Decrypt(totp.Secret)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for skew := -TotpSkew; skew <= TotpSkew; skew++ {
+		at := now.Add(time.Duration(skew) * TotpPeriod)
+		expected, err := GenerateTotpCode(secret, at)
+		if err != nil {
+			return 0, err
+		}
+
+		step := at.Unix() / int64(TotpPeriod.Seconds())
+		if hmac.Equal([]byte(expected), []byte(code)) && step > totp.LastUsedStep {
+			return step, nil
+		}
+	}
+
+	return 0, &exception.InvalidMfaCodeException{Message: "invalid mfa code"}
+}
+
+func GenerateTotpCode(secret string, at time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/int64(TotpPeriod.Seconds())))
+
+	h := hmac.New(sha1.New, key)
+	h.Write(counter)
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TotpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", TotpDigits, value%mod), nil
+}
@@ -0,0 +1,322 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+const totpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestMfaServiceGenerateTotpCode(t *testing.T) {
+	var cases = map[string]struct {
+		inputTime    time.Time
+		expectedCode string
+	}{
+		"should generate rfc 6238 code at 59": {
+			inputTime:    time.Unix(59, 0),
+			expectedCode: "287082",
+		},
+		"should generate rfc 6238 code at 1111111109": {
+			inputTime:    time.Unix(1111111109, 0),
+			expectedCode: "081804",
+		},
+		"should generate rfc 6238 code at 1234567890": {
+			inputTime:    time.Unix(1234567890, 0),
+			expectedCode: "005924",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			code, err := service.GenerateTotpCode(totpSecret, cs.inputTime)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedCode, code)
+		})
+	}
+}
+
+func TestMfaServiceGetStatus(t *testing.T) {
+	now := time.Now()
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	technician := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		injectEnforceManager bool
+		inputUser            *model.User
+		mocking              func(mfaRepository *mock.MockMfaRepository)
+		expectedStatus       *model.MfaStatus
+		expectedErr          error
+	}{
+		"should not require mfa when user is not enrolled": {
+			inputUser: technician,
+			mocking: func(mfaRepository *mock.MockMfaRepository) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 2).
+					Return(nil, &exception.NotFoundException{Message: "totp not found"})
+			},
+			expectedStatus: &model.MfaStatus{},
+		},
+		"should not require mfa when enrollment is not confirmed": {
+			inputUser: technician,
+			mocking: func(mfaRepository *mock.MockMfaRepository) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 2).Return(&model.UserTotp{UserID: 2}, nil)
+			},
+			expectedStatus: &model.MfaStatus{},
+		},
+		"should require mfa when user is enrolled": {
+			inputUser: technician,
+			mocking: func(mfaRepository *mock.MockMfaRepository) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 2).Return(&model.UserTotp{UserID: 2, ConfirmedAt: &now}, nil)
+			},
+			expectedStatus: &model.MfaStatus{Enrolled: true, Required: true},
+		},
+		"should require mfa enrollment when manager mfa is enforced": {
+			injectEnforceManager: true,
+			inputUser:            manager,
+			mocking: func(mfaRepository *mock.MockMfaRepository) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "totp not found"})
+			},
+			expectedStatus: &model.MfaStatus{Required: true},
+		},
+		"should throw error when mfa repository get totp": {
+			inputUser: manager,
+			mocking: func(mfaRepository *mock.MockMfaRepository) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mfaRepositoryMock := mock.NewMockMfaRepository(ctrl)
+			mfaService := service.NewMfaService(mfaRepositoryMock, nil, "Sword Health", cs.injectEnforceManager)
+
+			cs.mocking(mfaRepositoryMock)
+
+			// when
+			status, err := mfaService.GetStatus(ctx, cs.inputUser)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedStatus, status)
+		})
+	}
+}
+
+func TestMfaServiceEnrollTotp(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 1, Username: "admin", Role: model.UserRoleManager}
+
+	var cases = map[string]struct {
+		mocking     func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService)
+		expectedErr error
+	}{
+		"should enroll totp": {
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "totp not found"})
+				cryptoService.EXPECT().Encrypt(gomock.Any()).Return("encrypted", nil)
+				mfaRepository.EXPECT().SaveTotp(gomock.Any(), 1, "encrypted").Return(nil)
+			},
+		},
+		"should throw conflict when totp is already enrolled": {
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(&model.UserTotp{UserID: 1, ConfirmedAt: &now}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "totp already enrolled"},
+		},
+		"should throw error when mfa repository save totp": {
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(&model.UserTotp{UserID: 1}, nil)
+				cryptoService.EXPECT().Encrypt(gomock.Any()).Return("encrypted", nil)
+				mfaRepository.EXPECT().SaveTotp(gomock.Any(), 1, "encrypted").Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mfaRepositoryMock := mock.NewMockMfaRepository(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			mfaService := service.NewMfaService(mfaRepositoryMock, cryptoServiceMock, "Sword Health", false)
+
+			cs.mocking(mfaRepositoryMock, cryptoServiceMock)
+
+			// when
+			enrollment, err := mfaService.EnrollTotp(ctx, user)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Len(t, enrollment.Secret, 32)
+				assert.True(t, strings.HasPrefix(enrollment.OtpauthURI, "otpauth://totp/Sword%20Health:admin?"))
+				assert.Contains(t, enrollment.OtpauthURI, "secret="+enrollment.Secret)
+			}
+		})
+	}
+}
+
+func TestMfaServiceConfirmTotp(t *testing.T) {
+	now := time.Now()
+	code, _ := service.GenerateTotpCode(totpSecret, now)
+
+	var cases = map[string]struct {
+		inputCode         string
+		mocking           func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService)
+		expectedCodesSize int
+		expectedErr       error
+	}{
+		"should confirm totp": {
+			inputCode: code,
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(&model.UserTotp{UserID: 1, Secret: "encrypted"}, nil)
+				cryptoService.EXPECT().Decrypt("encrypted").Return(totpSecret, nil)
+				cryptoService.EXPECT().Hash(gomock.Any()).Return("hash").Times(service.MfaRecoveryCodesCount)
+				mfaRepository.EXPECT().ConfirmTotp(gomock.Any(), 1, now.Unix()/30, gomock.Len(service.MfaRecoveryCodesCount)).Return(nil)
+			},
+			expectedCodesSize: service.MfaRecoveryCodesCount,
+		},
+		"should throw invalid mfa code": {
+			inputCode: "000000",
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(&model.UserTotp{UserID: 1, Secret: "encrypted"}, nil)
+				cryptoService.EXPECT().Decrypt("encrypted").Return("AAAAAAAAAAAAAAAA", nil)
+			},
+			expectedErr: &exception.InvalidMfaCodeException{Message: "invalid mfa code"},
+		},
+		"should throw not found when totp is not enrolled": {
+			inputCode: code,
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "totp not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "totp enrollment not found"},
+		},
+		"should throw conflict when totp is already confirmed": {
+			inputCode: code,
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(&model.UserTotp{UserID: 1, ConfirmedAt: &now}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "totp already enrolled"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mfaRepositoryMock := mock.NewMockMfaRepository(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			mfaService := service.NewMfaService(mfaRepositoryMock, cryptoServiceMock, "Sword Health", false)
+
+			cs.mocking(mfaRepositoryMock, cryptoServiceMock)
+
+			// when
+			recoveryCodes, err := mfaService.ConfirmTotp(ctx, 1, cs.inputCode)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Len(t, recoveryCodes, cs.expectedCodesSize)
+		})
+	}
+}
+
+func TestMfaServiceVerifyCode(t *testing.T) {
+	now := time.Now()
+	code, _ := service.GenerateTotpCode(totpSecret, now)
+	totp := &model.UserTotp{UserID: 1, Secret: "encrypted", ConfirmedAt: &now}
+
+	var cases = map[string]struct {
+		inputCode   string
+		mocking     func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService)
+		expectedErr error
+	}{
+		"should verify totp code": {
+			inputCode: code,
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(totp, nil)
+				cryptoService.EXPECT().Decrypt("encrypted").Return(totpSecret, nil)
+				mfaRepository.EXPECT().UseTotpStep(gomock.Any(), 1, now.Unix()/30).Return(nil)
+			},
+		},
+		"should verify recovery code": {
+			inputCode: "A1B2C-3D4E5",
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(totp, nil)
+				cryptoService.EXPECT().Hash("a1b2c-3d4e5").Return("hash")
+				mfaRepository.EXPECT().UseRecoveryCode(gomock.Any(), 1, "hash").Return(nil)
+			},
+		},
+		"should throw invalid mfa code when recovery code was used": {
+			inputCode: "a1b2c-3d4e5",
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(totp, nil)
+				cryptoService.EXPECT().Hash("a1b2c-3d4e5").Return("hash")
+				mfaRepository.EXPECT().UseRecoveryCode(gomock.Any(), 1, "hash").
+					Return(&exception.NotFoundException{Message: "recovery code not found"})
+			},
+			expectedErr: &exception.InvalidMfaCodeException{Message: "invalid mfa code"},
+		},
+		"should throw invalid mfa code when totp code was replayed": {
+			inputCode: code,
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(totp, nil)
+				cryptoService.EXPECT().Decrypt("encrypted").Return(totpSecret, nil)
+				mfaRepository.EXPECT().UseTotpStep(gomock.Any(), 1, now.Unix()/30).
+					Return(&exception.NotFoundException{Message: "totp step already used"})
+			},
+			expectedErr: &exception.InvalidMfaCodeException{Message: "invalid mfa code"},
+		},
+		"should throw invalid mfa code when user is not enrolled": {
+			inputCode: code,
+			mocking: func(mfaRepository *mock.MockMfaRepository, cryptoService *mock.MockCryptoService) {
+				mfaRepository.EXPECT().GetTotpByUserID(gomock.Any(), 1).Return(&model.UserTotp{UserID: 1}, nil)
+			},
+			expectedErr: &exception.InvalidMfaCodeException{Message: "invalid mfa code"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mfaRepositoryMock := mock.NewMockMfaRepository(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			mfaService := service.NewMfaService(mfaRepositoryMock, cryptoServiceMock, "Sword Health", false)
+
+			cs.mocking(mfaRepositoryMock, cryptoServiceMock)
+
+			// when
+			err := mfaService.VerifyCode(ctx, 1, cs.inputCode)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
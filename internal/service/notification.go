@@ -3,24 +3,34 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/viniosilva/swordhealth-api/internal/model"
 	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"golang.org/x/exp/slices"
 )
 
 //go:generate mockgen -destination=../../mock/notification_service_mock.go -package=mock . NotificationService
 type NotificationService interface {
 	NotifyAdminUserOnSaveTask(ctx context.Context, task *model.Task, actionUserID int) error
+	NotifyPasswordReset(ctx context.Context, user *model.User, resetLink string, expiresAt time.Time) error
+	NotifyInvitation(ctx context.Context, invitation *model.Invitation, inviteLink string) error
+	NotifyTaskAssigned(ctx context.Context, task *model.Task) error
+	NotifyTaskTransitioned(ctx context.Context, task *model.Task, transition *model.TaskTransition) error
+	NotifyTaskReminder(ctx context.Context, task *model.Task, threshold model.TaskReminderThreshold) error
+	NotifyTaskCommented(ctx context.Context, task *model.Task, comment *model.TaskComment, recipientIDs []int) error
 }
 
 type notificationService struct {
 	userRepository repository.UserRepository
+	mailer         repository.Mailer
 }
 
-func NewNotificationService(userRepository repository.UserRepository) NotificationService {
+func NewNotificationService(userRepository repository.UserRepository, mailer repository.Mailer) NotificationService {
 	return &notificationService{
 		userRepository: userRepository,
+		mailer:         mailer,
 	}
 }
 
@@ -67,3 +77,185 @@ func (impl *notificationService) NotifyAdminUserOnSaveTask(ctx context.Context,
 
 	return nil
 }
+
+func (impl *notificationService) NotifyPasswordReset(ctx context.Context, user *model.User, resetLink string, expiresAt time.Time) error {
+	err := impl.mailer.Send(ctx, model.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\nUse the link below to choose a new password. It expires at %s and can only be used once.\n\n%s\n\n"+
+			"If you did not ask for a password reset, you can ignore this email.\n",
+			user.Username, expiresAt.UTC().Format("2006-01-02 15:04:05 MST"), resetLink),
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifypasswordreset",
+		}).Error(err.Error())
+	}
+
+	return err
+}
+
+func (impl *notificationService) NotifyInvitation(ctx context.Context, invitation *model.Invitation, inviteLink string) error {
+	err := impl.mailer.Send(ctx, model.Mail{
+		To:      invitation.Email,
+		Subject: "You have been invited to Sword Health",
+		Body: fmt.Sprintf("Hello %s,\n\nYou have been invited to join Sword Health as %s. Use the link below to choose your password. "+
+			"It expires at %s.\n\n%s\n",
+			invitation.Username, invitation.Role, invitation.ExpiresAt.UTC().Format("2006-01-02 15:04:05 MST"), inviteLink),
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifyinvitation",
+		}).Error(err.Error())
+	}
+
+	return err
+}
+
+func (impl *notificationService) NotifyTaskAssigned(ctx context.Context, task *model.Task) error {
+	assignee, err := impl.userRepository.GetUserByID(ctx, task.AssigneeID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifytaskassigned",
+		}).Error(err.Error())
+		return err
+	}
+
+	if assignee.Email == "" {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifytaskassigned",
+		}).Info("does not notify when assignee has no email")
+
+		return nil
+	}
+
+	err = impl.mailer.Send(ctx, model.Mail{
+		To:      assignee.Email,
+		Subject: fmt.Sprintf("Task %d was assigned to you", task.ID),
+		Body:    fmt.Sprintf("Hello %s,\n\nThe task %d was assigned to you:\n\n%s\n", assignee.Username, task.ID, task.Summary),
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifytaskassigned",
+		}).Error(err.Error())
+	}
+
+	return err
+}
+
+// NotifyTaskTransitioned mails the creator and the assignee of the task,
+// except for whoever moved it.
+func (impl *notificationService) NotifyTaskTransitioned(ctx context.Context, task *model.Task, transition *model.TaskTransition) error {
+	recipientIDs := []int{}
+	for _, id := range []int{task.UserID, task.AssigneeID} {
+		if id != transition.UserID && !slices.Contains(recipientIDs, id) {
+			recipientIDs = append(recipientIDs, id)
+		}
+	}
+
+	var lastErr error
+	for _, id := range recipientIDs {
+		recipient, err := impl.userRepository.GetUserByID(ctx, id)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.notification.notifytasktransitioned",
+			}).Error(err.Error())
+			lastErr = err
+			continue
+		}
+
+		if recipient.Email == "" {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.notification.notifytasktransitioned",
+			}).Info("does not notify when recipient has no email")
+			continue
+		}
+
+		err = impl.mailer.Send(ctx, model.Mail{
+			To:      recipient.Email,
+			Subject: fmt.Sprintf("Task %d is now %s", task.ID, transition.ToStatus),
+			Body: fmt.Sprintf("Hello %s,\n\nThe task %d was moved from %s to %s:\n\n%s\n",
+				recipient.Username, task.ID, transition.FromStatus, transition.ToStatus, task.Summary),
+		})
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.notification.notifytasktransitioned",
+			}).Error(err.Error())
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (impl *notificationService) NotifyTaskReminder(ctx context.Context, task *model.Task, threshold model.TaskReminderThreshold) error {
+	assignee, err := impl.userRepository.GetUserByID(ctx, task.AssigneeID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifytaskreminder",
+		}).Error(err.Error())
+		return err
+	}
+
+	if assignee.Email == "" {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifytaskreminder",
+		}).Info("does not notify when assignee has no email")
+
+		return nil
+	}
+
+	state := "is due soon"
+	if threshold == model.TaskReminderThresholdOverdue {
+		state = "is overdue"
+	}
+
+	err = impl.mailer.Send(ctx, model.Mail{
+		To:      assignee.Email,
+		Subject: fmt.Sprintf("Task %d %s", task.ID, state),
+		Body: fmt.Sprintf("Hello %s,\n\nThe task %d %s (due at %s):\n\n%s\n",
+			assignee.Username, task.ID, state, task.DueAt.Format("2006-01-02 15:04:05"), task.Summary),
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.notification.notifytaskreminder",
+		}).Error(err.Error())
+	}
+
+	return err
+}
+
+func (impl *notificationService) NotifyTaskCommented(ctx context.Context, task *model.Task, comment *model.TaskComment, recipientIDs []int) error {
+	var lastErr error
+	for _, id := range recipientIDs {
+		recipient, err := impl.userRepository.GetUserByID(ctx, id)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.notification.notifytaskcommented",
+			}).Error(err.Error())
+			lastErr = err
+			continue
+		}
+
+		if recipient.Email == "" {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.notification.notifytaskcommented",
+			}).Info("does not notify when recipient has no email")
+			continue
+		}
+
+		err = impl.mailer.Send(ctx, model.Mail{
+			To:      recipient.Email,
+			Subject: fmt.Sprintf("New comment on task %d", task.ID),
+			Body:    fmt.Sprintf("Hello %s,\n\nThere is a new comment on the task %d:\n\n%s\n", recipient.Username, task.ID, comment.Body),
+		})
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.notification.notifytaskcommented",
+			}).Error(err.Error())
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
@@ -118,7 +118,7 @@ func TestNotificationServiceNotifyAdminUserOnSaveTask(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepositoryMock := mock.NewMockUserRepository(ctrl)
-			notificationService := service.NewNotificationService(userRepositoryMock)
+			notificationService := service.NewNotificationService(userRepositoryMock, nil)
 
 			cs.mocking(userRepositoryMock)
 
@@ -138,7 +138,7 @@ func BenchmarkNotificationServiceNotifyAdminUserOnSaveTask(b *testing.B) {
 	defer ctrl.Finish()
 
 	userRepositoryMock := mock.NewMockUserRepository(ctrl)
-	notificationService := service.NewNotificationService(userRepositoryMock)
+	notificationService := service.NewNotificationService(userRepositoryMock, nil)
 
 	userRepositoryMock.EXPECT().ListUsers(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().
@@ -162,3 +162,366 @@ func BenchmarkNotificationServiceNotifyAdminUserOnSaveTask(b *testing.B) {
 		notificationService.NotifyAdminUserOnSaveTask(ctx, task, task.UserID)
 	}
 }
+
+func TestNotificationServiceNotifyPasswordReset(t *testing.T) {
+	expiresAt := time.Date(2022, 9, 15, 12, 0, 0, 0, time.UTC)
+	user := &model.User{ID: 1, Username: "username", Email: "email@email.com"}
+
+	var cases = map[string]struct {
+		mocking     func(mailer *mock.MockMailer)
+		expectedErr error
+	}{
+		"should send password reset mail": {
+			mocking: func(mailer *mock.MockMailer) {
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, mail model.Mail) {
+						assert.Equal(t, "email@email.com", mail.To)
+						assert.Equal(t, "Reset your password", mail.Subject)
+						assert.Contains(t, mail.Body, "http://localhost/reset-password?token=abc")
+						assert.Contains(t, mail.Body, "2022-09-15 12:00:00 UTC")
+					})
+			},
+		},
+		"should throw error when mailer send": {
+			mocking: func(mailer *mock.MockMailer) {
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mailerMock := mock.NewMockMailer(ctrl)
+			notificationService := service.NewNotificationService(nil, mailerMock)
+
+			cs.mocking(mailerMock)
+
+			// when
+			err := notificationService.NotifyPasswordReset(ctx, user, "http://localhost/reset-password?token=abc", expiresAt)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestNotificationServiceNotifyInvitation(t *testing.T) {
+	invitation := &model.Invitation{
+		ID:        1,
+		ExpiresAt: time.Date(2022, 9, 22, 12, 0, 0, 0, time.UTC),
+		UserID:    2,
+		Username:  "username",
+		Email:     "email@email.com",
+		Role:      model.UserRoleTechnician,
+	}
+
+	var cases = map[string]struct {
+		mocking     func(mailer *mock.MockMailer)
+		expectedErr error
+	}{
+		"should send invitation mail": {
+			mocking: func(mailer *mock.MockMailer) {
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, mail model.Mail) {
+						assert.Equal(t, "email@email.com", mail.To)
+						assert.Equal(t, "You have been invited to Sword Health", mail.Subject)
+						assert.Contains(t, mail.Body, "http://localhost/accept-invitation?token=abc")
+						assert.Contains(t, mail.Body, "2022-09-22 12:00:00 UTC")
+					})
+			},
+		},
+		"should throw error when mailer send": {
+			mocking: func(mailer *mock.MockMailer) {
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mailerMock := mock.NewMockMailer(ctrl)
+			notificationService := service.NewNotificationService(nil, mailerMock)
+
+			cs.mocking(mailerMock)
+
+			// when
+			err := notificationService.NotifyInvitation(ctx, invitation, "http://localhost/accept-invitation?token=abc")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestNotificationServiceNotifyTaskAssigned(t *testing.T) {
+	task := &model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", Status: model.TaskStatusOpened}
+
+	var cases = map[string]struct {
+		mocking     func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer)
+		expectedErr error
+	}{
+		"should send task assigned mail": {
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Username: "technician", Email: "technician@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, mail model.Mail) {
+						assert.Equal(t, "technician@email.com", mail.To)
+						assert.Equal(t, "Task 1 was assigned to you", mail.Subject)
+						assert.Contains(t, mail.Body, "summary")
+					})
+			},
+		},
+		"should not notify when assignee has no email": {
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Username: "technician"}, nil)
+			},
+		},
+		"should throw error when user repository get user by id": {
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(nil, &exception.NotFoundException{Message: "user not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "user not found"},
+		},
+		"should throw error when mailer send": {
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Username: "technician", Email: "technician@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			mailerMock := mock.NewMockMailer(ctrl)
+			notificationService := service.NewNotificationService(userRepositoryMock, mailerMock)
+
+			cs.mocking(userRepositoryMock, mailerMock)
+
+			// when
+			err := notificationService.NotifyTaskAssigned(ctx, task)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestNotificationServiceNotifyTaskTransitioned(t *testing.T) {
+	task := &model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", Status: model.TaskStatusInProgress}
+
+	var cases = map[string]struct {
+		inputTransition *model.TaskTransition
+		mocking         func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer)
+		expectedErr     error
+	}{
+		"should notify creator when assignee moved the task": {
+			inputTransition: &model.TaskTransition{TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress},
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).
+					Return(&model.User{ID: 3, Username: "creator", Email: "creator@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, mail model.Mail) {
+						assert.Equal(t, "creator@email.com", mail.To)
+						assert.Equal(t, "Task 1 is now in_progress", mail.Subject)
+						assert.Contains(t, mail.Body, "from opened to in_progress")
+					})
+			},
+		},
+		"should notify creator and assignee when manager moved the task": {
+			inputTransition: &model.TaskTransition{TaskID: 1, UserID: 9, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress},
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).
+					Return(&model.User{ID: 3, Username: "creator", Email: "creator@email.com"}, nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Username: "technician", Email: "technician@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Times(2)
+			},
+		},
+		"should not notify when recipient has no email": {
+			inputTransition: &model.TaskTransition{TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress},
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).Return(&model.User{ID: 3, Username: "creator"}, nil)
+			},
+		},
+		"should throw error when user repository get user by id": {
+			inputTransition: &model.TaskTransition{TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress},
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when mailer send": {
+			inputTransition: &model.TaskTransition{TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress},
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).
+					Return(&model.User{ID: 3, Username: "creator", Email: "creator@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			mailerMock := mock.NewMockMailer(ctrl)
+			notificationService := service.NewNotificationService(userRepositoryMock, mailerMock)
+
+			cs.mocking(userRepositoryMock, mailerMock)
+
+			// when
+			err := notificationService.NotifyTaskTransitioned(ctx, task, cs.inputTransition)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestNotificationServiceNotifyTaskReminder(t *testing.T) {
+	dueAt := time.Date(1992, 8, 28, 18, 0, 0, 0, time.Local)
+	task := &model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", DueAt: &dueAt}
+
+	var cases = map[string]struct {
+		inputThreshold model.TaskReminderThreshold
+		mocking        func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer)
+		expectedErr    error
+	}{
+		"should send due soon mail": {
+			inputThreshold: model.TaskReminderThresholdDueSoon,
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Username: "technician", Email: "technician@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, mail model.Mail) {
+						assert.Equal(t, "technician@email.com", mail.To)
+						assert.Equal(t, "Task 1 is due soon", mail.Subject)
+						assert.Contains(t, mail.Body, "1992-08-28 18:00:00")
+					})
+			},
+		},
+		"should send overdue mail": {
+			inputThreshold: model.TaskReminderThresholdOverdue,
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Username: "technician", Email: "technician@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, mail model.Mail) {
+						assert.Equal(t, "Task 1 is overdue", mail.Subject)
+					})
+			},
+		},
+		"should not notify when assignee has no email": {
+			inputThreshold: model.TaskReminderThresholdOverdue,
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(&model.User{ID: 2, Username: "technician"}, nil)
+			},
+		},
+		"should throw error when mailer send": {
+			inputThreshold: model.TaskReminderThresholdOverdue,
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Username: "technician", Email: "technician@email.com"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			mailerMock := mock.NewMockMailer(ctrl)
+			notificationService := service.NewNotificationService(userRepositoryMock, mailerMock)
+
+			cs.mocking(userRepositoryMock, mailerMock)
+
+			// when
+			err := notificationService.NotifyTaskReminder(ctx, task, cs.inputThreshold)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestNotificationServiceNotifyTaskCommented(t *testing.T) {
+	task := &model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary"}
+	comment := &model.TaskComment{ID: 1, TaskID: 1, UserID: 2, Body: "waiting for the part"}
+
+	var cases = map[string]struct {
+		mocking     func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer)
+		expectedErr error
+	}{
+		"should send comment mail to recipients": {
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).
+					Return(&model.User{ID: 3, Username: "creator", Email: "creator@email.com"}, nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 5).Return(&model.User{ID: 5, Username: "noemail"}, nil)
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, mail model.Mail) {
+						assert.Equal(t, "creator@email.com", mail.To)
+						assert.Equal(t, "New comment on task 1", mail.Subject)
+						assert.Contains(t, mail.Body, "waiting for the part")
+					})
+			},
+		},
+		"should throw error when user repository get user by id": {
+			mocking: func(userRepository *mock.MockUserRepository, mailer *mock.MockMailer) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 3).
+					Return(&model.User{ID: 3, Username: "creator", Email: "creator@email.com"}, nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 5).Return(nil, fmt.Errorf("error"))
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			mailerMock := mock.NewMockMailer(ctrl)
+			notificationService := service.NewNotificationService(userRepositoryMock, mailerMock)
+
+			cs.mocking(userRepositoryMock, mailerMock)
+
+			// when
+			err := notificationService.NotifyTaskCommented(ctx, task, comment, []int{3, 5})
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"golang.org/x/exp/slices"
+)
+
+type OidcConfig struct {
+	ClientID         string
+	RedirectURL      string
+	Scopes           []string
+	RoleClaim        string
+	ManagerGroups    []string
+	SessionExpiresIn time.Duration
+}
+
+//go:generate mockgen -destination=../../mock/oidc_service_mock.go -package=mock . OidcService
+type OidcService interface {
+	Authorize(ctx context.Context) (*model.OidcAuthorization, error)
+	Callback(ctx context.Context, session, state, code string) (*model.User, error)
+}
+
+type oidcService struct {
+	oidcClient     repository.OidcClient
+	userRepository repository.UserRepository
+	cryptoService  CryptoService
+	config         OidcConfig
+}
+
+func NewOidcService(oidcClient repository.OidcClient, userRepository repository.UserRepository, cryptoService CryptoService,
+	config OidcConfig) OidcService {
+	return &oidcService{
+		oidcClient:     oidcClient,
+		userRepository: userRepository,
+		cryptoService:  cryptoService,
+		config:         config,
+	}
+}
+
+func (impl *oidcService) Authorize(ctx context.Context) (*model.OidcAuthorization, error) {
+	metadata, err := impl.oidcClient.Discover(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.authorize",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	session := model.OidcSession{ExpiresAt: time.Now().Add(impl.config.SessionExpiresIn).Unix()}
+	for _, v := range []*string{&session.State, &session.Nonce, &session.CodeVerifier} {
+		if *v, err = impl.randomString(32); err != nil {
+			return nil, err
+		}
+	}
+
+	sessionJSON, _ := json.Marshal(session)
+	sealedSession, err := impl.cryptoService.Encrypt(string(sessionJSON))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.authorize",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	challenge := sha256.Sum256([]byte(session.CodeVerifier))
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", impl.config.ClientID)
+	query.Set("redirect_uri", impl.config.RedirectURL)
+	query.Set("scope", strings.Join(impl.config.Scopes, " "))
+	query.Set("state", session.State)
+	query.Set("nonce", session.Nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return &model.OidcAuthorization{
+		URL:     metadata.AuthorizationEndpoint + separator + query.Encode(),
+		Session: sealedSession,
+	}, nil
+}
+
+func (impl *oidcService) Callback(ctx context.Context, sealedSession, state, code string) (*model.User, error) {
+	session, err := impl.openSession(sealedSession)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(session.State), []byte(state)) {
+		return nil, &exception.ForbiddenException{Message: "invalid oidc state"}
+	}
+
+	metadata, err := impl.oidcClient.Discover(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.callback",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	tokens, err := impl.oidcClient.ExchangeCode(ctx, metadata.TokenEndpoint, code, session.CodeVerifier)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.callback",
+		}).Error(err.Error())
+
+		if _, ok := err.(*repository.OidcClientError); ok {
+			return nil, &exception.ForbiddenException{Message: "oidc authentication failed"}
+		}
+		return nil, err
+	}
+
+	identity, err := impl.verifyIDToken(ctx, metadata, tokens.IDToken, session.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return impl.provisionUser(ctx, identity)
+}
+
+func (impl *oidcService) openSession(sealedSession string) (*model.OidcSession, error) {
+	if sealedSession == "" {
+		return nil, &exception.ForbiddenException{Message: "invalid oidc session"}
+	}
+
+	sessionJSON, err := impl.cryptoService.Decrypt(sealedSession)
+	if err != nil {
+		return nil, &exception.ForbiddenException{Message: "invalid oidc session"}
+	}
+
+	var session model.OidcSession
+	if err := json.Unmarshal([]byte(sessionJSON), &session); err != nil {
+		return nil, &exception.ForbiddenException{Message: "invalid oidc session"}
+	}
+	if time.Now().Unix() > session.ExpiresAt {
+		return nil, &exception.ExpiredTokenException{Message: "oidc session expired"}
+	}
+
+	return &session, nil
+}
+
+func (impl *oidcService) verifyIDToken(ctx context.Context, metadata *model.OidcProviderMetadata, idToken, nonce string) (*model.OidcIdentity, error) {
+	jwks, err := impl.oidcClient.GetJwks(ctx, metadata.JwksURI)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.verifyidtoken",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	token, err := jwt.Parse(idToken, func(t *jwt.Token) (interface{}, error) {
+		if t.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", t.Method.Alg())
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		return impl.publicKey(jwks, kid)
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.verifyidtoken",
+		}).Warn(err.Error())
+		return nil, &exception.ForbiddenException{Message: "invalid id token"}
+	}
+
+	claims, _ := token.Claims.(jwt.MapClaims)
+	tokenNonce, _ := claims["nonce"].(string)
+	if !claims.VerifyIssuer(metadata.Issuer, true) || !claims.VerifyAudience(impl.config.ClientID, true) ||
+		!hmac.Equal([]byte(tokenNonce), []byte(nonce)) {
+		return nil, &exception.ForbiddenException{Message: "invalid id token"}
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, &exception.ForbiddenException{Message: "invalid id token"}
+	}
+
+	identity := &model.OidcIdentity{
+		Issuer:  metadata.Issuer,
+		Subject: subject,
+		Groups:  impl.claimStrings(claims[impl.config.RoleClaim]),
+	}
+	identity.Email, _ = claims["email"].(string)
+	identity.Username, _ = claims["preferred_username"].(string)
+	if identity.Username == "" {
+		identity.Username = strings.Split(identity.Email, "@")[0]
+	}
+	if identity.Username == "" {
+		identity.Username = subject
+	}
+	identity.Username = truncateRunes(identity.Username, 100)
+
+	return identity, nil
+}
+
+func (impl *oidcService) provisionUser(ctx context.Context, identity *model.OidcIdentity) (*model.User, error) {
+	role := model.UserRoleTechnician
+	for _, group := range identity.Groups {
+		if slices.Contains(impl.config.ManagerGroups, group) {
+			role = model.UserRoleManager
+			break
+		}
+	}
+
+	user, err := impl.userRepository.GetUserByIdentity(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		if user.Role != role {
+			if err := impl.userRepository.UpdateUserRole(ctx, user.ID, role); err != nil {
+				log.WithContext(ctx).WithFields(log.Fields{
+					"trace": "internal.service.oidc.provisionuser",
+				}).Error(err.Error())
+				return nil, err
+			}
+			user.Role = role
+		}
+
+		return user, nil
+	}
+	if _, ok := err.(*exception.NotFoundException); !ok {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.provisionuser",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	users, _, err := impl.userRepository.ListUsers(ctx, 1, 0,
+		repository.SetWhere("WHERE username = ?", []interface{}{identity.Username}))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.provisionuser",
+		}).Error(err.Error())
+		return nil, err
+	}
+	if len(users) > 0 {
+		return nil, &exception.ConflictException{Message: "username already taken"}
+	}
+
+	user, err = impl.userRepository.CreateUserWithIdentity(ctx, dto.CreateUserDto{
+		Username: identity.Username,
+		Email:    identity.Email,
+		Role:     role,
+	}, identity.Issuer, identity.Subject)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.oidc.provisionuser",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (impl *oidcService) publicKey(jwks []model.OidcJwk, kid string) (*rsa.PublicKey, error) {
+	for _, jwk := range jwks {
+		if jwk.Kty != "RSA" || (kid != "" && jwk.Kid != kid) {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("signing key not found: %s", kid)
+}
+
+func (impl *oidcService) claimStrings(claim interface{}) []string {
+	res := []string{}
+	switch v := claim.(type) {
+	case string:
+		res = append(res, strings.Fields(v)...)
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				res = append(res, s)
+			}
+		}
+	}
+
+	return res
+}
+
+func (impl *oidcService) randomString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+var oidcConfig = service.OidcConfig{
+	ClientID:         "swordhealth-api",
+	RedirectURL:      "http://localhost:8080/api/auth/oidc/callback",
+	Scopes:           []string{"openid", "profile", "email", "groups"},
+	RoleClaim:        "groups",
+	ManagerGroups:    []string{"managers"},
+	SessionExpiresIn: 10 * time.Minute,
+}
+
+func TestOidcServiceAuthorize(t *testing.T) {
+	// given
+	ctx := context.Background()
+	idp := mock.NewOidcIdP(oidcConfig.ClientID)
+	defer idp.Close()
+
+	oidcService := service.NewOidcService(
+		repository.NewOidcClient(idp.URL, oidcConfig.ClientID, "", oidcConfig.RedirectURL),
+		nil, service.NewCryptoService("hash-key", "jwt-key", 900000), oidcConfig)
+
+	// when
+	authorization, err := oidcService.Authorize(ctx)
+
+	// then
+	assert.Nil(t, err)
+	assert.NotEmpty(t, authorization.Session)
+	assert.True(t, strings.HasPrefix(authorization.URL, idp.URL+"/authorize?"))
+
+	authorizationURL, _ := url.Parse(authorization.URL)
+	query := authorizationURL.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, oidcConfig.ClientID, query.Get("client_id"))
+	assert.Equal(t, oidcConfig.RedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid profile email groups", query.Get("scope"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.NotEmpty(t, query.Get("state"))
+	assert.NotEmpty(t, query.Get("nonce"))
+}
+
+func TestOidcServiceCallback(t *testing.T) {
+	idp := mock.NewOidcIdP(oidcConfig.ClientID)
+	defer idp.Close()
+
+	technician := &model.User{ID: 1, Username: "jdoe", Email: "jdoe@email.com", Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputClaims  map[string]interface{}
+		inputSession func(session string) string
+		inputState   func(state string) string
+		inputCode    func(code string) string
+		mocking      func(userRepository *mock.MockUserRepository)
+		expectedUser *model.User
+		expectedErr  error
+	}{
+		"should provision technician user just in time": {
+			inputClaims: map[string]interface{}{
+				"sub": "idp-1", "preferred_username": "jdoe", "email": "jdoe@email.com", "groups": []string{"engineers"},
+			},
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByIdentity(gomock.Any(), idp.URL, "idp-1").
+					Return(nil, &exception.NotFoundException{Message: "user not found"})
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).Return([]model.User{}, 0, nil)
+				userRepository.EXPECT().CreateUserWithIdentity(gomock.Any(), dto.CreateUserDto{
+					Username: "jdoe",
+					Email:    "jdoe@email.com",
+					Role:     model.UserRoleTechnician,
+				}, idp.URL, "idp-1").Return(technician, nil)
+			},
+			expectedUser: technician,
+		},
+		"should truncate username without splitting characters": {
+			inputClaims: map[string]interface{}{"sub": "idp-1", "preferred_username": strings.Repeat("ü", 101)},
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByIdentity(gomock.Any(), idp.URL, "idp-1").
+					Return(nil, &exception.NotFoundException{Message: "user not found"})
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).Return([]model.User{}, 0, nil)
+				userRepository.EXPECT().CreateUserWithIdentity(gomock.Any(), dto.CreateUserDto{
+					Username: strings.Repeat("ü", 100),
+					Role:     model.UserRoleTechnician,
+				}, idp.URL, "idp-1").Return(technician, nil)
+			},
+			expectedUser: technician,
+		},
+		"should map manager group to manager role on existing user": {
+			inputClaims: map[string]interface{}{
+				"sub": "idp-1", "preferred_username": "jdoe", "groups": []string{"engineers", "managers"},
+			},
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByIdentity(gomock.Any(), idp.URL, "idp-1").
+					Return(&model.User{ID: 1, Username: "jdoe", Role: model.UserRoleTechnician}, nil)
+				userRepository.EXPECT().UpdateUserRole(gomock.Any(), 1, model.UserRoleManager).Return(nil)
+			},
+			expectedUser: &model.User{ID: 1, Username: "jdoe", Role: model.UserRoleManager},
+		},
+		"should login existing user without role change": {
+			inputClaims: map[string]interface{}{"sub": "idp-1", "email": "jdoe@email.com"},
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByIdentity(gomock.Any(), idp.URL, "idp-1").Return(technician, nil)
+			},
+			expectedUser: technician,
+		},
+		"should throw conflict when username is taken by a local user": {
+			inputClaims: map[string]interface{}{"sub": "idp-2", "preferred_username": "admin"},
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByIdentity(gomock.Any(), idp.URL, "idp-2").
+					Return(nil, &exception.NotFoundException{Message: "user not found"})
+				userRepository.EXPECT().ListUsers(gomock.Any(), 1, 0, gomock.Any()).
+					Return([]model.User{{ID: 1, Username: "admin"}}, 1, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "username already taken"},
+		},
+		"should throw forbidden when state does not match": {
+			inputClaims: map[string]interface{}{"sub": "idp-1"},
+			inputState:  func(state string) string { return "forged" },
+			mocking:     func(userRepository *mock.MockUserRepository) {},
+			expectedErr: &exception.ForbiddenException{Message: "invalid oidc state"},
+		},
+		"should throw forbidden when session is missing": {
+			inputClaims:  map[string]interface{}{"sub": "idp-1"},
+			inputSession: func(session string) string { return "" },
+			mocking:      func(userRepository *mock.MockUserRepository) {},
+			expectedErr:  &exception.ForbiddenException{Message: "invalid oidc session"},
+		},
+		"should throw forbidden when session is tampered": {
+			inputClaims:  map[string]interface{}{"sub": "idp-1"},
+			inputSession: func(session string) string { return "x" + session },
+			mocking:      func(userRepository *mock.MockUserRepository) {},
+			expectedErr:  &exception.ForbiddenException{Message: "invalid oidc session"},
+		},
+		"should throw forbidden when code is rejected by the provider": {
+			inputClaims: map[string]interface{}{"sub": "idp-1"},
+			inputCode:   func(code string) string { return "invalid" },
+			mocking:     func(userRepository *mock.MockUserRepository) {},
+			expectedErr: &exception.ForbiddenException{Message: "oidc authentication failed"},
+		},
+		"should throw error when user repository get user by identity": {
+			inputClaims: map[string]interface{}{"sub": "idp-1"},
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByIdentity(gomock.Any(), idp.URL, "idp-1").Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			oidcService := service.NewOidcService(
+				repository.NewOidcClient(idp.URL, oidcConfig.ClientID, "", oidcConfig.RedirectURL),
+				userRepositoryMock, service.NewCryptoService("hash-key", "jwt-key", 900000), oidcConfig)
+
+			cs.mocking(userRepositoryMock)
+
+			authorization, err := oidcService.Authorize(ctx)
+			assert.Nil(t, err)
+			code, state, err := idp.Authorize(authorization.URL, cs.inputClaims)
+			assert.Nil(t, err)
+
+			session := authorization.Session
+			if cs.inputSession != nil {
+				session = cs.inputSession(session)
+			}
+			if cs.inputState != nil {
+				state = cs.inputState(state)
+			}
+			if cs.inputCode != nil {
+				code = cs.inputCode(code)
+			}
+
+			// when
+			user, err := oidcService.Callback(ctx, session, state, code)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedUser, user)
+		})
+	}
+}
+
+func TestOidcServiceCallbackIDToken(t *testing.T) {
+	idp := mock.NewOidcIdP(oidcConfig.ClientID)
+	defer idp.Close()
+
+	var cases = map[string]struct {
+		inputClaims func(nonce string) map[string]interface{}
+	}{
+		"should reject token with wrong audience": {
+			inputClaims: func(nonce string) map[string]interface{} {
+				return map[string]interface{}{"iss": idp.URL, "aud": "other", "sub": "idp-1", "nonce": nonce,
+					"exp": time.Now().Add(time.Minute).Unix()}
+			},
+		},
+		"should reject token with wrong issuer": {
+			inputClaims: func(nonce string) map[string]interface{} {
+				return map[string]interface{}{"iss": "http://evil", "aud": oidcConfig.ClientID, "sub": "idp-1", "nonce": nonce,
+					"exp": time.Now().Add(time.Minute).Unix()}
+			},
+		},
+		"should reject token with wrong nonce": {
+			inputClaims: func(nonce string) map[string]interface{} {
+				return map[string]interface{}{"iss": idp.URL, "aud": oidcConfig.ClientID, "sub": "idp-1", "nonce": "replayed",
+					"exp": time.Now().Add(time.Minute).Unix()}
+			},
+		},
+		"should reject expired token": {
+			inputClaims: func(nonce string) map[string]interface{} {
+				return map[string]interface{}{"iss": idp.URL, "aud": oidcConfig.ClientID, "sub": "idp-1", "nonce": nonce,
+					"exp": time.Now().Add(-time.Minute).Unix()}
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			oidcClientMock := mock.NewMockOidcClient(ctrl)
+			realClient := repository.NewOidcClient(idp.URL, oidcConfig.ClientID, "", oidcConfig.RedirectURL)
+			metadata, _ := realClient.Discover(ctx)
+			jwks, _ := realClient.GetJwks(ctx, metadata.JwksURI)
+
+			cryptoService := service.NewCryptoService("hash-key", "jwt-key", 900000)
+			oidcService := service.NewOidcService(oidcClientMock, nil, cryptoService, oidcConfig)
+
+			oidcClientMock.EXPECT().Discover(gomock.Any()).Return(metadata, nil).AnyTimes()
+			oidcClientMock.EXPECT().GetJwks(gomock.Any(), metadata.JwksURI).Return(jwks, nil)
+
+			authorization, _ := oidcService.Authorize(ctx)
+			authorizationURL, _ := url.Parse(authorization.URL)
+			query := authorizationURL.Query()
+
+			oidcClientMock.EXPECT().ExchangeCode(gomock.Any(), metadata.TokenEndpoint, "code", gomock.Any()).
+				Return(&model.OidcTokens{IDToken: idp.SignIDToken(cs.inputClaims(query.Get("nonce")))}, nil)
+
+			// when
+			user, err := oidcService.Callback(ctx, authorization.Session, query.Get("state"), "code")
+
+			// then
+			assert.Equal(t, &exception.ForbiddenException{Message: "invalid id token"}, err)
+			assert.Nil(t, user)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	History          int
+}
+
+//go:generate mockgen -destination=../../mock/password_policy_service_mock.go -package=mock . PasswordPolicyService
+type PasswordPolicyService interface {
+	Validate(ctx context.Context, userID int, password string) error
+}
+
+type passwordPolicyService struct {
+	passwordHistoryRepository  repository.PasswordHistoryRepository
+	breachedPasswordRepository repository.BreachedPasswordRepository
+	cryptoService              CryptoService
+	policy                     PasswordPolicy
+}
+
+func NewPasswordPolicyService(passwordHistoryRepository repository.PasswordHistoryRepository,
+	breachedPasswordRepository repository.BreachedPasswordRepository, cryptoService CryptoService,
+	policy PasswordPolicy) PasswordPolicyService {
+	return &passwordPolicyService{
+		passwordHistoryRepository:  passwordHistoryRepository,
+		breachedPasswordRepository: breachedPasswordRepository,
+		cryptoService:              cryptoService,
+		policy:                     policy,
+	}
+}
+
+// Validate checks the password against the policy. The userID is used to
+// reject reuse of previous passwords, use 0 for users without any yet.
+func (impl *passwordPolicyService) Validate(ctx context.Context, userID int, password string) error {
+	fields := []exception.FieldError{}
+	fail := func(tag, message string) {
+		fields = append(fields, exception.FieldError{Field: "password", Tag: tag, Message: message})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if impl.policy.MinLength > 0 && length < impl.policy.MinLength {
+		fail("min", fmt.Sprintf("password must have at least %d characters", impl.policy.MinLength))
+	}
+	if impl.policy.MaxLength > 0 && length > impl.policy.MaxLength {
+		fail("max", fmt.Sprintf("password must have at most %d characters", impl.policy.MaxLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if impl.policy.RequireUppercase && !upper {
+		fail("uppercase", "password must contain an uppercase letter")
+	}
+	if impl.policy.RequireLowercase && !lower {
+		fail("lowercase", "password must contain a lowercase letter")
+	}
+	if impl.policy.RequireDigit && !digit {
+		fail("digit", "password must contain a digit")
+	}
+	if impl.policy.RequireSymbol && !symbol {
+		fail("symbol", "password must contain a symbol")
+	}
+
+	if impl.breachedPasswordRepository.Contains(password) {
+		fail("breached", "password was found in a data breach")
+	}
+
+	if userID != 0 && impl.policy.History > 0 {
+		history, err := impl.passwordHistoryRepository.ListPasswordHistory(ctx, userID, impl.policy.History)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.passwordpolicy.validate",
+			}).Error(err.Error())
+			return err
+		}
+
+		hash := impl.cryptoService.Hash(password)
+		for _, h := range history {
+			if h == hash {
+				fail("reused", fmt.Sprintf("password must not match the last %d passwords", impl.policy.History))
+				break
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return &exception.ValidationException{Message: "invalid fields", Fields: fields}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestPasswordPolicyServiceValidate(t *testing.T) {
+	policy := service.PasswordPolicy{
+		MinLength:        10,
+		MaxLength:        16,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		History:          3,
+	}
+
+	var cases = map[string]struct {
+		inputUserID int
+		inputPass   string
+		mocking     func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository)
+		expectedErr error
+	}{
+		"should accept password for new user": {
+			inputPass: "S3cure-pass",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains("S3cure-pass").Return(false)
+			},
+		},
+		"should accept password not in history": {
+			inputUserID: 1,
+			inputPass:   "S3cure-pass",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains("S3cure-pass").Return(false)
+				passwordHistoryRepository.EXPECT().ListPasswordHistory(gomock.Any(), 1, 3).Return([]string{"old-hash"}, nil)
+			},
+		},
+		"should reject short password without character classes": {
+			inputPass: "abc",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains("abc").Return(false)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "password", Tag: "min", Message: "password must have at least 10 characters"},
+					{Field: "password", Tag: "uppercase", Message: "password must contain an uppercase letter"},
+					{Field: "password", Tag: "digit", Message: "password must contain a digit"},
+					{Field: "password", Tag: "symbol", Message: "password must contain a symbol"},
+				},
+			},
+		},
+		"should reject long password": {
+			inputPass: "S3cure-pass-phrase-too-long",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains(gomock.Any()).Return(false)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "password", Tag: "max", Message: "password must have at most 16 characters"},
+				},
+			},
+		},
+		"should reject password without lowercase": {
+			inputPass: "S3CURE-PASS",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains(gomock.Any()).Return(false)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "password", Tag: "lowercase", Message: "password must contain a lowercase letter"},
+				},
+			},
+		},
+		"should reject breached password": {
+			inputPass: "P@ssw0rd123",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains("P@ssw0rd123").Return(true)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "password", Tag: "breached", Message: "password was found in a data breach"},
+				},
+			},
+		},
+		"should reject reused password": {
+			inputUserID: 1,
+			inputPass:   "S3cure-pass",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains("S3cure-pass").Return(false)
+				passwordHistoryRepository.EXPECT().ListPasswordHistory(gomock.Any(), 1, 3).
+					Return([]string{"old-hash", service.NewCryptoService("hash-key", "", 0).Hash("S3cure-pass")}, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "password", Tag: "reused", Message: "password must not match the last 3 passwords"},
+				},
+			},
+		},
+		"should throw error when password history repository list password history": {
+			inputUserID: 1,
+			inputPass:   "S3cure-pass",
+			mocking: func(passwordHistoryRepository *mock.MockPasswordHistoryRepository, breachedPasswordRepository *mock.MockBreachedPasswordRepository) {
+				breachedPasswordRepository.EXPECT().Contains("S3cure-pass").Return(false)
+				passwordHistoryRepository.EXPECT().ListPasswordHistory(gomock.Any(), 1, 3).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			passwordHistoryRepositoryMock := mock.NewMockPasswordHistoryRepository(ctrl)
+			breachedPasswordRepositoryMock := mock.NewMockBreachedPasswordRepository(ctrl)
+			passwordPolicyService := service.NewPasswordPolicyService(passwordHistoryRepositoryMock, breachedPasswordRepositoryMock,
+				service.NewCryptoService("hash-key", "", 0), policy)
+
+			cs.mocking(passwordHistoryRepositoryMock, breachedPasswordRepositoryMock)
+
+			// when
+			err := passwordPolicyService.Validate(ctx, cs.inputUserID, cs.inputPass)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
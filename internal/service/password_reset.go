@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/password_reset_service_mock.go -package=mock . PasswordResetService
+type PasswordResetService interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+type passwordResetService struct {
+	passwordResetRepository repository.PasswordResetRepository
+	userRepository          repository.UserRepository
+	notificationService     NotificationService
+	cryptoService           CryptoService
+	passwordPolicyService   PasswordPolicyService
+	sessionService          SessionService
+	resetURL                string
+	expiresIn               time.Duration
+}
+
+func NewPasswordResetService(passwordResetRepository repository.PasswordResetRepository, userRepository repository.UserRepository,
+	notificationService NotificationService, cryptoService CryptoService, passwordPolicyService PasswordPolicyService,
+	sessionService SessionService, resetURL string, expiresIn time.Duration) PasswordResetService {
+	return &passwordResetService{
+		passwordResetRepository: passwordResetRepository,
+		userRepository:          userRepository,
+		notificationService:     notificationService,
+		cryptoService:           cryptoService,
+		passwordPolicyService:   passwordPolicyService,
+		sessionService:          sessionService,
+		resetURL:                resetURL,
+		expiresIn:               expiresIn,
+	}
+}
+
+func (impl *passwordResetService) ForgotPassword(ctx context.Context, email string) error {
+	users, _, err := impl.userRepository.ListUsers(ctx, 0, 0,
+		repository.SetWhere("WHERE email = ? AND status = 'active' AND deleted_at IS NULL", []interface{}{email}))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.passwordreset.forgotpassword",
+		}).Error(err.Error())
+		return err
+	}
+
+	for _, user := range users {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return err
+		}
+		token := base64.RawURLEncoding.EncodeToString(b)
+		expiresAt := time.Now().Add(impl.expiresIn)
+
+		err := impl.passwordResetRepository.CreatePasswordResetToken(ctx, user.ID, impl.cryptoService.Hash(token), expiresAt)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.passwordreset.forgotpassword",
+			}).Error(err.Error())
+			return err
+		}
+
+		if err := impl.notificationService.NotifyPasswordReset(ctx, &user, impl.resetLink(token), expiresAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResetPassword revokes every session of the user once the password is
+// changed, so whoever knew the old one is signed out.
+func (impl *passwordResetService) ResetPassword(ctx context.Context, token, password string) error {
+	tokenHash := impl.cryptoService.Hash(token)
+
+	userID, err := impl.passwordResetRepository.GetPasswordResetUserID(ctx, tokenHash)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.ForbiddenException{Message: "invalid or expired password reset token"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.passwordreset.resetpassword",
+		}).Error(err.Error())
+		return err
+	}
+
+	if err := impl.passwordPolicyService.Validate(ctx, userID, password); err != nil {
+		return err
+	}
+
+	userID, err = impl.passwordResetRepository.ResetPassword(ctx, tokenHash, impl.cryptoService.Hash(password))
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.ForbiddenException{Message: "invalid or expired password reset token"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.passwordreset.resetpassword",
+		}).Error(err.Error())
+		return err
+	}
+
+	return impl.sessionService.RevokeUserSessions(ctx, userID)
+}
+
+func (impl *passwordResetService) resetLink(token string) string {
+	separator := "?"
+	if strings.Contains(impl.resetURL, "?") {
+		separator = "&"
+	}
+
+	return fmt.Sprintf("%s%stoken=%s", impl.resetURL, separator, url.QueryEscape(token))
+}
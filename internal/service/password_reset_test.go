@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestPasswordResetServiceForgotPassword(t *testing.T) {
+	user := model.User{ID: 1, Username: "username", Email: "email@email.com"}
+
+	var cases = map[string]struct {
+		mocking     func(passwordResetRepository *mock.MockPasswordResetRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService)
+		expectedErr error
+	}{
+		"should send reset token when account exists": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				var tokenHash string
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0, gomock.Any()).Return([]model.User{user}, 1, nil)
+				passwordResetRepository.EXPECT().CreatePasswordResetToken(gomock.Any(), 1, gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, userID int, hash string, expiresAt time.Time) {
+						tokenHash = hash
+						assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Second)
+					})
+				notificationService.EXPECT().NotifyPasswordReset(gomock.Any(), &user, gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, u *model.User, resetLink string, expiresAt time.Time) {
+						token := strings.TrimPrefix(resetLink, "http://localhost/reset-password?token=")
+						assert.NotEqual(t, resetLink, token)
+						assert.NotEqual(t, token, tokenHash)
+						assert.Equal(t, service.NewCryptoService("hash-key", "", 0).Hash(token), tokenHash)
+					})
+			},
+		},
+		"should do nothing when account does not exist": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0, gomock.Any()).Return([]model.User{}, 0, nil)
+			},
+		},
+		"should throw error when password reset repository create token": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0, gomock.Any()).Return([]model.User{user}, 1, nil)
+				passwordResetRepository.EXPECT().CreatePasswordResetToken(gomock.Any(), 1, gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when user repository list users": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, userRepository *mock.MockUserRepository, notificationService *mock.MockNotificationService) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0, gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			passwordResetRepositoryMock := mock.NewMockPasswordResetRepository(ctrl)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			passwordResetService := service.NewPasswordResetService(passwordResetRepositoryMock, userRepositoryMock,
+				notificationServiceMock, service.NewCryptoService("hash-key", "", 0), nil, nil, "http://localhost/reset-password", 15*time.Minute)
+
+			cs.mocking(passwordResetRepositoryMock, userRepositoryMock, notificationServiceMock)
+
+			// when
+			err := passwordResetService.ForgotPassword(ctx, "email@email.com")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestPasswordResetServiceResetPassword(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService)
+		expectedErr error
+	}{
+		"should reset password": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService) {
+				passwordResetRepository.EXPECT().GetPasswordResetUserID(gomock.Any(), "token-hash").Return(1, nil)
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "S3cR31-p4ss").Return(nil)
+				cryptoService.EXPECT().Hash("S3cR31-p4ss").Return("password-hash")
+				passwordResetRepository.EXPECT().ResetPassword(gomock.Any(), "token-hash", "password-hash").Return(1, nil)
+				sessionService.EXPECT().RevokeUserSessions(gomock.Any(), 1).Return(nil)
+			},
+		},
+		"should throw error when session service revoke user sessions": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService) {
+				passwordResetRepository.EXPECT().GetPasswordResetUserID(gomock.Any(), "token-hash").Return(1, nil)
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "S3cR31-p4ss").Return(nil)
+				cryptoService.EXPECT().Hash("S3cR31-p4ss").Return("password-hash")
+				passwordResetRepository.EXPECT().ResetPassword(gomock.Any(), "token-hash", "password-hash").Return(1, nil)
+				sessionService.EXPECT().RevokeUserSessions(gomock.Any(), 1).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw forbidden when token is invalid, used or expired": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService) {
+				passwordResetRepository.EXPECT().GetPasswordResetUserID(gomock.Any(), "token-hash").
+					Return(0, &exception.NotFoundException{Message: "password reset token not found"})
+			},
+			expectedErr: &exception.ForbiddenException{Message: "invalid or expired password reset token"},
+		},
+		"should throw validation error when password does not match the policy": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService) {
+				passwordResetRepository.EXPECT().GetPasswordResetUserID(gomock.Any(), "token-hash").Return(1, nil)
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "S3cR31-p4ss").
+					Return(&exception.ValidationException{Message: "invalid fields"})
+			},
+			expectedErr: &exception.ValidationException{Message: "invalid fields"},
+		},
+		"should throw forbidden when token is used concurrently": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService) {
+				passwordResetRepository.EXPECT().GetPasswordResetUserID(gomock.Any(), "token-hash").Return(1, nil)
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "S3cR31-p4ss").Return(nil)
+				cryptoService.EXPECT().Hash("S3cR31-p4ss").Return("password-hash")
+				passwordResetRepository.EXPECT().ResetPassword(gomock.Any(), "token-hash", "password-hash").
+					Return(0, &exception.NotFoundException{Message: "password reset token not found"})
+			},
+			expectedErr: &exception.ForbiddenException{Message: "invalid or expired password reset token"},
+		},
+		"should throw error when password reset repository reset password": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService) {
+				passwordResetRepository.EXPECT().GetPasswordResetUserID(gomock.Any(), "token-hash").Return(1, nil)
+				passwordPolicyService.EXPECT().Validate(gomock.Any(), 1, "S3cR31-p4ss").Return(nil)
+				cryptoService.EXPECT().Hash("S3cR31-p4ss").Return("password-hash")
+				passwordResetRepository.EXPECT().ResetPassword(gomock.Any(), "token-hash", "password-hash").
+					Return(0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when password reset repository get user id": {
+			mocking: func(passwordResetRepository *mock.MockPasswordResetRepository, cryptoService *mock.MockCryptoService, passwordPolicyService *mock.MockPasswordPolicyService, sessionService *mock.MockSessionService) {
+				passwordResetRepository.EXPECT().GetPasswordResetUserID(gomock.Any(), "token-hash").Return(0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			passwordResetRepositoryMock := mock.NewMockPasswordResetRepository(ctrl)
+			cryptoServiceMock := mock.NewMockCryptoService(ctrl)
+			passwordPolicyServiceMock := mock.NewMockPasswordPolicyService(ctrl)
+			sessionServiceMock := mock.NewMockSessionService(ctrl)
+			passwordResetService := service.NewPasswordResetService(passwordResetRepositoryMock, nil, nil,
+				cryptoServiceMock, passwordPolicyServiceMock, sessionServiceMock, "http://localhost/reset-password", 15*time.Minute)
+
+			cryptoServiceMock.EXPECT().Hash("token").Return("token-hash")
+			cs.mocking(passwordResetRepositoryMock, cryptoServiceMock, passwordPolicyServiceMock, sessionServiceMock)
+
+			// when
+			err := passwordResetService.ResetPassword(ctx, "token", "S3cR31-p4ss")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
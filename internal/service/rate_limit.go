@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+type RateLimitPolicy struct {
+	Name        string
+	Capacity    int
+	RefillEvery time.Duration
+}
+
+//go:generate mockgen -destination=../../mock/rate_limit_service_mock.go -package=mock . RateLimitService
+type RateLimitService interface {
+	Take(ctx context.Context, policy RateLimitPolicy, identity string) (*model.RateLimit, error)
+}
+
+type rateLimitService struct {
+	rateLimitStore repository.RateLimitStore
+}
+
+func NewRateLimitService(rateLimitStore repository.RateLimitStore) RateLimitService {
+	return &rateLimitService{
+		rateLimitStore: rateLimitStore,
+	}
+}
+
+func (impl *rateLimitService) Take(ctx context.Context, policy RateLimitPolicy, identity string) (*model.RateLimit, error) {
+	key := fmt.Sprintf("ratelimit:%s:%s", policy.Name, identity)
+
+	rateLimit, err := impl.rateLimitStore.Take(ctx, key, policy.Capacity, policy.RefillEvery, time.Now())
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.ratelimit.take",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	if !rateLimit.Allowed() {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace":    "internal.service.ratelimit.take",
+			"policy":   policy.Name,
+			"identity": identity,
+		}).Warn("rate limit exceeded")
+	}
+
+	return rateLimit, nil
+}
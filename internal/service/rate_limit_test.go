@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestRateLimitServiceTake(t *testing.T) {
+	policy := service.RateLimitPolicy{Name: "tasks", Capacity: 10, RefillEvery: time.Second}
+
+	var cases = map[string]struct {
+		mocking           func(rateLimitStore *mock.MockRateLimitStore)
+		expectedRateLimit *model.RateLimit
+		expectedErr       error
+	}{
+		"should take token": {
+			mocking: func(rateLimitStore *mock.MockRateLimitStore) {
+				rateLimitStore.EXPECT().Take(gomock.Any(), "ratelimit:tasks:user:1", 10, time.Second, gomock.Any()).
+					Return(&model.RateLimit{Limit: 10, Remaining: 9, Reset: time.Second}, nil)
+			},
+			expectedRateLimit: &model.RateLimit{Limit: 10, Remaining: 9, Reset: time.Second},
+		},
+		"should return exceeded rate limit": {
+			mocking: func(rateLimitStore *mock.MockRateLimitStore) {
+				rateLimitStore.EXPECT().Take(gomock.Any(), "ratelimit:tasks:user:1", 10, time.Second, gomock.Any()).
+					Return(&model.RateLimit{Limit: 10, Reset: 10 * time.Second, RetryAfter: time.Second}, nil)
+			},
+			expectedRateLimit: &model.RateLimit{Limit: 10, Reset: 10 * time.Second, RetryAfter: time.Second},
+		},
+		"should throw error when rate limit store take": {
+			mocking: func(rateLimitStore *mock.MockRateLimitStore) {
+				rateLimitStore.EXPECT().Take(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rateLimitStoreMock := mock.NewMockRateLimitStore(ctrl)
+			rateLimitService := service.NewRateLimitService(rateLimitStoreMock)
+
+			cs.mocking(rateLimitStoreMock)
+
+			// when
+			rateLimit, err := rateLimitService.Take(ctx, policy, "user:1")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedRateLimit, rateLimit)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/session_service_mock.go -package=mock . SessionService
+type SessionService interface {
+	CreateSession(ctx context.Context, userID int, ip, userAgent string) (*model.Session, error)
+	ListSessions(ctx context.Context, userID int) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userID, id int) error
+	RevokeUserSessions(ctx context.Context, userID int) error
+	ValidateSession(ctx context.Context, userID, id int) error
+}
+
+type sessionCacheEntry struct {
+	userID    int
+	checkedAt time.Time
+}
+
+type sessionService struct {
+	sessionRepository repository.SessionRepository
+	expiresIn         time.Duration
+	cacheTTL          time.Duration
+
+	mu    sync.Mutex
+	cache map[int]sessionCacheEntry
+}
+
+// NewSessionService creates the session service. Validated sessions are
+// cached for cacheTTL, which bounds how long a revocation made on another
+// instance takes to be enforced.
+func NewSessionService(sessionRepository repository.SessionRepository, expiresIn, cacheTTL time.Duration) SessionService {
+	return &sessionService{
+		sessionRepository: sessionRepository,
+		expiresIn:         expiresIn,
+		cacheTTL:          cacheTTL,
+		cache:             map[int]sessionCacheEntry{},
+	}
+}
+
+func (impl *sessionService) CreateSession(ctx context.Context, userID int, ip, userAgent string) (*model.Session, error) {
+	userAgent = truncateRunes(userAgent, 255)
+
+	session, err := impl.sessionRepository.CreateSession(ctx, model.Session{
+		ExpiresAt: time.Now().Add(impl.expiresIn),
+		UserID:    userID,
+		Device:    model.DeviceFromUserAgent(userAgent),
+		IP:        ip,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.session.createsession",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (impl *sessionService) ListSessions(ctx context.Context, userID int) ([]model.Session, error) {
+	sessions, err := impl.sessionRepository.ListSessionsByUserID(ctx, userID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.session.listsessions",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (impl *sessionService) RevokeSession(ctx context.Context, userID, id int) error {
+	err := impl.sessionRepository.RevokeSession(ctx, userID, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.session.revokesession",
+			}).Error(err.Error())
+		}
+
+		return err
+	}
+
+	impl.mu.Lock()
+	delete(impl.cache, id)
+	impl.mu.Unlock()
+
+	return nil
+}
+
+func (impl *sessionService) RevokeUserSessions(ctx context.Context, userID int) error {
+	err := impl.sessionRepository.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.session.revokeusersessions",
+		}).Error(err.Error())
+		return err
+	}
+
+	impl.mu.Lock()
+	for id, entry := range impl.cache {
+		if entry.userID == userID {
+			delete(impl.cache, id)
+		}
+	}
+	impl.mu.Unlock()
+
+	return nil
+}
+
+func (impl *sessionService) ValidateSession(ctx context.Context, userID, id int) error {
+	now := time.Now()
+
+	impl.mu.Lock()
+	entry, ok := impl.cache[id]
+	impl.mu.Unlock()
+	if ok && entry.userID == userID && now.Sub(entry.checkedAt) < impl.cacheTTL {
+		return nil
+	}
+
+	session, err := impl.sessionRepository.GetSessionByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.ForbiddenException{Message: "session revoked"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.session.validatesession",
+		}).Error(err.Error())
+		return err
+	}
+	if session.UserID != userID || !session.Active(now) {
+		impl.mu.Lock()
+		delete(impl.cache, id)
+		impl.mu.Unlock()
+
+		return &exception.ForbiddenException{Message: "session revoked"}
+	}
+
+	if err := impl.sessionRepository.TouchSession(ctx, id, now); err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.session.validatesession",
+		}).Error(err.Error())
+	}
+
+	impl.mu.Lock()
+	for k, e := range impl.cache {
+		if now.Sub(e.checkedAt) >= impl.cacheTTL {
+			delete(impl.cache, k)
+		}
+	}
+	impl.cache[id] = sessionCacheEntry{userID: userID, checkedAt: now}
+	impl.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,333 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestSessionServiceCreateSession(t *testing.T) {
+	userAgent := "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) Chrome/105.0.0.0 Safari/537.36"
+
+	var cases = map[string]struct {
+		inputUserAgent  string
+		mocking         func(sessionRepository *mock.MockSessionRepository)
+		expectedSession *model.Session
+		expectedErr     error
+	}{
+		"should create session": {
+			inputUserAgent: userAgent,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, session model.Session) (*model.Session, error) {
+						assert.Equal(t, 1, session.UserID)
+						assert.Equal(t, "Chrome on macOS", session.Device)
+						assert.Equal(t, "127.0.0.1", session.IP)
+						assert.Equal(t, userAgent, session.UserAgent)
+						assert.WithinDuration(t, time.Now().Add(time.Hour), session.ExpiresAt, time.Minute)
+						session.ID = 7
+						return &session, nil
+					})
+			},
+			expectedSession: &model.Session{ID: 7},
+		},
+		"should truncate user agent without splitting characters": {
+			inputUserAgent: strings.Repeat("ü", 256),
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, session model.Session) (*model.Session, error) {
+						assert.Equal(t, strings.Repeat("ü", 255), session.UserAgent)
+						session.ID = 7
+						return &session, nil
+					})
+			},
+			expectedSession: &model.Session{ID: 7},
+		},
+		"should throw error when session repository create session": {
+			inputUserAgent: userAgent,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepositoryMock := mock.NewMockSessionRepository(ctrl)
+			sessionService := service.NewSessionService(sessionRepositoryMock, time.Hour, time.Minute)
+
+			cs.mocking(sessionRepositoryMock)
+
+			// when
+			res, err := sessionService.CreateSession(ctx, 1, "127.0.0.1", cs.inputUserAgent)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedSession != nil {
+				assert.Equal(t, cs.expectedSession.ID, res.ID)
+			}
+		})
+	}
+}
+
+func TestSessionServiceListSessions(t *testing.T) {
+	sessions := []model.Session{{ID: 7, UserID: 1, Device: "Chrome on macOS"}}
+
+	var cases = map[string]struct {
+		mocking          func(sessionRepository *mock.MockSessionRepository)
+		expectedSessions []model.Session
+		expectedErr      error
+	}{
+		"should list sessions": {
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().ListSessionsByUserID(gomock.Any(), 1).Return(sessions, nil)
+			},
+			expectedSessions: sessions,
+		},
+		"should throw error when session repository list sessions": {
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().ListSessionsByUserID(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepositoryMock := mock.NewMockSessionRepository(ctrl)
+			sessionService := service.NewSessionService(sessionRepositoryMock, time.Hour, time.Minute)
+
+			cs.mocking(sessionRepositoryMock)
+
+			// when
+			res, err := sessionService.ListSessions(ctx, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedSessions, res)
+		})
+	}
+}
+
+func TestSessionServiceRevokeSession(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(sessionRepository *mock.MockSessionRepository)
+		expectedErr error
+	}{
+		"should revoke session and drop it from cache": {
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				gomock.InOrder(
+					sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+						Return(&model.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil),
+					sessionRepository.EXPECT().TouchSession(gomock.Any(), 7, gomock.Any()).Return(nil),
+					sessionRepository.EXPECT().RevokeSession(gomock.Any(), 1, 7).Return(nil),
+					sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+						Return(nil, &exception.NotFoundException{Message: "session not found"}),
+				)
+			},
+			expectedErr: &exception.ForbiddenException{Message: "session revoked"},
+		},
+		"should throw not found": {
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				gomock.InOrder(
+					sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+						Return(&model.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil),
+					sessionRepository.EXPECT().TouchSession(gomock.Any(), 7, gomock.Any()).Return(nil),
+					sessionRepository.EXPECT().RevokeSession(gomock.Any(), 1, 7).
+						Return(&exception.NotFoundException{Message: "session not found"}),
+				)
+			},
+			expectedErr: &exception.NotFoundException{Message: "session not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepositoryMock := mock.NewMockSessionRepository(ctrl)
+			sessionService := service.NewSessionService(sessionRepositoryMock, time.Hour, time.Minute)
+
+			cs.mocking(sessionRepositoryMock)
+			assert.Nil(t, sessionService.ValidateSession(ctx, 1, 7))
+
+			// when
+			err := sessionService.RevokeSession(ctx, 1, 7)
+			if err == nil {
+				err = sessionService.ValidateSession(ctx, 1, 7)
+			}
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestSessionServiceRevokeUserSessions(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(sessionRepository *mock.MockSessionRepository)
+		expectedErr error
+	}{
+		"should revoke user sessions and drop them from cache": {
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				gomock.InOrder(
+					sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+						Return(&model.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil),
+					sessionRepository.EXPECT().TouchSession(gomock.Any(), 7, gomock.Any()).Return(nil),
+					sessionRepository.EXPECT().RevokeUserSessions(gomock.Any(), 1).Return(nil),
+					sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+						Return(&model.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &time.Time{}}, nil),
+				)
+			},
+			expectedErr: &exception.ForbiddenException{Message: "session revoked"},
+		},
+		"should throw error when session repository revoke user sessions": {
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				gomock.InOrder(
+					sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+						Return(&model.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil),
+					sessionRepository.EXPECT().TouchSession(gomock.Any(), 7, gomock.Any()).Return(nil),
+					sessionRepository.EXPECT().RevokeUserSessions(gomock.Any(), 1).Return(fmt.Errorf("error")),
+				)
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepositoryMock := mock.NewMockSessionRepository(ctrl)
+			sessionService := service.NewSessionService(sessionRepositoryMock, time.Hour, time.Minute)
+
+			cs.mocking(sessionRepositoryMock)
+			assert.Nil(t, sessionService.ValidateSession(ctx, 1, 7))
+
+			// when
+			err := sessionService.RevokeUserSessions(ctx, 1)
+			if err == nil {
+				err = sessionService.ValidateSession(ctx, 1, 7)
+			}
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestSessionServiceValidateSession(t *testing.T) {
+	now := time.Now()
+	active := &model.Session{ID: 7, UserID: 1, ExpiresAt: now.Add(time.Hour)}
+
+	var cases = map[string]struct {
+		inputUserID int
+		inputCalls  int
+		mocking     func(sessionRepository *mock.MockSessionRepository)
+		expectedErr error
+	}{
+		"should validate session and cache it": {
+			inputUserID: 1,
+			inputCalls:  2,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).Return(active, nil)
+				sessionRepository.EXPECT().TouchSession(gomock.Any(), 7, gomock.Any()).Return(nil)
+			},
+		},
+		"should validate session when touch session fails": {
+			inputUserID: 1,
+			inputCalls:  1,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).Return(active, nil)
+				sessionRepository.EXPECT().TouchSession(gomock.Any(), 7, gomock.Any()).Return(fmt.Errorf("error"))
+			},
+		},
+		"should throw forbidden when session is revoked": {
+			inputUserID: 1,
+			inputCalls:  1,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+					Return(&model.Session{ID: 7, UserID: 1, ExpiresAt: now.Add(time.Hour), RevokedAt: &now}, nil)
+			},
+			expectedErr: &exception.ForbiddenException{Message: "session revoked"},
+		},
+		"should throw forbidden when session is expired": {
+			inputUserID: 1,
+			inputCalls:  1,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+					Return(&model.Session{ID: 7, UserID: 1, ExpiresAt: now.Add(-time.Hour)}, nil)
+			},
+			expectedErr: &exception.ForbiddenException{Message: "session revoked"},
+		},
+		"should throw forbidden when session belongs to another user": {
+			inputUserID: 2,
+			inputCalls:  1,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).Return(active, nil)
+			},
+			expectedErr: &exception.ForbiddenException{Message: "session revoked"},
+		},
+		"should throw forbidden when session is not found": {
+			inputUserID: 1,
+			inputCalls:  1,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).
+					Return(nil, &exception.NotFoundException{Message: "session not found"})
+			},
+			expectedErr: &exception.ForbiddenException{Message: "session revoked"},
+		},
+		"should throw error when session repository get session by id": {
+			inputUserID: 1,
+			inputCalls:  1,
+			mocking: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().GetSessionByID(gomock.Any(), 7).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepositoryMock := mock.NewMockSessionRepository(ctrl)
+			sessionService := service.NewSessionService(sessionRepositoryMock, time.Hour, time.Minute)
+
+			cs.mocking(sessionRepositoryMock)
+
+			// when
+			var err error
+			for i := 0; i < cs.inputCalls; i++ {
+				err = sessionService.ValidateSession(ctx, cs.inputUserID, 7)
+			}
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
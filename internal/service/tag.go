@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9]+([ _-][a-z0-9]+)*$`)
+
+//go:generate mockgen -destination=../../mock/tag_service_mock.go -package=mock . TagService
+type TagService interface {
+	CreateTag(ctx context.Context, data dto.CreateTagDto) (*model.Tag, error)
+	ListTags(ctx context.Context) ([]model.Tag, error)
+	UpdateTag(ctx context.Context, id int, data dto.UpdateTagDto) (*model.Tag, error)
+	DeleteTag(ctx context.Context, id int) error
+}
+
+type tagService struct {
+	tagRepository repository.TagRepository
+}
+
+func NewTagService(tagRepository repository.TagRepository) TagService {
+	return &tagService{
+		tagRepository: tagRepository,
+	}
+}
+
+func (impl *tagService) CreateTag(ctx context.Context, data dto.CreateTagDto) (*model.Tag, error) {
+	name, err := parseTagName(data.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := impl.tagRepository.CreateTag(ctx, name)
+	if err != nil {
+		if _, ok := err.(*exception.ConflictException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tag.createtag",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (impl *tagService) ListTags(ctx context.Context) ([]model.Tag, error) {
+	tags, err := impl.tagRepository.ListTags(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.tag.listtags",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (impl *tagService) UpdateTag(ctx context.Context, id int, data dto.UpdateTagDto) (*model.Tag, error) {
+	name, err := parseTagName(data.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := impl.tagRepository.UpdateTag(ctx, id, name)
+	if err != nil {
+		switch err.(type) {
+		case *exception.NotFoundException, *exception.ConflictException:
+		default:
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tag.updatetag",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (impl *tagService) DeleteTag(ctx context.Context, id int) error {
+	err := impl.tagRepository.DeleteTag(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tag.deletetag",
+			}).Error(err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// parseTagName lower cases the name, so "Patient Visit" and "patient visit"
+// are the same tag.
+func parseTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !tagNamePattern.MatchString(name) {
+		return "", &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{{
+				Field: "name", Tag: "tag",
+				Message: "name must only have letters and numbers, separated by a space, _ or -",
+			}},
+		}
+	}
+
+	return name, nil
+}
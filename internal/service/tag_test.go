@@ -0,0 +1,220 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTagServiceCreateTag(t *testing.T) {
+	now := time.Now()
+	tag := &model.Tag{ID: 1, CreatedAt: now, UpdatedAt: now, Name: "patient visit"}
+
+	var cases = map[string]struct {
+		inputData   dto.CreateTagDto
+		mocking     func(tagRepository *mock.MockTagRepository)
+		expectedTag *model.Tag
+		expectedErr error
+	}{
+		"should create tag": {
+			inputData: dto.CreateTagDto{Name: " Patient Visit "},
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().CreateTag(gomock.Any(), "patient visit").Return(tag, nil)
+			},
+			expectedTag: tag,
+		},
+		"should throw validation exception when name is invalid": {
+			inputData: dto.CreateTagDto{Name: "a,b"},
+			mocking:   func(tagRepository *mock.MockTagRepository) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{{
+					Field: "name", Tag: "tag", Message: "name must only have letters and numbers, separated by a space, _ or -",
+				}},
+			},
+		},
+		"should throw conflict when tag already exists": {
+			inputData: dto.CreateTagDto{Name: "maintenance"},
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().CreateTag(gomock.Any(), "maintenance").
+					Return(nil, &exception.ConflictException{Message: "tag already exists"})
+			},
+			expectedErr: &exception.ConflictException{Message: "tag already exists"},
+		},
+		"should throw error when tag repository create tag": {
+			inputData: dto.CreateTagDto{Name: "maintenance"},
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().CreateTag(gomock.Any(), "maintenance").Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tagRepositoryMock := mock.NewMockTagRepository(ctrl)
+			tagService := service.NewTagService(tagRepositoryMock)
+
+			cs.mocking(tagRepositoryMock)
+
+			// when
+			tag, err := tagService.CreateTag(ctx, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedTag, tag)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTagServiceListTags(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(tagRepository *mock.MockTagRepository)
+		expectedTags []model.Tag
+		expectedErr  error
+	}{
+		"should list tags": {
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTags(gomock.Any()).Return([]model.Tag{{ID: 1, Name: "maintenance", UsageCount: 3}}, nil)
+			},
+			expectedTags: []model.Tag{{ID: 1, Name: "maintenance", UsageCount: 3}},
+		},
+		"should throw error when tag repository list tags": {
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTags(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tagRepositoryMock := mock.NewMockTagRepository(ctrl)
+			tagService := service.NewTagService(tagRepositoryMock)
+
+			cs.mocking(tagRepositoryMock)
+
+			// when
+			tags, err := tagService.ListTags(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedTags, tags)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTagServiceUpdateTag(t *testing.T) {
+	tag := &model.Tag{ID: 1, Name: "equipment", UsageCount: 2}
+
+	var cases = map[string]struct {
+		inputData   dto.UpdateTagDto
+		mocking     func(tagRepository *mock.MockTagRepository)
+		expectedTag *model.Tag
+		expectedErr error
+	}{
+		"should update tag": {
+			inputData: dto.UpdateTagDto{Name: "Equipment"},
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().UpdateTag(gomock.Any(), 1, "equipment").Return(tag, nil)
+			},
+			expectedTag: tag,
+		},
+		"should throw not found": {
+			inputData: dto.UpdateTagDto{Name: "equipment"},
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().UpdateTag(gomock.Any(), 1, "equipment").
+					Return(nil, &exception.NotFoundException{Message: "tag not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "tag not found"},
+		},
+		"should throw error when tag repository update tag": {
+			inputData: dto.UpdateTagDto{Name: "equipment"},
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().UpdateTag(gomock.Any(), 1, "equipment").Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tagRepositoryMock := mock.NewMockTagRepository(ctrl)
+			tagService := service.NewTagService(tagRepositoryMock)
+
+			cs.mocking(tagRepositoryMock)
+
+			// when
+			tag, err := tagService.UpdateTag(ctx, 1, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedTag, tag)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTagServiceDeleteTag(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(tagRepository *mock.MockTagRepository)
+		expectedErr error
+	}{
+		"should delete tag": {
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().DeleteTag(gomock.Any(), 1).Return(nil)
+			},
+		},
+		"should throw not found": {
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().DeleteTag(gomock.Any(), 1).Return(&exception.NotFoundException{Message: "tag not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "tag not found"},
+		},
+		"should throw error when tag repository delete tag": {
+			mocking: func(tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().DeleteTag(gomock.Any(), 1).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tagRepositoryMock := mock.NewMockTagRepository(ctrl)
+			tagService := service.NewTagService(tagRepositoryMock)
+
+			cs.mocking(tagRepositoryMock)
+
+			// when
+			err := tagService.DeleteTag(ctx, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -2,30 +2,147 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
 	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"golang.org/x/exp/slices"
 )
 
+type TaskTransitionRule struct {
+	From  model.TaskStatus
+	To    model.TaskStatus
+	Roles []model.UserRole
+}
+
+// TaskWorkflow lists the allowed status transitions. Tasks are created
+// opened, so every other status must be reachable from a rule.
+type TaskWorkflow struct {
+	Transitions []TaskTransitionRule
+}
+
+func (w TaskWorkflow) HasStatus(status model.TaskStatus) bool {
+	if status == model.TaskStatusOpened {
+		return true
+	}
+	for _, t := range w.Transitions {
+		if t.From == status || t.To == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w TaskWorkflow) Rule(from, to model.TaskStatus) (TaskTransitionRule, bool) {
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return t, true
+		}
+	}
+
+	return TaskTransitionRule{}, false
+}
+
+func (w TaskWorkflow) Targets(from model.TaskStatus) []string {
+	targets := []string{}
+	for _, t := range w.Transitions {
+		if t.From == from {
+			targets = append(targets, string(t.To))
+		}
+	}
+
+	return targets
+}
+
+// maxTaskTreeDepth bounds the levels of subtasks returned by GetTaskTree.
+const maxTaskTreeDepth = 10
+
+var taskSorts = map[string]string{
+	"created_at":  "created_at, id",
+	"-created_at": "created_at DESC, id DESC",
+	"due_at":      "due_at IS NULL, due_at, id",
+	"-due_at":     "due_at IS NULL, due_at DESC, id",
+	"priority":    "FIELD(priority, 'low', 'normal', 'high', 'urgent'), id",
+	"-priority":   "FIELD(priority, 'low', 'normal', 'high', 'urgent') DESC, id",
+}
+
 //go:generate mockgen -destination=../../mock/task_service_mock.go -package=mock . TaskService
 type TaskService interface {
-	CreateTask(ctx context.Context, userID int, summary string) (*model.Task, error)
-	ListTasks(ctx context.Context, limit, offset int, user *model.User) ([]model.Task, int, error)
+	CreateTask(ctx context.Context, user *model.User, data dto.CreateTaskDto) (*model.Task, error)
+	ListTasks(ctx context.Context, limit, offset int, user *model.User, filter dto.ListTasksFilterDto) ([]model.Task, int, error)
+	ExportTasks(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error
+	GetTask(ctx context.Context, user *model.User, id int) (*model.Task, error)
+	UpdateTask(ctx context.Context, user *model.User, id int, data dto.UpdateTaskDto) (*model.Task, error)
+	AssignTask(ctx context.Context, id, assigneeID int) (*model.Task, error)
+	TagTask(ctx context.Context, user *model.User, id int, tags []string) (*model.Task, error)
+	DeleteTask(ctx context.Context, user *model.User, id int) error
+	TransitionTask(ctx context.Context, user *model.User, id int, to model.TaskStatus, force bool) (*model.Task, *model.TaskTransition, error)
+	ListTaskTransitions(ctx context.Context, user *model.User, id int) ([]model.TaskTransition, error)
+	AddTaskDependency(ctx context.Context, user *model.User, id, blockedByID int) (*model.Task, error)
+	RemoveTaskDependency(ctx context.Context, user *model.User, id, blockedByID int) error
+	GetTaskTree(ctx context.Context, user *model.User, id int) (*model.TaskTree, error)
 }
 
 type taskService struct {
 	taskRepository repository.TaskRepository
+	userRepository repository.UserRepository
+	tagRepository  repository.TagRepository
+	workflow       TaskWorkflow
 }
 
-func NewTaskService(taskRepository repository.TaskRepository) TaskService {
+func NewTaskService(taskRepository repository.TaskRepository, userRepository repository.UserRepository,
+	tagRepository repository.TagRepository, workflow TaskWorkflow) TaskService {
 	return &taskService{
 		taskRepository: taskRepository,
+		userRepository: userRepository,
+		tagRepository:  tagRepository,
+		workflow:       workflow,
 	}
 }
 
-func (impl *taskService) CreateTask(ctx context.Context, userID int, summary string) (*model.Task, error) {
-	task, err := impl.taskRepository.CreateTask(ctx, userID, summary)
+func (impl *taskService) CreateTask(ctx context.Context, user *model.User, data dto.CreateTaskDto) (*model.Task, error) {
+	assigneeID := user.ID
+	if data.AssigneeID != nil && *data.AssigneeID != user.ID {
+		if user.Role != model.UserRoleManager {
+			return nil, &exception.UnauthorizedException{Message: "only managers can assign tasks"}
+		}
+		if err := impl.validateAssignee(ctx, *data.AssigneeID); err != nil {
+			return nil, err
+		}
+		assigneeID = *data.AssigneeID
+	}
+
+	dueAt, err := parseTaskDateTime("due_at", data.DueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := impl.resolveTags(ctx, data.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ParentID != nil {
+		if err := impl.validateParent(ctx, user, 0, *data.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := impl.taskRepository.CreateTask(ctx, model.Task{
+		UserID:     user.ID,
+		AssigneeID: assigneeID,
+		ParentID:   data.ParentID,
+		Summary:    data.Summary,
+		DueAt:      dueAt,
+		Priority:   taskPriority(data.Priority),
+		Tags:       tags,
+	})
 	if err != nil {
 		log.WithContext(ctx).WithFields(log.Fields{
 			"trace": "internal.service.task.createtask",
@@ -35,13 +152,13 @@ func (impl *taskService) CreateTask(ctx context.Context, userID int, summary str
 	return task, err
 }
 
-func (impl *taskService) ListTasks(ctx context.Context, limit, offset int, user *model.User) ([]model.Task, int, error) {
-	opts := []repository.WhereOpt{}
-	if user.Role != model.UserRoleManager {
-		opts = append(opts, repository.SetWhere("WHERE user_id = ?", []interface{}{user.ID}))
+func (impl *taskService) ListTasks(ctx context.Context, limit, offset int, user *model.User, filter dto.ListTasksFilterDto) ([]model.Task, int, error) {
+	where, err := taskFilterWhere(user, filter)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	tasks, total, err := impl.taskRepository.ListTasks(ctx, limit, offset, opts...)
+	tasks, total, err := impl.taskRepository.ListTasks(ctx, limit, offset, taskSorts[filter.Sort], where)
 	if err != nil {
 		log.WithContext(ctx).WithFields(log.Fields{
 			"trace": "internal.service.task.listtasks",
@@ -50,3 +167,548 @@ func (impl *taskService) ListTasks(ctx context.Context, limit, offset int, user
 
 	return tasks, total, err
 }
+
+// ExportTasks hands fn the tasks ListTasks would list, oldest first unless
+// sorted, without loading them all.
+func (impl *taskService) ExportTasks(ctx context.Context, user *model.User, filter dto.ListTasksFilterDto, fn func(task model.TaskExport) error) error {
+	where, err := taskFilterWhere(user, filter)
+	if err != nil {
+		return err
+	}
+
+	orderBy := taskSorts[filter.Sort]
+	if orderBy == "" {
+		orderBy = taskSorts["created_at"]
+	}
+
+	err = impl.taskRepository.ExportTasks(ctx, orderBy, fn, where)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.task.exporttasks",
+		}).Error(err.Error())
+	}
+
+	return err
+}
+
+// taskFilterWhere builds the ListTasks conditions. Technicians only see the
+// tasks they created or are assigned to.
+func taskFilterWhere(user *model.User, filter dto.ListTasksFilterDto) (repository.WhereOpt, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	values := []interface{}{}
+	if user.Role != model.UserRoleManager {
+		conditions = append(conditions, "(user_id = ? OR assignee_id = ?)")
+		values = append(values, user.ID, user.ID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		values = append(values, filter.Status)
+	}
+	if filter.Priority != "" {
+		conditions = append(conditions, "priority = ?")
+		values = append(values, filter.Priority)
+	}
+	if filter.AssigneeID > 0 {
+		conditions = append(conditions, "assignee_id = ?")
+		values = append(values, filter.AssigneeID)
+	}
+	dueAfter, err := parseTaskDateTime("due_after", filter.DueAfter)
+	if err != nil {
+		return nil, err
+	}
+	if dueAfter != nil {
+		conditions = append(conditions, "due_at >= ?")
+		values = append(values, *dueAfter)
+	}
+	dueBefore, err := parseTaskDateTime("due_before", filter.DueBefore)
+	if err != nil {
+		return nil, err
+	}
+	if dueBefore != nil {
+		conditions = append(conditions, "due_at <= ?")
+		values = append(values, *dueBefore)
+	}
+	if filter.Overdue {
+		conditions = append(conditions, "due_at < ? AND status <> ?")
+		values = append(values, time.Now(), model.TaskStatusClosed)
+	}
+	if tags := parseTagFilter(filter.Tags); len(tags) > 0 {
+		condition := "id IN (SELECT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id" +
+			" WHERE tags.name IN (?" + strings.Repeat(", ?", len(tags)-1) + ")"
+		for _, tag := range tags {
+			values = append(values, tag)
+		}
+		if filter.TagMatch == "all" {
+			condition += " GROUP BY task_tags.task_id HAVING COUNT(*) = ?"
+			values = append(values, len(tags))
+		}
+		conditions = append(conditions, condition+")")
+	}
+
+	return repository.SetWhere("WHERE "+strings.Join(conditions, " AND "), values), nil
+}
+
+func (impl *taskService) AssignTask(ctx context.Context, id, assigneeID int) (*model.Task, error) {
+	if err := impl.validateAssignee(ctx, assigneeID); err != nil {
+		return nil, err
+	}
+
+	task, err := impl.taskRepository.UpdateTaskAssignee(ctx, id, assigneeID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.assigntask",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// TagTask adds the tags to the ones the task already has.
+func (impl *taskService) TagTask(ctx context.Context, user *model.User, id int, tags []string) (*model.Task, error) {
+	added, err := impl.resolveTags(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := impl.GetTask(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range added {
+		if !slices.Contains(task.Tags, tag) {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	task, err = impl.taskRepository.UpdateTask(ctx, *task)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.tagtask",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// DeleteTask refuses to delete a task that still has subtasks.
+func (impl *taskService) DeleteTask(ctx context.Context, user *model.User, id int) error {
+	task, err := impl.GetTask(ctx, user, id)
+	if err != nil {
+		return err
+	}
+
+	if task.SubtaskCount > 0 {
+		return &exception.ConflictException{
+			Message: fmt.Sprintf("task has %d subtasks, delete them first", task.SubtaskCount),
+		}
+	}
+
+	err = impl.taskRepository.DeleteTask(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.deletetask",
+			}).Error(err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetTask applies the ListTasks visibility rules: technicians only see the
+// tasks they created or are assigned to.
+func (impl *taskService) GetTask(ctx context.Context, user *model.User, id int) (*model.Task, error) {
+	task, err := impl.taskRepository.GetTaskByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.gettask",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	if user.Role != model.UserRoleManager && task.UserID != user.ID && task.AssigneeID != user.ID {
+		return nil, &exception.NotFoundException{Message: "task not found"}
+	}
+
+	return task, nil
+}
+
+func (impl *taskService) UpdateTask(ctx context.Context, user *model.User, id int, data dto.UpdateTaskDto) (*model.Task, error) {
+	dueAt, err := parseTaskDateTime("due_at", data.DueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := impl.resolveTags(ctx, data.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := impl.GetTask(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ParentID != nil {
+		if err := impl.validateParent(ctx, user, id, *data.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	task.Summary = data.Summary
+	task.ParentID = data.ParentID
+	task.DueAt = dueAt
+	task.Priority = taskPriority(data.Priority)
+	task.Tags = tags
+
+	task, err = impl.taskRepository.UpdateTask(ctx, *task)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.updatetask",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// TransitionTask refuses to close a task with open subtasks unless forced.
+func (impl *taskService) TransitionTask(ctx context.Context, user *model.User, id int, to model.TaskStatus, force bool) (*model.Task, *model.TaskTransition, error) {
+	if !impl.workflow.HasStatus(to) {
+		return nil, nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "to", Tag: "status", Message: "to must be a workflow status"}},
+		}
+	}
+
+	task, err := impl.GetTask(ctx, user, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule, ok := impl.workflow.Rule(task.Status, to)
+	if !ok {
+		return nil, nil, &exception.ConflictException{
+			Message: fmt.Sprintf("can not move task from %s to %s, allowed: %s",
+				task.Status, to, strings.Join(impl.workflow.Targets(task.Status), ", ")),
+		}
+	}
+	if !slices.Contains(rule.Roles, user.Role) {
+		return nil, nil, &exception.UnauthorizedException{
+			Message: fmt.Sprintf("%s can not move task from %s to %s", user.Role, task.Status, to),
+		}
+	}
+	if to == model.TaskStatusClosed && task.OpenSubtaskCount > 0 && !force {
+		return nil, nil, &exception.ConflictException{
+			Message: fmt.Sprintf("task has %d open subtasks, close them first or force", task.OpenSubtaskCount),
+		}
+	}
+
+	transition, err := impl.taskRepository.TransitionTask(ctx, model.TaskTransition{
+		TaskID:     task.ID,
+		UserID:     user.ID,
+		FromStatus: task.Status,
+		ToStatus:   to,
+	})
+	if err != nil {
+		if _, ok := err.(*exception.ConflictException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.transitiontask",
+			}).Error(err.Error())
+		}
+		return nil, nil, err
+	}
+
+	task.Status = transition.ToStatus
+	task.UpdatedAt = transition.CreatedAt
+
+	return task, transition, nil
+}
+
+func (impl *taskService) ListTaskTransitions(ctx context.Context, user *model.User, id int) ([]model.TaskTransition, error) {
+	if _, err := impl.GetTask(ctx, user, id); err != nil {
+		return nil, err
+	}
+
+	transitions, err := impl.taskRepository.ListTaskTransitions(ctx, id)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.task.listtasktransitions",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return transitions, nil
+}
+
+// AddTaskDependency marks the task as blocked by blockedByID. Both tasks must
+// be visible to the user.
+func (impl *taskService) AddTaskDependency(ctx context.Context, user *model.User, id, blockedByID int) (*model.Task, error) {
+	if _, err := impl.GetTask(ctx, user, id); err != nil {
+		return nil, err
+	}
+	if _, err := impl.GetTask(ctx, user, blockedByID); err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "blocked_by_id", Tag: "exists", Message: "blocked_by_id must be an existing task"},
+				},
+			}
+		}
+		return nil, err
+	}
+
+	cycle := id == blockedByID
+	if !cycle {
+		var err error
+		cycle, err = impl.taskRepository.IsTaskBlockedBy(ctx, blockedByID, id)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.addtaskdependency",
+			}).Error(err.Error())
+			return nil, err
+		}
+	}
+	if cycle {
+		return nil, &exception.ConflictException{Message: "dependency would create a cycle"}
+	}
+
+	_, err := impl.taskRepository.CreateTaskDependency(ctx, model.TaskDependency{
+		TaskID:      id,
+		BlockedByID: blockedByID,
+		UserID:      user.ID,
+	})
+	if err != nil {
+		if _, ok := err.(*exception.ConflictException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.addtaskdependency",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return impl.GetTask(ctx, user, id)
+}
+
+func (impl *taskService) RemoveTaskDependency(ctx context.Context, user *model.User, id, blockedByID int) error {
+	if _, err := impl.GetTask(ctx, user, id); err != nil {
+		return err
+	}
+
+	err := impl.taskRepository.DeleteTaskDependency(ctx, id, blockedByID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.removetaskdependency",
+			}).Error(err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetTaskTree returns the task with its subtasks, loading a level per query.
+// Technicians only see the subtasks they created or are assigned to.
+func (impl *taskService) GetTaskTree(ctx context.Context, user *model.User, id int) (*model.TaskTree, error) {
+	task, err := impl.GetTask(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subtasks := map[int][]model.Task{}
+	parentIDs := []int{task.ID}
+	for depth := 0; depth < maxTaskTreeDepth && len(parentIDs) > 0; depth++ {
+		query := "WHERE parent_id IN (?" + strings.Repeat(", ?", len(parentIDs)-1) + ") AND deleted_at IS NULL"
+		values := []interface{}{}
+		for _, parentID := range parentIDs {
+			values = append(values, parentID)
+		}
+		if user.Role != model.UserRoleManager {
+			query += " AND (user_id = ? OR assignee_id = ?)"
+			values = append(values, user.ID, user.ID)
+		}
+
+		tasks, _, err := impl.taskRepository.ListTasks(ctx, 0, 0, taskSorts["created_at"], repository.SetWhere(query, values))
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.gettasktree",
+			}).Error(err.Error())
+			return nil, err
+		}
+
+		parentIDs = []int{}
+		for _, t := range tasks {
+			subtasks[*t.ParentID] = append(subtasks[*t.ParentID], t)
+			parentIDs = append(parentIDs, t.ID)
+		}
+	}
+
+	tree := buildTaskTree(*task, subtasks)
+
+	return &tree, nil
+}
+
+// validateParent checks the parent is visible to the user and, when id is
+// set, that moving the task under it does not create a cycle.
+func (impl *taskService) validateParent(ctx context.Context, user *model.User, id, parentID int) error {
+	if _, err := impl.GetTask(ctx, user, parentID); err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "parent_id", Tag: "exists", Message: "parent_id must be an existing task"},
+				},
+			}
+		}
+		return err
+	}
+	if id == 0 {
+		return nil
+	}
+
+	cycle := id == parentID
+	if !cycle {
+		var err error
+		cycle, err = impl.taskRepository.IsTaskAncestor(ctx, id, parentID)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.task.validateparent",
+			}).Error(err.Error())
+			return err
+		}
+	}
+	if cycle {
+		return &exception.ConflictException{Message: "parent would create a cycle"}
+	}
+
+	return nil
+}
+
+func (impl *taskService) validateAssignee(ctx context.Context, assigneeID int) error {
+	invalid := &exception.ValidationException{
+		Message: "invalid fields",
+		Fields: []exception.FieldError{
+			{Field: "assignee_id", Tag: "technician", Message: "assignee_id must be an active technician"},
+		},
+	}
+
+	assignee, err := impl.userRepository.GetUserByID(ctx, assigneeID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return invalid
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.task.validateassignee",
+		}).Error(err.Error())
+		return err
+	}
+
+	if assignee.Role != model.UserRoleTechnician || assignee.Status != model.UserStatusActive {
+		return invalid
+	}
+
+	return nil
+}
+
+// resolveTags normalizes the tag names and checks they all exist. Tasks can
+// only be tagged with the tags managers created.
+func (impl *taskService) resolveTags(ctx context.Context, names []string) (model.TaskTags, error) {
+	names = parseTagFilter(strings.Join(names, ","))
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags, err := impl.tagRepository.ListTagsByNames(ctx, names)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.task.resolvetags",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	found := model.TaskTags{}
+	for _, tag := range tags {
+		found = append(found, tag.Name)
+	}
+
+	unknown := []string{}
+	for _, name := range names {
+		if !slices.Contains(found, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{{
+				Field: "tags", Tag: "exists", Message: "tags must exist, unknown: " + strings.Join(unknown, ", "),
+			}},
+		}
+	}
+
+	return found, nil
+}
+
+// parseTagFilter splits comma separated tag names, dropping blanks and
+// duplicates.
+func parseTagFilter(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func buildTaskTree(task model.Task, subtasks map[int][]model.Task) model.TaskTree {
+	tree := model.TaskTree{Task: task, Subtasks: []model.TaskTree{}}
+	for _, subtask := range subtasks[task.ID] {
+		tree.Subtasks = append(tree.Subtasks, buildTaskTree(subtask, subtasks))
+	}
+
+	return tree
+}
+
+func parseTaskDateTime(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+	if err != nil {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{
+				{Field: field, Tag: "datetime", Message: field + " must match the format 2006-01-02 15:04:05"},
+			},
+		}
+	}
+
+	return &t, nil
+}
+
+func taskPriority(priority model.TaskPriority) model.TaskPriority {
+	if priority == "" {
+		return model.TaskPriorityNormal
+	}
+
+	return priority
+}
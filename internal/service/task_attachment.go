@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"golang.org/x/exp/slices"
+)
+
+type TaskAttachmentPolicy struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+//go:generate mockgen -destination=../../mock/task_attachment_service_mock.go -package=mock . TaskAttachmentService
+type TaskAttachmentService interface {
+	UploadAttachment(ctx context.Context, user *model.User, taskID int, filename string, size int64, body io.Reader) (*model.TaskAttachment, error)
+	ListAttachments(ctx context.Context, user *model.User, taskID int) ([]model.TaskAttachment, error)
+	GetAttachment(ctx context.Context, user *model.User, taskID, id int) (*model.TaskAttachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, user *model.User, taskID, id int) error
+}
+
+type taskAttachmentService struct {
+	taskAttachmentRepository repository.TaskAttachmentRepository
+	blobStore                repository.BlobStore
+	taskService              TaskService
+	policy                   TaskAttachmentPolicy
+}
+
+func NewTaskAttachmentService(taskAttachmentRepository repository.TaskAttachmentRepository, blobStore repository.BlobStore,
+	taskService TaskService, policy TaskAttachmentPolicy) TaskAttachmentService {
+	return &taskAttachmentService{
+		taskAttachmentRepository: taskAttachmentRepository,
+		blobStore:                blobStore,
+		taskService:              taskService,
+		policy:                   policy,
+	}
+}
+
+// UploadAttachment checks the content type from the file content instead of
+// trusting the one sent by the client.
+func (impl *taskAttachmentService) UploadAttachment(ctx context.Context, user *model.User, taskID int, filename string, size int64, body io.Reader) (*model.TaskAttachment, error) {
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, err
+	}
+
+	if size <= 0 || size > impl.policy.MaxSize {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{{
+				Field: "file", Tag: "max", Message: fmt.Sprintf("file must have between 1 and %d bytes", impl.policy.MaxSize),
+			}},
+		}
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !slices.Contains(impl.policy.AllowedTypes, contentType) {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "file", Tag: "mime", Message: "file type is not allowed"}},
+		}
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(b))
+
+	if err := impl.blobStore.Put(ctx, key, io.MultiReader(bytes.NewReader(head), body), size, contentType); err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskattachment.uploadattachment",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	attachment, err := impl.taskAttachmentRepository.CreateTaskAttachment(ctx, model.TaskAttachment{
+		TaskID:      taskID,
+		UserID:      user.ID,
+		Filename:    impl.filename(filename),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskattachment.uploadattachment",
+		}).Error(err.Error())
+		impl.blobStore.Delete(ctx, key)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (impl *taskAttachmentService) ListAttachments(ctx context.Context, user *model.User, taskID int) ([]model.TaskAttachment, error) {
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := impl.taskAttachmentRepository.ListTaskAttachments(ctx, taskID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskattachment.listattachments",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (impl *taskAttachmentService) GetAttachment(ctx context.Context, user *model.User, taskID, id int) (*model.TaskAttachment, io.ReadCloser, error) {
+	attachment, err := impl.getAttachment(ctx, user, taskID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := impl.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskattachment.getattachment",
+		}).Error(err.Error())
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment is allowed to the uploader and to managers.
+func (impl *taskAttachmentService) DeleteAttachment(ctx context.Context, user *model.User, taskID, id int) error {
+	attachment, err := impl.getAttachment(ctx, user, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	if attachment.UserID != user.ID && user.Role != model.UserRoleManager {
+		return &exception.UnauthorizedException{Message: "only the uploader or a manager can delete the attachment"}
+	}
+
+	if err := impl.taskAttachmentRepository.DeleteTaskAttachment(ctx, id); err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskattachment.deleteattachment",
+			}).Error(err.Error())
+		}
+		return err
+	}
+
+	if err := impl.blobStore.Delete(ctx, attachment.StorageKey); err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskattachment.deleteattachment",
+		}).Error(err.Error())
+	}
+
+	return nil
+}
+
+func (impl *taskAttachmentService) getAttachment(ctx context.Context, user *model.User, taskID, id int) (*model.TaskAttachment, error) {
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := impl.taskAttachmentRepository.GetTaskAttachmentByID(ctx, taskID, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskattachment.getattachment",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// filename drops any directory from the uploaded name and cuts it to 255
+// characters, keeping the extension.
+func (impl *taskAttachmentService) filename(filename string) string {
+	filename = filepath.Base(filepath.Clean("/" + filename))
+
+	ext := filepath.Ext(filename)
+	if utf8.RuneCountInString(ext) >= 255 {
+		return truncateRunes(filename, 255)
+	}
+
+	return truncateRunes(strings.TrimSuffix(filename, ext), 255-utf8.RuneCountInString(ext)) + ext
+}
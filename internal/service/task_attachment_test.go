@@ -0,0 +1,342 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+func TestTaskAttachmentServiceUploadAttachment(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	task := &model.Task{ID: 1, UserID: 2}
+	attachment := &model.TaskAttachment{ID: 1, CreatedAt: now, TaskID: 1, UserID: 2, Filename: "photo.png",
+		ContentType: "image/png", Size: int64(len(pngHeader)), StorageKey: "tasks/1/key"}
+
+	var cases = map[string]struct {
+		inputFilename      string
+		inputBody          []byte
+		inputSize          int64
+		mocking            func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService)
+		expectedAttachment *model.TaskAttachment
+		expectedErr        error
+	}{
+		"should upload attachment": {
+			inputFilename: "../../photo.png",
+			inputBody:     pngHeader,
+			inputSize:     int64(len(pngHeader)),
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(pngHeader)), "image/png").
+					DoAndReturn(func(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+						b, _ := io.ReadAll(body)
+						assert.Equal(t, pngHeader, b)
+						assert.Regexp(t, "^tasks/1/[0-9a-f]{32}$", key)
+						return nil
+					})
+				taskAttachmentRepository.EXPECT().CreateTaskAttachment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, a model.TaskAttachment) (*model.TaskAttachment, error) {
+						assert.Equal(t, "photo.png", a.Filename)
+						assert.Equal(t, "image/png", a.ContentType)
+						return attachment, nil
+					})
+			},
+			expectedAttachment: attachment,
+		},
+		"should truncate filename keeping the extension": {
+			inputFilename: strings.Repeat("ü", 300) + ".png",
+			inputBody:     pngHeader,
+			inputSize:     int64(len(pngHeader)),
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(pngHeader)), "image/png").Return(nil)
+				taskAttachmentRepository.EXPECT().CreateTaskAttachment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, a model.TaskAttachment) (*model.TaskAttachment, error) {
+						assert.Equal(t, strings.Repeat("ü", 251)+".png", a.Filename)
+						return attachment, nil
+					})
+			},
+			expectedAttachment: attachment,
+		},
+		"should throw not found when task is not visible": {
+			inputFilename: "photo.png",
+			inputBody:     pngHeader,
+			inputSize:     int64(len(pngHeader)),
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw validation exception when file is too large": {
+			inputFilename: "photo.png",
+			inputBody:     pngHeader,
+			inputSize:     2048,
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "file", Tag: "max", Message: "file must have between 1 and 1024 bytes"}},
+			},
+		},
+		"should throw validation exception when file type is not allowed": {
+			inputFilename: "photo.png",
+			inputBody:     []byte("#!/bin/sh\necho hello"),
+			inputSize:     20,
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "file", Tag: "mime", Message: "file type is not allowed"}},
+			},
+		},
+		"should throw error when blob store put": {
+			inputFilename: "photo.png",
+			inputBody:     pngHeader,
+			inputSize:     int64(len(pngHeader)),
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should delete blob when task attachment repository create task attachment": {
+			inputFilename: "photo.png",
+			inputBody:     pngHeader,
+			inputSize:     int64(len(pngHeader)),
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				taskAttachmentRepository.EXPECT().CreateTaskAttachment(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+				blobStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskAttachmentRepositoryMock := mock.NewMockTaskAttachmentRepository(ctrl)
+			blobStoreMock := mock.NewMockBlobStore(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskAttachmentService := service.NewTaskAttachmentService(taskAttachmentRepositoryMock, blobStoreMock, taskServiceMock,
+				service.TaskAttachmentPolicy{MaxSize: 1024, AllowedTypes: []string{"image/png", "application/pdf"}})
+
+			cs.mocking(taskAttachmentRepositoryMock, blobStoreMock, taskServiceMock)
+
+			// when
+			attachment, err := taskAttachmentService.UploadAttachment(ctx, user, 1, cs.inputFilename, cs.inputSize, bytes.NewReader(cs.inputBody))
+
+			// then
+			assert.Equal(t, cs.expectedAttachment, attachment)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskAttachmentServiceListAttachments(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	task := &model.Task{ID: 1, UserID: 2}
+
+	var cases = map[string]struct {
+		mocking             func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, taskService *mock.MockTaskService)
+		expectedAttachments []model.TaskAttachment
+		expectedErr         error
+	}{
+		"should list attachments": {
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().ListTaskAttachments(gomock.Any(), 1).Return([]model.TaskAttachment{{ID: 1}}, nil)
+			},
+			expectedAttachments: []model.TaskAttachment{{ID: 1}},
+		},
+		"should throw not found when task is not visible": {
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task attachment repository list task attachments": {
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().ListTaskAttachments(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskAttachmentRepositoryMock := mock.NewMockTaskAttachmentRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskAttachmentService := service.NewTaskAttachmentService(taskAttachmentRepositoryMock, nil, taskServiceMock,
+				service.TaskAttachmentPolicy{})
+
+			cs.mocking(taskAttachmentRepositoryMock, taskServiceMock)
+
+			// when
+			attachments, err := taskAttachmentService.ListAttachments(ctx, user, 1)
+
+			// then
+			assert.Equal(t, cs.expectedAttachments, attachments)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskAttachmentServiceGetAttachment(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	task := &model.Task{ID: 1, UserID: 2}
+	attachment := &model.TaskAttachment{ID: 1, TaskID: 1, UserID: 3, StorageKey: "tasks/1/key"}
+	content := io.NopCloser(bytes.NewReader(pngHeader))
+
+	var cases = map[string]struct {
+		mocking            func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService)
+		expectedAttachment *model.TaskAttachment
+		expectedContent    io.ReadCloser
+		expectedErr        error
+	}{
+		"should get attachment": {
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().GetTaskAttachmentByID(gomock.Any(), 1, 1).Return(attachment, nil)
+				blobStore.EXPECT().Get(gomock.Any(), "tasks/1/key").Return(content, nil)
+			},
+			expectedAttachment: attachment,
+			expectedContent:    content,
+		},
+		"should throw not found when attachment does not exist": {
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().GetTaskAttachmentByID(gomock.Any(), 1, 1).
+					Return(nil, &exception.NotFoundException{Message: "attachment not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "attachment not found"},
+		},
+		"should throw error when blob store get": {
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().GetTaskAttachmentByID(gomock.Any(), 1, 1).Return(attachment, nil)
+				blobStore.EXPECT().Get(gomock.Any(), "tasks/1/key").Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskAttachmentRepositoryMock := mock.NewMockTaskAttachmentRepository(ctrl)
+			blobStoreMock := mock.NewMockBlobStore(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskAttachmentService := service.NewTaskAttachmentService(taskAttachmentRepositoryMock, blobStoreMock, taskServiceMock,
+				service.TaskAttachmentPolicy{})
+
+			cs.mocking(taskAttachmentRepositoryMock, blobStoreMock, taskServiceMock)
+
+			// when
+			attachment, content, err := taskAttachmentService.GetAttachment(ctx, user, 1, 1)
+
+			// then
+			assert.Equal(t, cs.expectedAttachment, attachment)
+			assert.Equal(t, cs.expectedContent, content)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskAttachmentServiceDeleteAttachment(t *testing.T) {
+	technician := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	task := &model.Task{ID: 1, UserID: 2}
+	attachment := &model.TaskAttachment{ID: 1, TaskID: 1, UserID: 2, StorageKey: "tasks/1/key"}
+
+	var cases = map[string]struct {
+		inputUser   *model.User
+		mocking     func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService)
+		expectedErr error
+	}{
+		"should delete attachment when user is the uploader": {
+			inputUser: technician,
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), technician, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().GetTaskAttachmentByID(gomock.Any(), 1, 1).Return(attachment, nil)
+				taskAttachmentRepository.EXPECT().DeleteTaskAttachment(gomock.Any(), 1).Return(nil)
+				blobStore.EXPECT().Delete(gomock.Any(), "tasks/1/key").Return(nil)
+			},
+		},
+		"should delete attachment when user is manager": {
+			inputUser: manager,
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), manager, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().GetTaskAttachmentByID(gomock.Any(), 1, 1).Return(attachment, nil)
+				taskAttachmentRepository.EXPECT().DeleteTaskAttachment(gomock.Any(), 1).Return(nil)
+				blobStore.EXPECT().Delete(gomock.Any(), "tasks/1/key").Return(fmt.Errorf("error"))
+			},
+		},
+		"should throw unauthorized when user is not the uploader": {
+			inputUser: &model.User{ID: 3, Role: model.UserRoleTechnician},
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), gomock.Any(), 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().GetTaskAttachmentByID(gomock.Any(), 1, 1).Return(attachment, nil)
+			},
+			expectedErr: &exception.UnauthorizedException{Message: "only the uploader or a manager can delete the attachment"},
+		},
+		"should throw error when task attachment repository delete task attachment": {
+			inputUser: technician,
+			mocking: func(taskAttachmentRepository *mock.MockTaskAttachmentRepository, blobStore *mock.MockBlobStore, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), technician, 1).Return(task, nil)
+				taskAttachmentRepository.EXPECT().GetTaskAttachmentByID(gomock.Any(), 1, 1).Return(attachment, nil)
+				taskAttachmentRepository.EXPECT().DeleteTaskAttachment(gomock.Any(), 1).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskAttachmentRepositoryMock := mock.NewMockTaskAttachmentRepository(ctrl)
+			blobStoreMock := mock.NewMockBlobStore(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskAttachmentService := service.NewTaskAttachmentService(taskAttachmentRepositoryMock, blobStoreMock, taskServiceMock,
+				service.TaskAttachmentPolicy{})
+
+			cs.mocking(taskAttachmentRepositoryMock, blobStoreMock, taskServiceMock)
+
+			// when
+			err := taskAttachmentService.DeleteAttachment(ctx, cs.inputUser, 1, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"golang.org/x/exp/slices"
+)
+
+// TaskBulkResult is the outcome of the bulk action on a task. Task is nil
+// when the task was deleted or the action failed with Err.
+type TaskBulkResult struct {
+	TaskID     int
+	Task       *model.Task
+	Transition *model.TaskTransition
+	Err        error
+}
+
+//go:generate mockgen -destination=../../mock/task_bulk_service_mock.go -package=mock . TaskBulkService
+type TaskBulkService interface {
+	BulkTasks(ctx context.Context, user *model.User, data dto.BulkTasksDto) ([]TaskBulkResult, error)
+}
+
+type taskBulkService struct {
+	taskService TaskService
+	maxItems    int
+}
+
+func NewTaskBulkService(taskService TaskService, maxItems int) TaskBulkService {
+	return &taskBulkService{
+		taskService: taskService,
+		maxItems:    maxItems,
+	}
+}
+
+// BulkTasks runs the action on each task on its own, through the same
+// TaskService methods as the single task endpoints, so a task failing does
+// not stop the others. Reassigning and deleting are for managers only.
+func (impl *taskBulkService) BulkTasks(ctx context.Context, user *model.User, data dto.BulkTasksDto) ([]TaskBulkResult, error) {
+	if err := validateBulkTasks(data); err != nil {
+		return nil, err
+	}
+
+	if (data.Action == model.TaskBulkActionReassign || data.Action == model.TaskBulkActionDelete) &&
+		user.Role != model.UserRoleManager {
+		return nil, &exception.UnauthorizedException{Message: "unauthorized user role"}
+	}
+
+	ids, err := impl.resolveTaskIDs(ctx, user, data)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []TaskBulkResult{}
+	for _, id := range ids {
+		results = append(results, impl.runTask(ctx, user, data, id))
+	}
+
+	return results, nil
+}
+
+func (impl *taskBulkService) resolveTaskIDs(ctx context.Context, user *model.User, data dto.BulkTasksDto) ([]int, error) {
+	if data.Filter == nil {
+		ids := []int{}
+		for _, id := range data.IDs {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > impl.maxItems {
+			return nil, &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "ids", Tag: "max", Message: fmt.Sprintf("ids must have at most %d tasks", impl.maxItems)},
+				},
+			}
+		}
+
+		return ids, nil
+	}
+
+	tasks, total, err := impl.taskService.ListTasks(ctx, impl.maxItems, 0, user, *data.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if total > impl.maxItems {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{{
+				Field: "filter", Tag: "max",
+				Message: fmt.Sprintf("filter matches %d tasks, at most %d are allowed", total, impl.maxItems),
+			}},
+		}
+	}
+
+	ids := []int{}
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+
+	return ids, nil
+}
+
+func (impl *taskBulkService) runTask(ctx context.Context, user *model.User, data dto.BulkTasksDto, id int) TaskBulkResult {
+	res := TaskBulkResult{TaskID: id}
+	switch data.Action {
+	case model.TaskBulkActionClose:
+		res.Task, res.Transition, res.Err = impl.taskService.TransitionTask(ctx, user, id, model.TaskStatusClosed, data.Force)
+	case model.TaskBulkActionReopen:
+		res.Task, res.Transition, res.Err = impl.taskService.TransitionTask(ctx, user, id, model.TaskStatusOpened, false)
+	case model.TaskBulkActionReassign:
+		res.Task, res.Err = impl.taskService.AssignTask(ctx, id, data.AssigneeID)
+	case model.TaskBulkActionTag:
+		res.Task, res.Err = impl.taskService.TagTask(ctx, user, id, data.Tags)
+	case model.TaskBulkActionDelete:
+		res.Err = impl.taskService.DeleteTask(ctx, user, id)
+	}
+
+	return res
+}
+
+func validateBulkTasks(data dto.BulkTasksDto) error {
+	fields := []exception.FieldError{}
+	if len(data.IDs) == 0 && data.Filter == nil {
+		fields = append(fields, exception.FieldError{Field: "ids", Tag: "required_without", Message: "ids or filter is required"})
+	}
+	if len(data.IDs) > 0 && data.Filter != nil {
+		fields = append(fields, exception.FieldError{Field: "filter", Tag: "excluded_with", Message: "filter must not be sent with ids"})
+	}
+	if data.Action == model.TaskBulkActionReassign && data.AssigneeID == 0 {
+		fields = append(fields, exception.FieldError{Field: "assignee_id", Tag: "required_if", Message: "assignee_id is required to reassign"})
+	}
+	if data.Action == model.TaskBulkActionTag && len(data.Tags) == 0 {
+		fields = append(fields, exception.FieldError{Field: "tags", Tag: "required_if", Message: "tags is required to tag"})
+	}
+	if len(fields) > 0 {
+		return &exception.ValidationException{Message: "invalid fields", Fields: fields}
+	}
+
+	return nil
+}
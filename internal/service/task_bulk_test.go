@@ -0,0 +1,154 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskBulkServiceBulkTasks(t *testing.T) {
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	technician := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	closed := &model.Task{ID: 1, Status: model.TaskStatusClosed}
+	transition := &model.TaskTransition{ID: 1, TaskID: 1, FromStatus: model.TaskStatusInReview, ToStatus: model.TaskStatusClosed}
+
+	var cases = map[string]struct {
+		inputUser       *model.User
+		inputData       dto.BulkTasksDto
+		mocking         func(taskService *mock.MockTaskService)
+		expectedResults []service.TaskBulkResult
+		expectedErr     error
+	}{
+		"should close each task and keep going when one fails": {
+			inputUser: technician,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionClose, IDs: []int{1, 2, 1}, Force: true},
+			mocking: func(taskService *mock.MockTaskService) {
+				taskService.EXPECT().TransitionTask(gomock.Any(), technician, 1, model.TaskStatusClosed, true).Return(closed, transition, nil)
+				taskService.EXPECT().TransitionTask(gomock.Any(), technician, 2, model.TaskStatusClosed, true).
+					Return(nil, nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedResults: []service.TaskBulkResult{
+				{TaskID: 1, Task: closed, Transition: transition},
+				{TaskID: 2, Err: &exception.NotFoundException{Message: "task not found"}},
+			},
+		},
+		"should reopen the tasks matching the filter": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionReopen, Filter: &dto.ListTasksFilterDto{Tags: "maintenance"}},
+			mocking: func(taskService *mock.MockTaskService) {
+				taskService.EXPECT().ListTasks(gomock.Any(), 2, 0, manager, dto.ListTasksFilterDto{Tags: "maintenance"}).
+					Return([]model.Task{{ID: 1}}, 1, nil)
+				taskService.EXPECT().TransitionTask(gomock.Any(), manager, 1, model.TaskStatusOpened, false).Return(closed, transition, nil)
+			},
+			expectedResults: []service.TaskBulkResult{{TaskID: 1, Task: closed, Transition: transition}},
+		},
+		"should delete tasks": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionDelete, IDs: []int{1}},
+			mocking: func(taskService *mock.MockTaskService) {
+				taskService.EXPECT().DeleteTask(gomock.Any(), manager, 1).Return(nil)
+			},
+			expectedResults: []service.TaskBulkResult{{TaskID: 1}},
+		},
+		"should tag tasks": {
+			inputUser: technician,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionTag, IDs: []int{1}, Tags: []string{"maintenance"}},
+			mocking: func(taskService *mock.MockTaskService) {
+				taskService.EXPECT().TagTask(gomock.Any(), technician, 1, []string{"maintenance"}).Return(closed, nil)
+			},
+			expectedResults: []service.TaskBulkResult{{TaskID: 1, Task: closed}},
+		},
+		"should reassign tasks": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionReassign, IDs: []int{1}, AssigneeID: 2},
+			mocking: func(taskService *mock.MockTaskService) {
+				taskService.EXPECT().AssignTask(gomock.Any(), 1, 2).Return(closed, nil)
+			},
+			expectedResults: []service.TaskBulkResult{{TaskID: 1, Task: closed}},
+		},
+		"should throw unauthorized when technician reassigns tasks": {
+			inputUser:   technician,
+			inputData:   dto.BulkTasksDto{Action: model.TaskBulkActionReassign, IDs: []int{1}, AssigneeID: 2},
+			mocking:     func(taskService *mock.MockTaskService) {},
+			expectedErr: &exception.UnauthorizedException{Message: "unauthorized user role"},
+		},
+		"should throw validation exception when the fields of the action are missing": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionReassign},
+			mocking:   func(taskService *mock.MockTaskService) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "ids", Tag: "required_without", Message: "ids or filter is required"},
+					{Field: "assignee_id", Tag: "required_if", Message: "assignee_id is required to reassign"},
+				},
+			},
+		},
+		"should throw validation exception when ids and filter are sent": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionClose, IDs: []int{1}, Filter: &dto.ListTasksFilterDto{}},
+			mocking:   func(taskService *mock.MockTaskService) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "filter", Tag: "excluded_with", Message: "filter must not be sent with ids"}},
+			},
+		},
+		"should throw validation exception when there are too many ids": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionClose, IDs: []int{1, 2, 3}},
+			mocking:   func(taskService *mock.MockTaskService) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "ids", Tag: "max", Message: "ids must have at most 2 tasks"}},
+			},
+		},
+		"should throw validation exception when the filter matches too many tasks": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionClose, Filter: &dto.ListTasksFilterDto{}},
+			mocking: func(taskService *mock.MockTaskService) {
+				taskService.EXPECT().ListTasks(gomock.Any(), 2, 0, manager, dto.ListTasksFilterDto{}).
+					Return([]model.Task{{ID: 1}, {ID: 2}}, 5, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "filter", Tag: "max", Message: "filter matches 5 tasks, at most 2 are allowed"}},
+			},
+		},
+		"should throw error when task service list tasks": {
+			inputUser: manager,
+			inputData: dto.BulkTasksDto{Action: model.TaskBulkActionClose, Filter: &dto.ListTasksFilterDto{}},
+			mocking: func(taskService *mock.MockTaskService) {
+				taskService.EXPECT().ListTasks(gomock.Any(), 2, 0, manager, gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskBulkService := service.NewTaskBulkService(taskServiceMock, 2)
+
+			cs.mocking(taskServiceMock)
+
+			// when
+			results, err := taskBulkService.BulkTasks(ctx, cs.inputUser, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedResults, results)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"golang.org/x/exp/slices"
+)
+
+//go:generate mockgen -destination=../../mock/task_comment_service_mock.go -package=mock . TaskCommentService
+type TaskCommentService interface {
+	CreateComment(ctx context.Context, user *model.User, taskID int, data dto.CreateTaskCommentDto) (*model.TaskComment, error)
+	ListComments(ctx context.Context, user *model.User, taskID, limit, offset int) ([]model.TaskComment, int, error)
+	UpdateComment(ctx context.Context, user *model.User, taskID, id int, data dto.UpdateTaskCommentDto) (*model.TaskComment, error)
+	DeleteComment(ctx context.Context, user *model.User, taskID, id int) error
+}
+
+type taskCommentService struct {
+	taskCommentRepository repository.TaskCommentRepository
+	taskService           TaskService
+	notificationService   NotificationService
+	editWindow            time.Duration
+}
+
+func NewTaskCommentService(taskCommentRepository repository.TaskCommentRepository, taskService TaskService,
+	notificationService NotificationService, editWindow time.Duration) TaskCommentService {
+	return &taskCommentService{
+		taskCommentRepository: taskCommentRepository,
+		taskService:           taskService,
+		notificationService:   notificationService,
+		editWindow:            editWindow,
+	}
+}
+
+// CreateComment notifies the creator and the assignee of the task and
+// everyone who commented on it before, except for the author.
+func (impl *taskCommentService) CreateComment(ctx context.Context, user *model.User, taskID int, data dto.CreateTaskCommentDto) (*model.TaskComment, error) {
+	task, err := impl.taskService.GetTask(ctx, user, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientIDs, err := impl.taskCommentRepository.ListTaskCommenterIDs(ctx, taskID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskcomment.createcomment",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	comment, err := impl.taskCommentRepository.CreateTaskComment(ctx, model.TaskComment{
+		TaskID: taskID,
+		UserID: user.ID,
+		Body:   data.Body,
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskcomment.createcomment",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	ids := []int{}
+	for _, id := range append([]int{task.UserID, task.AssigneeID}, recipientIDs...) {
+		if id != user.ID && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		impl.notificationService.NotifyTaskCommented(ctx, task, comment, ids)
+	}
+
+	return comment, nil
+}
+
+func (impl *taskCommentService) ListComments(ctx context.Context, user *model.User, taskID, limit, offset int) ([]model.TaskComment, int, error) {
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, 0, err
+	}
+
+	comments, total, err := impl.taskCommentRepository.ListTaskComments(ctx, taskID, limit, offset)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskcomment.listcomments",
+		}).Error(err.Error())
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (impl *taskCommentService) UpdateComment(ctx context.Context, user *model.User, taskID, id int, data dto.UpdateTaskCommentDto) (*model.TaskComment, error) {
+	if _, err := impl.getEditableComment(ctx, user, taskID, id); err != nil {
+		return nil, err
+	}
+
+	comment, err := impl.taskCommentRepository.UpdateTaskComment(ctx, id, data.Body)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskcomment.updatecomment",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (impl *taskCommentService) DeleteComment(ctx context.Context, user *model.User, taskID, id int) error {
+	if _, err := impl.getEditableComment(ctx, user, taskID, id); err != nil {
+		return err
+	}
+
+	err := impl.taskCommentRepository.DeleteTaskComment(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskcomment.deletecomment",
+			}).Error(err.Error())
+		}
+	}
+
+	return err
+}
+
+// getEditableComment only lets the author change a comment, and only within
+// editWindow of posting it.
+func (impl *taskCommentService) getEditableComment(ctx context.Context, user *model.User, taskID, id int) (*model.TaskComment, error) {
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, err
+	}
+
+	comment, err := impl.taskCommentRepository.GetTaskCommentByID(ctx, taskID, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskcomment.geteditablecomment",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	if comment.UserID != user.ID {
+		return nil, &exception.UnauthorizedException{Message: "only the author can change the comment"}
+	}
+	if time.Since(comment.CreatedAt) > impl.editWindow {
+		return nil, &exception.ConflictException{Message: "comment can no longer be changed"}
+	}
+
+	return comment, nil
+}
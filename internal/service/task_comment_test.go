@@ -0,0 +1,273 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskCommentServiceCreateComment(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	task := &model.Task{ID: 1, UserID: 3, AssigneeID: 2}
+	comment := &model.TaskComment{ID: 1, CreatedAt: now, UpdatedAt: now, TaskID: 1, UserID: 2, Body: "body"}
+
+	var cases = map[string]struct {
+		mocking         func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService, notificationService *mock.MockNotificationService)
+		expectedComment *model.TaskComment
+		expectedErr     error
+	}{
+		"should create comment and notify owner and previous commenters": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService, notificationService *mock.MockNotificationService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				taskCommentRepository.EXPECT().ListTaskCommenterIDs(gomock.Any(), 1).Return([]int{2, 3, 5}, nil)
+				taskCommentRepository.EXPECT().CreateTaskComment(gomock.Any(), model.TaskComment{TaskID: 1, UserID: 2, Body: "body"}).
+					Return(comment, nil)
+				notificationService.EXPECT().NotifyTaskCommented(gomock.Any(), task, comment, []int{3, 5})
+			},
+			expectedComment: comment,
+		},
+		"should not notify when author is the only participant": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService, notificationService *mock.MockNotificationService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1, UserID: 2, AssigneeID: 2}, nil)
+				taskCommentRepository.EXPECT().ListTaskCommenterIDs(gomock.Any(), 1).Return([]int{}, nil)
+				taskCommentRepository.EXPECT().CreateTaskComment(gomock.Any(), gomock.Any()).Return(comment, nil)
+			},
+			expectedComment: comment,
+		},
+		"should throw not found when task is not visible": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService, notificationService *mock.MockNotificationService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task comment repository create task comment": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService, notificationService *mock.MockNotificationService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(task, nil)
+				taskCommentRepository.EXPECT().ListTaskCommenterIDs(gomock.Any(), 1).Return([]int{}, nil)
+				taskCommentRepository.EXPECT().CreateTaskComment(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskCommentRepositoryMock := mock.NewMockTaskCommentRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			taskCommentService := service.NewTaskCommentService(taskCommentRepositoryMock, taskServiceMock,
+				notificationServiceMock, 15*time.Minute)
+
+			cs.mocking(taskCommentRepositoryMock, taskServiceMock, notificationServiceMock)
+
+			// when
+			comment, err := taskCommentService.CreateComment(ctx, user, 1, dto.CreateTaskCommentDto{Body: "body"})
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedComment, comment)
+		})
+	}
+}
+
+func TestTaskCommentServiceListComments(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	comments := []model.TaskComment{{ID: 1, TaskID: 1, UserID: 3, Body: "body"}}
+
+	var cases = map[string]struct {
+		mocking          func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService)
+		expectedComments []model.TaskComment
+		expectedTotal    int
+		expectedErr      error
+	}{
+		"should list comments": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().ListTaskComments(gomock.Any(), 1, 10, 0).Return(comments, 1, nil)
+			},
+			expectedComments: comments,
+			expectedTotal:    1,
+		},
+		"should throw not found when task is not visible": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task comment repository list task comments": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().ListTaskComments(gomock.Any(), 1, 10, 0).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskCommentRepositoryMock := mock.NewMockTaskCommentRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskCommentService := service.NewTaskCommentService(taskCommentRepositoryMock, taskServiceMock, nil, 15*time.Minute)
+
+			cs.mocking(taskCommentRepositoryMock, taskServiceMock)
+
+			// when
+			comments, total, err := taskCommentService.ListComments(ctx, user, 1, 10, 0)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedComments, comments)
+			assert.Equal(t, cs.expectedTotal, total)
+		})
+	}
+}
+
+func TestTaskCommentServiceUpdateComment(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	updated := &model.TaskComment{ID: 4, CreatedAt: now, UpdatedAt: now, TaskID: 1, UserID: 2, Body: "new body"}
+
+	var cases = map[string]struct {
+		mocking         func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService)
+		expectedComment *model.TaskComment
+		expectedErr     error
+	}{
+		"should update comment": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now.Add(-time.Minute), TaskID: 1, UserID: 2}, nil)
+				taskCommentRepository.EXPECT().UpdateTaskComment(gomock.Any(), 4, "new body").Return(updated, nil)
+			},
+			expectedComment: updated,
+		},
+		"should throw unauthorized when user is not the author": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now, TaskID: 1, UserID: 3}, nil)
+			},
+			expectedErr: &exception.UnauthorizedException{Message: "only the author can change the comment"},
+		},
+		"should throw conflict when edit window has passed": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now.Add(-time.Hour), TaskID: 1, UserID: 2}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "comment can no longer be changed"},
+		},
+		"should throw not found": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(nil, &exception.NotFoundException{Message: "comment not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "comment not found"},
+		},
+		"should throw error when task comment repository update task comment": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now, TaskID: 1, UserID: 2}, nil)
+				taskCommentRepository.EXPECT().UpdateTaskComment(gomock.Any(), 4, "new body").Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskCommentRepositoryMock := mock.NewMockTaskCommentRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskCommentService := service.NewTaskCommentService(taskCommentRepositoryMock, taskServiceMock, nil, 15*time.Minute)
+
+			cs.mocking(taskCommentRepositoryMock, taskServiceMock)
+
+			// when
+			comment, err := taskCommentService.UpdateComment(ctx, user, 1, 4, dto.UpdateTaskCommentDto{Body: "new body"})
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedComment, comment)
+		})
+	}
+}
+
+func TestTaskCommentServiceDeleteComment(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: 2, Role: model.UserRoleManager}
+
+	var cases = map[string]struct {
+		mocking     func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService)
+		expectedErr error
+	}{
+		"should delete comment": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now, TaskID: 1, UserID: 2}, nil)
+				taskCommentRepository.EXPECT().DeleteTaskComment(gomock.Any(), 4).Return(nil)
+			},
+		},
+		"should throw unauthorized when manager is not the author": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now, TaskID: 1, UserID: 3}, nil)
+			},
+			expectedErr: &exception.UnauthorizedException{Message: "only the author can change the comment"},
+		},
+		"should throw error when task comment repository delete task comment": {
+			mocking: func(taskCommentRepository *mock.MockTaskCommentRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskCommentRepository.EXPECT().GetTaskCommentByID(gomock.Any(), 1, 4).
+					Return(&model.TaskComment{ID: 4, CreatedAt: now, TaskID: 1, UserID: 2}, nil)
+				taskCommentRepository.EXPECT().DeleteTaskComment(gomock.Any(), 4).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskCommentRepositoryMock := mock.NewMockTaskCommentRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskCommentService := service.NewTaskCommentService(taskCommentRepositoryMock, taskServiceMock, nil, 15*time.Minute)
+
+			cs.mocking(taskCommentRepositoryMock, taskServiceMock)
+
+			// when
+			err := taskCommentService.DeleteComment(ctx, user, 1, 4)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"golang.org/x/exp/slices"
+)
+
+//go:generate mockgen -destination=../../mock/task_import_service_mock.go -package=mock . TaskImportService
+type TaskImportService interface {
+	ImportTasks(ctx context.Context, user *model.User, rows []dto.ImportTaskDto, dryRun bool) ([]model.Task, error)
+}
+
+type taskImportService struct {
+	taskRepository repository.TaskRepository
+	userRepository repository.UserRepository
+	tagRepository  repository.TagRepository
+	workflow       TaskWorkflow
+	maxRows        int
+}
+
+func NewTaskImportService(taskRepository repository.TaskRepository, userRepository repository.UserRepository,
+	tagRepository repository.TagRepository, workflow TaskWorkflow, maxRows int) TaskImportService {
+	return &taskImportService{
+		taskRepository: taskRepository,
+		userRepository: userRepository,
+		tagRepository:  tagRepository,
+		workflow:       workflow,
+		maxRows:        maxRows,
+	}
+}
+
+// ImportTasks creates a task owned by user for each row, assigned to the
+// technician with the row username or else to user, in the row status when
+// the workflow has it. Every row is checked before anything is written and
+// the errors of all rows, the binding ones included, are returned together,
+// keyed by rows[<line>]. With dryRun the tasks are only checked.
+func (impl *taskImportService) ImportTasks(ctx context.Context, user *model.User, rows []dto.ImportTaskDto, dryRun bool) ([]model.Task, error) {
+	if len(rows) == 0 {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "file", Tag: "required", Message: "file must have at least one task"}},
+		}
+	}
+	if len(rows) > impl.maxRows {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{
+				{Field: "file", Tag: "max", Message: fmt.Sprintf("file must have at most %d tasks", impl.maxRows)},
+			},
+		}
+	}
+
+	assignees, err := impl.listAssignees(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := impl.listTags(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []model.Task{}
+	fields := []exception.FieldError{}
+	for _, row := range rows {
+		task, rowFields := impl.parseRow(user, row, assignees, tags)
+		for _, f := range append(row.Errors, rowFields...) {
+			f.Field = fmt.Sprintf("rows[%d].%s", row.Line, f.Field)
+			fields = append(fields, f)
+		}
+		tasks = append(tasks, task)
+	}
+	if len(fields) > 0 {
+		return nil, &exception.ValidationException{Message: "invalid rows", Fields: fields}
+	}
+
+	if dryRun {
+		return tasks, nil
+	}
+
+	tasks, err = impl.taskRepository.ImportTasks(ctx, tasks)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskimport.importtasks",
+		}).Error(err.Error())
+	}
+
+	return tasks, err
+}
+
+func (impl *taskImportService) parseRow(user *model.User, row dto.ImportTaskDto,
+	assignees map[string]model.User, tags []string) (model.Task, []exception.FieldError) {
+	fields := []exception.FieldError{}
+	task := model.Task{
+		UserID:     user.ID,
+		AssigneeID: user.ID,
+		Summary:    row.Summary,
+		Status:     row.Status,
+		Priority:   taskPriority(row.Priority),
+	}
+	if task.Status == "" {
+		task.Status = model.TaskStatusOpened
+	}
+	if !impl.workflow.HasStatus(task.Status) {
+		fields = append(fields, exception.FieldError{
+			Field: "status", Tag: "status", Message: "status must be a workflow status",
+		})
+	}
+
+	if row.AssigneeUsername != "" {
+		assignee, ok := assignees[row.AssigneeUsername]
+		if !ok || assignee.Role != model.UserRoleTechnician || assignee.Status != model.UserStatusActive {
+			fields = append(fields, exception.FieldError{
+				Field: "assignee", Tag: "technician", Message: "assignee must be an active technician",
+			})
+		}
+		task.AssigneeID = assignee.ID
+	}
+
+	// dates that failed binding are already reported with the row
+	dueAt, err := parseTaskDateTime("due_at", row.DueAt)
+	if err != nil && !hasFieldError(row.Errors, "due_at") {
+		fields = append(fields, err.(*exception.ValidationException).Fields...)
+	}
+	task.DueAt = dueAt
+
+	createdAt, err := parseTaskDateTime("created_at", row.CreatedAt)
+	if err != nil && !hasFieldError(row.Errors, "created_at") {
+		fields = append(fields, err.(*exception.ValidationException).Fields...)
+	}
+	if createdAt != nil {
+		task.CreatedAt = *createdAt
+	}
+
+	unknown := []string{}
+	for _, name := range parseTagFilter(strings.Join(row.Tags, ",")) {
+		if slices.Contains(tags, name) {
+			task.Tags = append(task.Tags, name)
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		fields = append(fields, exception.FieldError{
+			Field: "tags", Tag: "exists", Message: "tags must exist, unknown: " + strings.Join(unknown, ", "),
+		})
+	}
+
+	return task, fields
+}
+
+func hasFieldError(fields []exception.FieldError, field string) bool {
+	for _, f := range fields {
+		if f.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listAssignees reads the users named in the rows at once, by username.
+func (impl *taskImportService) listAssignees(ctx context.Context, rows []dto.ImportTaskDto) (map[string]model.User, error) {
+	usernames := []string{}
+	values := []interface{}{}
+	for _, row := range rows {
+		if row.AssigneeUsername != "" && !slices.Contains(usernames, row.AssigneeUsername) {
+			usernames = append(usernames, row.AssigneeUsername)
+			values = append(values, row.AssigneeUsername)
+		}
+	}
+
+	assignees := map[string]model.User{}
+	if len(usernames) == 0 {
+		return assignees, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(usernames)), ", ")
+	users, _, err := impl.userRepository.ListUsers(ctx, 0, 0,
+		repository.SetWhere("WHERE username IN ("+placeholders+") AND deleted_at IS NULL", values))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskimport.listassignees",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	for _, u := range users {
+		assignees[u.Username] = u
+	}
+
+	return assignees, nil
+}
+
+// listTags reads the existing tags among the ones in the rows at once.
+func (impl *taskImportService) listTags(ctx context.Context, rows []dto.ImportTaskDto) ([]string, error) {
+	names := []string{}
+	for _, row := range rows {
+		for _, name := range parseTagFilter(strings.Join(row.Tags, ",")) {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags, err := impl.tagRepository.ListTagsByNames(ctx, names)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskimport.listtags",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	found := []string{}
+	for _, tag := range tags {
+		found = append(found, tag.Name)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskImportServiceImportTasks(t *testing.T) {
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	technician := model.User{ID: 2, Username: "technician", Role: model.UserRoleTechnician, Status: model.UserStatusActive}
+	createdAt := time.Date(1992, 8, 21, 12, 3, 43, 0, time.Local)
+	dueAt := time.Date(1992, 8, 28, 18, 0, 0, 0, time.Local)
+	workflow := service.TaskWorkflow{Transitions: []service.TaskTransitionRule{
+		{From: model.TaskStatusOpened, To: model.TaskStatusClosed, Roles: []model.UserRole{model.UserRoleManager}},
+	}}
+
+	rows := []dto.ImportTaskDto{
+		{
+			CreateTaskDto: dto.CreateTaskDto{
+				Summary:  "summary",
+				DueAt:    "1992-08-28 18:00:00",
+				Priority: model.TaskPriorityHigh,
+				Tags:     []string{"Maintenance"},
+			},
+			Line:             2,
+			Status:           model.TaskStatusClosed,
+			AssigneeUsername: "technician",
+			CreatedAt:        "1992-08-21 12:03:43",
+		},
+		{CreateTaskDto: dto.CreateTaskDto{Summary: "other"}, Line: 3},
+	}
+	tasks := []model.Task{
+		{UserID: 1, AssigneeID: 2, Summary: "summary", Status: model.TaskStatusClosed, DueAt: &dueAt,
+			Priority: model.TaskPriorityHigh, Tags: model.TaskTags{"maintenance"}, CreatedAt: createdAt},
+		{UserID: 1, AssigneeID: 1, Summary: "other", Status: model.TaskStatusOpened, Priority: model.TaskPriorityNormal},
+	}
+
+	var cases = map[string]struct {
+		inputRows     []dto.ImportTaskDto
+		inputDryRun   bool
+		mocking       func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository)
+		expectedTasks []model.Task
+		expectedErr   error
+	}{
+		"should import tasks": {
+			inputRows: rows,
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0,
+					repository.SetWhere("WHERE username IN (?) AND deleted_at IS NULL", []interface{}{"technician"})).
+					Return([]model.User{technician}, 1, nil)
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), []string{"maintenance"}).
+					Return([]model.Tag{{ID: 1, Name: "maintenance"}}, nil)
+				taskRepository.EXPECT().ImportTasks(gomock.Any(), tasks).Return([]model.Task{{ID: 1}, {ID: 2}}, nil)
+			},
+			expectedTasks: []model.Task{{ID: 1}, {ID: 2}},
+		},
+		"should not write tasks on dry run": {
+			inputRows:   rows,
+			inputDryRun: true,
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0, gomock.Any()).Return([]model.User{technician}, 1, nil)
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), gomock.Any()).Return([]model.Tag{{ID: 1, Name: "maintenance"}}, nil)
+			},
+			expectedTasks: tasks,
+		},
+		"should throw validation exception with the errors of every row": {
+			inputRows: []dto.ImportTaskDto{
+				{CreateTaskDto: dto.CreateTaskDto{Summary: "summary", Tags: []string{"unknown"}, DueAt: "1992-08-28"}, Line: 2,
+					AssigneeUsername: "manager", Errors: []exception.FieldError{
+						{Field: "due_at", Tag: "datetime", Message: "due_at must match the format 2006-01-02 15:04:05"},
+					}},
+				{CreateTaskDto: dto.CreateTaskDto{Summary: "summary"}, Line: 3, AssigneeUsername: "nobody",
+					Status: model.TaskStatusInReview},
+			},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0,
+					repository.SetWhere("WHERE username IN (?, ?) AND deleted_at IS NULL", []interface{}{"manager", "nobody"})).
+					Return([]model.User{{ID: 1, Username: "manager", Role: model.UserRoleManager, Status: model.UserStatusActive}}, 1, nil)
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), []string{"unknown"}).Return([]model.Tag{}, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid rows",
+				Fields: []exception.FieldError{
+					{Field: "rows[2].due_at", Tag: "datetime", Message: "due_at must match the format 2006-01-02 15:04:05"},
+					{Field: "rows[2].assignee", Tag: "technician", Message: "assignee must be an active technician"},
+					{Field: "rows[2].tags", Tag: "exists", Message: "tags must exist, unknown: unknown"},
+					{Field: "rows[3].status", Tag: "status", Message: "status must be a workflow status"},
+					{Field: "rows[3].assignee", Tag: "technician", Message: "assignee must be an active technician"},
+				},
+			},
+		},
+		"should throw validation exception when there are no rows": {
+			inputRows: []dto.ImportTaskDto{},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "file", Tag: "required", Message: "file must have at least one task"}},
+			},
+		},
+		"should throw validation exception when there are too many rows": {
+			inputRows: append(rows, dto.ImportTaskDto{CreateTaskDto: dto.CreateTaskDto{Summary: "summary"}, Line: 4}),
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "file", Tag: "max", Message: "file must have at most 2 tasks"}},
+			},
+		},
+		"should throw error when user repository list users": {
+			inputRows: rows,
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				userRepository.EXPECT().ListUsers(gomock.Any(), 0, 0, gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when task repository import tasks": {
+			inputRows: rows[1:],
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				taskRepository.EXPECT().ImportTasks(gomock.Any(), tasks[1:]).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			tagRepositoryMock := mock.NewMockTagRepository(ctrl)
+			taskImportService := service.NewTaskImportService(taskRepositoryMock, userRepositoryMock, tagRepositoryMock, workflow, 2)
+
+			cs.mocking(taskRepositoryMock, userRepositoryMock, tagRepositoryMock)
+
+			// when
+			tasks, err := taskImportService.ImportTasks(ctx, manager, cs.inputRows, cs.inputDryRun)
+
+			// then
+			assert.Equal(t, cs.expectedTasks, tasks)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/task_reminder_service_mock.go -package=mock . TaskReminderService
+type TaskReminderService interface {
+	Run(ctx context.Context)
+	SendReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type taskReminderService struct {
+	taskReminderRepository repository.TaskReminderRepository
+	notificationService    NotificationService
+	interval               time.Duration
+	dueSoon                time.Duration
+}
+
+func NewTaskReminderService(taskReminderRepository repository.TaskReminderRepository, notificationService NotificationService,
+	interval, dueSoon time.Duration) TaskReminderService {
+	return &taskReminderService{
+		taskReminderRepository: taskReminderRepository,
+		notificationService:    notificationService,
+		interval:               interval,
+		dueSoon:                dueSoon,
+	}
+}
+
+// Run sends the reminders every interval until ctx is done.
+func (impl *taskReminderService) Run(ctx context.Context) {
+	ticker := time.NewTicker(impl.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			impl.SendReminders(ctx, now)
+		}
+	}
+}
+
+// SendReminders notifies the assignees of tasks due within dueSoon and of
+// overdue tasks. Each reminder is claimed before it is sent, so it goes out
+// at most once per threshold and due date even across instances.
+func (impl *taskReminderService) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	windows := []struct {
+		threshold model.TaskReminderThreshold
+		dueAfter  time.Time
+		dueBefore time.Time
+	}{
+		{threshold: model.TaskReminderThresholdDueSoon, dueAfter: now, dueBefore: now.Add(impl.dueSoon)},
+		{threshold: model.TaskReminderThresholdOverdue, dueAfter: time.Unix(0, 0), dueBefore: now},
+	}
+
+	sent := 0
+	for _, w := range windows {
+		tasks, err := impl.taskReminderRepository.ListTasksToRemind(ctx, w.threshold, w.dueAfter, w.dueBefore)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskreminder.sendreminders",
+			}).Error(err.Error())
+			return sent, err
+		}
+
+		for i := range tasks {
+			task := &tasks[i]
+			_, err := impl.taskReminderRepository.CreateTaskReminder(ctx, model.TaskReminder{
+				TaskID:    task.ID,
+				Threshold: w.threshold,
+				DueAt:     *task.DueAt,
+			})
+			if err != nil {
+				if _, ok := err.(*exception.ConflictException); ok {
+					continue
+				}
+
+				log.WithContext(ctx).WithFields(log.Fields{
+					"trace": "internal.service.taskreminder.sendreminders",
+				}).Error(err.Error())
+				return sent, err
+			}
+
+			impl.notificationService.NotifyTaskReminder(ctx, task, w.threshold)
+			sent++
+		}
+	}
+
+	return sent, nil
+}
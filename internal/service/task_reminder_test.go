@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskReminderServiceSendReminders(t *testing.T) {
+	now := time.Date(1992, 8, 28, 12, 0, 0, 0, time.Local)
+	dueSoonAt := now.Add(time.Hour)
+	overdueAt := now.Add(-time.Hour)
+	dueSoonTask := model.Task{ID: 1, AssigneeID: 2, DueAt: &dueSoonAt}
+	overdueTask := model.Task{ID: 2, AssigneeID: 2, DueAt: &overdueAt}
+
+	var cases = map[string]struct {
+		mocking      func(taskReminderRepository *mock.MockTaskReminderRepository, notificationService *mock.MockNotificationService)
+		expectedSent int
+		expectedErr  error
+	}{
+		"should send due soon and overdue reminders": {
+			mocking: func(taskReminderRepository *mock.MockTaskReminderRepository, notificationService *mock.MockNotificationService) {
+				taskReminderRepository.EXPECT().
+					ListTasksToRemind(gomock.Any(), model.TaskReminderThresholdDueSoon, now, now.Add(24*time.Hour)).
+					Return([]model.Task{dueSoonTask}, nil)
+				taskReminderRepository.EXPECT().CreateTaskReminder(gomock.Any(), model.TaskReminder{
+					TaskID: 1, Threshold: model.TaskReminderThresholdDueSoon, DueAt: dueSoonAt,
+				}).Return(&model.TaskReminder{ID: 1}, nil)
+				notificationService.EXPECT().NotifyTaskReminder(gomock.Any(), &dueSoonTask, model.TaskReminderThresholdDueSoon)
+
+				taskReminderRepository.EXPECT().
+					ListTasksToRemind(gomock.Any(), model.TaskReminderThresholdOverdue, time.Unix(0, 0), now).
+					Return([]model.Task{overdueTask}, nil)
+				taskReminderRepository.EXPECT().CreateTaskReminder(gomock.Any(), model.TaskReminder{
+					TaskID: 2, Threshold: model.TaskReminderThresholdOverdue, DueAt: overdueAt,
+				}).Return(&model.TaskReminder{ID: 2}, nil)
+				notificationService.EXPECT().NotifyTaskReminder(gomock.Any(), &overdueTask, model.TaskReminderThresholdOverdue)
+			},
+			expectedSent: 2,
+		},
+		"should skip reminder already claimed": {
+			mocking: func(taskReminderRepository *mock.MockTaskReminderRepository, notificationService *mock.MockNotificationService) {
+				taskReminderRepository.EXPECT().
+					ListTasksToRemind(gomock.Any(), model.TaskReminderThresholdDueSoon, gomock.Any(), gomock.Any()).
+					Return([]model.Task{dueSoonTask}, nil)
+				taskReminderRepository.EXPECT().CreateTaskReminder(gomock.Any(), gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "task reminder already sent"})
+				taskReminderRepository.EXPECT().
+					ListTasksToRemind(gomock.Any(), model.TaskReminderThresholdOverdue, gomock.Any(), gomock.Any()).
+					Return([]model.Task{}, nil)
+			},
+		},
+		"should throw error when task reminder repository list tasks to remind": {
+			mocking: func(taskReminderRepository *mock.MockTaskReminderRepository, notificationService *mock.MockNotificationService) {
+				taskReminderRepository.EXPECT().ListTasksToRemind(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when task reminder repository create task reminder": {
+			mocking: func(taskReminderRepository *mock.MockTaskReminderRepository, notificationService *mock.MockNotificationService) {
+				taskReminderRepository.EXPECT().
+					ListTasksToRemind(gomock.Any(), model.TaskReminderThresholdDueSoon, gomock.Any(), gomock.Any()).
+					Return([]model.Task{dueSoonTask}, nil)
+				taskReminderRepository.EXPECT().CreateTaskReminder(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskReminderRepositoryMock := mock.NewMockTaskReminderRepository(ctrl)
+			notificationServiceMock := mock.NewMockNotificationService(ctrl)
+			taskReminderService := service.NewTaskReminderService(taskReminderRepositoryMock, notificationServiceMock,
+				time.Minute, 24*time.Hour)
+
+			cs.mocking(taskReminderRepositoryMock, notificationServiceMock)
+
+			// when
+			sent, err := taskReminderService.SendReminders(ctx, now)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedSent, sent)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+// taskReportMaxPeriods bounds the days, or weeks, a report spans.
+const taskReportMaxPeriods = 366
+
+var taskReportStatuses = []model.TaskStatus{
+	model.TaskStatusOpened,
+	model.TaskStatusInProgress,
+	model.TaskStatusBlocked,
+	model.TaskStatusInReview,
+	model.TaskStatusClosed,
+}
+
+//go:generate mockgen -destination=../../mock/task_report_service_mock.go -package=mock . TaskReportService
+type TaskReportService interface {
+	ReportTasks(ctx context.Context, filter dto.TaskReportFilterDto) (*model.TaskReport, error)
+}
+
+type taskReportService struct {
+	taskReportRepository repository.TaskReportRepository
+}
+
+func NewTaskReportService(taskReportRepository repository.TaskReportRepository) TaskReportService {
+	return &taskReportService{
+		taskReportRepository: taskReportRepository,
+	}
+}
+
+// ReportTasks reports on the tasks from filter.From to filter.To, the last
+// seven days by default, per day unless asked per week. Every status and
+// period is listed, with zeros when nothing happened.
+func (impl *taskReportService) ReportTasks(ctx context.Context, filter dto.TaskReportFilterDto) (*model.TaskReport, error) {
+	interval := filter.Interval
+	if interval == "" {
+		interval = model.TaskReportIntervalDay
+	}
+
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if filter.To != "" {
+		to, _ = time.ParseInLocation("2006-01-02", filter.To, time.Local)
+	}
+	from := to.AddDate(0, 0, -6)
+	if filter.From != "" {
+		from, _ = time.ParseInLocation("2006-01-02", filter.From, time.Local)
+	}
+
+	if from.After(to) {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "from", Tag: "ltefield", Message: "from must not be after to"}},
+		}
+	}
+	periods := taskReportPeriods(from, to, interval)
+	if len(periods) > taskReportMaxPeriods {
+		return nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{{
+				Field: "from", Tag: "max",
+				Message: fmt.Sprintf("from and to must span at most %d %ss", taskReportMaxPeriods, interval),
+			}},
+		}
+	}
+
+	report, err := impl.taskReportRepository.ReportTasks(ctx, from, to.AddDate(0, 0, 1), interval)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskreport.reporttasks",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	report.From = from
+	report.To = to
+	report.Interval = interval
+
+	statuses := []model.TaskStatusCount{}
+	for _, status := range taskReportStatuses {
+		count := model.TaskStatusCount{Status: status}
+		for _, s := range report.Statuses {
+			if s.Status == status {
+				count.Total = s.Total
+			}
+		}
+		statuses = append(statuses, count)
+	}
+	report.Statuses = statuses
+
+	counts := []model.TaskPeriodCount{}
+	for _, period := range periods {
+		count := model.TaskPeriodCount{Period: period}
+		for _, p := range report.Periods {
+			if p.Period == period {
+				count = p
+			}
+		}
+		counts = append(counts, count)
+	}
+	report.Periods = counts
+
+	return report, nil
+}
+
+// taskReportPeriods lists the periods from from to to as the repository
+// names them: the day, or the monday of the week, formatted as 2006-01-02.
+func taskReportPeriods(from, to time.Time, interval model.TaskReportInterval) []string {
+	step := 1
+	if interval == model.TaskReportIntervalWeek {
+		step = 7
+		from = from.AddDate(0, 0, -(int(from.Weekday())+6)%7)
+	}
+
+	periods := []string{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, step) {
+		periods = append(periods, day.Format("2006-01-02"))
+		if len(periods) > taskReportMaxPeriods {
+			break
+		}
+	}
+
+	return periods
+}
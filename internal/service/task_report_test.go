@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskReportServiceReportTasks(t *testing.T) {
+	day := func(value string) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", value, time.Local)
+		return d
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	mean := 93600.0
+	technicians := []model.TechnicianTaskStats{{UserID: 2, Username: "technician", Assigned: 3, Closed: 2, MeanSeconds: &mean, LoggedSeconds: 27000}}
+
+	var cases = map[string]struct {
+		inputFilter    dto.TaskReportFilterDto
+		mocking        func(taskReportRepository *mock.MockTaskReportRepository)
+		expectedReport *model.TaskReport
+		expectedErr    error
+	}{
+		"should report tasks per day": {
+			inputFilter: dto.TaskReportFilterDto{From: "1992-08-17", To: "1992-08-19"},
+			mocking: func(taskReportRepository *mock.MockTaskReportRepository) {
+				taskReportRepository.EXPECT().ReportTasks(gomock.Any(), day("1992-08-17"), day("1992-08-20"), model.TaskReportIntervalDay).
+					Return(&model.TaskReport{
+						Statuses:    []model.TaskStatusCount{{Status: model.TaskStatusClosed, Total: 2}, {Status: model.TaskStatusOpened, Total: 1}},
+						Periods:     []model.TaskPeriodCount{{Period: "1992-08-18", Created: 3, Closed: 2}},
+						Closed:      model.TaskCloseStats{Total: 2, MeanSeconds: &mean},
+						Technicians: technicians,
+					}, nil)
+			},
+			expectedReport: &model.TaskReport{
+				From:     day("1992-08-17"),
+				To:       day("1992-08-19"),
+				Interval: model.TaskReportIntervalDay,
+				Statuses: []model.TaskStatusCount{
+					{Status: model.TaskStatusOpened, Total: 1},
+					{Status: model.TaskStatusInProgress},
+					{Status: model.TaskStatusBlocked},
+					{Status: model.TaskStatusInReview},
+					{Status: model.TaskStatusClosed, Total: 2},
+				},
+				Periods: []model.TaskPeriodCount{
+					{Period: "1992-08-17"},
+					{Period: "1992-08-18", Created: 3, Closed: 2},
+					{Period: "1992-08-19"},
+				},
+				Closed:      model.TaskCloseStats{Total: 2, MeanSeconds: &mean},
+				Technicians: technicians,
+			},
+		},
+		"should report tasks per week starting on monday": {
+			inputFilter: dto.TaskReportFilterDto{From: "1992-08-19", To: "1992-08-31", Interval: model.TaskReportIntervalWeek},
+			mocking: func(taskReportRepository *mock.MockTaskReportRepository) {
+				taskReportRepository.EXPECT().ReportTasks(gomock.Any(), day("1992-08-19"), day("1992-09-01"), model.TaskReportIntervalWeek).
+					Return(&model.TaskReport{Periods: []model.TaskPeriodCount{{Period: "1992-08-24", Created: 1}}}, nil)
+			},
+			expectedReport: &model.TaskReport{
+				From:     day("1992-08-19"),
+				To:       day("1992-08-31"),
+				Interval: model.TaskReportIntervalWeek,
+				Statuses: []model.TaskStatusCount{
+					{Status: model.TaskStatusOpened},
+					{Status: model.TaskStatusInProgress},
+					{Status: model.TaskStatusBlocked},
+					{Status: model.TaskStatusInReview},
+					{Status: model.TaskStatusClosed},
+				},
+				Periods: []model.TaskPeriodCount{
+					{Period: "1992-08-17"},
+					{Period: "1992-08-24", Created: 1},
+					{Period: "1992-08-31"},
+				},
+			},
+		},
+		"should report the last seven days by default": {
+			mocking: func(taskReportRepository *mock.MockTaskReportRepository) {
+				taskReportRepository.EXPECT().ReportTasks(gomock.Any(), today.AddDate(0, 0, -6), today.AddDate(0, 0, 1), model.TaskReportIntervalDay).
+					Return(&model.TaskReport{}, nil)
+			},
+			expectedReport: func() *model.TaskReport {
+				report := &model.TaskReport{From: today.AddDate(0, 0, -6), To: today, Interval: model.TaskReportIntervalDay}
+				for _, status := range []model.TaskStatus{model.TaskStatusOpened, model.TaskStatusInProgress,
+					model.TaskStatusBlocked, model.TaskStatusInReview, model.TaskStatusClosed} {
+					report.Statuses = append(report.Statuses, model.TaskStatusCount{Status: status})
+				}
+				for d := today.AddDate(0, 0, -6); !d.After(today); d = d.AddDate(0, 0, 1) {
+					report.Periods = append(report.Periods, model.TaskPeriodCount{Period: d.Format("2006-01-02")})
+				}
+				return report
+			}(),
+		},
+		"should throw validation exception when from is after to": {
+			inputFilter: dto.TaskReportFilterDto{From: "1992-08-20", To: "1992-08-19"},
+			mocking:     func(taskReportRepository *mock.MockTaskReportRepository) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "from", Tag: "ltefield", Message: "from must not be after to"}},
+			},
+		},
+		"should throw validation exception when the range is too long": {
+			inputFilter: dto.TaskReportFilterDto{From: "1992-01-01", To: "1993-01-01"},
+			mocking:     func(taskReportRepository *mock.MockTaskReportRepository) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "from", Tag: "max", Message: "from and to must span at most 366 days"}},
+			},
+		},
+		"should throw error when task report repository report tasks": {
+			inputFilter: dto.TaskReportFilterDto{From: "1992-08-17", To: "1992-08-19"},
+			mocking: func(taskReportRepository *mock.MockTaskReportRepository) {
+				taskReportRepository.EXPECT().ReportTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskReportRepositoryMock := mock.NewMockTaskReportRepository(ctrl)
+			taskReportService := service.NewTaskReportService(taskReportRepositoryMock)
+
+			cs.mocking(taskReportRepositoryMock)
+
+			// when
+			report, err := taskReportService.ReportTasks(ctx, cs.inputFilter)
+
+			// then
+			assert.Equal(t, cs.expectedReport, report)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
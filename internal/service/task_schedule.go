@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+const taskScheduleBatchSize = 50
+
+//go:generate mockgen -destination=../../mock/task_schedule_service_mock.go -package=mock . TaskScheduleService
+type TaskScheduleService interface {
+	CreateTaskSchedule(ctx context.Context, user *model.User, data dto.CreateTaskScheduleDto) (*model.TaskSchedule, error)
+	ListTaskSchedules(ctx context.Context) ([]model.TaskSchedule, error)
+	GetTaskSchedule(ctx context.Context, id int) (*model.TaskSchedule, error)
+	UpdateTaskSchedule(ctx context.Context, id int, data dto.UpdateTaskScheduleDto) (*model.TaskSchedule, error)
+	DeleteTaskSchedule(ctx context.Context, id int) error
+
+	Run(ctx context.Context)
+	RunDueSchedules(ctx context.Context, now time.Time) (int, error)
+}
+
+type taskScheduleService struct {
+	taskScheduleRepository repository.TaskScheduleRepository
+	userRepository         repository.UserRepository
+	taskService            TaskService
+	owner                  string
+	interval               time.Duration
+	lease                  time.Duration
+}
+
+func NewTaskScheduleService(taskScheduleRepository repository.TaskScheduleRepository, userRepository repository.UserRepository,
+	taskService TaskService, interval, lease time.Duration) TaskScheduleService {
+	return &taskScheduleService{
+		taskScheduleRepository: taskScheduleRepository,
+		userRepository:         userRepository,
+		taskService:            taskService,
+		owner:                  schedulerOwner(),
+		interval:               interval,
+		lease:                  lease,
+	}
+}
+
+func (impl *taskScheduleService) CreateTaskSchedule(ctx context.Context, user *model.User, data dto.CreateTaskScheduleDto) (*model.TaskSchedule, error) {
+	schedule := model.TaskSchedule{
+		UserID:     user.ID,
+		AssigneeID: user.ID,
+		Summary:    data.Summary,
+		Priority:   taskPriority(data.Priority),
+		Cron:       data.Cron,
+		Enabled:    data.Enabled == nil || *data.Enabled,
+	}
+	if data.AssigneeID != nil {
+		schedule.AssigneeID = *data.AssigneeID
+	}
+
+	if err := impl.prepareTaskSchedule(ctx, &schedule, data.AssigneeID != nil); err != nil {
+		return nil, err
+	}
+
+	res, err := impl.taskScheduleRepository.CreateTaskSchedule(ctx, schedule)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskschedule.createtaskschedule",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (impl *taskScheduleService) ListTaskSchedules(ctx context.Context) ([]model.TaskSchedule, error) {
+	schedules, err := impl.taskScheduleRepository.ListTaskSchedules(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskschedule.listtaskschedules",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (impl *taskScheduleService) GetTaskSchedule(ctx context.Context, id int) (*model.TaskSchedule, error) {
+	schedule, err := impl.taskScheduleRepository.GetTaskScheduleByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskschedule.gettaskschedule",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// UpdateTaskSchedule replaces the schedule and moves its next run after now,
+// so a schedule enabled again does not catch up on the runs it missed.
+func (impl *taskScheduleService) UpdateTaskSchedule(ctx context.Context, id int, data dto.UpdateTaskScheduleDto) (*model.TaskSchedule, error) {
+	schedule, err := impl.GetTaskSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Summary = data.Summary
+	schedule.Priority = taskPriority(data.Priority)
+	schedule.Cron = data.Cron
+	schedule.Enabled = data.Enabled == nil || *data.Enabled
+	if data.AssigneeID != nil {
+		schedule.AssigneeID = *data.AssigneeID
+	}
+
+	if err := impl.prepareTaskSchedule(ctx, schedule, data.AssigneeID != nil); err != nil {
+		return nil, err
+	}
+
+	res, err := impl.taskScheduleRepository.UpdateTaskSchedule(ctx, *schedule)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskschedule.updatetaskschedule",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (impl *taskScheduleService) DeleteTaskSchedule(ctx context.Context, id int) error {
+	err := impl.taskScheduleRepository.DeleteTaskSchedule(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskschedule.deletetaskschedule",
+			}).Error(err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Run creates the tasks of the due schedules every interval until ctx is
+// done.
+func (impl *taskScheduleService) Run(ctx context.Context) {
+	ticker := time.NewTicker(impl.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			impl.RunDueSchedules(ctx, now)
+		}
+	}
+}
+
+// RunDueSchedules leases the due schedules and creates one task per
+// schedule. The lease keeps replicas from running the same schedule at once
+// and the occurrence is claimed before its task is created, so a run that
+// lost its lease or crashed never creates the task twice. Occurrences
+// missed while the scheduler was down collapse into a single task.
+func (impl *taskScheduleService) RunDueSchedules(ctx context.Context, now time.Time) (int, error) {
+	schedules, err := impl.taskScheduleRepository.AcquireDueTaskSchedules(ctx, impl.owner, now, now.Add(impl.lease),
+		taskScheduleBatchSize)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskschedule.rundueschedules",
+		}).Error(err.Error())
+		return 0, err
+	}
+
+	created := 0
+	for i := range schedules {
+		schedule := &schedules[i]
+		ok, err := impl.runTaskSchedule(ctx, schedule)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+
+		nextRunAt := now
+		if schedule.NextRunAt.After(nextRunAt) {
+			nextRunAt = schedule.NextRunAt
+		}
+		cron, err := parseCron(schedule.Cron)
+		if err == nil {
+			nextRunAt = cron.Next(nextRunAt)
+		}
+
+		err = impl.taskScheduleRepository.ReleaseTaskSchedule(ctx, schedule.ID, impl.owner, nextRunAt, &schedule.NextRunAt)
+		if err != nil {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.taskschedule.rundueschedules",
+			}).Error(err.Error())
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+// runTaskSchedule creates the task of the schedule occurrence, unless it was
+// already claimed. Failures to create the task are logged and the
+// occurrence is skipped, so one broken schedule does not hold the others.
+func (impl *taskScheduleService) runTaskSchedule(ctx context.Context, schedule *model.TaskSchedule) (bool, error) {
+	err := impl.taskScheduleRepository.CreateTaskScheduleRun(ctx, model.TaskScheduleRun{
+		ScheduleID: schedule.ID,
+		RunAt:      schedule.NextRunAt,
+	})
+	if err != nil {
+		if _, ok := err.(*exception.ConflictException); ok {
+			return false, nil
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskschedule.runtaskschedule",
+		}).Error(err.Error())
+		return false, err
+	}
+
+	task, err := impl.createScheduledTask(ctx, schedule)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace":       "internal.service.taskschedule.runtaskschedule",
+			"schedule_id": schedule.ID,
+		}).Error(err.Error())
+		return false, nil
+	}
+
+	err = impl.taskScheduleRepository.SetTaskScheduleRunTask(ctx, schedule.ID, schedule.NextRunAt, task.ID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskschedule.runtaskschedule",
+		}).Error(err.Error())
+	}
+
+	return true, nil
+}
+
+// createScheduledTask creates the task as the schedule creator, so it goes
+// through the same rules as a task created by hand.
+func (impl *taskScheduleService) createScheduledTask(ctx context.Context, schedule *model.TaskSchedule) (*model.Task, error) {
+	creator, err := impl.userRepository.GetUserByID(ctx, schedule.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	assigneeID := schedule.AssigneeID
+	return impl.taskService.CreateTask(ctx, creator, dto.CreateTaskDto{
+		Summary:    renderTaskScheduleSummary(schedule.Summary, schedule.NextRunAt),
+		AssigneeID: &assigneeID,
+		Priority:   schedule.Priority,
+	})
+}
+
+// prepareTaskSchedule validates the cron expression, the rendered summary
+// and the assignee, and sets the next run after now.
+func (impl *taskScheduleService) prepareTaskSchedule(ctx context.Context, schedule *model.TaskSchedule, checkAssignee bool) error {
+	cron, err := parseCron(schedule.Cron)
+	if err != nil {
+		return &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "cron", Tag: "cron", Message: err.Error()}},
+		}
+	}
+
+	schedule.NextRunAt = cron.Next(time.Now())
+	if schedule.NextRunAt.IsZero() {
+		return &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "cron", Tag: "cron", Message: "cron never matches"}},
+		}
+	}
+
+	if len(renderTaskScheduleSummary(schedule.Summary, schedule.NextRunAt)) > 2500 {
+		return &exception.ValidationException{
+			Message: "invalid fields",
+			Fields: []exception.FieldError{{
+				Field: "summary", Tag: "max",
+				Message: "summary must have at most 2500 characters once rendered",
+			}},
+		}
+	}
+
+	if checkAssignee {
+		return impl.validateScheduleAssignee(ctx, schedule)
+	}
+
+	return nil
+}
+
+func (impl *taskScheduleService) validateScheduleAssignee(ctx context.Context, schedule *model.TaskSchedule) error {
+	if schedule.AssigneeID == schedule.UserID {
+		return nil
+	}
+
+	invalid := &exception.ValidationException{
+		Message: "invalid fields",
+		Fields: []exception.FieldError{
+			{Field: "assignee_id", Tag: "technician", Message: "assignee_id must be an active technician"},
+		},
+	}
+
+	assignee, err := impl.userRepository.GetUserByID(ctx, schedule.AssigneeID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return invalid
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.taskschedule.validatescheduleassignee",
+		}).Error(err.Error())
+		return err
+	}
+
+	if assignee.Role != model.UserRoleTechnician || assignee.Status != model.UserStatusActive {
+		return invalid
+	}
+
+	return nil
+}
+
+// renderTaskScheduleSummary fills the {{date}}, {{week}}, {{month}} and
+// {{year}} placeholders with the occurrence time.
+func renderTaskScheduleSummary(summary string, at time.Time) string {
+	_, week := at.ISOWeek()
+
+	return strings.NewReplacer(
+		"{{date}}", at.Format("2006-01-02"),
+		"{{week}}", fmt.Sprintf("%02d", week),
+		"{{month}}", at.Format("01"),
+		"{{year}}", at.Format("2006"),
+	).Replace(summary)
+}
+
+// schedulerOwner identifies this process in the schedule leases.
+func schedulerOwner() string {
+	hostname, _ := os.Hostname()
+	b := make([]byte, 4)
+	rand.Read(b)
+
+	owner := fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(b))
+	if len(owner) > 64 {
+		owner = owner[len(owner)-64:]
+	}
+
+	return owner
+}
@@ -0,0 +1,354 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskScheduleServiceCreateTaskSchedule(t *testing.T) {
+	manager := &model.User{ID: 1, Role: model.UserRoleManager, Status: model.UserStatusActive}
+	technician := &model.User{ID: 2, Role: model.UserRoleTechnician, Status: model.UserStatusActive}
+	assigneeID := 2
+	disabled := false
+
+	var cases = map[string]struct {
+		inputData        dto.CreateTaskScheduleDto
+		mocking          func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository)
+		expectedSchedule *model.TaskSchedule
+		expectedErr      error
+	}{
+		"should create task schedule": {
+			inputData: dto.CreateTaskScheduleDto{Summary: "weekly check {{date}}", AssigneeID: &assigneeID, Cron: "0 9 * * 1"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(technician, nil)
+				taskScheduleRepository.EXPECT().CreateTaskSchedule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, schedule model.TaskSchedule) (*model.TaskSchedule, error) {
+						assert.Equal(t, 1, schedule.UserID)
+						assert.Equal(t, 2, schedule.AssigneeID)
+						assert.Equal(t, model.TaskPriorityNormal, schedule.Priority)
+						assert.True(t, schedule.Enabled)
+						assert.Equal(t, time.Monday, schedule.NextRunAt.Weekday())
+						assert.Equal(t, 9, schedule.NextRunAt.Hour())
+						assert.True(t, schedule.NextRunAt.After(time.Now()))
+						return &model.TaskSchedule{ID: 1}, nil
+					})
+			},
+			expectedSchedule: &model.TaskSchedule{ID: 1},
+		},
+		"should create disabled task schedule assigned to its creator": {
+			inputData: dto.CreateTaskScheduleDto{Summary: "monthly check", Cron: "@monthly", Enabled: &disabled},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository) {
+				taskScheduleRepository.EXPECT().CreateTaskSchedule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, schedule model.TaskSchedule) (*model.TaskSchedule, error) {
+						assert.Equal(t, 1, schedule.AssigneeID)
+						assert.False(t, schedule.Enabled)
+						assert.Equal(t, 1, schedule.NextRunAt.Day())
+						return &model.TaskSchedule{ID: 1}, nil
+					})
+			},
+			expectedSchedule: &model.TaskSchedule{ID: 1},
+		},
+		"should throw validation exception when cron is invalid": {
+			inputData: dto.CreateTaskScheduleDto{Summary: "check", Cron: "0 25 * * *"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository) {
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "cron", Tag: "cron", Message: `cron field "25": out of range 0-23`}},
+			},
+		},
+		"should throw validation exception when cron does not have 5 fields": {
+			inputData: dto.CreateTaskScheduleDto{Summary: "check", Cron: "0 9 * *"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository) {
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "cron", Tag: "cron", Message: "cron must have 5 fields, got 4"}},
+			},
+		},
+		"should throw validation exception when cron never matches": {
+			inputData: dto.CreateTaskScheduleDto{Summary: "check", Cron: "0 9 31 2 *"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository) {
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "cron", Tag: "cron", Message: "cron never matches"}},
+			},
+		},
+		"should throw validation exception when assignee is not a technician": {
+			inputData: dto.CreateTaskScheduleDto{Summary: "check", AssigneeID: &assigneeID, Cron: "@daily"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(&model.User{ID: 2, Role: model.UserRoleManager}, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "assignee_id", Tag: "technician", Message: "assignee_id must be an active technician"},
+				},
+			},
+		},
+		"should throw error when task schedule repository create task schedule": {
+			inputData: dto.CreateTaskScheduleDto{Summary: "check", Cron: "@daily"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository) {
+				taskScheduleRepository.EXPECT().CreateTaskSchedule(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskScheduleRepositoryMock := mock.NewMockTaskScheduleRepository(ctrl)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			taskScheduleService := service.NewTaskScheduleService(taskScheduleRepositoryMock, userRepositoryMock, nil,
+				time.Minute, 5*time.Minute)
+
+			cs.mocking(taskScheduleRepositoryMock, userRepositoryMock)
+
+			// when
+			schedule, err := taskScheduleService.CreateTaskSchedule(ctx, manager, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedSchedule, schedule)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskScheduleServiceUpdateTaskSchedule(t *testing.T) {
+	lastRunAt := time.Date(1992, 8, 24, 9, 0, 0, 0, time.Local)
+	schedule := model.TaskSchedule{ID: 1, UserID: 1, AssigneeID: 2, Summary: "check", Cron: "0 9 * * 1",
+		Enabled: false, LastRunAt: &lastRunAt}
+
+	var cases = map[string]struct {
+		inputID          int
+		inputData        dto.UpdateTaskScheduleDto
+		mocking          func(taskScheduleRepository *mock.MockTaskScheduleRepository)
+		expectedSchedule *model.TaskSchedule
+		expectedErr      error
+	}{
+		"should update task schedule and move its next run after now": {
+			inputID:   1,
+			inputData: dto.UpdateTaskScheduleDto{Summary: "daily check", Cron: "30 7 * * *"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository) {
+				found := schedule
+				taskScheduleRepository.EXPECT().GetTaskScheduleByID(gomock.Any(), 1).Return(&found, nil)
+				taskScheduleRepository.EXPECT().UpdateTaskSchedule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, s model.TaskSchedule) (*model.TaskSchedule, error) {
+						assert.Equal(t, 2, s.AssigneeID)
+						assert.Equal(t, "daily check", s.Summary)
+						assert.True(t, s.Enabled)
+						assert.Equal(t, 7, s.NextRunAt.Hour())
+						assert.Equal(t, 30, s.NextRunAt.Minute())
+						assert.True(t, s.NextRunAt.After(time.Now()))
+						return &model.TaskSchedule{ID: 1}, nil
+					})
+			},
+			expectedSchedule: &model.TaskSchedule{ID: 1},
+		},
+		"should throw not found when task schedule does not exist": {
+			inputID:   2,
+			inputData: dto.UpdateTaskScheduleDto{Summary: "check", Cron: "@daily"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository) {
+				taskScheduleRepository.EXPECT().GetTaskScheduleByID(gomock.Any(), 2).
+					Return(nil, &exception.NotFoundException{Message: "task schedule not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task schedule not found"},
+		},
+		"should throw error when task schedule repository update task schedule": {
+			inputID:   1,
+			inputData: dto.UpdateTaskScheduleDto{Summary: "check", Cron: "@daily"},
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository) {
+				found := schedule
+				taskScheduleRepository.EXPECT().GetTaskScheduleByID(gomock.Any(), 1).Return(&found, nil)
+				taskScheduleRepository.EXPECT().UpdateTaskSchedule(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskScheduleRepositoryMock := mock.NewMockTaskScheduleRepository(ctrl)
+			taskScheduleService := service.NewTaskScheduleService(taskScheduleRepositoryMock, nil, nil,
+				time.Minute, 5*time.Minute)
+
+			cs.mocking(taskScheduleRepositoryMock)
+
+			// when
+			res, err := taskScheduleService.UpdateTaskSchedule(ctx, cs.inputID, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedSchedule, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskScheduleServiceDeleteTaskSchedule(t *testing.T) {
+	var cases = map[string]struct {
+		inputID     int
+		mocking     func(taskScheduleRepository *mock.MockTaskScheduleRepository)
+		expectedErr error
+	}{
+		"should delete task schedule": {
+			inputID: 1,
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository) {
+				taskScheduleRepository.EXPECT().DeleteTaskSchedule(gomock.Any(), 1).Return(nil)
+			},
+		},
+		"should throw not found when task schedule does not exist": {
+			inputID: 2,
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository) {
+				taskScheduleRepository.EXPECT().DeleteTaskSchedule(gomock.Any(), 2).
+					Return(&exception.NotFoundException{Message: "task schedule not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task schedule not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskScheduleRepositoryMock := mock.NewMockTaskScheduleRepository(ctrl)
+			taskScheduleService := service.NewTaskScheduleService(taskScheduleRepositoryMock, nil, nil,
+				time.Minute, 5*time.Minute)
+
+			cs.mocking(taskScheduleRepositoryMock)
+
+			// when
+			err := taskScheduleService.DeleteTaskSchedule(ctx, cs.inputID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskScheduleServiceRunDueSchedules(t *testing.T) {
+	// friday
+	now := time.Date(1992, 8, 28, 9, 0, 30, 0, time.Local)
+	runAt := time.Date(1992, 8, 28, 9, 0, 0, 0, time.Local)
+	missedRunAt := time.Date(1992, 8, 10, 9, 0, 0, 0, time.Local)
+	manager := &model.User{ID: 1, Role: model.UserRoleManager, Status: model.UserStatusActive}
+	assigneeID := 2
+
+	var cases = map[string]struct {
+		mocking         func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository, taskService *mock.MockTaskService)
+		expectedCreated int
+		expectedErr     error
+	}{
+		"should create task and move schedule to its next run": {
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository, taskService *mock.MockTaskService) {
+				taskScheduleRepository.EXPECT().AcquireDueTaskSchedules(gomock.Any(), gomock.Any(), now, now.Add(5*time.Minute), 50).
+					Return([]model.TaskSchedule{{ID: 1, UserID: 1, AssigneeID: 2, Summary: "check {{date}} week {{week}}",
+						Priority: model.TaskPriorityHigh, Cron: "0 9 * * 1-5", NextRunAt: runAt}}, nil)
+				taskScheduleRepository.EXPECT().CreateTaskScheduleRun(gomock.Any(), model.TaskScheduleRun{ScheduleID: 1, RunAt: runAt}).
+					Return(nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().CreateTask(gomock.Any(), manager, dto.CreateTaskDto{
+					Summary: "check 1992-08-28 week 35", AssigneeID: &assigneeID, Priority: model.TaskPriorityHigh,
+				}).Return(&model.Task{ID: 10}, nil)
+				taskScheduleRepository.EXPECT().SetTaskScheduleRunTask(gomock.Any(), 1, runAt, 10).Return(nil)
+				taskScheduleRepository.EXPECT().ReleaseTaskSchedule(gomock.Any(), 1, gomock.Any(),
+					time.Date(1992, 8, 31, 9, 0, 0, 0, time.Local), &runAt).Return(nil)
+			},
+			expectedCreated: 1,
+		},
+		"should create one task for missed runs and skip to the next run after now": {
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository, taskService *mock.MockTaskService) {
+				taskScheduleRepository.EXPECT().AcquireDueTaskSchedules(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]model.TaskSchedule{{ID: 1, UserID: 1, AssigneeID: 2, Summary: "check",
+						Cron: "0 9 1,15 * *", NextRunAt: missedRunAt}}, nil)
+				taskScheduleRepository.EXPECT().CreateTaskScheduleRun(gomock.Any(), gomock.Any()).Return(nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.Task{ID: 10}, nil)
+				taskScheduleRepository.EXPECT().SetTaskScheduleRunTask(gomock.Any(), 1, missedRunAt, 10).Return(nil)
+				taskScheduleRepository.EXPECT().ReleaseTaskSchedule(gomock.Any(), 1, gomock.Any(),
+					time.Date(1992, 9, 1, 9, 0, 0, 0, time.Local), &missedRunAt).Return(nil)
+			},
+			expectedCreated: 1,
+		},
+		"should skip run already claimed": {
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository, taskService *mock.MockTaskService) {
+				taskScheduleRepository.EXPECT().AcquireDueTaskSchedules(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]model.TaskSchedule{{ID: 1, UserID: 1, Cron: "*/15 * * * *", NextRunAt: runAt}}, nil)
+				taskScheduleRepository.EXPECT().CreateTaskScheduleRun(gomock.Any(), gomock.Any()).
+					Return(&exception.ConflictException{Message: "task schedule run already claimed"})
+				taskScheduleRepository.EXPECT().ReleaseTaskSchedule(gomock.Any(), 1, gomock.Any(),
+					time.Date(1992, 8, 28, 9, 15, 0, 0, time.Local), &runAt).Return(nil)
+			},
+		},
+		"should skip run when task service create task": {
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository, taskService *mock.MockTaskService) {
+				taskScheduleRepository.EXPECT().AcquireDueTaskSchedules(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]model.TaskSchedule{{ID: 1, UserID: 1, Cron: "@daily", NextRunAt: runAt}}, nil)
+				taskScheduleRepository.EXPECT().CreateTaskScheduleRun(gomock.Any(), gomock.Any()).Return(nil)
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 1).Return(manager, nil)
+				taskService.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, &exception.ValidationException{Message: "invalid fields"})
+				taskScheduleRepository.EXPECT().ReleaseTaskSchedule(gomock.Any(), 1, gomock.Any(),
+					time.Date(1992, 8, 29, 0, 0, 0, 0, time.Local), &runAt).Return(nil)
+			},
+		},
+		"should throw error when task schedule repository acquire due task schedules": {
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository, taskService *mock.MockTaskService) {
+				taskScheduleRepository.EXPECT().AcquireDueTaskSchedules(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when task schedule repository create task schedule run": {
+			mocking: func(taskScheduleRepository *mock.MockTaskScheduleRepository, userRepository *mock.MockUserRepository, taskService *mock.MockTaskService) {
+				taskScheduleRepository.EXPECT().AcquireDueTaskSchedules(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]model.TaskSchedule{{ID: 1, UserID: 1, Cron: "@daily", NextRunAt: runAt}}, nil)
+				taskScheduleRepository.EXPECT().CreateTaskScheduleRun(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskScheduleRepositoryMock := mock.NewMockTaskScheduleRepository(ctrl)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskScheduleService := service.NewTaskScheduleService(taskScheduleRepositoryMock, userRepositoryMock,
+				taskServiceMock, time.Minute, 5*time.Minute)
+
+			cs.mocking(taskScheduleRepositoryMock, userRepositoryMock, taskServiceMock)
+
+			// when
+			created, err := taskScheduleService.RunDueSchedules(ctx, now)
+
+			// then
+			assert.Equal(t, cs.expectedCreated, created)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
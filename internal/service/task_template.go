@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/task_template_service_mock.go -package=mock . TaskTemplateService
+type TaskTemplateService interface {
+	CreateTaskTemplate(ctx context.Context, user *model.User, data dto.CreateTaskTemplateDto) (*model.TaskTemplate, error)
+	ListTaskTemplates(ctx context.Context) ([]model.TaskTemplate, error)
+	GetTaskTemplate(ctx context.Context, id int) (*model.TaskTemplate, error)
+	UpdateTaskTemplate(ctx context.Context, id int, data dto.UpdateTaskTemplateDto) (*model.TaskTemplate, error)
+	DeleteTaskTemplate(ctx context.Context, id int) error
+	RenderTaskTemplate(ctx context.Context, id int, data dto.CreateTaskFromTemplateDto) (dto.CreateTaskDto, error)
+}
+
+type taskTemplateService struct {
+	taskTemplateRepository repository.TaskTemplateRepository
+}
+
+func NewTaskTemplateService(taskTemplateRepository repository.TaskTemplateRepository) TaskTemplateService {
+	return &taskTemplateService{
+		taskTemplateRepository: taskTemplateRepository,
+	}
+}
+
+func (impl *taskTemplateService) CreateTaskTemplate(ctx context.Context, user *model.User, data dto.CreateTaskTemplateDto) (*model.TaskTemplate, error) {
+	template, err := impl.taskTemplateRepository.CreateTaskTemplate(ctx, model.TaskTemplate{
+		UserID:   user.ID,
+		Name:     strings.TrimSpace(data.Name),
+		Summary:  data.Summary,
+		Priority: taskPriority(data.Priority),
+	})
+	if err != nil {
+		if _, ok := err.(*exception.ConflictException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tasktemplate.createtasktemplate",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (impl *taskTemplateService) ListTaskTemplates(ctx context.Context) ([]model.TaskTemplate, error) {
+	templates, err := impl.taskTemplateRepository.ListTaskTemplates(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.tasktemplate.listtasktemplates",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (impl *taskTemplateService) GetTaskTemplate(ctx context.Context, id int) (*model.TaskTemplate, error) {
+	template, err := impl.taskTemplateRepository.GetTaskTemplateByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tasktemplate.gettasktemplate",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// UpdateTaskTemplate only changes the fields sent.
+func (impl *taskTemplateService) UpdateTaskTemplate(ctx context.Context, id int, data dto.UpdateTaskTemplateDto) (*model.TaskTemplate, error) {
+	template, err := impl.GetTaskTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Name != nil {
+		template.Name = strings.TrimSpace(*data.Name)
+	}
+	if data.Summary != nil {
+		template.Summary = *data.Summary
+	}
+	if data.Priority != nil {
+		template.Priority = taskPriority(*data.Priority)
+	}
+
+	res, err := impl.taskTemplateRepository.UpdateTaskTemplate(ctx, *template)
+	if err != nil {
+		switch err.(type) {
+		case *exception.NotFoundException, *exception.ConflictException:
+		default:
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tasktemplate.updatetasktemplate",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (impl *taskTemplateService) DeleteTaskTemplate(ctx context.Context, id int) error {
+	err := impl.taskTemplateRepository.DeleteTaskTemplate(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tasktemplate.deletetasktemplate",
+			}).Error(err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RenderTaskTemplate builds the task to create from the template. Every
+// placeholder must have a value and every value a placeholder. The template
+// priority is used unless another one is sent. The rendered summary is not
+// validated here, the caller validates the task like any other.
+func (impl *taskTemplateService) RenderTaskTemplate(ctx context.Context, id int, data dto.CreateTaskFromTemplateDto) (dto.CreateTaskDto, error) {
+	template, err := impl.taskTemplateRepository.GetTaskTemplateByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return dto.CreateTaskDto{}, &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "template_id", Tag: "exists", Message: "template_id must exist"}},
+			}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.tasktemplate.rendertasktemplate",
+		}).Error(err.Error())
+		return dto.CreateTaskDto{}, err
+	}
+
+	placeholders := template.Placeholders()
+	missing := []string{}
+	known := map[string]bool{}
+	for _, name := range placeholders {
+		known[name] = true
+		if _, ok := data.Variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	unknown := []string{}
+	for name := range data.Variables {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	fields := []exception.FieldError{}
+	if len(missing) > 0 {
+		fields = append(fields, exception.FieldError{
+			Field: "variables", Tag: "required",
+			Message: "variables must have " + strings.Join(missing, ", "),
+		})
+	}
+	if len(unknown) > 0 {
+		fields = append(fields, exception.FieldError{
+			Field: "variables", Tag: "placeholder",
+			Message: "variables must only have template placeholders, unknown: " + strings.Join(unknown, ", "),
+		})
+	}
+	if len(fields) > 0 {
+		return dto.CreateTaskDto{}, &exception.ValidationException{Message: "invalid fields", Fields: fields}
+	}
+
+	priority := data.Priority
+	if priority == "" {
+		priority = template.Priority
+	}
+
+	return dto.CreateTaskDto{
+		Summary:    template.Render(data.Variables),
+		AssigneeID: data.AssigneeID,
+		ParentID:   data.ParentID,
+		DueAt:      data.DueAt,
+		Priority:   priority,
+		Tags:       data.Tags,
+	}, nil
+}
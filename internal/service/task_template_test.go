@@ -0,0 +1,279 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskTemplateServiceCreateTaskTemplate(t *testing.T) {
+	manager := &model.User{ID: 1, Role: model.UserRoleManager}
+	template := &model.TaskTemplate{ID: 1, UserID: 1, Name: "equipment check", Summary: "check the {{equipment}}",
+		Priority: model.TaskPriorityNormal}
+
+	var cases = map[string]struct {
+		inputData        dto.CreateTaskTemplateDto
+		mocking          func(taskTemplateRepository *mock.MockTaskTemplateRepository)
+		expectedTemplate *model.TaskTemplate
+		expectedErr      error
+	}{
+		"should create task template": {
+			inputData: dto.CreateTaskTemplateDto{Name: " equipment check ", Summary: "check the {{equipment}}"},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().CreateTaskTemplate(gomock.Any(), model.TaskTemplate{
+					UserID: 1, Name: "equipment check", Summary: "check the {{equipment}}", Priority: model.TaskPriorityNormal,
+				}).Return(template, nil)
+			},
+			expectedTemplate: template,
+		},
+		"should throw conflict when task template already exists": {
+			inputData: dto.CreateTaskTemplateDto{Name: "equipment check", Summary: "check"},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().CreateTaskTemplate(gomock.Any(), gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "task template already exists"})
+			},
+			expectedErr: &exception.ConflictException{Message: "task template already exists"},
+		},
+		"should throw error when task template repository create task template": {
+			inputData: dto.CreateTaskTemplateDto{Name: "equipment check", Summary: "check"},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().CreateTaskTemplate(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTemplateRepositoryMock := mock.NewMockTaskTemplateRepository(ctrl)
+			taskTemplateService := service.NewTaskTemplateService(taskTemplateRepositoryMock)
+
+			cs.mocking(taskTemplateRepositoryMock)
+
+			// when
+			res, err := taskTemplateService.CreateTaskTemplate(ctx, manager, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedTemplate, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskTemplateServiceUpdateTaskTemplate(t *testing.T) {
+	now := time.Now()
+	template := model.TaskTemplate{ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, Name: "equipment check",
+		Summary: "check the {{equipment}}", Priority: model.TaskPriorityNormal}
+	summary := "check the {{equipment}} in room {{room}}"
+	priority := model.TaskPriorityHigh
+
+	var cases = map[string]struct {
+		inputID          int
+		inputData        dto.UpdateTaskTemplateDto
+		mocking          func(taskTemplateRepository *mock.MockTaskTemplateRepository)
+		expectedTemplate *model.TaskTemplate
+		expectedErr      error
+	}{
+		"should only update the fields sent": {
+			inputID:   1,
+			inputData: dto.UpdateTaskTemplateDto{Summary: &summary, Priority: &priority},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				found := template
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 1).Return(&found, nil)
+				taskTemplateRepository.EXPECT().UpdateTaskTemplate(gomock.Any(), model.TaskTemplate{
+					ID: 1, CreatedAt: now, UpdatedAt: now, UserID: 1, Name: "equipment check", Summary: summary,
+					Priority: model.TaskPriorityHigh,
+				}).Return(&model.TaskTemplate{ID: 1}, nil)
+			},
+			expectedTemplate: &model.TaskTemplate{ID: 1},
+		},
+		"should throw not found when task template does not exist": {
+			inputID:   2,
+			inputData: dto.UpdateTaskTemplateDto{Summary: &summary},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 2).
+					Return(nil, &exception.NotFoundException{Message: "task template not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task template not found"},
+		},
+		"should throw error when task template repository update task template": {
+			inputID:   1,
+			inputData: dto.UpdateTaskTemplateDto{Summary: &summary},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				found := template
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 1).Return(&found, nil)
+				taskTemplateRepository.EXPECT().UpdateTaskTemplate(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTemplateRepositoryMock := mock.NewMockTaskTemplateRepository(ctrl)
+			taskTemplateService := service.NewTaskTemplateService(taskTemplateRepositoryMock)
+
+			cs.mocking(taskTemplateRepositoryMock)
+
+			// when
+			res, err := taskTemplateService.UpdateTaskTemplate(ctx, cs.inputID, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedTemplate, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskTemplateServiceDeleteTaskTemplate(t *testing.T) {
+	var cases = map[string]struct {
+		inputID     int
+		mocking     func(taskTemplateRepository *mock.MockTaskTemplateRepository)
+		expectedErr error
+	}{
+		"should delete task template": {
+			inputID: 1,
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().DeleteTaskTemplate(gomock.Any(), 1).Return(nil)
+			},
+		},
+		"should throw not found when task template does not exist": {
+			inputID: 2,
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().DeleteTaskTemplate(gomock.Any(), 2).
+					Return(&exception.NotFoundException{Message: "task template not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task template not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTemplateRepositoryMock := mock.NewMockTaskTemplateRepository(ctrl)
+			taskTemplateService := service.NewTaskTemplateService(taskTemplateRepositoryMock)
+
+			cs.mocking(taskTemplateRepositoryMock)
+
+			// when
+			err := taskTemplateService.DeleteTaskTemplate(ctx, cs.inputID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskTemplateServiceRenderTaskTemplate(t *testing.T) {
+	template := &model.TaskTemplate{ID: 1, Name: "equipment check",
+		Summary: "check the {{equipment}} in room {{room}}, then the {{equipment}} log", Priority: model.TaskPriorityHigh}
+	assigneeID := 2
+
+	var cases = map[string]struct {
+		inputData    dto.CreateTaskFromTemplateDto
+		mocking      func(taskTemplateRepository *mock.MockTaskTemplateRepository)
+		expectedData dto.CreateTaskDto
+		expectedErr  error
+	}{
+		"should render task template": {
+			inputData: dto.CreateTaskFromTemplateDto{
+				Variables:  map[string]string{"equipment": "defibrillator", "room": "{{room}}"},
+				AssigneeID: &assigneeID,
+				Tags:       []string{"maintenance"},
+			},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 1).Return(template, nil)
+			},
+			expectedData: dto.CreateTaskDto{
+				Summary:    "check the defibrillator in room {{room}}, then the defibrillator log",
+				AssigneeID: &assigneeID,
+				Priority:   model.TaskPriorityHigh,
+				Tags:       []string{"maintenance"},
+			},
+		},
+		"should keep the priority sent": {
+			inputData: dto.CreateTaskFromTemplateDto{
+				Variables: map[string]string{"equipment": "defibrillator", "room": "3"},
+				Priority:  model.TaskPriorityLow,
+			},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 1).Return(template, nil)
+			},
+			expectedData: dto.CreateTaskDto{
+				Summary:  "check the defibrillator in room 3, then the defibrillator log",
+				Priority: model.TaskPriorityLow,
+			},
+		},
+		"should throw validation exception when variables are missing or unknown": {
+			inputData: dto.CreateTaskFromTemplateDto{
+				Variables: map[string]string{"equipment": "defibrillator", "floor": "2", "bed": "1"},
+			},
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 1).Return(template, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "variables", Tag: "required", Message: "variables must have room"},
+					{Field: "variables", Tag: "placeholder", Message: "variables must only have template placeholders, unknown: bed, floor"},
+				},
+			},
+		},
+		"should throw validation exception when task template does not exist": {
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "task template not found"})
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "template_id", Tag: "exists", Message: "template_id must exist"}},
+			},
+		},
+		"should throw error when task template repository get task template by id": {
+			mocking: func(taskTemplateRepository *mock.MockTaskTemplateRepository) {
+				taskTemplateRepository.EXPECT().GetTaskTemplateByID(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTemplateRepositoryMock := mock.NewMockTaskTemplateRepository(ctrl)
+			taskTemplateService := service.NewTaskTemplateService(taskTemplateRepositoryMock)
+
+			cs.mocking(taskTemplateRepositoryMock)
+
+			// when
+			data, err := taskTemplateService.RenderTaskTemplate(ctx, 1, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedData, data)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
 	"github.com/viniosilva/swordhealth-api/internal/exception"
 	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
 	"github.com/viniosilva/swordhealth-api/internal/service"
 	"github.com/viniosilva/swordhealth-api/mock"
 )
@@ -17,43 +19,138 @@ import (
 func TestTaskServiceCreateTask(t *testing.T) {
 	now := time.Now()
 	task := &model.Task{
-		ID:        1,
-		CreatedAt: now,
-		UpdatedAt: now,
-		UserID:    1,
-		Summary:   "summary",
-		Status:    model.TaskStatusOpened,
+		ID:         1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UserID:     1,
+		AssigneeID: 1,
+		Summary:    "summary",
+		Status:     model.TaskStatusOpened,
 	}
+	technician := &model.User{ID: 1, Role: model.UserRoleTechnician, Status: model.UserStatusActive}
+	manager := &model.User{ID: 3, Role: model.UserRoleManager, Status: model.UserStatusActive}
+	assigneeID := 2
 
 	var cases = map[string]struct {
-		inputUserID  int
-		inputSummary string
-		mocking      func(taskRepository *mock.MockTaskRepository)
+		inputUser    *model.User
+		inputData    dto.CreateTaskDto
+		mocking      func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository)
 		expectedTask *model.Task
 		expectedErr  error
 	}{
 		"should create task": {
-			inputUserID:  task.UserID,
-			inputSummary: task.Summary,
-			mocking: func(taskRepository *mock.MockTaskRepository) {
-				taskRepository.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(task, nil)
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				taskRepository.EXPECT().CreateTask(gomock.Any(), model.Task{
+					UserID: 1, AssigneeID: 1, Summary: "summary", Priority: model.TaskPriorityNormal,
+				}).Return(task, nil)
+			},
+			expectedTask: task,
+		},
+		"should create task with tags": {
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, Tags: []string{"Maintenance", "equipment", "maintenance"}},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), []string{"maintenance", "equipment"}).
+					Return([]model.Tag{{ID: 2, Name: "equipment"}, {ID: 1, Name: "maintenance"}}, nil)
+				taskRepository.EXPECT().CreateTask(gomock.Any(), model.Task{
+					UserID: 1, AssigneeID: 1, Summary: "summary", Priority: model.TaskPriorityNormal,
+					Tags: model.TaskTags{"equipment", "maintenance"},
+				}).Return(task, nil)
+			},
+			expectedTask: task,
+		},
+		"should throw validation exception when tag does not exist": {
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, Tags: []string{"maintenance", "unknown"}},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), []string{"maintenance", "unknown"}).
+					Return([]model.Tag{{ID: 1, Name: "maintenance"}}, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "tags", Tag: "exists", Message: "tags must exist, unknown: unknown"}},
+			},
+		},
+		"should throw error when tag repository list tags by names": {
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, Tags: []string{"maintenance"}},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), []string{"maintenance"}).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should create task assigned to technician when user is manager": {
+			inputUser: manager,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, AssigneeID: &assigneeID},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Role: model.UserRoleTechnician, Status: model.UserStatusActive}, nil)
+				taskRepository.EXPECT().CreateTask(gomock.Any(), model.Task{
+					UserID: 3, AssigneeID: 2, Summary: "summary", Priority: model.TaskPriorityNormal,
+				}).Return(task, nil)
 			},
 			expectedTask: task,
 		},
+		"should create task with due date and priority": {
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, DueAt: "1992-08-28 18:00:00", Priority: model.TaskPriorityUrgent},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				dueAt := time.Date(1992, 8, 28, 18, 0, 0, 0, time.Local)
+				taskRepository.EXPECT().CreateTask(gomock.Any(), model.Task{
+					UserID: 1, AssigneeID: 1, Summary: "summary", DueAt: &dueAt, Priority: model.TaskPriorityUrgent,
+				}).Return(task, nil)
+			},
+			expectedTask: task,
+		},
+		"should throw validation error when due date is invalid": {
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, DueAt: "28/08/1992"},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "due_at", Tag: "datetime", Message: "due_at must match the format 2006-01-02 15:04:05"},
+				},
+			},
+		},
+		"should throw unauthorized when technician assigns another user": {
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, AssigneeID: &assigneeID},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+			},
+			expectedErr: &exception.UnauthorizedException{Message: "only managers can assign tasks"},
+		},
+		"should throw validation error when assignee is not an active technician": {
+			inputUser: manager,
+			inputData: dto.CreateTaskDto{Summary: task.Summary, AssigneeID: &assigneeID},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Role: model.UserRoleTechnician, Status: model.UserStatusPending}, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "assignee_id", Tag: "technician", Message: "assignee_id must be an active technician"},
+				},
+			},
+		},
 		"should throw foreign key constraint exception when user not found": {
-			inputUserID:  task.UserID,
-			inputSummary: task.Summary,
-			mocking: func(taskRepository *mock.MockTaskRepository) {
-				taskRepository.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				taskRepository.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
 					Return(nil, &exception.ForeignKeyConstraintException{Message: "user not found"})
 			},
 			expectedErr: &exception.ForeignKeyConstraintException{Message: "user not found"},
 		},
 		"should throw error when task repository create task": {
-			inputUserID:  task.UserID,
-			inputSummary: task.Summary,
-			mocking: func(taskRepository *mock.MockTaskRepository) {
-				taskRepository.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).
+			inputUser: technician,
+			inputData: dto.CreateTaskDto{Summary: task.Summary},
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository, tagRepository *mock.MockTagRepository) {
+				taskRepository.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
 					Return(nil, fmt.Errorf("error"))
 			},
 			expectedErr: fmt.Errorf("error"),
@@ -67,12 +164,14 @@ func TestTaskServiceCreateTask(t *testing.T) {
 			defer ctrl.Finish()
 
 			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
-			taskService := service.NewTaskService(taskRepositoryMock)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			tagRepositoryMock := mock.NewMockTagRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, userRepositoryMock, tagRepositoryMock, service.TaskWorkflow{})
 
-			cs.mocking(taskRepositoryMock)
+			cs.mocking(taskRepositoryMock, userRepositoryMock, tagRepositoryMock)
 
 			// when
-			task, err := taskService.CreateTask(ctx, cs.inputUserID, cs.inputSummary)
+			task, err := taskService.CreateTask(ctx, cs.inputUser, cs.inputData)
 
 			// then
 			assert.Equal(t, cs.expectedErr, err)
@@ -88,24 +187,26 @@ func BenchmarkTaskServiceCreateTask(b *testing.B) {
 	defer ctrl.Finish()
 
 	taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
-	taskService := service.NewTaskService(taskRepositoryMock)
+	taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
 
 	now := time.Now()
 	task := &model.Task{
-		ID:        1,
-		CreatedAt: now,
-		UpdatedAt: now,
-		UserID:    1,
-		Summary:   "summary",
-		Status:    model.TaskStatusOpened,
+		ID:         1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UserID:     1,
+		AssigneeID: 1,
+		Summary:    "summary",
+		Status:     model.TaskStatusOpened,
 	}
+	user := &model.User{ID: 1, Role: model.UserRoleTechnician}
 
-	taskRepositoryMock.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).
+	taskRepositoryMock.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
 		AnyTimes().Return(task, nil)
 
 	// when
 	for i := 0; i < b.N; i++ {
-		taskService.CreateTask(ctx, task.UserID, task.Summary)
+		taskService.CreateTask(ctx, user, dto.CreateTaskDto{Summary: task.Summary})
 	}
 }
 
@@ -124,6 +225,7 @@ func TestTaskServiceListTasks(t *testing.T) {
 		inputLimit    int
 		inputOffset   int
 		inputUser     *model.User
+		inputFilter   dto.ListTasksFilterDto
 		mocking       func(taskRepository *mock.MockTaskRepository)
 		expectedTasks []model.Task
 		expectedTotal int
@@ -137,7 +239,7 @@ func TestTaskServiceListTasks(t *testing.T) {
 				Role: model.UserRoleManager,
 			},
 			mocking: func(taskRepository *mock.MockTaskRepository) {
-				taskRepository.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.Task{task}, 1, nil)
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 10, 0, "", repository.SetWhere("WHERE deleted_at IS NULL", []interface{}{})).Return([]model.Task{task}, 1, nil)
 			},
 			expectedTasks: []model.Task{task},
 			expectedTotal: 1,
@@ -150,7 +252,99 @@ func TestTaskServiceListTasks(t *testing.T) {
 				Role: model.UserRoleTechnician,
 			},
 			mocking: func(taskRepository *mock.MockTaskRepository) {
-				taskRepository.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.Task{task}, 1, nil)
+				taskRepository.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), "", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, limit, offset int, orderBy string, opts ...repository.WhereOpt) ([]model.Task, int, error) {
+						assert.Equal(t, "WHERE deleted_at IS NULL AND (user_id = ? OR assignee_id = ?)", opts[0].Query())
+						assert.Equal(t, []interface{}{1, 1}, opts[0].Values())
+						return []model.Task{task}, 1, nil
+					})
+			},
+			expectedTasks: []model.Task{task},
+			expectedTotal: 1,
+		},
+		"should list tasks filtered and sorted": {
+			inputLimit:  10,
+			inputOffset: 0,
+			inputUser: &model.User{
+				ID:   1,
+				Role: model.UserRoleTechnician,
+			},
+			inputFilter: dto.ListTasksFilterDto{
+				Status:     model.TaskStatusOpened,
+				Priority:   model.TaskPriorityHigh,
+				AssigneeID: 1,
+				DueAfter:   "1992-08-21 00:00:00",
+				DueBefore:  "1992-08-28 18:00:00",
+				Sort:       "-priority",
+			},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 10, 0, "FIELD(priority, 'low', 'normal', 'high', 'urgent') DESC, id", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, limit, offset int, orderBy string, opts ...repository.WhereOpt) ([]model.Task, int, error) {
+						assert.Equal(t, "WHERE deleted_at IS NULL AND (user_id = ? OR assignee_id = ?) AND status = ? AND priority = ? AND assignee_id = ? AND due_at >= ? AND due_at <= ?", opts[0].Query())
+						assert.Equal(t, []interface{}{
+							1, 1, model.TaskStatusOpened, model.TaskPriorityHigh, 1,
+							time.Date(1992, 8, 21, 0, 0, 0, 0, time.Local),
+							time.Date(1992, 8, 28, 18, 0, 0, 0, time.Local),
+						}, opts[0].Values())
+						return []model.Task{task}, 1, nil
+					})
+			},
+			expectedTasks: []model.Task{task},
+			expectedTotal: 1,
+		},
+		"should list overdue tasks": {
+			inputLimit:  10,
+			inputOffset: 0,
+			inputUser: &model.User{
+				ID:   1,
+				Role: model.UserRoleManager,
+			},
+			inputFilter: dto.ListTasksFilterDto{Overdue: true},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 10, 0, "", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, limit, offset int, orderBy string, opts ...repository.WhereOpt) ([]model.Task, int, error) {
+						assert.Equal(t, "WHERE deleted_at IS NULL AND due_at < ? AND status <> ?", opts[0].Query())
+						assert.Equal(t, model.TaskStatusClosed, opts[0].Values()[1])
+						return []model.Task{task}, 1, nil
+					})
+			},
+			expectedTasks: []model.Task{task},
+			expectedTotal: 1,
+		},
+		"should list tasks with any of the tags": {
+			inputLimit:  10,
+			inputOffset: 0,
+			inputUser: &model.User{
+				ID:   1,
+				Role: model.UserRoleManager,
+			},
+			inputFilter: dto.ListTasksFilterDto{Tags: "Maintenance, equipment,,maintenance"},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 10, 0, "", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, limit, offset int, orderBy string, opts ...repository.WhereOpt) ([]model.Task, int, error) {
+						assert.Equal(t, "WHERE deleted_at IS NULL AND id IN (SELECT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN (?, ?))", opts[0].Query())
+						assert.Equal(t, []interface{}{"maintenance", "equipment"}, opts[0].Values())
+						return []model.Task{task}, 1, nil
+					})
+			},
+			expectedTasks: []model.Task{task},
+			expectedTotal: 1,
+		},
+		"should list tasks with all of the tags": {
+			inputLimit:  10,
+			inputOffset: 0,
+			inputUser: &model.User{
+				ID:   1,
+				Role: model.UserRoleManager,
+			},
+			inputFilter: dto.ListTasksFilterDto{Tags: "maintenance,equipment", TagMatch: "all"},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 10, 0, "", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, limit, offset int, orderBy string, opts ...repository.WhereOpt) ([]model.Task, int, error) {
+						assert.Equal(t, "WHERE deleted_at IS NULL AND id IN (SELECT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN (?, ?) GROUP BY task_tags.task_id HAVING COUNT(*) = ?)", opts[0].Query())
+						assert.Equal(t, []interface{}{"maintenance", "equipment", 2}, opts[0].Values())
+						return []model.Task{task}, 1, nil
+					})
 			},
 			expectedTasks: []model.Task{task},
 			expectedTotal: 1,
@@ -163,7 +357,7 @@ func TestTaskServiceListTasks(t *testing.T) {
 				Role: model.UserRoleManager,
 			},
 			mocking: func(taskRepository *mock.MockTaskRepository) {
-				taskRepository.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
+				taskRepository.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
 			},
 			expectedErr: fmt.Errorf("error"),
 		},
@@ -176,12 +370,12 @@ func TestTaskServiceListTasks(t *testing.T) {
 			defer ctrl.Finish()
 
 			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
-			taskService := service.NewTaskService(taskRepositoryMock)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
 
 			cs.mocking(taskRepositoryMock)
 
 			// when
-			tasks, total, err := taskService.ListTasks(ctx, cs.inputLimit, cs.inputOffset, cs.inputUser)
+			tasks, total, err := taskService.ListTasks(ctx, cs.inputLimit, cs.inputOffset, cs.inputUser, cs.inputFilter)
 
 			// then
 			assert.Equal(t, cs.expectedErr, err)
@@ -198,7 +392,7 @@ func BenchmarkTaskServiceListTasks(b *testing.B) {
 	defer ctrl.Finish()
 
 	taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
-	taskService := service.NewTaskService(taskRepositoryMock)
+	taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
 
 	now := time.Now()
 	tasks := []model.Task{{
@@ -210,7 +404,7 @@ func BenchmarkTaskServiceListTasks(b *testing.B) {
 		Status:    model.TaskStatusOpened,
 	}}
 
-	taskRepositoryMock.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any()).
+	taskRepositoryMock.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().Return(tasks, 1, nil)
 
 	// when
@@ -218,6 +412,955 @@ func BenchmarkTaskServiceListTasks(b *testing.B) {
 		taskService.ListTasks(ctx, 10, 0, &model.User{
 			ID:   1,
 			Role: model.UserRoleManager,
+		}, dto.ListTasksFilterDto{})
+	}
+}
+
+func TestTaskServiceExportTasks(t *testing.T) {
+	task := model.TaskExport{
+		Task:          model.Task{ID: 1, UserID: 1, Summary: "summary", Status: model.TaskStatusOpened},
+		OwnerUsername: "username",
+		OwnerEmail:    "email@email.com",
+	}
+
+	var cases = map[string]struct {
+		inputUser     *model.User
+		inputFilter   dto.ListTasksFilterDto
+		mocking       func(taskRepository *mock.MockTaskRepository)
+		expectedTasks []model.TaskExport
+		expectedErr   error
+	}{
+		"should export tasks": {
+			inputUser: &model.User{ID: 1, Role: model.UserRoleManager},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().ExportTasks(gomock.Any(), "created_at, id", gomock.Any(), repository.SetWhere("WHERE deleted_at IS NULL", []interface{}{})).
+					DoAndReturn(func(ctx context.Context, orderBy string, fn func(task model.TaskExport) error, opts ...repository.WhereOpt) error {
+						return fn(task)
+					})
+			},
+			expectedTasks: []model.TaskExport{task},
+		},
+		"should export tasks with the list filters when user is not manager": {
+			inputUser:   &model.User{ID: 1, Role: model.UserRoleTechnician},
+			inputFilter: dto.ListTasksFilterDto{Status: model.TaskStatusOpened, Sort: "-due_at"},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().ExportTasks(gomock.Any(), "due_at IS NULL, due_at DESC, id", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, orderBy string, fn func(task model.TaskExport) error, opts ...repository.WhereOpt) error {
+						assert.Equal(t, "WHERE deleted_at IS NULL AND (user_id = ? OR assignee_id = ?) AND status = ?", opts[0].Query())
+						assert.Equal(t, []interface{}{1, 1, model.TaskStatusOpened}, opts[0].Values())
+						return fn(task)
+					})
+			},
+			expectedTasks: []model.TaskExport{task},
+		},
+		"should throw validation exception when due_after is invalid": {
+			inputUser:   &model.User{ID: 1, Role: model.UserRoleManager},
+			inputFilter: dto.ListTasksFilterDto{DueAfter: "invalid"},
+			mocking:     func(taskRepository *mock.MockTaskRepository) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "due_after", Tag: "datetime", Message: "due_after must match the format 2006-01-02 15:04:05"}},
+			},
+		},
+		"should throw error when task repository export tasks": {
+			inputUser: &model.User{ID: 1, Role: model.UserRoleManager},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			var tasks []model.TaskExport
+			err := taskService.ExportTasks(ctx, cs.inputUser, cs.inputFilter, func(task model.TaskExport) error {
+				tasks = append(tasks, task)
+				return nil
+			})
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTasks, tasks)
+		})
+	}
+}
+
+func TestTaskServiceAssignTask(t *testing.T) {
+	task := &model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", Status: model.TaskStatusOpened}
+	technician := &model.User{ID: 2, Role: model.UserRoleTechnician, Status: model.UserStatusActive}
+	invalidAssignee := &exception.ValidationException{
+		Message: "invalid fields",
+		Fields: []exception.FieldError{
+			{Field: "assignee_id", Tag: "technician", Message: "assignee_id must be an active technician"},
+		},
+	}
+
+	var cases = map[string]struct {
+		mocking      func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository)
+		expectedTask *model.Task
+		expectedErr  error
+	}{
+		"should assign task": {
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(technician, nil)
+				taskRepository.EXPECT().UpdateTaskAssignee(gomock.Any(), 1, 2).Return(task, nil)
+			},
+			expectedTask: task,
+		},
+		"should throw validation error when assignee is a manager": {
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(&model.User{ID: 2, Role: model.UserRoleManager, Status: model.UserStatusActive}, nil)
+			},
+			expectedErr: invalidAssignee,
+		},
+		"should throw validation error when assignee is not found": {
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).
+					Return(nil, &exception.NotFoundException{Message: "user not found"})
+			},
+			expectedErr: invalidAssignee,
+		},
+		"should throw error when user repository get user by id": {
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw not found": {
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(technician, nil)
+				taskRepository.EXPECT().UpdateTaskAssignee(gomock.Any(), 1, 2).
+					Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task repository update task assignee": {
+			mocking: func(taskRepository *mock.MockTaskRepository, userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().GetUserByID(gomock.Any(), 2).Return(technician, nil)
+				taskRepository.EXPECT().UpdateTaskAssignee(gomock.Any(), 1, 2).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, userRepositoryMock, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock, userRepositoryMock)
+
+			// when
+			task, err := taskService.AssignTask(ctx, 1, 2)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTask, task)
+		})
+	}
+}
+
+func TestTaskServiceTransitionTask(t *testing.T) {
+	now := time.Now()
+	workflow := service.TaskWorkflow{Transitions: []service.TaskTransitionRule{
+		{From: model.TaskStatusOpened, To: model.TaskStatusInProgress, Roles: []model.UserRole{model.UserRoleTechnician, model.UserRoleManager}},
+		{From: model.TaskStatusOpened, To: model.TaskStatusClosed, Roles: []model.UserRole{model.UserRoleManager}},
+		{From: model.TaskStatusClosed, To: model.TaskStatusOpened, Roles: []model.UserRole{model.UserRoleManager}},
+	}}
+	technician := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	manager := &model.User{ID: 9, Role: model.UserRoleManager}
+
+	var cases = map[string]struct {
+		inputUser          *model.User
+		inputTo            model.TaskStatus
+		inputForce         bool
+		mocking            func(taskRepository *mock.MockTaskRepository)
+		expectedTask       *model.Task
+		expectedTransition *model.TaskTransition
+		expectedErr        error
+	}{
+		"should transition task": {
+			inputUser: technician,
+			inputTo:   model.TaskStatusInProgress,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusOpened}, nil)
+				taskRepository.EXPECT().TransitionTask(gomock.Any(), model.TaskTransition{
+					TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress,
+				}).Return(&model.TaskTransition{
+					ID: 1, CreatedAt: now, TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress,
+				}, nil)
+			},
+			expectedTask: &model.Task{ID: 1, UpdatedAt: now, UserID: 3, AssigneeID: 2, Status: model.TaskStatusInProgress},
+			expectedTransition: &model.TaskTransition{
+				ID: 1, CreatedAt: now, TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress,
+			},
+		},
+		"should reopen task when user is manager": {
+			inputUser: manager,
+			inputTo:   model.TaskStatusOpened,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusClosed}, nil)
+				taskRepository.EXPECT().TransitionTask(gomock.Any(), model.TaskTransition{
+					TaskID: 1, UserID: 9, FromStatus: model.TaskStatusClosed, ToStatus: model.TaskStatusOpened,
+				}).Return(&model.TaskTransition{
+					ID: 2, CreatedAt: now, TaskID: 1, UserID: 9, FromStatus: model.TaskStatusClosed, ToStatus: model.TaskStatusOpened,
+				}, nil)
+			},
+			expectedTask: &model.Task{ID: 1, UpdatedAt: now, UserID: 3, AssigneeID: 2, Status: model.TaskStatusOpened},
+			expectedTransition: &model.TaskTransition{
+				ID: 2, CreatedAt: now, TaskID: 1, UserID: 9, FromStatus: model.TaskStatusClosed, ToStatus: model.TaskStatusOpened,
+			},
+		},
+		"should close task with open subtasks when forced": {
+			inputUser:  manager,
+			inputTo:    model.TaskStatusClosed,
+			inputForce: true,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusOpened, SubtaskCount: 2, OpenSubtaskCount: 1}, nil)
+				taskRepository.EXPECT().TransitionTask(gomock.Any(), model.TaskTransition{
+					TaskID: 1, UserID: 9, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusClosed,
+				}).Return(&model.TaskTransition{
+					ID: 3, CreatedAt: now, TaskID: 1, UserID: 9, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusClosed,
+				}, nil)
+			},
+			expectedTask: &model.Task{
+				ID: 1, UpdatedAt: now, UserID: 3, AssigneeID: 2, Status: model.TaskStatusClosed, SubtaskCount: 2, OpenSubtaskCount: 1,
+			},
+			expectedTransition: &model.TaskTransition{
+				ID: 3, CreatedAt: now, TaskID: 1, UserID: 9, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusClosed,
+			},
+		},
+		"should throw conflict when closing task with open subtasks": {
+			inputUser: manager,
+			inputTo:   model.TaskStatusClosed,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusOpened, SubtaskCount: 2, OpenSubtaskCount: 1}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "task has 1 open subtasks, close them first or force"},
+		},
+		"should throw validation error when status is unknown": {
+			inputUser: technician,
+			inputTo:   "done",
+			mocking:   func(taskRepository *mock.MockTaskRepository) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "to", Tag: "status", Message: "to must be a workflow status"}},
+			},
+		},
+		"should throw conflict when transition is not allowed": {
+			inputUser: technician,
+			inputTo:   model.TaskStatusInProgress,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusClosed}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "can not move task from closed to in_progress, allowed: opened"},
+		},
+		"should throw unauthorized when role is not allowed": {
+			inputUser: technician,
+			inputTo:   model.TaskStatusOpened,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusClosed}, nil)
+			},
+			expectedErr: &exception.UnauthorizedException{Message: "technician can not move task from closed to opened"},
+		},
+		"should throw not found when technician is not related to task": {
+			inputUser: technician,
+			inputTo:   model.TaskStatusInProgress,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 4, Status: model.TaskStatusOpened}, nil)
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw not found": {
+			inputUser: technician,
+			inputTo:   model.TaskStatusInProgress,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw conflict when task status has changed": {
+			inputUser: technician,
+			inputTo:   model.TaskStatusInProgress,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusOpened}, nil)
+				taskRepository.EXPECT().TransitionTask(gomock.Any(), gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "task status has changed"})
+			},
+			expectedErr: &exception.ConflictException{Message: "task status has changed"},
+		},
+		"should throw error when task repository transition task": {
+			inputUser: technician,
+			inputTo:   model.TaskStatusInProgress,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Status: model.TaskStatusOpened}, nil)
+				taskRepository.EXPECT().TransitionTask(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, workflow)
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			task, transition, err := taskService.TransitionTask(ctx, cs.inputUser, 1, cs.inputTo, cs.inputForce)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTask, task)
+			assert.Equal(t, cs.expectedTransition, transition)
+		})
+	}
+}
+
+func TestTaskServiceListTaskTransitions(t *testing.T) {
+	now := time.Now()
+	transitions := []model.TaskTransition{
+		{ID: 1, CreatedAt: now, TaskID: 1, UserID: 2, FromStatus: model.TaskStatusOpened, ToStatus: model.TaskStatusInProgress},
+	}
+
+	var cases = map[string]struct {
+		mocking             func(taskRepository *mock.MockTaskRepository)
+		expectedTransitions []model.TaskTransition
+		expectedErr         error
+	}{
+		"should list task transitions": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2, AssigneeID: 2}, nil)
+				taskRepository.EXPECT().ListTaskTransitions(gomock.Any(), 1).Return(transitions, nil)
+			},
+			expectedTransitions: transitions,
+		},
+		"should throw not found when technician is not related to task": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 3}, nil)
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task repository list task transitions": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2, AssigneeID: 2}, nil)
+				taskRepository.EXPECT().ListTaskTransitions(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			transitions, err := taskService.ListTaskTransitions(ctx, &model.User{ID: 2, Role: model.UserRoleTechnician}, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTransitions, transitions)
+		})
+	}
+}
+
+func TestTaskServiceUpdateTask(t *testing.T) {
+	now := time.Now()
+	dueAt := time.Date(1992, 8, 28, 18, 0, 0, 0, time.Local)
+	updated := &model.Task{
+		ID: 1, UpdatedAt: now, UserID: 3, AssigneeID: 2, Summary: "new summary",
+		Status: model.TaskStatusOpened, DueAt: &dueAt, Priority: model.TaskPriorityHigh,
+	}
+
+	var cases = map[string]struct {
+		inputData    dto.UpdateTaskDto
+		mocking      func(taskRepository *mock.MockTaskRepository)
+		expectedTask *model.Task
+		expectedErr  error
+	}{
+		"should update task": {
+			inputData: dto.UpdateTaskDto{Summary: "new summary", DueAt: "1992-08-28 18:00:00", Priority: model.TaskPriorityHigh},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", Status: model.TaskStatusOpened, Priority: model.TaskPriorityNormal}, nil)
+				taskRepository.EXPECT().UpdateTask(gomock.Any(), model.Task{
+					ID: 1, UserID: 3, AssigneeID: 2, Summary: "new summary",
+					Status: model.TaskStatusOpened, DueAt: &dueAt, Priority: model.TaskPriorityHigh,
+				}).Return(updated, nil)
+			},
+			expectedTask: updated,
+		},
+		"should clear due date and reset priority": {
+			inputData: dto.UpdateTaskDto{Summary: "summary"},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", DueAt: &dueAt, Priority: model.TaskPriorityHigh}, nil)
+				taskRepository.EXPECT().UpdateTask(gomock.Any(), model.Task{
+					ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", Priority: model.TaskPriorityNormal,
+				}).Return(&model.Task{ID: 1}, nil)
+			},
+			expectedTask: &model.Task{ID: 1},
+		},
+		"should throw not found when technician is not related to task": {
+			inputData: dto.UpdateTaskDto{Summary: "summary"},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 4}, nil)
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task repository update task": {
+			inputData: dto.UpdateTaskDto{Summary: "summary"},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 3, AssigneeID: 2}, nil)
+				taskRepository.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			task, err := taskService.UpdateTask(ctx, &model.User{ID: 2, Role: model.UserRoleTechnician}, 1, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTask, task)
+		})
+	}
+}
+
+func TestTaskServiceGetTask(t *testing.T) {
+	task := &model.Task{ID: 1, UserID: 3, AssigneeID: 2, Summary: "summary", Status: model.TaskStatusOpened}
+
+	var cases = map[string]struct {
+		inputUser    *model.User
+		mocking      func(taskRepository *mock.MockTaskRepository)
+		expectedTask *model.Task
+		expectedErr  error
+	}{
+		"should get task when user is the assignee": {
+			inputUser: &model.User{ID: 2, Role: model.UserRoleTechnician},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(task, nil)
+			},
+			expectedTask: task,
+		},
+		"should get task when user is manager": {
+			inputUser: &model.User{ID: 9, Role: model.UserRoleManager},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(task, nil)
+			},
+			expectedTask: task,
+		},
+		"should throw not found when technician is not related to task": {
+			inputUser: &model.User{ID: 4, Role: model.UserRoleTechnician},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(task, nil)
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task repository get task by id": {
+			inputUser: &model.User{ID: 2, Role: model.UserRoleTechnician},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			task, err := taskService.GetTask(ctx, cs.inputUser, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTask, task)
+		})
+	}
+}
+
+func TestTaskServiceUpdateTaskParent(t *testing.T) {
+	parentID := 5
+	selfID := 1
+
+	var cases = map[string]struct {
+		inputParentID *int
+		mocking       func(taskRepository *mock.MockTaskRepository)
+		expectedTask  *model.Task
+		expectedErr   error
+	}{
+		"should move task under parent": {
+			inputParentID: &parentID,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, UserID: 2}, nil)
+				taskRepository.EXPECT().IsTaskAncestor(gomock.Any(), 1, 5).Return(false, nil)
+				taskRepository.EXPECT().UpdateTask(gomock.Any(), model.Task{
+					ID: 1, UserID: 2, ParentID: &parentID, Summary: "summary", Priority: model.TaskPriorityNormal,
+				}).Return(&model.Task{ID: 1, ParentID: &parentID}, nil)
+			},
+			expectedTask: &model.Task{ID: 1, ParentID: &parentID},
+		},
+		"should throw validation error when parent is not visible": {
+			inputParentID: &parentID,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, UserID: 3}, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "parent_id", Tag: "exists", Message: "parent_id must be an existing task"},
+				},
+			},
+		},
+		"should throw conflict when task is its own parent": {
+			inputParentID: &selfID,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Times(2).Return(&model.Task{ID: 1, UserID: 2}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "parent would create a cycle"},
+		},
+		"should throw conflict when parent is a subtask of the task": {
+			inputParentID: &parentID,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, UserID: 2}, nil)
+				taskRepository.EXPECT().IsTaskAncestor(gomock.Any(), 1, 5).Return(true, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "parent would create a cycle"},
+		},
+		"should throw error when task repository is task ancestor": {
+			inputParentID: &parentID,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, UserID: 2}, nil)
+				taskRepository.EXPECT().IsTaskAncestor(gomock.Any(), 1, 5).Return(false, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			task, err := taskService.UpdateTask(ctx, &model.User{ID: 2, Role: model.UserRoleTechnician}, 1,
+				dto.UpdateTaskDto{Summary: "summary", ParentID: cs.inputParentID})
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTask, task)
+		})
+	}
+}
+
+func TestTaskServiceAddTaskDependency(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputBlockedByID int
+		mocking          func(taskRepository *mock.MockTaskRepository)
+		expectedTask     *model.Task
+		expectedErr      error
+	}{
+		"should add task dependency": {
+			inputBlockedByID: 5,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, AssigneeID: 2}, nil)
+				taskRepository.EXPECT().IsTaskBlockedBy(gomock.Any(), 5, 1).Return(false, nil)
+				taskRepository.EXPECT().CreateTaskDependency(gomock.Any(), model.TaskDependency{TaskID: 1, BlockedByID: 5, UserID: 2}).
+					Return(&model.TaskDependency{TaskID: 1, BlockedByID: 5, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2, BlockedBy: model.TaskIDs{5}}, nil)
+			},
+			expectedTask: &model.Task{ID: 1, UserID: 2, BlockedBy: model.TaskIDs{5}},
+		},
+		"should throw validation error when blocking task does not exist": {
+			inputBlockedByID: 5,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "blocked_by_id", Tag: "exists", Message: "blocked_by_id must be an existing task"},
+				},
+			},
+		},
+		"should throw conflict when task blocks itself": {
+			inputBlockedByID: 1,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Times(2).Return(&model.Task{ID: 1, UserID: 2}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "dependency would create a cycle"},
+		},
+		"should throw conflict when blocking task is blocked by the task": {
+			inputBlockedByID: 5,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, UserID: 2}, nil)
+				taskRepository.EXPECT().IsTaskBlockedBy(gomock.Any(), 5, 1).Return(true, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "dependency would create a cycle"},
+		},
+		"should throw conflict when dependency already exists": {
+			inputBlockedByID: 5,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, UserID: 2}, nil)
+				taskRepository.EXPECT().IsTaskBlockedBy(gomock.Any(), 5, 1).Return(false, nil)
+				taskRepository.EXPECT().CreateTaskDependency(gomock.Any(), gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "dependency already exists"})
+			},
+			expectedErr: &exception.ConflictException{Message: "dependency already exists"},
+		},
+		"should throw error when task repository is task blocked by": {
+			inputBlockedByID: 5,
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 5).Return(&model.Task{ID: 5, UserID: 2}, nil)
+				taskRepository.EXPECT().IsTaskBlockedBy(gomock.Any(), 5, 1).Return(false, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			task, err := taskService.AddTaskDependency(ctx, user, 1, cs.inputBlockedByID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTask, task)
+		})
+	}
+}
+
+func TestTaskServiceRemoveTaskDependency(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		mocking     func(taskRepository *mock.MockTaskRepository)
+		expectedErr error
+	}{
+		"should remove task dependency": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().DeleteTaskDependency(gomock.Any(), 1, 5).Return(nil)
+			},
+		},
+		"should throw not found when dependency does not exist": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().DeleteTaskDependency(gomock.Any(), 1, 5).
+					Return(&exception.NotFoundException{Message: "dependency not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "dependency not found"},
+		},
+		"should throw not found when technician is not related to task": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 3}, nil)
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			err := taskService.RemoveTaskDependency(ctx, user, 1, 5)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskServiceTagTask(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+
+	var cases = map[string]struct {
+		inputTags    []string
+		mocking      func(taskRepository *mock.MockTaskRepository, tagRepository *mock.MockTagRepository)
+		expectedTask *model.Task
+		expectedErr  error
+	}{
+		"should add tags to the task tags": {
+			inputTags: []string{"Equipment", "maintenance"},
+			mocking: func(taskRepository *mock.MockTaskRepository, tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), []string{"equipment", "maintenance"}).
+					Return([]model.Tag{{Name: "equipment"}, {Name: "maintenance"}}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).
+					Return(&model.Task{ID: 1, UserID: 2, Tags: model.TaskTags{"maintenance"}}, nil)
+				taskRepository.EXPECT().UpdateTask(gomock.Any(), model.Task{ID: 1, UserID: 2, Tags: model.TaskTags{"maintenance", "equipment"}}).
+					Return(&model.Task{ID: 1}, nil)
+			},
+			expectedTask: &model.Task{ID: 1},
+		},
+		"should throw validation exception when tag does not exist": {
+			inputTags: []string{"unknown"},
+			mocking: func(taskRepository *mock.MockTaskRepository, tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), []string{"unknown"}).Return([]model.Tag{}, nil)
+			},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "tags", Tag: "exists", Message: "tags must exist, unknown: unknown"}},
+			},
+		},
+		"should throw not found when technician is not related to task": {
+			inputTags: []string{"maintenance"},
+			mocking: func(taskRepository *mock.MockTaskRepository, tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), gomock.Any()).Return([]model.Tag{{Name: "maintenance"}}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 3}, nil)
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task repository update task": {
+			inputTags: []string{"maintenance"},
+			mocking: func(taskRepository *mock.MockTaskRepository, tagRepository *mock.MockTagRepository) {
+				tagRepository.EXPECT().ListTagsByNames(gomock.Any(), gomock.Any()).Return([]model.Tag{{Name: "maintenance"}}, nil)
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			tagRepositoryMock := mock.NewMockTagRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, tagRepositoryMock, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock, tagRepositoryMock)
+
+			// when
+			task, err := taskService.TagTask(ctx, user, 1, cs.inputTags)
+
+			// then
+			assert.Equal(t, cs.expectedTask, task)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskServiceDeleteTask(t *testing.T) {
+	user := &model.User{ID: 1, Role: model.UserRoleManager}
+
+	var cases = map[string]struct {
+		mocking     func(taskRepository *mock.MockTaskRepository)
+		expectedErr error
+	}{
+		"should delete task": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().DeleteTask(gomock.Any(), 1).Return(nil)
+			},
+		},
+		"should throw conflict when task has subtasks": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2, SubtaskCount: 2}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "task has 2 subtasks, delete them first"},
+		},
+		"should throw not found when task does not exist": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task repository delete task": {
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&model.Task{ID: 1, UserID: 2}, nil)
+				taskRepository.EXPECT().DeleteTask(gomock.Any(), 1).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			err := taskService.DeleteTask(ctx, user, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskServiceGetTaskTree(t *testing.T) {
+	one := 1
+	two := 2
+	root := model.Task{ID: 1, UserID: 2}
+	child := model.Task{ID: 2, UserID: 2, ParentID: &one}
+	grandchild := model.Task{ID: 3, AssigneeID: 2, ParentID: &two}
+
+	var cases = map[string]struct {
+		inputUser    *model.User
+		mocking      func(taskRepository *mock.MockTaskRepository)
+		expectedTree *model.TaskTree
+		expectedErr  error
+	}{
+		"should get task tree": {
+			inputUser: &model.User{ID: 2, Role: model.UserRoleTechnician},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&root, nil)
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 0, 0, "created_at, id", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, limit, offset int, orderBy string, opts ...repository.WhereOpt) ([]model.Task, int, error) {
+						assert.Equal(t, "WHERE parent_id IN (?) AND deleted_at IS NULL AND (user_id = ? OR assignee_id = ?)", opts[0].Query())
+						assert.Equal(t, []interface{}{1, 2, 2}, opts[0].Values())
+						return []model.Task{child}, 1, nil
+					})
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 0, 0, "created_at, id", gomock.Any()).Return([]model.Task{grandchild}, 1, nil)
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 0, 0, "created_at, id", gomock.Any()).Return([]model.Task{}, 0, nil)
+			},
+			expectedTree: &model.TaskTree{Task: root, Subtasks: []model.TaskTree{
+				{Task: child, Subtasks: []model.TaskTree{
+					{Task: grandchild, Subtasks: []model.TaskTree{}},
+				}},
+			}},
+		},
+		"should get task tree without visibility filter when user is manager": {
+			inputUser: &model.User{ID: 9, Role: model.UserRoleManager},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&root, nil)
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 0, 0, "created_at, id", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, limit, offset int, orderBy string, opts ...repository.WhereOpt) ([]model.Task, int, error) {
+						assert.Equal(t, "WHERE parent_id IN (?) AND deleted_at IS NULL", opts[0].Query())
+						return []model.Task{}, 0, nil
+					})
+			},
+			expectedTree: &model.TaskTree{Task: root, Subtasks: []model.TaskTree{}},
+		},
+		"should throw error when task repository list tasks": {
+			inputUser: &model.User{ID: 9, Role: model.UserRoleManager},
+			mocking: func(taskRepository *mock.MockTaskRepository) {
+				taskRepository.EXPECT().GetTaskByID(gomock.Any(), 1).Return(&root, nil)
+				taskRepository.EXPECT().ListTasks(gomock.Any(), 0, 0, "created_at, id", gomock.Any()).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepositoryMock := mock.NewMockTaskRepository(ctrl)
+			taskService := service.NewTaskService(taskRepositoryMock, nil, nil, service.TaskWorkflow{})
+
+			cs.mocking(taskRepositoryMock)
+
+			// when
+			tree, err := taskService.GetTaskTree(ctx, cs.inputUser, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedTree, tree)
 		})
 	}
 }
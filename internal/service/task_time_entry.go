@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mock/task_time_entry_service_mock.go -package=mock . TaskTimeEntryService
+type TaskTimeEntryService interface {
+	StartTimer(ctx context.Context, user *model.User, taskID int, data dto.CreateTaskTimeEntryDto) (*model.TaskTimeEntry, error)
+	StopTimer(ctx context.Context, user *model.User, taskID int) (*model.TaskTimeEntry, error)
+	CreateTimeEntry(ctx context.Context, user *model.User, taskID int, data dto.CreateTaskTimeEntryDto) (*model.TaskTimeEntry, error)
+	ListTimeEntries(ctx context.Context, user *model.User, taskID int) ([]model.TaskTimeEntry, error)
+	SummarizeTimeEntries(ctx context.Context, user *model.User, filter dto.TimeEntrySummaryFilterDto) ([]model.TimeEntrySummary, error)
+}
+
+type taskTimeEntryService struct {
+	taskTimeEntryRepository repository.TaskTimeEntryRepository
+	taskService             TaskService
+}
+
+func NewTaskTimeEntryService(taskTimeEntryRepository repository.TaskTimeEntryRepository, taskService TaskService) TaskTimeEntryService {
+	return &taskTimeEntryService{
+		taskTimeEntryRepository: taskTimeEntryRepository,
+		taskService:             taskService,
+	}
+}
+
+// StartTimer starts the user timer on the task. Closed tasks take no new
+// timers and a user can only have one timer running, on any task.
+func (impl *taskTimeEntryService) StartTimer(ctx context.Context, user *model.User, taskID int, data dto.CreateTaskTimeEntryDto) (*model.TaskTimeEntry, error) {
+	task, err := impl.taskService.GetTask(ctx, user, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status == model.TaskStatusClosed {
+		return nil, &exception.ConflictException{Message: "task is closed"}
+	}
+
+	entry, err := impl.taskTimeEntryRepository.CreateTaskTimeEntry(ctx, model.TaskTimeEntry{
+		TaskID:    taskID,
+		UserID:    user.ID,
+		StartedAt: time.Now(),
+		Note:      data.Note,
+	})
+	if err != nil {
+		if _, ok := err.(*exception.ConflictException); !ok {
+			log.WithContext(ctx).WithFields(log.Fields{
+				"trace": "internal.service.tasktimeentry.starttimer",
+			}).Error(err.Error())
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (impl *taskTimeEntryService) StopTimer(ctx context.Context, user *model.User, taskID int) (*model.TaskTimeEntry, error) {
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, err
+	}
+
+	entry, err := impl.taskTimeEntryRepository.StopTaskTimeEntry(ctx, taskID, user.ID, time.Now())
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, &exception.ConflictException{Message: "no timer is running on this task"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.tasktimeentry.stoptimer",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// CreateTimeEntry logs time spent away from the timer. It is allowed on
+// closed tasks, so time can still be corrected after closing.
+func (impl *taskTimeEntryService) CreateTimeEntry(ctx context.Context, user *model.User, taskID int, data dto.CreateTaskTimeEntryDto) (*model.TaskTimeEntry, error) {
+	startedAt, endedAt, err := parseTimeEntryRange(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, err
+	}
+
+	entry, err := impl.taskTimeEntryRepository.CreateTaskTimeEntry(ctx, model.TaskTimeEntry{
+		TaskID:    taskID,
+		UserID:    user.ID,
+		StartedAt: *startedAt,
+		EndedAt:   endedAt,
+		Note:      data.Note,
+	})
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.tasktimeentry.createtimeentry",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (impl *taskTimeEntryService) ListTimeEntries(ctx context.Context, user *model.User, taskID int) ([]model.TaskTimeEntry, error) {
+	if _, err := impl.taskService.GetTask(ctx, user, taskID); err != nil {
+		return nil, err
+	}
+
+	entries, err := impl.taskTimeEntryRepository.ListTaskTimeEntries(ctx, taskID)
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.tasktimeentry.listtimeentries",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// SummarizeTimeEntries sums the finished entries per user and period, a
+// week by default. Technicians only see their own time.
+func (impl *taskTimeEntryService) SummarizeTimeEntries(ctx context.Context, user *model.User, filter dto.TimeEntrySummaryFilterDto) ([]model.TimeEntrySummary, error) {
+	conditions := []string{"ended_at IS NOT NULL"}
+	values := []interface{}{}
+	if user.Role != model.UserRoleManager {
+		conditions = append(conditions, "user_id = ?")
+		values = append(values, user.ID)
+	} else if filter.UserID > 0 {
+		conditions = append(conditions, "user_id = ?")
+		values = append(values, filter.UserID)
+	}
+	if filter.From != "" {
+		from, _ := time.ParseInLocation("2006-01-02", filter.From, time.Local)
+		conditions = append(conditions, "started_at >= ?")
+		values = append(values, from)
+	}
+	if filter.To != "" {
+		to, _ := time.ParseInLocation("2006-01-02", filter.To, time.Local)
+		conditions = append(conditions, "started_at < ?")
+		values = append(values, to.AddDate(0, 0, 1))
+	}
+
+	period := filter.Period
+	if period == "" {
+		period = model.TimeEntryPeriodWeek
+	}
+
+	summaries, err := impl.taskTimeEntryRepository.SummarizeTimeEntries(ctx, period,
+		repository.SetWhere("WHERE "+strings.Join(conditions, " AND "), values))
+	if err != nil {
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.tasktimeentry.summarizetimeentries",
+		}).Error(err.Error())
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+func parseTimeEntryRange(data dto.CreateTaskTimeEntryDto) (*time.Time, *time.Time, error) {
+	fields := []exception.FieldError{}
+	if data.StartedAt == "" {
+		fields = append(fields, exception.FieldError{Field: "started_at", Tag: "required", Message: "started_at is required"})
+	}
+	if data.EndedAt == "" {
+		fields = append(fields, exception.FieldError{Field: "ended_at", Tag: "required", Message: "ended_at is required"})
+	}
+	if len(fields) > 0 {
+		return nil, nil, &exception.ValidationException{Message: "invalid fields", Fields: fields}
+	}
+
+	startedAt, err := parseTaskDateTime("started_at", data.StartedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	endedAt, err := parseTaskDateTime("ended_at", data.EndedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !endedAt.After(*startedAt) {
+		return nil, nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "ended_at", Tag: "gtfield", Message: "ended_at must be after started_at"}},
+		}
+	}
+	if endedAt.After(time.Now()) {
+		return nil, nil, &exception.ValidationException{
+			Message: "invalid fields",
+			Fields:  []exception.FieldError{{Field: "ended_at", Tag: "past", Message: "ended_at must not be in the future"}},
+		}
+	}
+
+	return startedAt, endedAt, nil
+}
@@ -0,0 +1,289 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/swordhealth-api/internal/dto"
+	"github.com/viniosilva/swordhealth-api/internal/exception"
+	"github.com/viniosilva/swordhealth-api/internal/model"
+	"github.com/viniosilva/swordhealth-api/internal/repository"
+	"github.com/viniosilva/swordhealth-api/internal/service"
+	"github.com/viniosilva/swordhealth-api/mock"
+)
+
+func TestTaskTimeEntryServiceStartTimer(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	entry := &model.TaskTimeEntry{ID: 1, TaskID: 1, UserID: 2}
+
+	var cases = map[string]struct {
+		mocking       func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService)
+		expectedEntry *model.TaskTimeEntry
+		expectedErr   error
+	}{
+		"should start timer": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1, Status: model.TaskStatusOpened}, nil)
+				taskTimeEntryRepository.EXPECT().CreateTaskTimeEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, e model.TaskTimeEntry) (*model.TaskTimeEntry, error) {
+						assert.Equal(t, 1, e.TaskID)
+						assert.Equal(t, 2, e.UserID)
+						assert.Nil(t, e.EndedAt)
+						assert.Equal(t, "note", e.Note)
+						return entry, nil
+					})
+			},
+			expectedEntry: entry,
+		},
+		"should throw conflict when task is closed": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1, Status: model.TaskStatusClosed}, nil)
+			},
+			expectedErr: &exception.ConflictException{Message: "task is closed"},
+		},
+		"should throw conflict when a timer is already running": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1, Status: model.TaskStatusOpened}, nil)
+				taskTimeEntryRepository.EXPECT().CreateTaskTimeEntry(gomock.Any(), gomock.Any()).
+					Return(nil, &exception.ConflictException{Message: "a timer is already running"})
+			},
+			expectedErr: &exception.ConflictException{Message: "a timer is already running"},
+		},
+		"should throw not found when task is not visible": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(nil, &exception.NotFoundException{Message: "task not found"})
+			},
+			expectedErr: &exception.NotFoundException{Message: "task not found"},
+		},
+		"should throw error when task time entry repository create task time entry": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1, Status: model.TaskStatusOpened}, nil)
+				taskTimeEntryRepository.EXPECT().CreateTaskTimeEntry(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTimeEntryRepositoryMock := mock.NewMockTaskTimeEntryRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskTimeEntryService := service.NewTaskTimeEntryService(taskTimeEntryRepositoryMock, taskServiceMock)
+
+			cs.mocking(taskTimeEntryRepositoryMock, taskServiceMock)
+
+			// when
+			res, err := taskTimeEntryService.StartTimer(ctx, user, 1, dto.CreateTaskTimeEntryDto{Action: "start", Note: "note"})
+
+			// then
+			assert.Equal(t, cs.expectedEntry, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskTimeEntryServiceStopTimer(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	entry := &model.TaskTimeEntry{ID: 1, TaskID: 1, UserID: 2}
+
+	var cases = map[string]struct {
+		mocking       func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService)
+		expectedEntry *model.TaskTimeEntry
+		expectedErr   error
+	}{
+		"should stop timer": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskTimeEntryRepository.EXPECT().StopTaskTimeEntry(gomock.Any(), 1, 2, gomock.Any()).Return(entry, nil)
+			},
+			expectedEntry: entry,
+		},
+		"should throw conflict when no timer is running": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskTimeEntryRepository.EXPECT().StopTaskTimeEntry(gomock.Any(), 1, 2, gomock.Any()).
+					Return(nil, &exception.NotFoundException{Message: "no timer is running on this task"})
+			},
+			expectedErr: &exception.ConflictException{Message: "no timer is running on this task"},
+		},
+		"should throw error when task time entry repository stop task time entry": {
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskTimeEntryRepository.EXPECT().StopTaskTimeEntry(gomock.Any(), 1, 2, gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTimeEntryRepositoryMock := mock.NewMockTaskTimeEntryRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskTimeEntryService := service.NewTaskTimeEntryService(taskTimeEntryRepositoryMock, taskServiceMock)
+
+			cs.mocking(taskTimeEntryRepositoryMock, taskServiceMock)
+
+			// when
+			res, err := taskTimeEntryService.StopTimer(ctx, user, 1)
+
+			// then
+			assert.Equal(t, cs.expectedEntry, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskTimeEntryServiceCreateTimeEntry(t *testing.T) {
+	user := &model.User{ID: 2, Role: model.UserRoleTechnician}
+	startedAt := time.Date(2022, 8, 21, 9, 0, 0, 0, time.Local)
+	endedAt := time.Date(2022, 8, 21, 10, 30, 0, 0, time.Local)
+	entry := &model.TaskTimeEntry{ID: 1, TaskID: 1, UserID: 2, StartedAt: startedAt, EndedAt: &endedAt}
+
+	var cases = map[string]struct {
+		inputData     dto.CreateTaskTimeEntryDto
+		mocking       func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService)
+		expectedEntry *model.TaskTimeEntry
+		expectedErr   error
+	}{
+		"should create time entry on a closed task": {
+			inputData: dto.CreateTaskTimeEntryDto{Action: "manual", StartedAt: "2022-08-21 09:00:00", EndedAt: "2022-08-21 10:30:00"},
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1, Status: model.TaskStatusClosed}, nil)
+				taskTimeEntryRepository.EXPECT().CreateTaskTimeEntry(gomock.Any(), model.TaskTimeEntry{
+					TaskID: 1, UserID: 2, StartedAt: startedAt, EndedAt: &endedAt,
+				}).Return(entry, nil)
+			},
+			expectedEntry: entry,
+		},
+		"should throw validation exception when range is missing": {
+			inputData: dto.CreateTaskTimeEntryDto{Action: "manual"},
+			mocking:   func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields: []exception.FieldError{
+					{Field: "started_at", Tag: "required", Message: "started_at is required"},
+					{Field: "ended_at", Tag: "required", Message: "ended_at is required"},
+				},
+			},
+		},
+		"should throw validation exception when ended_at is not after started_at": {
+			inputData: dto.CreateTaskTimeEntryDto{Action: "manual", StartedAt: "2022-08-21 10:30:00", EndedAt: "2022-08-21 10:30:00"},
+			mocking:   func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "ended_at", Tag: "gtfield", Message: "ended_at must be after started_at"}},
+			},
+		},
+		"should throw validation exception when ended_at is in the future": {
+			inputData: dto.CreateTaskTimeEntryDto{Action: "manual", StartedAt: "2022-08-21 10:30:00",
+				EndedAt: time.Now().Add(time.Hour).Format("2006-01-02 15:04:05")},
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {},
+			expectedErr: &exception.ValidationException{
+				Message: "invalid fields",
+				Fields:  []exception.FieldError{{Field: "ended_at", Tag: "past", Message: "ended_at must not be in the future"}},
+			},
+		},
+		"should throw error when task time entry repository create task time entry": {
+			inputData: dto.CreateTaskTimeEntryDto{Action: "manual", StartedAt: "2022-08-21 09:00:00", EndedAt: "2022-08-21 10:30:00"},
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository, taskService *mock.MockTaskService) {
+				taskService.EXPECT().GetTask(gomock.Any(), user, 1).Return(&model.Task{ID: 1}, nil)
+				taskTimeEntryRepository.EXPECT().CreateTaskTimeEntry(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTimeEntryRepositoryMock := mock.NewMockTaskTimeEntryRepository(ctrl)
+			taskServiceMock := mock.NewMockTaskService(ctrl)
+			taskTimeEntryService := service.NewTaskTimeEntryService(taskTimeEntryRepositoryMock, taskServiceMock)
+
+			cs.mocking(taskTimeEntryRepositoryMock, taskServiceMock)
+
+			// when
+			res, err := taskTimeEntryService.CreateTimeEntry(ctx, user, 1, cs.inputData)
+
+			// then
+			assert.Equal(t, cs.expectedEntry, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func TestTaskTimeEntryServiceSummarizeTimeEntries(t *testing.T) {
+	summaries := []model.TimeEntrySummary{{UserID: 2, Period: "2022-08-15", Seconds: 5400, Entries: 2}}
+	from := time.Date(2022, 8, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2022, 9, 1, 0, 0, 0, 0, time.Local)
+
+	var cases = map[string]struct {
+		inputUser         *model.User
+		inputFilter       dto.TimeEntrySummaryFilterDto
+		mocking           func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository)
+		expectedSummaries []model.TimeEntrySummary
+		expectedErr       error
+	}{
+		"should summarize manager filter by week": {
+			inputUser:   &model.User{ID: 1, Role: model.UserRoleManager},
+			inputFilter: dto.TimeEntrySummaryFilterDto{UserID: 2, From: "2022-08-01", To: "2022-08-31"},
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository) {
+				taskTimeEntryRepository.EXPECT().SummarizeTimeEntries(gomock.Any(), model.TimeEntryPeriodWeek,
+					repository.SetWhere("WHERE ended_at IS NOT NULL AND user_id = ? AND started_at >= ? AND started_at < ?",
+						[]interface{}{2, from, to})).Return(summaries, nil)
+			},
+			expectedSummaries: summaries,
+		},
+		"should only summarize technician own time": {
+			inputUser:   &model.User{ID: 2, Role: model.UserRoleTechnician},
+			inputFilter: dto.TimeEntrySummaryFilterDto{UserID: 3, Period: model.TimeEntryPeriodDay},
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository) {
+				taskTimeEntryRepository.EXPECT().SummarizeTimeEntries(gomock.Any(), model.TimeEntryPeriodDay,
+					repository.SetWhere("WHERE ended_at IS NOT NULL AND user_id = ?", []interface{}{2})).Return(summaries, nil)
+			},
+			expectedSummaries: summaries,
+		},
+		"should throw error when task time entry repository summarize time entries": {
+			inputUser: &model.User{ID: 1, Role: model.UserRoleManager},
+			mocking: func(taskTimeEntryRepository *mock.MockTaskTimeEntryRepository) {
+				taskTimeEntryRepository.EXPECT().SummarizeTimeEntries(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskTimeEntryRepositoryMock := mock.NewMockTaskTimeEntryRepository(ctrl)
+			taskTimeEntryService := service.NewTaskTimeEntryService(taskTimeEntryRepositoryMock, mock.NewMockTaskService(ctrl))
+
+			cs.mocking(taskTimeEntryRepositoryMock)
+
+			// when
+			res, err := taskTimeEntryService.SummarizeTimeEntries(ctx, cs.inputUser, cs.inputFilter)
+
+			// then
+			assert.Equal(t, cs.expectedSummaries, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -15,6 +15,7 @@ type UserService interface {
 	CreateUser(ctx context.Context, data dto.CreateUserDto) (*model.User, error)
 	GetUserByID(ctx context.Context, id int) (*model.User, error)
 	GetUserByUsernameAndPassword(ctx context.Context, username, password string) (*model.User, error)
+	ChangePassword(ctx context.Context, id int, currentPassword, password string) error
 }
 
 type userService struct {
@@ -72,3 +73,19 @@ func (impl *userService) GetUserByUsernameAndPassword(ctx context.Context, usern
 
 	return user, nil
 }
+
+func (impl *userService) ChangePassword(ctx context.Context, id int, currentPassword, password string) error {
+	err := impl.userRepository.UpdateUserPassword(ctx, id, currentPassword, password)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return &exception.ForbiddenException{Message: "invalid current password"}
+		}
+
+		log.WithContext(ctx).WithFields(log.Fields{
+			"trace": "internal.service.user.changepassword",
+		}).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -313,3 +313,49 @@ func BenchmarkUserServiceGetUserByUsernameAndPassword(b *testing.B) {
 		userService.GetUserByUsernameAndPassword(ctx, user.Username, user.Password)
 	}
 }
+
+func TestUserServiceChangePassword(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(userRepository *mock.MockUserRepository)
+		expectedErr error
+	}{
+		"should change password": {
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().UpdateUserPassword(gomock.Any(), 1, "current-hash", "new-hash").Return(nil)
+			},
+		},
+		"should throw forbidden when current password is invalid": {
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().UpdateUserPassword(gomock.Any(), 1, "current-hash", "new-hash").
+					Return(&exception.NotFoundException{Message: "user not found"})
+			},
+			expectedErr: &exception.ForbiddenException{Message: "invalid current password"},
+		},
+		"should throw error": {
+			mocking: func(userRepository *mock.MockUserRepository) {
+				userRepository.EXPECT().UpdateUserPassword(gomock.Any(), 1, "current-hash", "new-hash").
+					Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepositoryMock := mock.NewMockUserRepository(ctrl)
+			userService := service.NewUserService(userRepositoryMock)
+
+			cs.mocking(userRepositoryMock)
+
+			// when
+			err := userService.ChangePassword(ctx, 1, "current-hash", "new-hash")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
@@ -12,6 +14,7 @@ import (
 	"github.com/viniosilva/swordhealth-api/docs"
 	"github.com/viniosilva/swordhealth-api/internal/config"
 	"github.com/viniosilva/swordhealth-api/internal/controller"
+	"github.com/viniosilva/swordhealth-api/internal/model"
 	"github.com/viniosilva/swordhealth-api/internal/repository"
 	"github.com/viniosilva/swordhealth-api/internal/service"
 )
@@ -35,25 +38,167 @@ func main() {
 	}
 
 	r := gin.Default()
-	router := r.Group("/api")
 
 	healthRepository := repository.NewHealthRepository(db)
 	userRepository := repository.NewUserRepository(db)
 	taskRepository := repository.NewTaskRepository(db)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(db)
+	mfaRepository := repository.NewMfaRepository(db)
+	apiKeyRepository := repository.NewApiKeyRepository(db)
+	passwordResetRepository := repository.NewPasswordResetRepository(db)
+	invitationRepository := repository.NewInvitationRepository(db)
+	passwordHistoryRepository := repository.NewPasswordHistoryRepository(db)
+	sessionRepository := repository.NewSessionRepository(db)
+	auditLogRepository := repository.NewAuditLogRepository(db)
+	taskReminderRepository := repository.NewTaskReminderRepository(db)
+	taskCommentRepository := repository.NewTaskCommentRepository(db)
+	taskAttachmentRepository := repository.NewTaskAttachmentRepository(db)
+	tagRepository := repository.NewTagRepository(db)
+	taskScheduleRepository := repository.NewTaskScheduleRepository(db)
+	taskTemplateRepository := repository.NewTaskTemplateRepository(db)
+	taskTimeEntryRepository := repository.NewTaskTimeEntryRepository(db)
+	taskReportRepository := repository.NewTaskReportRepository(db)
+
+	breachedPasswordRepository, err := repository.NewBreachedPasswordList(c.PasswordPolicy.BreachedList)
+	if err != nil {
+		panic(err)
+	}
+
+	loginLimiter := repository.NewMemoryLimiter()
+	if c.LoginGuard.Store == "mysql" {
+		loginLimiter = repository.NewMySQLLimiter(db)
+	}
+
+	mailer := repository.NewLogMailer()
+	if c.Mailer.Driver == "smtp" {
+		mailer = repository.NewSMTPMailer(c.Mailer.Host, c.Mailer.Port, c.Mailer.Username, c.Mailer.Password, c.Mailer.From)
+	}
+
+	blobStore := repository.NewLocalBlobStore(c.BlobStore.Dir)
+	if c.BlobStore.Driver == "s3" {
+		blobStore = repository.NewS3BlobStore(c.BlobStore.Endpoint, c.BlobStore.Region, c.BlobStore.Bucket,
+			c.BlobStore.AccessKey, c.BlobStore.SecretKey)
+	}
 
 	cryptoService := service.NewCryptoService(c.Crypto.HashKey, c.Crypto.JwtKey, c.Crypto.ExpiresIn)
 	healthService := service.NewHealthService(healthRepository)
 	userService := service.NewUserService(userRepository)
-	taskService := service.NewTaskService(taskRepository)
-	notificationService := service.NewNotificationService(userRepository)
+	taskService := service.NewTaskService(taskRepository, userRepository, tagRepository, taskWorkflow(c))
+	notificationService := service.NewNotificationService(userRepository, mailer)
+	passwordPolicyService := service.NewPasswordPolicyService(passwordHistoryRepository, breachedPasswordRepository,
+		cryptoService, service.PasswordPolicy{
+			MinLength:        c.PasswordPolicy.MinLength,
+			MaxLength:        c.PasswordPolicy.MaxLength,
+			RequireUppercase: c.PasswordPolicy.RequireUppercase,
+			RequireLowercase: c.PasswordPolicy.RequireLowercase,
+			RequireDigit:     c.PasswordPolicy.RequireDigit,
+			RequireSymbol:    c.PasswordPolicy.RequireSymbol,
+			History:          c.PasswordPolicy.History,
+		})
 	authService := service.NewAuthService()
+	invitationService := service.NewInvitationService(invitationRepository, userRepository, notificationService,
+		cryptoService, passwordPolicyService, c.Invitation.URL, time.Millisecond*time.Duration(c.Invitation.ExpiresIn))
+	rateLimitService := service.NewRateLimitService(repository.NewMemoryRateLimitStore())
+	apiKeyService := service.NewApiKeyService(apiKeyRepository, userRepository, cryptoService)
+	sessionService := service.NewSessionService(sessionRepository, time.Millisecond*time.Duration(c.Crypto.ExpiresIn),
+		time.Millisecond*time.Duration(c.Session.CacheTTL))
+	passwordResetService := service.NewPasswordResetService(passwordResetRepository, userRepository, notificationService,
+		cryptoService, passwordPolicyService, sessionService, c.PasswordReset.URL,
+		time.Millisecond*time.Duration(c.PasswordReset.ExpiresIn))
+	auditService := service.NewAuditService(auditLogRepository)
+	taskReminderService := service.NewTaskReminderService(taskReminderRepository, notificationService,
+		time.Millisecond*time.Duration(c.TaskReminder.Interval), time.Millisecond*time.Duration(c.TaskReminder.DueSoon))
+	taskScheduleService := service.NewTaskScheduleService(taskScheduleRepository, userRepository, taskService,
+		time.Millisecond*time.Duration(c.TaskScheduler.Interval), time.Millisecond*time.Duration(c.TaskScheduler.Lease))
+	taskCommentService := service.NewTaskCommentService(taskCommentRepository, taskService, notificationService,
+		time.Millisecond*time.Duration(c.TaskComment.EditWindow))
+	tagService := service.NewTagService(tagRepository)
+	taskTemplateService := service.NewTaskTemplateService(taskTemplateRepository)
+	taskTimeEntryService := service.NewTaskTimeEntryService(taskTimeEntryRepository, taskService)
+	taskBulkService := service.NewTaskBulkService(taskService, c.TaskBulk.MaxItems)
+	taskImportService := service.NewTaskImportService(taskRepository, userRepository, tagRepository, taskWorkflow(c),
+		c.TaskImport.MaxRows)
+	taskReportService := service.NewTaskReportService(taskReportRepository)
+	taskAttachmentService := service.NewTaskAttachmentService(taskAttachmentRepository, blobStore, taskService,
+		service.TaskAttachmentPolicy{
+			MaxSize:      c.TaskAttachment.MaxSize,
+			AllowedTypes: c.TaskAttachment.AllowedTypes,
+		})
+	impersonationService := service.NewImpersonationService(userRepository)
+	mfaService := service.NewMfaService(mfaRepository, cryptoService, c.Mfa.Issuer, c.Mfa.EnforceManager)
+	loginGuardService := service.NewLoginGuardService(loginLimiter, loginAttemptRepository, service.LoginGuardPolicy{
+		Window:                 time.Millisecond * time.Duration(c.LoginGuard.Window),
+		MaxAttemptsPerUsername: c.LoginGuard.MaxAttemptsPerUsername,
+		MaxAttemptsPerIP:       c.LoginGuard.MaxAttemptsPerIP,
+		LockoutThreshold:       c.LoginGuard.LockoutThreshold,
+		LockoutDuration:        time.Millisecond * time.Duration(c.LoginGuard.LockoutDuration),
+		BaseDelay:              time.Millisecond * time.Duration(c.LoginGuard.BaseDelay),
+		MaxDelay:               time.Millisecond * time.Duration(c.LoginGuard.MaxDelay),
+	})
 
-	middleware := controller.NewMiddlewareController(cryptoService)
+	middleware := controller.NewMiddlewareController(cryptoService, rateLimitService, apiKeyService, sessionService,
+		auditService)
+	r.Use(middleware.RequestID, middleware.HandleErrors)
+	router := r.Group("/api", middleware.RateLimit(rateLimitPolicy(c, "global")))
 
 	controller.NewHealthController(router, healthService)
-	controller.NewUserController(router, userService, cryptoService, middleware.AccessToken, middleware.UserManager)
-	controller.NewTaskController(router, taskService, userService, notificationService, middleware.AccessToken)
-	controller.NewAuthController(router, authService, userService, cryptoService)
+	controller.NewUserController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "users"))),
+		userService, cryptoService, passwordPolicyService, middleware.AccessToken, middleware.UserManager,
+		middleware.NotImpersonated)
+	controller.NewTaskController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		taskService, userService, notificationService, taskTemplateService, taskBulkService, taskImportService,
+		auditService, c.TaskImport.MaxRows, middleware.AccessToken, middleware.UserManager)
+	controller.NewTaskTemplateController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		taskTemplateService, userService, middleware.AccessToken, middleware.UserManager)
+	controller.NewTagController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		tagService, middleware.AccessToken, middleware.UserManager)
+	controller.NewTaskScheduleController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		taskScheduleService, userService, middleware.AccessToken, middleware.UserManager)
+	controller.NewTaskCommentController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		taskCommentService, userService, middleware.AccessToken)
+	controller.NewTaskAttachmentController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		taskAttachmentService, userService, c.TaskAttachment.MaxSize, middleware.AccessToken)
+	controller.NewTaskTimeEntryController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		taskTimeEntryService, userService, middleware.AccessToken)
+	controller.NewReportController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "tasks"))),
+		taskReportService, middleware.AccessToken, middleware.UserManager)
+	controller.NewAuthController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "auth"))),
+		authService, userService, cryptoService, loginGuardService, mfaService, sessionService)
+	controller.NewMfaController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "auth"))), mfaService,
+		userService, middleware.MfaEnrollmentToken, middleware.NotImpersonated)
+	controller.NewPasswordController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "auth"))),
+		passwordResetService)
+	if c.Oidc.Enabled {
+		oidcService := service.NewOidcService(
+			repository.NewOidcClient(c.Oidc.Issuer, c.Oidc.ClientID, c.Oidc.ClientSecret, c.Oidc.RedirectURL),
+			userRepository, cryptoService, service.OidcConfig{
+				ClientID:         c.Oidc.ClientID,
+				RedirectURL:      c.Oidc.RedirectURL,
+				Scopes:           c.Oidc.Scopes,
+				RoleClaim:        c.Oidc.RoleClaim,
+				ManagerGroups:    c.Oidc.ManagerGroups,
+				SessionExpiresIn: time.Millisecond * time.Duration(c.Oidc.SessionExpiresIn),
+			})
+		controller.NewOidcController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "auth"))),
+			oidcService, cryptoService, sessionService, mfaService, int(c.Oidc.SessionExpiresIn/1000), c.Oidc.CookieSecure)
+	}
+	controller.NewApiKeyController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "users"))),
+		apiKeyService, middleware.AccessToken, middleware.NotImpersonated)
+	controller.NewInvitationController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "users"))),
+		invitationService, middleware.AccessToken, middleware.UserManager, middleware.NotImpersonated)
+	controller.NewSessionController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "users"))),
+		sessionService, middleware.AccessToken, middleware.UserManager)
+	controller.NewImpersonationController(router.Group("", middleware.RateLimit(rateLimitPolicy(c, "auth"))),
+		impersonationService, cryptoService, auditService,
+		time.Millisecond*time.Duration(c.Impersonation.ExpiresIn),
+		middleware.AccessToken, middleware.UserManager, middleware.NotImpersonated)
+
+	if c.TaskReminder.Enabled {
+		go taskReminderService.Run(context.Background())
+	}
+	if c.TaskScheduler.Enabled {
+		go taskScheduleService.Run(context.Background())
+	}
 
 	host := fmt.Sprintf("%s:%s", c.Server.Host, c.Server.Port)
 	docs.SwaggerInfo.Host = host
@@ -61,3 +206,31 @@ func main() {
 
 	r.Run(host)
 }
+
+func rateLimitPolicy(c config.Config, group string) service.RateLimitPolicy {
+	policy := c.RateLimit.Groups[group]
+
+	return service.RateLimitPolicy{
+		Name:        group,
+		Capacity:    policy.Capacity,
+		RefillEvery: time.Millisecond * time.Duration(policy.RefillEvery),
+	}
+}
+
+func taskWorkflow(c config.Config) service.TaskWorkflow {
+	workflow := service.TaskWorkflow{}
+	for _, t := range c.TaskWorkflow.Transitions {
+		roles := []model.UserRole{}
+		for _, role := range t.Roles {
+			roles = append(roles, model.UserRole(role))
+		}
+
+		workflow.Transitions = append(workflow.Transitions, service.TaskTransitionRule{
+			From:  model.TaskStatus(t.From),
+			To:    model.TaskStatus(t.To),
+			Roles: roles,
+		})
+	}
+
+	return workflow
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: BreachedPasswordRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBreachedPasswordRepository is a mock of BreachedPasswordRepository interface.
+type MockBreachedPasswordRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreachedPasswordRepositoryMockRecorder
+}
+
+// MockBreachedPasswordRepositoryMockRecorder is the mock recorder for MockBreachedPasswordRepository.
+type MockBreachedPasswordRepositoryMockRecorder struct {
+	mock *MockBreachedPasswordRepository
+}
+
+// NewMockBreachedPasswordRepository creates a new mock instance.
+func NewMockBreachedPasswordRepository(ctrl *gomock.Controller) *MockBreachedPasswordRepository {
+	mock := &MockBreachedPasswordRepository{ctrl: ctrl}
+	mock.recorder = &MockBreachedPasswordRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreachedPasswordRepository) EXPECT() *MockBreachedPasswordRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Contains mocks base method.
+func (m *MockBreachedPasswordRepository) Contains(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contains", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Contains indicates an expected call of Contains.
+func (mr *MockBreachedPasswordRepositoryMockRecorder) Contains(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contains", reflect.TypeOf((*MockBreachedPasswordRepository)(nil).Contains), arg0)
+}
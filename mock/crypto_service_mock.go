@@ -34,6 +34,21 @@ func (m *MockCryptoService) EXPECT() *MockCryptoServiceMockRecorder {
 	return m.recorder
 }
 
+// Decrypt mocks base method.
+func (m *MockCryptoService) Decrypt(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockCryptoServiceMockRecorder) Decrypt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockCryptoService)(nil).Decrypt), arg0)
+}
+
 // DecryptJwt mocks base method.
 func (m *MockCryptoService) DecryptJwt(arg0 context.Context, arg1 string) (map[string]interface{}, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +64,21 @@ func (mr *MockCryptoServiceMockRecorder) DecryptJwt(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptJwt", reflect.TypeOf((*MockCryptoService)(nil).DecryptJwt), arg0, arg1)
 }
 
+// Encrypt mocks base method.
+func (m *MockCryptoService) Encrypt(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockCryptoServiceMockRecorder) Encrypt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockCryptoService)(nil).Encrypt), arg0)
+}
+
 // EncryptJwt mocks base method.
 func (m *MockCryptoService) EncryptJwt(arg0 context.Context, arg1 interface{}, arg2 map[string]interface{}) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: MfaRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockMfaRepository is a mock of MfaRepository interface.
+type MockMfaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMfaRepositoryMockRecorder
+}
+
+// MockMfaRepositoryMockRecorder is the mock recorder for MockMfaRepository.
+type MockMfaRepositoryMockRecorder struct {
+	mock *MockMfaRepository
+}
+
+// NewMockMfaRepository creates a new mock instance.
+func NewMockMfaRepository(ctrl *gomock.Controller) *MockMfaRepository {
+	mock := &MockMfaRepository{ctrl: ctrl}
+	mock.recorder = &MockMfaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMfaRepository) EXPECT() *MockMfaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTotp mocks base method.
+func (m *MockMfaRepository) ConfirmTotp(arg0 context.Context, arg1 int, arg2 int64, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTotp", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTotp indicates an expected call of ConfirmTotp.
+func (mr *MockMfaRepositoryMockRecorder) ConfirmTotp(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTotp", reflect.TypeOf((*MockMfaRepository)(nil).ConfirmTotp), arg0, arg1, arg2, arg3)
+}
+
+// GetTotpByUserID mocks base method.
+func (m *MockMfaRepository) GetTotpByUserID(arg0 context.Context, arg1 int) (*model.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotpByUserID", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotpByUserID indicates an expected call of GetTotpByUserID.
+func (mr *MockMfaRepositoryMockRecorder) GetTotpByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotpByUserID", reflect.TypeOf((*MockMfaRepository)(nil).GetTotpByUserID), arg0, arg1)
+}
+
+// SaveTotp mocks base method.
+func (m *MockMfaRepository) SaveTotp(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTotp", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTotp indicates an expected call of SaveTotp.
+func (mr *MockMfaRepositoryMockRecorder) SaveTotp(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTotp", reflect.TypeOf((*MockMfaRepository)(nil).SaveTotp), arg0, arg1, arg2)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMfaRepository) UseRecoveryCode(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMfaRepositoryMockRecorder) UseRecoveryCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMfaRepository)(nil).UseRecoveryCode), arg0, arg1, arg2)
+}
+
+// UseTotpStep mocks base method.
+func (m *MockMfaRepository) UseTotpStep(arg0 context.Context, arg1 int, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTotpStep", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTotpStep indicates an expected call of UseTotpStep.
+func (mr *MockMfaRepositoryMockRecorder) UseTotpStep(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTotpStep", reflect.TypeOf((*MockMfaRepository)(nil).UseTotpStep), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: MfaService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockMfaService is a mock of MfaService interface.
+type MockMfaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMfaServiceMockRecorder
+}
+
+// MockMfaServiceMockRecorder is the mock recorder for MockMfaService.
+type MockMfaServiceMockRecorder struct {
+	mock *MockMfaService
+}
+
+// NewMockMfaService creates a new mock instance.
+func NewMockMfaService(ctrl *gomock.Controller) *MockMfaService {
+	mock := &MockMfaService{ctrl: ctrl}
+	mock.recorder = &MockMfaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMfaService) EXPECT() *MockMfaServiceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTotp mocks base method.
+func (m *MockMfaService) ConfirmTotp(arg0 context.Context, arg1 int, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTotp", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTotp indicates an expected call of ConfirmTotp.
+func (mr *MockMfaServiceMockRecorder) ConfirmTotp(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTotp", reflect.TypeOf((*MockMfaService)(nil).ConfirmTotp), arg0, arg1, arg2)
+}
+
+// EnrollTotp mocks base method.
+func (m *MockMfaService) EnrollTotp(arg0 context.Context, arg1 *model.User) (*model.TotpEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTotp", arg0, arg1)
+	ret0, _ := ret[0].(*model.TotpEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTotp indicates an expected call of EnrollTotp.
+func (mr *MockMfaServiceMockRecorder) EnrollTotp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTotp", reflect.TypeOf((*MockMfaService)(nil).EnrollTotp), arg0, arg1)
+}
+
+// GetStatus mocks base method.
+func (m *MockMfaService) GetStatus(arg0 context.Context, arg1 *model.User) (*model.MfaStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", arg0, arg1)
+	ret0, _ := ret[0].(*model.MfaStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockMfaServiceMockRecorder) GetStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockMfaService)(nil).GetStatus), arg0, arg1)
+}
+
+// VerifyCode mocks base method.
+func (m *MockMfaService) VerifyCode(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCode indicates an expected call of VerifyCode.
+func (mr *MockMfaServiceMockRecorder) VerifyCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCode", reflect.TypeOf((*MockMfaService)(nil).VerifyCode), arg0, arg1, arg2)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/viniosilva/swordhealth-api/internal/model"
@@ -48,3 +49,87 @@ func (mr *MockNotificationServiceMockRecorder) NotifyAdminUserOnSaveTask(arg0, a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyAdminUserOnSaveTask", reflect.TypeOf((*MockNotificationService)(nil).NotifyAdminUserOnSaveTask), arg0, arg1, arg2)
 }
+
+// NotifyInvitation mocks base method.
+func (m *MockNotificationService) NotifyInvitation(arg0 context.Context, arg1 *model.Invitation, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyInvitation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyInvitation indicates an expected call of NotifyInvitation.
+func (mr *MockNotificationServiceMockRecorder) NotifyInvitation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyInvitation", reflect.TypeOf((*MockNotificationService)(nil).NotifyInvitation), arg0, arg1, arg2)
+}
+
+// NotifyPasswordReset mocks base method.
+func (m *MockNotificationService) NotifyPasswordReset(arg0 context.Context, arg1 *model.User, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPasswordReset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPasswordReset indicates an expected call of NotifyPasswordReset.
+func (mr *MockNotificationServiceMockRecorder) NotifyPasswordReset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPasswordReset", reflect.TypeOf((*MockNotificationService)(nil).NotifyPasswordReset), arg0, arg1, arg2, arg3)
+}
+
+// NotifyTaskAssigned mocks base method.
+func (m *MockNotificationService) NotifyTaskAssigned(arg0 context.Context, arg1 *model.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyTaskAssigned", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyTaskAssigned indicates an expected call of NotifyTaskAssigned.
+func (mr *MockNotificationServiceMockRecorder) NotifyTaskAssigned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTaskAssigned", reflect.TypeOf((*MockNotificationService)(nil).NotifyTaskAssigned), arg0, arg1)
+}
+
+// NotifyTaskCommented mocks base method.
+func (m *MockNotificationService) NotifyTaskCommented(arg0 context.Context, arg1 *model.Task, arg2 *model.TaskComment, arg3 []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyTaskCommented", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyTaskCommented indicates an expected call of NotifyTaskCommented.
+func (mr *MockNotificationServiceMockRecorder) NotifyTaskCommented(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTaskCommented", reflect.TypeOf((*MockNotificationService)(nil).NotifyTaskCommented), arg0, arg1, arg2, arg3)
+}
+
+// NotifyTaskReminder mocks base method.
+func (m *MockNotificationService) NotifyTaskReminder(arg0 context.Context, arg1 *model.Task, arg2 model.TaskReminderThreshold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyTaskReminder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyTaskReminder indicates an expected call of NotifyTaskReminder.
+func (mr *MockNotificationServiceMockRecorder) NotifyTaskReminder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTaskReminder", reflect.TypeOf((*MockNotificationService)(nil).NotifyTaskReminder), arg0, arg1, arg2)
+}
+
+// NotifyTaskTransitioned mocks base method.
+func (m *MockNotificationService) NotifyTaskTransitioned(arg0 context.Context, arg1 *model.Task, arg2 *model.TaskTransition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyTaskTransitioned", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyTaskTransitioned indicates an expected call of NotifyTaskTransitioned.
+func (mr *MockNotificationServiceMockRecorder) NotifyTaskTransitioned(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTaskTransitioned", reflect.TypeOf((*MockNotificationService)(nil).NotifyTaskTransitioned), arg0, arg1, arg2)
+}
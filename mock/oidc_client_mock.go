@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: OidcClient)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockOidcClient is a mock of OidcClient interface.
+type MockOidcClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOidcClientMockRecorder
+}
+
+// MockOidcClientMockRecorder is the mock recorder for MockOidcClient.
+type MockOidcClientMockRecorder struct {
+	mock *MockOidcClient
+}
+
+// NewMockOidcClient creates a new mock instance.
+func NewMockOidcClient(ctrl *gomock.Controller) *MockOidcClient {
+	mock := &MockOidcClient{ctrl: ctrl}
+	mock.recorder = &MockOidcClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOidcClient) EXPECT() *MockOidcClientMockRecorder {
+	return m.recorder
+}
+
+// Discover mocks base method.
+func (m *MockOidcClient) Discover(arg0 context.Context) (*model.OidcProviderMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discover", arg0)
+	ret0, _ := ret[0].(*model.OidcProviderMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Discover indicates an expected call of Discover.
+func (mr *MockOidcClientMockRecorder) Discover(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discover", reflect.TypeOf((*MockOidcClient)(nil).Discover), arg0)
+}
+
+// ExchangeCode mocks base method.
+func (m *MockOidcClient) ExchangeCode(arg0 context.Context, arg1, arg2, arg3 string) (*model.OidcTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeCode", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.OidcTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeCode indicates an expected call of ExchangeCode.
+func (mr *MockOidcClientMockRecorder) ExchangeCode(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeCode", reflect.TypeOf((*MockOidcClient)(nil).ExchangeCode), arg0, arg1, arg2, arg3)
+}
+
+// GetJwks mocks base method.
+func (m *MockOidcClient) GetJwks(arg0 context.Context, arg1 string) ([]model.OidcJwk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJwks", arg0, arg1)
+	ret0, _ := ret[0].([]model.OidcJwk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJwks indicates an expected call of GetJwks.
+func (mr *MockOidcClientMockRecorder) GetJwks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwks", reflect.TypeOf((*MockOidcClient)(nil).GetJwks), arg0, arg1)
+}
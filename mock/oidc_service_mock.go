@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: OidcService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockOidcService is a mock of OidcService interface.
+type MockOidcService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOidcServiceMockRecorder
+}
+
+// MockOidcServiceMockRecorder is the mock recorder for MockOidcService.
+type MockOidcServiceMockRecorder struct {
+	mock *MockOidcService
+}
+
+// NewMockOidcService creates a new mock instance.
+func NewMockOidcService(ctrl *gomock.Controller) *MockOidcService {
+	mock := &MockOidcService{ctrl: ctrl}
+	mock.recorder = &MockOidcServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOidcService) EXPECT() *MockOidcServiceMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockOidcService) Authorize(arg0 context.Context) (*model.OidcAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", arg0)
+	ret0, _ := ret[0].(*model.OidcAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockOidcServiceMockRecorder) Authorize(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockOidcService)(nil).Authorize), arg0)
+}
+
+// Callback mocks base method.
+func (m *MockOidcService) Callback(arg0 context.Context, arg1, arg2, arg3 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Callback", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Callback indicates an expected call of Callback.
+func (mr *MockOidcServiceMockRecorder) Callback(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Callback", reflect.TypeOf((*MockOidcService)(nil).Callback), arg0, arg1, arg2, arg3)
+}
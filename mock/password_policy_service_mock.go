@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: PasswordPolicyService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPasswordPolicyService is a mock of PasswordPolicyService interface.
+type MockPasswordPolicyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordPolicyServiceMockRecorder
+}
+
+// MockPasswordPolicyServiceMockRecorder is the mock recorder for MockPasswordPolicyService.
+type MockPasswordPolicyServiceMockRecorder struct {
+	mock *MockPasswordPolicyService
+}
+
+// NewMockPasswordPolicyService creates a new mock instance.
+func NewMockPasswordPolicyService(ctrl *gomock.Controller) *MockPasswordPolicyService {
+	mock := &MockPasswordPolicyService{ctrl: ctrl}
+	mock.recorder = &MockPasswordPolicyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordPolicyService) EXPECT() *MockPasswordPolicyServiceMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockPasswordPolicyService) Validate(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockPasswordPolicyServiceMockRecorder) Validate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockPasswordPolicyService)(nil).Validate), arg0, arg1, arg2)
+}
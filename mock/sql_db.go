@@ -0,0 +1,173 @@
+package mock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SqlStatement is a statement SqlDB expects, matched by a part of its query,
+// and what it answers: the rows of a query, the rows affected by an exec or an
+// error.
+type SqlStatement struct {
+	Query        string
+	Columns      []string
+	Rows         [][]driver.Value
+	RowsAffected int64
+	Err          error
+}
+
+// SqlDB is a database/sql driver answering the statements it runs from a
+// script, in order, used to run repositories in tests.
+type SqlDB struct {
+	DB *sqlx.DB
+
+	mu     sync.Mutex
+	script []SqlStatement
+}
+
+func NewSqlDB(script ...SqlStatement) *SqlDB {
+	db := &SqlDB{script: script}
+	db.DB = sqlx.NewDb(sql.OpenDB(sqlConnector{db: db}), "mysql")
+
+	return db
+}
+
+// Remaining returns the statements of the script that did not run.
+func (db *SqlDB) Remaining() []SqlStatement {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.script
+}
+
+func (db *SqlDB) next(query string) (SqlStatement, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if len(db.script) == 0 || !strings.Contains(query, db.script[0].Query) {
+		return SqlStatement{}, fmt.Errorf("unexpected statement: %s", query)
+	}
+
+	statement := db.script[0]
+	db.script = db.script[1:]
+
+	return statement, statement.Err
+}
+
+type sqlConnector struct {
+	db *SqlDB
+}
+
+func (c sqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &sqlConn{db: c.db}, nil
+}
+
+func (c sqlConnector) Driver() driver.Driver {
+	return sqlDriver{}
+}
+
+type sqlDriver struct{}
+
+func (sqlDriver) Open(name string) (driver.Conn, error) {
+	return nil, fmt.Errorf("open is not supported")
+}
+
+type sqlConn struct {
+	db *SqlDB
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return &sqlStmt{conn: c, query: query}, nil
+}
+
+func (c *sqlConn) Close() error {
+	return nil
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return sqlTx{}, nil
+}
+
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return sqlTx{}, nil
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	statement, err := c.db.next(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(statement.RowsAffected), nil
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	statement, err := c.db.next(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlRows{columns: statement.Columns, rows: statement.Rows}, nil
+}
+
+type sqlTx struct{}
+
+func (sqlTx) Commit() error {
+	return nil
+}
+
+func (sqlTx) Rollback() error {
+	return nil
+}
+
+type sqlStmt struct {
+	conn  *sqlConn
+	query string
+}
+
+func (s *sqlStmt) Close() error {
+	return nil
+}
+
+func (s *sqlStmt) NumInput() int {
+	return -1
+}
+
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, nil)
+}
+
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, nil)
+}
+
+type sqlRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *sqlRows) Columns() []string {
+	return r.columns
+}
+
+func (r *sqlRows) Close() error {
+	return nil
+}
+
+func (r *sqlRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+
+	return nil
+}
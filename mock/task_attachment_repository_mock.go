@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: TaskAttachmentRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskAttachmentRepository is a mock of TaskAttachmentRepository interface.
+type MockTaskAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskAttachmentRepositoryMockRecorder
+}
+
+// MockTaskAttachmentRepositoryMockRecorder is the mock recorder for MockTaskAttachmentRepository.
+type MockTaskAttachmentRepositoryMockRecorder struct {
+	mock *MockTaskAttachmentRepository
+}
+
+// NewMockTaskAttachmentRepository creates a new mock instance.
+func NewMockTaskAttachmentRepository(ctrl *gomock.Controller) *MockTaskAttachmentRepository {
+	mock := &MockTaskAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskAttachmentRepository) EXPECT() *MockTaskAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskAttachment mocks base method.
+func (m *MockTaskAttachmentRepository) CreateTaskAttachment(arg0 context.Context, arg1 model.TaskAttachment) (*model.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskAttachment indicates an expected call of CreateTaskAttachment.
+func (mr *MockTaskAttachmentRepositoryMockRecorder) CreateTaskAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskAttachment", reflect.TypeOf((*MockTaskAttachmentRepository)(nil).CreateTaskAttachment), arg0, arg1)
+}
+
+// DeleteTaskAttachment mocks base method.
+func (m *MockTaskAttachmentRepository) DeleteTaskAttachment(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskAttachment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskAttachment indicates an expected call of DeleteTaskAttachment.
+func (mr *MockTaskAttachmentRepositoryMockRecorder) DeleteTaskAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskAttachment", reflect.TypeOf((*MockTaskAttachmentRepository)(nil).DeleteTaskAttachment), arg0, arg1)
+}
+
+// GetTaskAttachmentByID mocks base method.
+func (m *MockTaskAttachmentRepository) GetTaskAttachmentByID(arg0 context.Context, arg1, arg2 int) (*model.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskAttachmentByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskAttachmentByID indicates an expected call of GetTaskAttachmentByID.
+func (mr *MockTaskAttachmentRepositoryMockRecorder) GetTaskAttachmentByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskAttachmentByID", reflect.TypeOf((*MockTaskAttachmentRepository)(nil).GetTaskAttachmentByID), arg0, arg1, arg2)
+}
+
+// ListTaskAttachments mocks base method.
+func (m *MockTaskAttachmentRepository) ListTaskAttachments(arg0 context.Context, arg1 int) ([]model.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAttachments", arg0, arg1)
+	ret0, _ := ret[0].([]model.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAttachments indicates an expected call of ListTaskAttachments.
+func (mr *MockTaskAttachmentRepositoryMockRecorder) ListTaskAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAttachments", reflect.TypeOf((*MockTaskAttachmentRepository)(nil).ListTaskAttachments), arg0, arg1)
+}
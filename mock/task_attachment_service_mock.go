@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskAttachmentService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskAttachmentService is a mock of TaskAttachmentService interface.
+type MockTaskAttachmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskAttachmentServiceMockRecorder
+}
+
+// MockTaskAttachmentServiceMockRecorder is the mock recorder for MockTaskAttachmentService.
+type MockTaskAttachmentServiceMockRecorder struct {
+	mock *MockTaskAttachmentService
+}
+
+// NewMockTaskAttachmentService creates a new mock instance.
+func NewMockTaskAttachmentService(ctrl *gomock.Controller) *MockTaskAttachmentService {
+	mock := &MockTaskAttachmentService{ctrl: ctrl}
+	mock.recorder = &MockTaskAttachmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskAttachmentService) EXPECT() *MockTaskAttachmentServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockTaskAttachmentService) DeleteAttachment(arg0 context.Context, arg1 *model.User, arg2, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockTaskAttachmentServiceMockRecorder) DeleteAttachment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockTaskAttachmentService)(nil).DeleteAttachment), arg0, arg1, arg2, arg3)
+}
+
+// GetAttachment mocks base method.
+func (m *MockTaskAttachmentService) GetAttachment(arg0 context.Context, arg1 *model.User, arg2, arg3 int) (*model.TaskAttachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.TaskAttachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockTaskAttachmentServiceMockRecorder) GetAttachment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockTaskAttachmentService)(nil).GetAttachment), arg0, arg1, arg2, arg3)
+}
+
+// ListAttachments mocks base method.
+func (m *MockTaskAttachmentService) ListAttachments(arg0 context.Context, arg1 *model.User, arg2 int) ([]model.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockTaskAttachmentServiceMockRecorder) ListAttachments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockTaskAttachmentService)(nil).ListAttachments), arg0, arg1, arg2)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockTaskAttachmentService) UploadAttachment(arg0 context.Context, arg1 *model.User, arg2 int, arg3 string, arg4 int64, arg5 io.Reader) (*model.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*model.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockTaskAttachmentServiceMockRecorder) UploadAttachment(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockTaskAttachmentService)(nil).UploadAttachment), arg0, arg1, arg2, arg3, arg4, arg5)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskBulkService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+	service "github.com/viniosilva/swordhealth-api/internal/service"
+)
+
+// MockTaskBulkService is a mock of TaskBulkService interface.
+type MockTaskBulkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskBulkServiceMockRecorder
+}
+
+// MockTaskBulkServiceMockRecorder is the mock recorder for MockTaskBulkService.
+type MockTaskBulkServiceMockRecorder struct {
+	mock *MockTaskBulkService
+}
+
+// NewMockTaskBulkService creates a new mock instance.
+func NewMockTaskBulkService(ctrl *gomock.Controller) *MockTaskBulkService {
+	mock := &MockTaskBulkService{ctrl: ctrl}
+	mock.recorder = &MockTaskBulkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskBulkService) EXPECT() *MockTaskBulkServiceMockRecorder {
+	return m.recorder
+}
+
+// BulkTasks mocks base method.
+func (m *MockTaskBulkService) BulkTasks(arg0 context.Context, arg1 *model.User, arg2 dto.BulkTasksDto) ([]service.TaskBulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkTasks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]service.TaskBulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkTasks indicates an expected call of BulkTasks.
+func (mr *MockTaskBulkServiceMockRecorder) BulkTasks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkTasks", reflect.TypeOf((*MockTaskBulkService)(nil).BulkTasks), arg0, arg1, arg2)
+}
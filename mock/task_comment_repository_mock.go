@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: TaskCommentRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskCommentRepository is a mock of TaskCommentRepository interface.
+type MockTaskCommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskCommentRepositoryMockRecorder
+}
+
+// MockTaskCommentRepositoryMockRecorder is the mock recorder for MockTaskCommentRepository.
+type MockTaskCommentRepositoryMockRecorder struct {
+	mock *MockTaskCommentRepository
+}
+
+// NewMockTaskCommentRepository creates a new mock instance.
+func NewMockTaskCommentRepository(ctrl *gomock.Controller) *MockTaskCommentRepository {
+	mock := &MockTaskCommentRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskCommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskCommentRepository) EXPECT() *MockTaskCommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskComment mocks base method.
+func (m *MockTaskCommentRepository) CreateTaskComment(arg0 context.Context, arg1 model.TaskComment) (*model.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskComment", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskComment indicates an expected call of CreateTaskComment.
+func (mr *MockTaskCommentRepositoryMockRecorder) CreateTaskComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskComment", reflect.TypeOf((*MockTaskCommentRepository)(nil).CreateTaskComment), arg0, arg1)
+}
+
+// DeleteTaskComment mocks base method.
+func (m *MockTaskCommentRepository) DeleteTaskComment(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskComment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskComment indicates an expected call of DeleteTaskComment.
+func (mr *MockTaskCommentRepositoryMockRecorder) DeleteTaskComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskComment", reflect.TypeOf((*MockTaskCommentRepository)(nil).DeleteTaskComment), arg0, arg1)
+}
+
+// GetTaskCommentByID mocks base method.
+func (m *MockTaskCommentRepository) GetTaskCommentByID(arg0 context.Context, arg1, arg2 int) (*model.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskCommentByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskCommentByID indicates an expected call of GetTaskCommentByID.
+func (mr *MockTaskCommentRepositoryMockRecorder) GetTaskCommentByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskCommentByID", reflect.TypeOf((*MockTaskCommentRepository)(nil).GetTaskCommentByID), arg0, arg1, arg2)
+}
+
+// ListTaskCommenterIDs mocks base method.
+func (m *MockTaskCommentRepository) ListTaskCommenterIDs(arg0 context.Context, arg1 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskCommenterIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskCommenterIDs indicates an expected call of ListTaskCommenterIDs.
+func (mr *MockTaskCommentRepositoryMockRecorder) ListTaskCommenterIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskCommenterIDs", reflect.TypeOf((*MockTaskCommentRepository)(nil).ListTaskCommenterIDs), arg0, arg1)
+}
+
+// ListTaskComments mocks base method.
+func (m *MockTaskCommentRepository) ListTaskComments(arg0 context.Context, arg1, arg2, arg3 int) ([]model.TaskComment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskComments", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.TaskComment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTaskComments indicates an expected call of ListTaskComments.
+func (mr *MockTaskCommentRepositoryMockRecorder) ListTaskComments(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskComments", reflect.TypeOf((*MockTaskCommentRepository)(nil).ListTaskComments), arg0, arg1, arg2, arg3)
+}
+
+// UpdateTaskComment mocks base method.
+func (m *MockTaskCommentRepository) UpdateTaskComment(arg0 context.Context, arg1 int, arg2 string) (*model.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskComment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskComment indicates an expected call of UpdateTaskComment.
+func (mr *MockTaskCommentRepositoryMockRecorder) UpdateTaskComment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskComment", reflect.TypeOf((*MockTaskCommentRepository)(nil).UpdateTaskComment), arg0, arg1, arg2)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskCommentService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskCommentService is a mock of TaskCommentService interface.
+type MockTaskCommentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskCommentServiceMockRecorder
+}
+
+// MockTaskCommentServiceMockRecorder is the mock recorder for MockTaskCommentService.
+type MockTaskCommentServiceMockRecorder struct {
+	mock *MockTaskCommentService
+}
+
+// NewMockTaskCommentService creates a new mock instance.
+func NewMockTaskCommentService(ctrl *gomock.Controller) *MockTaskCommentService {
+	mock := &MockTaskCommentService{ctrl: ctrl}
+	mock.recorder = &MockTaskCommentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskCommentService) EXPECT() *MockTaskCommentServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockTaskCommentService) CreateComment(arg0 context.Context, arg1 *model.User, arg2 int, arg3 dto.CreateTaskCommentDto) (*model.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockTaskCommentServiceMockRecorder) CreateComment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockTaskCommentService)(nil).CreateComment), arg0, arg1, arg2, arg3)
+}
+
+// DeleteComment mocks base method.
+func (m *MockTaskCommentService) DeleteComment(arg0 context.Context, arg1 *model.User, arg2, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockTaskCommentServiceMockRecorder) DeleteComment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockTaskCommentService)(nil).DeleteComment), arg0, arg1, arg2, arg3)
+}
+
+// ListComments mocks base method.
+func (m *MockTaskCommentService) ListComments(arg0 context.Context, arg1 *model.User, arg2, arg3, arg4 int) ([]model.TaskComment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.TaskComment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockTaskCommentServiceMockRecorder) ListComments(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockTaskCommentService)(nil).ListComments), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateComment mocks base method.
+func (m *MockTaskCommentService) UpdateComment(arg0 context.Context, arg1 *model.User, arg2, arg3 int, arg4 dto.UpdateTaskCommentDto) (*model.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockTaskCommentServiceMockRecorder) UpdateComment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockTaskCommentService)(nil).UpdateComment), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskImportService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskImportService is a mock of TaskImportService interface.
+type MockTaskImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskImportServiceMockRecorder
+}
+
+// MockTaskImportServiceMockRecorder is the mock recorder for MockTaskImportService.
+type MockTaskImportServiceMockRecorder struct {
+	mock *MockTaskImportService
+}
+
+// NewMockTaskImportService creates a new mock instance.
+func NewMockTaskImportService(ctrl *gomock.Controller) *MockTaskImportService {
+	mock := &MockTaskImportService{ctrl: ctrl}
+	mock.recorder = &MockTaskImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskImportService) EXPECT() *MockTaskImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportTasks mocks base method.
+func (m *MockTaskImportService) ImportTasks(arg0 context.Context, arg1 *model.User, arg2 []dto.ImportTaskDto, arg3 bool) ([]model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockTaskImportServiceMockRecorder) ImportTasks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockTaskImportService)(nil).ImportTasks), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: TaskReminderRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskReminderRepository is a mock of TaskReminderRepository interface.
+type MockTaskReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskReminderRepositoryMockRecorder
+}
+
+// MockTaskReminderRepositoryMockRecorder is the mock recorder for MockTaskReminderRepository.
+type MockTaskReminderRepositoryMockRecorder struct {
+	mock *MockTaskReminderRepository
+}
+
+// NewMockTaskReminderRepository creates a new mock instance.
+func NewMockTaskReminderRepository(ctrl *gomock.Controller) *MockTaskReminderRepository {
+	mock := &MockTaskReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskReminderRepository) EXPECT() *MockTaskReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskReminder mocks base method.
+func (m *MockTaskReminderRepository) CreateTaskReminder(arg0 context.Context, arg1 model.TaskReminder) (*model.TaskReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskReminder", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskReminder indicates an expected call of CreateTaskReminder.
+func (mr *MockTaskReminderRepositoryMockRecorder) CreateTaskReminder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskReminder", reflect.TypeOf((*MockTaskReminderRepository)(nil).CreateTaskReminder), arg0, arg1)
+}
+
+// ListTasksToRemind mocks base method.
+func (m *MockTaskReminderRepository) ListTasksToRemind(arg0 context.Context, arg1 model.TaskReminderThreshold, arg2, arg3 time.Time) ([]model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasksToRemind", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasksToRemind indicates an expected call of ListTasksToRemind.
+func (mr *MockTaskReminderRepositoryMockRecorder) ListTasksToRemind(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasksToRemind", reflect.TypeOf((*MockTaskReminderRepository)(nil).ListTasksToRemind), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskReminderService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaskReminderService is a mock of TaskReminderService interface.
+type MockTaskReminderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskReminderServiceMockRecorder
+}
+
+// MockTaskReminderServiceMockRecorder is the mock recorder for MockTaskReminderService.
+type MockTaskReminderServiceMockRecorder struct {
+	mock *MockTaskReminderService
+}
+
+// NewMockTaskReminderService creates a new mock instance.
+func NewMockTaskReminderService(ctrl *gomock.Controller) *MockTaskReminderService {
+	mock := &MockTaskReminderService{ctrl: ctrl}
+	mock.recorder = &MockTaskReminderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskReminderService) EXPECT() *MockTaskReminderServiceMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockTaskReminderService) Run(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", arg0)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockTaskReminderServiceMockRecorder) Run(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockTaskReminderService)(nil).Run), arg0)
+}
+
+// SendReminders mocks base method.
+func (m *MockTaskReminderService) SendReminders(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendReminders", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendReminders indicates an expected call of SendReminders.
+func (mr *MockTaskReminderServiceMockRecorder) SendReminders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendReminders", reflect.TypeOf((*MockTaskReminderService)(nil).SendReminders), arg0, arg1)
+}
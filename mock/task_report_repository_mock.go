@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: TaskReportRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskReportRepository is a mock of TaskReportRepository interface.
+type MockTaskReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskReportRepositoryMockRecorder
+}
+
+// MockTaskReportRepositoryMockRecorder is the mock recorder for MockTaskReportRepository.
+type MockTaskReportRepositoryMockRecorder struct {
+	mock *MockTaskReportRepository
+}
+
+// NewMockTaskReportRepository creates a new mock instance.
+func NewMockTaskReportRepository(ctrl *gomock.Controller) *MockTaskReportRepository {
+	mock := &MockTaskReportRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskReportRepository) EXPECT() *MockTaskReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReportTasks mocks base method.
+func (m *MockTaskReportRepository) ReportTasks(arg0 context.Context, arg1, arg2 time.Time, arg3 model.TaskReportInterval) (*model.TaskReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportTasks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.TaskReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportTasks indicates an expected call of ReportTasks.
+func (mr *MockTaskReportRepositoryMockRecorder) ReportTasks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportTasks", reflect.TypeOf((*MockTaskReportRepository)(nil).ReportTasks), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskReportService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskReportService is a mock of TaskReportService interface.
+type MockTaskReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskReportServiceMockRecorder
+}
+
+// MockTaskReportServiceMockRecorder is the mock recorder for MockTaskReportService.
+type MockTaskReportServiceMockRecorder struct {
+	mock *MockTaskReportService
+}
+
+// NewMockTaskReportService creates a new mock instance.
+func NewMockTaskReportService(ctrl *gomock.Controller) *MockTaskReportService {
+	mock := &MockTaskReportService{ctrl: ctrl}
+	mock.recorder = &MockTaskReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskReportService) EXPECT() *MockTaskReportServiceMockRecorder {
+	return m.recorder
+}
+
+// ReportTasks mocks base method.
+func (m *MockTaskReportService) ReportTasks(arg0 context.Context, arg1 dto.TaskReportFilterDto) (*model.TaskReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportTasks", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportTasks indicates an expected call of ReportTasks.
+func (mr *MockTaskReportServiceMockRecorder) ReportTasks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportTasks", reflect.TypeOf((*MockTaskReportService)(nil).ReportTasks), arg0, arg1)
+}
@@ -37,27 +37,164 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 }
 
 // CreateTask mocks base method.
-func (m *MockTaskRepository) CreateTask(arg0 context.Context, arg1 int, arg2 string) (*model.Task, error) {
+func (m *MockTaskRepository) CreateTask(arg0 context.Context, arg1 model.Task) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskRepositoryMockRecorder) CreateTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) CreateTask(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), arg0, arg1)
 }
 
-// ListTasks mocks base method.
-func (m *MockTaskRepository) ListTasks(arg0 context.Context, arg1, arg2 int, arg3 ...repository.WhereOpt) ([]model.Task, int, error) {
+// CreateTaskDependency mocks base method.
+func (m *MockTaskRepository) CreateTaskDependency(arg0 context.Context, arg1 model.TaskDependency) (*model.TaskDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskDependency", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskDependency indicates an expected call of CreateTaskDependency.
+func (mr *MockTaskRepositoryMockRecorder) CreateTaskDependency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskDependency", reflect.TypeOf((*MockTaskRepository)(nil).CreateTaskDependency), arg0, arg1)
+}
+
+// DeleteTask mocks base method.
+func (m *MockTaskRepository) DeleteTask(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTaskRepositoryMockRecorder) DeleteTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), arg0, arg1)
+}
+
+// DeleteTaskDependency mocks base method.
+func (m *MockTaskRepository) DeleteTaskDependency(arg0 context.Context, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskDependency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskDependency indicates an expected call of DeleteTaskDependency.
+func (mr *MockTaskRepositoryMockRecorder) DeleteTaskDependency(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskDependency", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTaskDependency), arg0, arg1, arg2)
+}
+
+// ExportTasks mocks base method.
+func (m *MockTaskRepository) ExportTasks(arg0 context.Context, arg1 string, arg2 func(model.TaskExport) error, arg3 ...repository.WhereOpt) error {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{arg0, arg1, arg2}
 	for _, a := range arg3 {
 		varargs = append(varargs, a)
 	}
+	ret := m.ctrl.Call(m, "ExportTasks", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTasks indicates an expected call of ExportTasks.
+func (mr *MockTaskRepositoryMockRecorder) ExportTasks(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTasks", reflect.TypeOf((*MockTaskRepository)(nil).ExportTasks), varargs...)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockTaskRepository) GetTaskByID(arg0 context.Context, arg1 int) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskByID), arg0, arg1)
+}
+
+// ImportTasks mocks base method.
+func (m *MockTaskRepository) ImportTasks(arg0 context.Context, arg1 []model.Task) ([]model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", arg0, arg1)
+	ret0, _ := ret[0].([]model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockTaskRepositoryMockRecorder) ImportTasks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockTaskRepository)(nil).ImportTasks), arg0, arg1)
+}
+
+// IsTaskAncestor mocks base method.
+func (m *MockTaskRepository) IsTaskAncestor(arg0 context.Context, arg1, arg2 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTaskAncestor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTaskAncestor indicates an expected call of IsTaskAncestor.
+func (mr *MockTaskRepositoryMockRecorder) IsTaskAncestor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTaskAncestor", reflect.TypeOf((*MockTaskRepository)(nil).IsTaskAncestor), arg0, arg1, arg2)
+}
+
+// IsTaskBlockedBy mocks base method.
+func (m *MockTaskRepository) IsTaskBlockedBy(arg0 context.Context, arg1, arg2 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTaskBlockedBy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTaskBlockedBy indicates an expected call of IsTaskBlockedBy.
+func (mr *MockTaskRepositoryMockRecorder) IsTaskBlockedBy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTaskBlockedBy", reflect.TypeOf((*MockTaskRepository)(nil).IsTaskBlockedBy), arg0, arg1, arg2)
+}
+
+// ListTaskTransitions mocks base method.
+func (m *MockTaskRepository) ListTaskTransitions(arg0 context.Context, arg1 int) ([]model.TaskTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTransitions", arg0, arg1)
+	ret0, _ := ret[0].([]model.TaskTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTransitions indicates an expected call of ListTaskTransitions.
+func (mr *MockTaskRepositoryMockRecorder) ListTaskTransitions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTransitions", reflect.TypeOf((*MockTaskRepository)(nil).ListTaskTransitions), arg0, arg1)
+}
+
+// ListTasks mocks base method.
+func (m *MockTaskRepository) ListTasks(arg0 context.Context, arg1, arg2 int, arg3 string, arg4 ...repository.WhereOpt) ([]model.Task, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
 	ret := m.ctrl.Call(m, "ListTasks", varargs...)
 	ret0, _ := ret[0].([]model.Task)
 	ret1, _ := ret[1].(int)
@@ -66,8 +203,53 @@ func (m *MockTaskRepository) ListTasks(arg0 context.Context, arg1, arg2 int, arg
 }
 
 // ListTasks indicates an expected call of ListTasks.
-func (mr *MockTaskRepositoryMockRecorder) ListTasks(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) ListTasks(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskRepository)(nil).ListTasks), varargs...)
 }
+
+// TransitionTask mocks base method.
+func (m *MockTaskRepository) TransitionTask(arg0 context.Context, arg1 model.TaskTransition) (*model.TaskTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionTask", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionTask indicates an expected call of TransitionTask.
+func (mr *MockTaskRepositoryMockRecorder) TransitionTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionTask", reflect.TypeOf((*MockTaskRepository)(nil).TransitionTask), arg0, arg1)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskRepository) UpdateTask(arg0 context.Context, arg1 model.Task) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskRepositoryMockRecorder) UpdateTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), arg0, arg1)
+}
+
+// UpdateTaskAssignee mocks base method.
+func (m *MockTaskRepository) UpdateTaskAssignee(arg0 context.Context, arg1, arg2 int) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskAssignee", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskAssignee indicates an expected call of UpdateTaskAssignee.
+func (mr *MockTaskRepositoryMockRecorder) UpdateTaskAssignee(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskAssignee", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTaskAssignee), arg0, arg1, arg2)
+}
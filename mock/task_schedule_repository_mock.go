@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: TaskScheduleRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskScheduleRepository is a mock of TaskScheduleRepository interface.
+type MockTaskScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskScheduleRepositoryMockRecorder
+}
+
+// MockTaskScheduleRepositoryMockRecorder is the mock recorder for MockTaskScheduleRepository.
+type MockTaskScheduleRepositoryMockRecorder struct {
+	mock *MockTaskScheduleRepository
+}
+
+// NewMockTaskScheduleRepository creates a new mock instance.
+func NewMockTaskScheduleRepository(ctrl *gomock.Controller) *MockTaskScheduleRepository {
+	mock := &MockTaskScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskScheduleRepository) EXPECT() *MockTaskScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcquireDueTaskSchedules mocks base method.
+func (m *MockTaskScheduleRepository) AcquireDueTaskSchedules(arg0 context.Context, arg1 string, arg2, arg3 time.Time, arg4 int) ([]model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireDueTaskSchedules", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireDueTaskSchedules indicates an expected call of AcquireDueTaskSchedules.
+func (mr *MockTaskScheduleRepositoryMockRecorder) AcquireDueTaskSchedules(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireDueTaskSchedules", reflect.TypeOf((*MockTaskScheduleRepository)(nil).AcquireDueTaskSchedules), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateTaskSchedule mocks base method.
+func (m *MockTaskScheduleRepository) CreateTaskSchedule(arg0 context.Context, arg1 model.TaskSchedule) (*model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskSchedule indicates an expected call of CreateTaskSchedule.
+func (mr *MockTaskScheduleRepositoryMockRecorder) CreateTaskSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskSchedule", reflect.TypeOf((*MockTaskScheduleRepository)(nil).CreateTaskSchedule), arg0, arg1)
+}
+
+// CreateTaskScheduleRun mocks base method.
+func (m *MockTaskScheduleRepository) CreateTaskScheduleRun(arg0 context.Context, arg1 model.TaskScheduleRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskScheduleRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskScheduleRun indicates an expected call of CreateTaskScheduleRun.
+func (mr *MockTaskScheduleRepositoryMockRecorder) CreateTaskScheduleRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskScheduleRun", reflect.TypeOf((*MockTaskScheduleRepository)(nil).CreateTaskScheduleRun), arg0, arg1)
+}
+
+// DeleteTaskSchedule mocks base method.
+func (m *MockTaskScheduleRepository) DeleteTaskSchedule(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskSchedule indicates an expected call of DeleteTaskSchedule.
+func (mr *MockTaskScheduleRepositoryMockRecorder) DeleteTaskSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskSchedule", reflect.TypeOf((*MockTaskScheduleRepository)(nil).DeleteTaskSchedule), arg0, arg1)
+}
+
+// GetTaskScheduleByID mocks base method.
+func (m *MockTaskScheduleRepository) GetTaskScheduleByID(arg0 context.Context, arg1 int) (*model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskScheduleByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskScheduleByID indicates an expected call of GetTaskScheduleByID.
+func (mr *MockTaskScheduleRepositoryMockRecorder) GetTaskScheduleByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskScheduleByID", reflect.TypeOf((*MockTaskScheduleRepository)(nil).GetTaskScheduleByID), arg0, arg1)
+}
+
+// ListTaskSchedules mocks base method.
+func (m *MockTaskScheduleRepository) ListTaskSchedules(arg0 context.Context) ([]model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskSchedules", arg0)
+	ret0, _ := ret[0].([]model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskSchedules indicates an expected call of ListTaskSchedules.
+func (mr *MockTaskScheduleRepositoryMockRecorder) ListTaskSchedules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskSchedules", reflect.TypeOf((*MockTaskScheduleRepository)(nil).ListTaskSchedules), arg0)
+}
+
+// ReleaseTaskSchedule mocks base method.
+func (m *MockTaskScheduleRepository) ReleaseTaskSchedule(arg0 context.Context, arg1 int, arg2 string, arg3 time.Time, arg4 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseTaskSchedule", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseTaskSchedule indicates an expected call of ReleaseTaskSchedule.
+func (mr *MockTaskScheduleRepositoryMockRecorder) ReleaseTaskSchedule(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTaskSchedule", reflect.TypeOf((*MockTaskScheduleRepository)(nil).ReleaseTaskSchedule), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetTaskScheduleRunTask mocks base method.
+func (m *MockTaskScheduleRepository) SetTaskScheduleRunTask(arg0 context.Context, arg1 int, arg2 time.Time, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskScheduleRunTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskScheduleRunTask indicates an expected call of SetTaskScheduleRunTask.
+func (mr *MockTaskScheduleRepositoryMockRecorder) SetTaskScheduleRunTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskScheduleRunTask", reflect.TypeOf((*MockTaskScheduleRepository)(nil).SetTaskScheduleRunTask), arg0, arg1, arg2, arg3)
+}
+
+// UpdateTaskSchedule mocks base method.
+func (m *MockTaskScheduleRepository) UpdateTaskSchedule(arg0 context.Context, arg1 model.TaskSchedule) (*model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskSchedule indicates an expected call of UpdateTaskSchedule.
+func (mr *MockTaskScheduleRepositoryMockRecorder) UpdateTaskSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskSchedule", reflect.TypeOf((*MockTaskScheduleRepository)(nil).UpdateTaskSchedule), arg0, arg1)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskScheduleService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskScheduleService is a mock of TaskScheduleService interface.
+type MockTaskScheduleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskScheduleServiceMockRecorder
+}
+
+// MockTaskScheduleServiceMockRecorder is the mock recorder for MockTaskScheduleService.
+type MockTaskScheduleServiceMockRecorder struct {
+	mock *MockTaskScheduleService
+}
+
+// NewMockTaskScheduleService creates a new mock instance.
+func NewMockTaskScheduleService(ctrl *gomock.Controller) *MockTaskScheduleService {
+	mock := &MockTaskScheduleService{ctrl: ctrl}
+	mock.recorder = &MockTaskScheduleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskScheduleService) EXPECT() *MockTaskScheduleServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskSchedule mocks base method.
+func (m *MockTaskScheduleService) CreateTaskSchedule(arg0 context.Context, arg1 *model.User, arg2 dto.CreateTaskScheduleDto) (*model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskSchedule indicates an expected call of CreateTaskSchedule.
+func (mr *MockTaskScheduleServiceMockRecorder) CreateTaskSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskSchedule", reflect.TypeOf((*MockTaskScheduleService)(nil).CreateTaskSchedule), arg0, arg1, arg2)
+}
+
+// DeleteTaskSchedule mocks base method.
+func (m *MockTaskScheduleService) DeleteTaskSchedule(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskSchedule indicates an expected call of DeleteTaskSchedule.
+func (mr *MockTaskScheduleServiceMockRecorder) DeleteTaskSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskSchedule", reflect.TypeOf((*MockTaskScheduleService)(nil).DeleteTaskSchedule), arg0, arg1)
+}
+
+// GetTaskSchedule mocks base method.
+func (m *MockTaskScheduleService) GetTaskSchedule(arg0 context.Context, arg1 int) (*model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskSchedule indicates an expected call of GetTaskSchedule.
+func (mr *MockTaskScheduleServiceMockRecorder) GetTaskSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskSchedule", reflect.TypeOf((*MockTaskScheduleService)(nil).GetTaskSchedule), arg0, arg1)
+}
+
+// ListTaskSchedules mocks base method.
+func (m *MockTaskScheduleService) ListTaskSchedules(arg0 context.Context) ([]model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskSchedules", arg0)
+	ret0, _ := ret[0].([]model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskSchedules indicates an expected call of ListTaskSchedules.
+func (mr *MockTaskScheduleServiceMockRecorder) ListTaskSchedules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskSchedules", reflect.TypeOf((*MockTaskScheduleService)(nil).ListTaskSchedules), arg0)
+}
+
+// Run mocks base method.
+func (m *MockTaskScheduleService) Run(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", arg0)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockTaskScheduleServiceMockRecorder) Run(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockTaskScheduleService)(nil).Run), arg0)
+}
+
+// RunDueSchedules mocks base method.
+func (m *MockTaskScheduleService) RunDueSchedules(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDueSchedules", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunDueSchedules indicates an expected call of RunDueSchedules.
+func (mr *MockTaskScheduleServiceMockRecorder) RunDueSchedules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDueSchedules", reflect.TypeOf((*MockTaskScheduleService)(nil).RunDueSchedules), arg0, arg1)
+}
+
+// UpdateTaskSchedule mocks base method.
+func (m *MockTaskScheduleService) UpdateTaskSchedule(arg0 context.Context, arg1 int, arg2 dto.UpdateTaskScheduleDto) (*model.TaskSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskSchedule indicates an expected call of UpdateTaskSchedule.
+func (mr *MockTaskScheduleServiceMockRecorder) UpdateTaskSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskSchedule", reflect.TypeOf((*MockTaskScheduleService)(nil).UpdateTaskSchedule), arg0, arg1, arg2)
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
 	model "github.com/viniosilva/swordhealth-api/internal/model"
 )
 
@@ -35,8 +36,38 @@ func (m *MockTaskService) EXPECT() *MockTaskServiceMockRecorder {
 	return m.recorder
 }
 
+// AddTaskDependency mocks base method.
+func (m *MockTaskService) AddTaskDependency(arg0 context.Context, arg1 *model.User, arg2, arg3 int) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskDependency", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTaskDependency indicates an expected call of AddTaskDependency.
+func (mr *MockTaskServiceMockRecorder) AddTaskDependency(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskDependency", reflect.TypeOf((*MockTaskService)(nil).AddTaskDependency), arg0, arg1, arg2, arg3)
+}
+
+// AssignTask mocks base method.
+func (m *MockTaskService) AssignTask(arg0 context.Context, arg1, arg2 int) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignTask indicates an expected call of AssignTask.
+func (mr *MockTaskServiceMockRecorder) AssignTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTask", reflect.TypeOf((*MockTaskService)(nil).AssignTask), arg0, arg1, arg2)
+}
+
 // CreateTask mocks base method.
-func (m *MockTaskService) CreateTask(arg0 context.Context, arg1 int, arg2 string) (*model.Task, error) {
+func (m *MockTaskService) CreateTask(arg0 context.Context, arg1 *model.User, arg2 dto.CreateTaskDto) (*model.Task, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.Task)
@@ -50,10 +81,83 @@ func (mr *MockTaskServiceMockRecorder) CreateTask(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskService)(nil).CreateTask), arg0, arg1, arg2)
 }
 
+// DeleteTask mocks base method.
+func (m *MockTaskService) DeleteTask(arg0 context.Context, arg1 *model.User, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTaskServiceMockRecorder) DeleteTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskService)(nil).DeleteTask), arg0, arg1, arg2)
+}
+
+// ExportTasks mocks base method.
+func (m *MockTaskService) ExportTasks(arg0 context.Context, arg1 *model.User, arg2 dto.ListTasksFilterDto, arg3 func(model.TaskExport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTasks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTasks indicates an expected call of ExportTasks.
+func (mr *MockTaskServiceMockRecorder) ExportTasks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTasks", reflect.TypeOf((*MockTaskService)(nil).ExportTasks), arg0, arg1, arg2, arg3)
+}
+
+// GetTask mocks base method.
+func (m *MockTaskService) GetTask(arg0 context.Context, arg1 *model.User, arg2 int) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockTaskServiceMockRecorder) GetTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskService)(nil).GetTask), arg0, arg1, arg2)
+}
+
+// GetTaskTree mocks base method.
+func (m *MockTaskService) GetTaskTree(arg0 context.Context, arg1 *model.User, arg2 int) (*model.TaskTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTree", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTree indicates an expected call of GetTaskTree.
+func (mr *MockTaskServiceMockRecorder) GetTaskTree(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTree", reflect.TypeOf((*MockTaskService)(nil).GetTaskTree), arg0, arg1, arg2)
+}
+
+// ListTaskTransitions mocks base method.
+func (m *MockTaskService) ListTaskTransitions(arg0 context.Context, arg1 *model.User, arg2 int) ([]model.TaskTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTransitions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.TaskTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTransitions indicates an expected call of ListTaskTransitions.
+func (mr *MockTaskServiceMockRecorder) ListTaskTransitions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTransitions", reflect.TypeOf((*MockTaskService)(nil).ListTaskTransitions), arg0, arg1, arg2)
+}
+
 // ListTasks mocks base method.
-func (m *MockTaskService) ListTasks(arg0 context.Context, arg1, arg2 int, arg3 *model.User) ([]model.Task, int, error) {
+func (m *MockTaskService) ListTasks(arg0 context.Context, arg1, arg2 int, arg3 *model.User, arg4 dto.ListTasksFilterDto) ([]model.Task, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTasks", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "ListTasks", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]model.Task)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -61,7 +165,67 @@ func (m *MockTaskService) ListTasks(arg0 context.Context, arg1, arg2 int, arg3 *
 }
 
 // ListTasks indicates an expected call of ListTasks.
-func (mr *MockTaskServiceMockRecorder) ListTasks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockTaskServiceMockRecorder) ListTasks(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskService)(nil).ListTasks), arg0, arg1, arg2, arg3, arg4)
+}
+
+// RemoveTaskDependency mocks base method.
+func (m *MockTaskService) RemoveTaskDependency(arg0 context.Context, arg1 *model.User, arg2, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskDependency", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTaskDependency indicates an expected call of RemoveTaskDependency.
+func (mr *MockTaskServiceMockRecorder) RemoveTaskDependency(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskDependency", reflect.TypeOf((*MockTaskService)(nil).RemoveTaskDependency), arg0, arg1, arg2, arg3)
+}
+
+// TagTask mocks base method.
+func (m *MockTaskService) TagTask(arg0 context.Context, arg1 *model.User, arg2 int, arg3 []string) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagTask indicates an expected call of TagTask.
+func (mr *MockTaskServiceMockRecorder) TagTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagTask", reflect.TypeOf((*MockTaskService)(nil).TagTask), arg0, arg1, arg2, arg3)
+}
+
+// TransitionTask mocks base method.
+func (m *MockTaskService) TransitionTask(arg0 context.Context, arg1 *model.User, arg2 int, arg3 model.TaskStatus, arg4 bool) (*model.Task, *model.TaskTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionTask", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(*model.TaskTransition)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TransitionTask indicates an expected call of TransitionTask.
+func (mr *MockTaskServiceMockRecorder) TransitionTask(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionTask", reflect.TypeOf((*MockTaskService)(nil).TransitionTask), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskService) UpdateTask(arg0 context.Context, arg1 *model.User, arg2 int, arg3 dto.UpdateTaskDto) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskServiceMockRecorder) UpdateTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskService)(nil).ListTasks), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskService)(nil).UpdateTask), arg0, arg1, arg2, arg3)
 }
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: TaskTemplateRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskTemplateRepository is a mock of TaskTemplateRepository interface.
+type MockTaskTemplateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTemplateRepositoryMockRecorder
+}
+
+// MockTaskTemplateRepositoryMockRecorder is the mock recorder for MockTaskTemplateRepository.
+type MockTaskTemplateRepositoryMockRecorder struct {
+	mock *MockTaskTemplateRepository
+}
+
+// NewMockTaskTemplateRepository creates a new mock instance.
+func NewMockTaskTemplateRepository(ctrl *gomock.Controller) *MockTaskTemplateRepository {
+	mock := &MockTaskTemplateRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskTemplateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTemplateRepository) EXPECT() *MockTaskTemplateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskTemplate mocks base method.
+func (m *MockTaskTemplateRepository) CreateTaskTemplate(arg0 context.Context, arg1 model.TaskTemplate) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskTemplate indicates an expected call of CreateTaskTemplate.
+func (mr *MockTaskTemplateRepositoryMockRecorder) CreateTaskTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTemplate", reflect.TypeOf((*MockTaskTemplateRepository)(nil).CreateTaskTemplate), arg0, arg1)
+}
+
+// DeleteTaskTemplate mocks base method.
+func (m *MockTaskTemplateRepository) DeleteTaskTemplate(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskTemplate indicates an expected call of DeleteTaskTemplate.
+func (mr *MockTaskTemplateRepositoryMockRecorder) DeleteTaskTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTemplate", reflect.TypeOf((*MockTaskTemplateRepository)(nil).DeleteTaskTemplate), arg0, arg1)
+}
+
+// GetTaskTemplateByID mocks base method.
+func (m *MockTaskTemplateRepository) GetTaskTemplateByID(arg0 context.Context, arg1 int) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTemplateByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTemplateByID indicates an expected call of GetTaskTemplateByID.
+func (mr *MockTaskTemplateRepositoryMockRecorder) GetTaskTemplateByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTemplateByID", reflect.TypeOf((*MockTaskTemplateRepository)(nil).GetTaskTemplateByID), arg0, arg1)
+}
+
+// ListTaskTemplates mocks base method.
+func (m *MockTaskTemplateRepository) ListTaskTemplates(arg0 context.Context) ([]model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTemplates", arg0)
+	ret0, _ := ret[0].([]model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTemplates indicates an expected call of ListTaskTemplates.
+func (mr *MockTaskTemplateRepositoryMockRecorder) ListTaskTemplates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTemplates", reflect.TypeOf((*MockTaskTemplateRepository)(nil).ListTaskTemplates), arg0)
+}
+
+// UpdateTaskTemplate mocks base method.
+func (m *MockTaskTemplateRepository) UpdateTaskTemplate(arg0 context.Context, arg1 model.TaskTemplate) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskTemplate indicates an expected call of UpdateTaskTemplate.
+func (mr *MockTaskTemplateRepositoryMockRecorder) UpdateTaskTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskTemplate", reflect.TypeOf((*MockTaskTemplateRepository)(nil).UpdateTaskTemplate), arg0, arg1)
+}
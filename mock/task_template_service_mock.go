@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskTemplateService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskTemplateService is a mock of TaskTemplateService interface.
+type MockTaskTemplateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTemplateServiceMockRecorder
+}
+
+// MockTaskTemplateServiceMockRecorder is the mock recorder for MockTaskTemplateService.
+type MockTaskTemplateServiceMockRecorder struct {
+	mock *MockTaskTemplateService
+}
+
+// NewMockTaskTemplateService creates a new mock instance.
+func NewMockTaskTemplateService(ctrl *gomock.Controller) *MockTaskTemplateService {
+	mock := &MockTaskTemplateService{ctrl: ctrl}
+	mock.recorder = &MockTaskTemplateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTemplateService) EXPECT() *MockTaskTemplateServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskTemplate mocks base method.
+func (m *MockTaskTemplateService) CreateTaskTemplate(arg0 context.Context, arg1 *model.User, arg2 dto.CreateTaskTemplateDto) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskTemplate indicates an expected call of CreateTaskTemplate.
+func (mr *MockTaskTemplateServiceMockRecorder) CreateTaskTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTemplate", reflect.TypeOf((*MockTaskTemplateService)(nil).CreateTaskTemplate), arg0, arg1, arg2)
+}
+
+// DeleteTaskTemplate mocks base method.
+func (m *MockTaskTemplateService) DeleteTaskTemplate(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskTemplate indicates an expected call of DeleteTaskTemplate.
+func (mr *MockTaskTemplateServiceMockRecorder) DeleteTaskTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTemplate", reflect.TypeOf((*MockTaskTemplateService)(nil).DeleteTaskTemplate), arg0, arg1)
+}
+
+// GetTaskTemplate mocks base method.
+func (m *MockTaskTemplateService) GetTaskTemplate(arg0 context.Context, arg1 int) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTemplate indicates an expected call of GetTaskTemplate.
+func (mr *MockTaskTemplateServiceMockRecorder) GetTaskTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTemplate", reflect.TypeOf((*MockTaskTemplateService)(nil).GetTaskTemplate), arg0, arg1)
+}
+
+// ListTaskTemplates mocks base method.
+func (m *MockTaskTemplateService) ListTaskTemplates(arg0 context.Context) ([]model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTemplates", arg0)
+	ret0, _ := ret[0].([]model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTemplates indicates an expected call of ListTaskTemplates.
+func (mr *MockTaskTemplateServiceMockRecorder) ListTaskTemplates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTemplates", reflect.TypeOf((*MockTaskTemplateService)(nil).ListTaskTemplates), arg0)
+}
+
+// RenderTaskTemplate mocks base method.
+func (m *MockTaskTemplateService) RenderTaskTemplate(arg0 context.Context, arg1 int, arg2 dto.CreateTaskFromTemplateDto) (dto.CreateTaskDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderTaskTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.CreateTaskDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderTaskTemplate indicates an expected call of RenderTaskTemplate.
+func (mr *MockTaskTemplateServiceMockRecorder) RenderTaskTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderTaskTemplate", reflect.TypeOf((*MockTaskTemplateService)(nil).RenderTaskTemplate), arg0, arg1, arg2)
+}
+
+// UpdateTaskTemplate mocks base method.
+func (m *MockTaskTemplateService) UpdateTaskTemplate(arg0 context.Context, arg1 int, arg2 dto.UpdateTaskTemplateDto) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskTemplate indicates an expected call of UpdateTaskTemplate.
+func (mr *MockTaskTemplateServiceMockRecorder) UpdateTaskTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskTemplate", reflect.TypeOf((*MockTaskTemplateService)(nil).UpdateTaskTemplate), arg0, arg1, arg2)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/repository (interfaces: TaskTimeEntryRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+	repository "github.com/viniosilva/swordhealth-api/internal/repository"
+)
+
+// MockTaskTimeEntryRepository is a mock of TaskTimeEntryRepository interface.
+type MockTaskTimeEntryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTimeEntryRepositoryMockRecorder
+}
+
+// MockTaskTimeEntryRepositoryMockRecorder is the mock recorder for MockTaskTimeEntryRepository.
+type MockTaskTimeEntryRepositoryMockRecorder struct {
+	mock *MockTaskTimeEntryRepository
+}
+
+// NewMockTaskTimeEntryRepository creates a new mock instance.
+func NewMockTaskTimeEntryRepository(ctrl *gomock.Controller) *MockTaskTimeEntryRepository {
+	mock := &MockTaskTimeEntryRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskTimeEntryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTimeEntryRepository) EXPECT() *MockTaskTimeEntryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskTimeEntry mocks base method.
+func (m *MockTaskTimeEntryRepository) CreateTaskTimeEntry(arg0 context.Context, arg1 model.TaskTimeEntry) (*model.TaskTimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskTimeEntry", arg0, arg1)
+	ret0, _ := ret[0].(*model.TaskTimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskTimeEntry indicates an expected call of CreateTaskTimeEntry.
+func (mr *MockTaskTimeEntryRepositoryMockRecorder) CreateTaskTimeEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTimeEntry", reflect.TypeOf((*MockTaskTimeEntryRepository)(nil).CreateTaskTimeEntry), arg0, arg1)
+}
+
+// ListTaskTimeEntries mocks base method.
+func (m *MockTaskTimeEntryRepository) ListTaskTimeEntries(arg0 context.Context, arg1 int) ([]model.TaskTimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTimeEntries", arg0, arg1)
+	ret0, _ := ret[0].([]model.TaskTimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTimeEntries indicates an expected call of ListTaskTimeEntries.
+func (mr *MockTaskTimeEntryRepositoryMockRecorder) ListTaskTimeEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTimeEntries", reflect.TypeOf((*MockTaskTimeEntryRepository)(nil).ListTaskTimeEntries), arg0, arg1)
+}
+
+// StopTaskTimeEntry mocks base method.
+func (m *MockTaskTimeEntryRepository) StopTaskTimeEntry(arg0 context.Context, arg1, arg2 int, arg3 time.Time) (*model.TaskTimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTaskTimeEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.TaskTimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTaskTimeEntry indicates an expected call of StopTaskTimeEntry.
+func (mr *MockTaskTimeEntryRepositoryMockRecorder) StopTaskTimeEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTaskTimeEntry", reflect.TypeOf((*MockTaskTimeEntryRepository)(nil).StopTaskTimeEntry), arg0, arg1, arg2, arg3)
+}
+
+// SummarizeTimeEntries mocks base method.
+func (m *MockTaskTimeEntryRepository) SummarizeTimeEntries(arg0 context.Context, arg1 model.TimeEntryPeriod, arg2 ...repository.WhereOpt) ([]model.TimeEntrySummary, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SummarizeTimeEntries", varargs...)
+	ret0, _ := ret[0].([]model.TimeEntrySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeTimeEntries indicates an expected call of SummarizeTimeEntries.
+func (mr *MockTaskTimeEntryRepositoryMockRecorder) SummarizeTimeEntries(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeTimeEntries", reflect.TypeOf((*MockTaskTimeEntryRepository)(nil).SummarizeTimeEntries), varargs...)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/swordhealth-api/internal/service (interfaces: TaskTimeEntryService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/swordhealth-api/internal/dto"
+	model "github.com/viniosilva/swordhealth-api/internal/model"
+)
+
+// MockTaskTimeEntryService is a mock of TaskTimeEntryService interface.
+type MockTaskTimeEntryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTimeEntryServiceMockRecorder
+}
+
+// MockTaskTimeEntryServiceMockRecorder is the mock recorder for MockTaskTimeEntryService.
+type MockTaskTimeEntryServiceMockRecorder struct {
+	mock *MockTaskTimeEntryService
+}
+
+// NewMockTaskTimeEntryService creates a new mock instance.
+func NewMockTaskTimeEntryService(ctrl *gomock.Controller) *MockTaskTimeEntryService {
+	mock := &MockTaskTimeEntryService{ctrl: ctrl}
+	mock.recorder = &MockTaskTimeEntryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTimeEntryService) EXPECT() *MockTaskTimeEntryServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTimeEntry mocks base method.
+func (m *MockTaskTimeEntryService) CreateTimeEntry(arg0 context.Context, arg1 *model.User, arg2 int, arg3 dto.CreateTaskTimeEntryDto) (*model.TaskTimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimeEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.TaskTimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockTaskTimeEntryServiceMockRecorder) CreateTimeEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockTaskTimeEntryService)(nil).CreateTimeEntry), arg0, arg1, arg2, arg3)
+}
+
+// ListTimeEntries mocks base method.
+func (m *MockTaskTimeEntryService) ListTimeEntries(arg0 context.Context, arg1 *model.User, arg2 int) ([]model.TaskTimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimeEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.TaskTimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimeEntries indicates an expected call of ListTimeEntries.
+func (mr *MockTaskTimeEntryServiceMockRecorder) ListTimeEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntries", reflect.TypeOf((*MockTaskTimeEntryService)(nil).ListTimeEntries), arg0, arg1, arg2)
+}
+
+// StartTimer mocks base method.
+func (m *MockTaskTimeEntryService) StartTimer(arg0 context.Context, arg1 *model.User, arg2 int, arg3 dto.CreateTaskTimeEntryDto) (*model.TaskTimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.TaskTimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTaskTimeEntryServiceMockRecorder) StartTimer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTaskTimeEntryService)(nil).StartTimer), arg0, arg1, arg2, arg3)
+}
+
+// StopTimer mocks base method.
+func (m *MockTaskTimeEntryService) StopTimer(arg0 context.Context, arg1 *model.User, arg2 int) (*model.TaskTimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TaskTimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTaskTimeEntryServiceMockRecorder) StopTimer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTaskTimeEntryService)(nil).StopTimer), arg0, arg1, arg2)
+}
+
+// SummarizeTimeEntries mocks base method.
+func (m *MockTaskTimeEntryService) SummarizeTimeEntries(arg0 context.Context, arg1 *model.User, arg2 dto.TimeEntrySummaryFilterDto) ([]model.TimeEntrySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeTimeEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.TimeEntrySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeTimeEntries indicates an expected call of SummarizeTimeEntries.
+func (mr *MockTaskTimeEntryServiceMockRecorder) SummarizeTimeEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeTimeEntries", reflect.TypeOf((*MockTaskTimeEntryService)(nil).SummarizeTimeEntries), arg0, arg1, arg2)
+}